@@ -19,6 +19,7 @@ import (
 	v2 "github.com/centrifuge/go-centrifuge/httpapi/v2"
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
+	"github.com/centrifuge/go-centrifuge/metrics"
 	"github.com/centrifuge/go-centrifuge/nft"
 	"github.com/centrifuge/go-centrifuge/node"
 	"github.com/centrifuge/go-centrifuge/oracle"
@@ -55,6 +56,8 @@ func (m *MainBootstrapper) PopulateBaseBootstrappers() {
 		&entityrelationship.Bootstrapper{},
 		generic.Bootstrapper{},
 		&nft.Bootstrapper{},
+		// collects the metrics of the jobs, queue and nft bootstrapped above
+		metrics.Bootstrapper{},
 		p2p.Bootstrapper{},
 		documents.PostBootstrapper{},
 		pending.Bootstrapper{},
@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/errors"
 	logging "github.com/ipfs/go-log"
 )
 
@@ -28,5 +29,20 @@ func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
 	if c.IsDebugLogEnabled() {
 		logging.SetAllLoggers(logging.LevelDebug)
 	}
+
+	if err := setLogLevels(c.GetDebugLogLevels()); err != nil {
+		return errors.NewTypedError(ErrConfigBootstrap, err)
+	}
+	return nil
+}
+
+// setLogLevels sets the log level of each subsystem keyed by the logger name.
+func setLogLevels(levels map[string]string) error {
+	for name, level := range levels {
+		if err := logging.SetLogLevel(name, level); err != nil {
+			return errors.New("failed to set log level %s of %s: %v", level, name, err)
+		}
+	}
+
 	return nil
 }
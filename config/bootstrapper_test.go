@@ -0,0 +1,23 @@
+// +build unit
+
+package config
+
+import (
+	"testing"
+
+	logging "github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLogLevels(t *testing.T) {
+	logging.Logger("config-test")
+
+	// unknown logger
+	assert.Error(t, setLogLevels(map[string]string{"unknown-subsystem": "debug"}))
+
+	// invalid level
+	assert.Error(t, setLogLevels(map[string]string{"config-test": "verbose"}))
+
+	assert.NoError(t, setLogLevels(nil))
+	assert.NoError(t, setLogLevels(map[string]string{"config-test": "debug", "config": "warn"}))
+}
@@ -43,6 +43,37 @@ type NodeConfig struct {
 	NumWorkers                     int
 	WorkerWaitTimeMS               int
 	TaskValidDuration              time.Duration
+	TaskAckMode                    string
+	TaskTimeouts                   map[string]time.Duration
+	TaskStoreResults               map[string]bool
+	TaskScheduleCatchUp            string
+	TaskDedupWindow                time.Duration
+	TaskMaxQueueDepth              int
+	TaskQueueFullTimeout           time.Duration
+	TaskEnqueueOnly                bool
+	TaskReconcilePolicy            string
+	TaskReplayEnabled              bool
+	JobHistoryEnabled              bool
+	JobRecoveryPolicies            map[string]string
+	JobMaxLogs                     int
+	JobReferenceKey                string
+	JobSerializationFormat         string
+	JobPollInterval                time.Duration
+	JobPollInitial                 time.Duration
+	JobPollMax                     time.Duration
+	JobShutdownGracePeriod         time.Duration
+	JobContextClosedPolicy         string
+	JobRetryAfter                  map[string]time.Duration
+	JobLogArchiveDir               string
+	JobLogArchiveThreshold         int
+	MaxConcurrentJobs              int
+	JobCapacityPolicy              string
+	NotificationDialTimeout        time.Duration
+	NotificationTLSTimeout         time.Duration
+	NotificationHeaderTimeout      time.Duration
+	NotificationOrderedDelivery    bool
+	NotificationMaxPayloadSize     int
+	NotificationOptInEvents        []string
 	EthereumNodeURL                string
 	EthereumContextReadWaitTimeout time.Duration
 	EthereumContextWaitTimeout     time.Duration
@@ -58,7 +89,17 @@ type NodeConfig struct {
 	SmartContractBytecode          map[config.ContractName]string
 	PprofEnabled                   bool
 	LowEntropyNFTTokenEnabled      bool
+	NFTRegistryProperties          map[string][]string
+	NFTRegistryAttestationSchemes  map[string]string
+	NFTRegistryMethods             map[string][]string
+	NFTRegistryEvents              map[string]string
+	NFTMintGasPadding              float64
+	NFTPrebindRegistries           bool
+	NFTReadCacheTTL                time.Duration
+	NFTRelayerURL                  string
+	NFTRelayerSigningScheme        string
 	DebugLogEnabled                bool
+	DebugLogLevels                 map[string]string
 	CentChainNodeURL               string
 	CentChainIntervalRetry         time.Duration
 	CentChainMaxRetries            int
@@ -175,6 +216,161 @@ func (nc *NodeConfig) GetTaskValidDuration() time.Duration {
 	return nc.TaskValidDuration
 }
 
+// GetTaskAckMode refer the interface
+func (nc *NodeConfig) GetTaskAckMode() string {
+	return nc.TaskAckMode
+}
+
+// GetTaskTimeouts refer the interface
+func (nc *NodeConfig) GetTaskTimeouts() map[string]time.Duration {
+	return nc.TaskTimeouts
+}
+
+// GetTaskStoreResults refer the interface
+func (nc *NodeConfig) GetTaskStoreResults() map[string]bool {
+	return nc.TaskStoreResults
+}
+
+// GetTaskScheduleCatchUp refer the interface
+func (nc *NodeConfig) GetTaskScheduleCatchUp() string {
+	return nc.TaskScheduleCatchUp
+}
+
+// GetTaskDedupWindow refer the interface
+func (nc *NodeConfig) GetTaskDedupWindow() time.Duration {
+	return nc.TaskDedupWindow
+}
+
+// GetTaskMaxQueueDepth refer the interface
+func (nc *NodeConfig) GetTaskMaxQueueDepth() int {
+	return nc.TaskMaxQueueDepth
+}
+
+// GetTaskQueueFullTimeout refer the interface
+func (nc *NodeConfig) GetTaskQueueFullTimeout() time.Duration {
+	return nc.TaskQueueFullTimeout
+}
+
+// GetTaskEnqueueOnly refer the interface
+func (nc *NodeConfig) GetTaskEnqueueOnly() bool {
+	return nc.TaskEnqueueOnly
+}
+
+// GetTaskReconcilePolicy refer the interface
+func (nc *NodeConfig) GetTaskReconcilePolicy() string {
+	return nc.TaskReconcilePolicy
+}
+
+// GetTaskReplayEnabled refer the interface
+func (nc *NodeConfig) GetTaskReplayEnabled() bool {
+	return nc.TaskReplayEnabled
+}
+
+// GetJobHistoryEnabled refer the interface
+func (nc *NodeConfig) GetJobHistoryEnabled() bool {
+	return nc.JobHistoryEnabled
+}
+
+// GetJobRecoveryPolicies refer the interface
+func (nc *NodeConfig) GetJobRecoveryPolicies() map[string]string {
+	return nc.JobRecoveryPolicies
+}
+
+// GetJobMaxLogs refer the interface
+func (nc *NodeConfig) GetJobMaxLogs() int {
+	return nc.JobMaxLogs
+}
+
+// GetJobReferenceKey refer the interface
+func (nc *NodeConfig) GetJobReferenceKey() string {
+	return nc.JobReferenceKey
+}
+
+// GetJobSerializationFormat refer the interface
+func (nc *NodeConfig) GetJobSerializationFormat() string {
+	return nc.JobSerializationFormat
+}
+
+// GetJobPollInterval refer the interface
+func (nc *NodeConfig) GetJobPollInterval() time.Duration {
+	return nc.JobPollInterval
+}
+
+// GetJobPollInitial refer the interface
+func (nc *NodeConfig) GetJobPollInitial() time.Duration {
+	return nc.JobPollInitial
+}
+
+// GetJobPollMax refer the interface
+func (nc *NodeConfig) GetJobPollMax() time.Duration {
+	return nc.JobPollMax
+}
+
+// GetJobShutdownGracePeriod refer the interface
+func (nc *NodeConfig) GetJobShutdownGracePeriod() time.Duration {
+	return nc.JobShutdownGracePeriod
+}
+
+// GetJobContextClosedPolicy refer the interface
+func (nc *NodeConfig) GetJobContextClosedPolicy() string {
+	return nc.JobContextClosedPolicy
+}
+
+// GetJobRetryAfter refer the interface
+func (nc *NodeConfig) GetJobRetryAfter() map[string]time.Duration {
+	return nc.JobRetryAfter
+}
+
+// GetJobLogArchiveDir refer the interface
+func (nc *NodeConfig) GetJobLogArchiveDir() string {
+	return nc.JobLogArchiveDir
+}
+
+// GetJobLogArchiveThreshold refer the interface
+func (nc *NodeConfig) GetJobLogArchiveThreshold() int {
+	return nc.JobLogArchiveThreshold
+}
+
+// GetMaxConcurrentJobs refer the interface
+func (nc *NodeConfig) GetMaxConcurrentJobs() int {
+	return nc.MaxConcurrentJobs
+}
+
+// GetJobCapacityPolicy refer the interface
+func (nc *NodeConfig) GetJobCapacityPolicy() string {
+	return nc.JobCapacityPolicy
+}
+
+// GetNotificationDialTimeout refer the interface
+func (nc *NodeConfig) GetNotificationDialTimeout() time.Duration {
+	return nc.NotificationDialTimeout
+}
+
+// GetNotificationTLSHandshakeTimeout refer the interface
+func (nc *NodeConfig) GetNotificationTLSHandshakeTimeout() time.Duration {
+	return nc.NotificationTLSTimeout
+}
+
+// GetNotificationResponseHeaderTimeout refer the interface
+func (nc *NodeConfig) GetNotificationResponseHeaderTimeout() time.Duration {
+	return nc.NotificationHeaderTimeout
+}
+
+// GetNotificationOrderedDelivery refer the interface
+func (nc *NodeConfig) GetNotificationOrderedDelivery() bool {
+	return nc.NotificationOrderedDelivery
+}
+
+// GetNotificationMaxPayloadSize refer the interface
+func (nc *NodeConfig) GetNotificationMaxPayloadSize() int {
+	return nc.NotificationMaxPayloadSize
+}
+
+// GetNotificationOptInEvents refer the interface
+func (nc *NodeConfig) GetNotificationOptInEvents() []string {
+	return nc.NotificationOptInEvents
+}
+
 // GetEthereumNodeURL refer the interface
 func (nc *NodeConfig) GetEthereumNodeURL() string {
 	return nc.EthereumNodeURL
@@ -310,6 +506,51 @@ func (nc *NodeConfig) GetLowEntropyNFTTokenEnabled() bool {
 	return nc.LowEntropyNFTTokenEnabled
 }
 
+// GetNFTRegistryProperties refer the interface
+func (nc *NodeConfig) GetNFTRegistryProperties() map[string][]string {
+	return nc.NFTRegistryProperties
+}
+
+// GetNFTRegistryAttestationSchemes refer the interface
+func (nc *NodeConfig) GetNFTRegistryAttestationSchemes() map[string]string {
+	return nc.NFTRegistryAttestationSchemes
+}
+
+// GetNFTRegistryMethods refer the interface
+func (nc *NodeConfig) GetNFTRegistryMethods() map[string][]string {
+	return nc.NFTRegistryMethods
+}
+
+// GetNFTRegistryEvents refer the interface
+func (nc *NodeConfig) GetNFTRegistryEvents() map[string]string {
+	return nc.NFTRegistryEvents
+}
+
+// GetNFTMintGasPadding refer the interface
+func (nc *NodeConfig) GetNFTMintGasPadding() float64 {
+	return nc.NFTMintGasPadding
+}
+
+// GetNFTPrebindRegistries refer the interface
+func (nc *NodeConfig) GetNFTPrebindRegistries() bool {
+	return nc.NFTPrebindRegistries
+}
+
+// GetNFTReadCacheTTL refer the interface
+func (nc *NodeConfig) GetNFTReadCacheTTL() time.Duration {
+	return nc.NFTReadCacheTTL
+}
+
+// GetNFTRelayerURL refer the interface
+func (nc *NodeConfig) GetNFTRelayerURL() string {
+	return nc.NFTRelayerURL
+}
+
+// GetNFTRelayerSigningScheme refer the interface
+func (nc *NodeConfig) GetNFTRelayerSigningScheme() string {
+	return nc.NFTRelayerSigningScheme
+}
+
 // IsPProfEnabled refer the interface
 func (nc *NodeConfig) IsPProfEnabled() bool {
 	return nc.PprofEnabled
@@ -320,6 +561,11 @@ func (nc *NodeConfig) IsDebugLogEnabled() bool {
 	return nc.DebugLogEnabled
 }
 
+// GetDebugLogLevels refer the interface
+func (nc *NodeConfig) GetDebugLogLevels() map[string]string {
+	return nc.DebugLogLevels
+}
+
 // ID Gets the ID of the document represented by this model
 func (nc *NodeConfig) ID() ([]byte, error) {
 	return []byte{}, nil
@@ -379,6 +625,37 @@ func NewNodeConfig(c config.Configuration) config.Configuration {
 		NumWorkers:                     c.GetNumWorkers(),
 		WorkerWaitTimeMS:               c.GetWorkerWaitTimeMS(),
 		TaskValidDuration:              c.GetTaskValidDuration(),
+		TaskAckMode:                    c.GetTaskAckMode(),
+		TaskTimeouts:                   c.GetTaskTimeouts(),
+		TaskStoreResults:               c.GetTaskStoreResults(),
+		TaskScheduleCatchUp:            c.GetTaskScheduleCatchUp(),
+		TaskDedupWindow:                c.GetTaskDedupWindow(),
+		TaskMaxQueueDepth:              c.GetTaskMaxQueueDepth(),
+		TaskQueueFullTimeout:           c.GetTaskQueueFullTimeout(),
+		TaskEnqueueOnly:                c.GetTaskEnqueueOnly(),
+		TaskReconcilePolicy:            c.GetTaskReconcilePolicy(),
+		TaskReplayEnabled:              c.GetTaskReplayEnabled(),
+		JobHistoryEnabled:              c.GetJobHistoryEnabled(),
+		JobRecoveryPolicies:            c.GetJobRecoveryPolicies(),
+		JobMaxLogs:                     c.GetJobMaxLogs(),
+		JobReferenceKey:                c.GetJobReferenceKey(),
+		JobSerializationFormat:         c.GetJobSerializationFormat(),
+		JobPollInterval:                c.GetJobPollInterval(),
+		JobPollInitial:                 c.GetJobPollInitial(),
+		JobPollMax:                     c.GetJobPollMax(),
+		JobShutdownGracePeriod:         c.GetJobShutdownGracePeriod(),
+		JobContextClosedPolicy:         c.GetJobContextClosedPolicy(),
+		JobRetryAfter:                  c.GetJobRetryAfter(),
+		JobLogArchiveDir:               c.GetJobLogArchiveDir(),
+		JobLogArchiveThreshold:         c.GetJobLogArchiveThreshold(),
+		MaxConcurrentJobs:              c.GetMaxConcurrentJobs(),
+		JobCapacityPolicy:              c.GetJobCapacityPolicy(),
+		NotificationDialTimeout:        c.GetNotificationDialTimeout(),
+		NotificationTLSTimeout:         c.GetNotificationTLSHandshakeTimeout(),
+		NotificationHeaderTimeout:      c.GetNotificationResponseHeaderTimeout(),
+		NotificationOrderedDelivery:    c.GetNotificationOrderedDelivery(),
+		NotificationMaxPayloadSize:     c.GetNotificationMaxPayloadSize(),
+		NotificationOptInEvents:        c.GetNotificationOptInEvents(),
 		EthereumNodeURL:                c.GetEthereumNodeURL(),
 		EthereumContextReadWaitTimeout: c.GetEthereumContextReadWaitTimeout(),
 		EthereumContextWaitTimeout:     c.GetEthereumContextWaitTimeout(),
@@ -392,7 +669,17 @@ func NewNodeConfig(c config.Configuration) config.Configuration {
 		SmartContractAddresses:         extractSmartContractAddresses(c),
 		PprofEnabled:                   c.IsPProfEnabled(),
 		DebugLogEnabled:                c.IsDebugLogEnabled(),
+		DebugLogLevels:                 c.GetDebugLogLevels(),
 		LowEntropyNFTTokenEnabled:      c.GetLowEntropyNFTTokenEnabled(),
+		NFTRegistryProperties:          c.GetNFTRegistryProperties(),
+		NFTRegistryAttestationSchemes:  c.GetNFTRegistryAttestationSchemes(),
+		NFTRegistryMethods:             c.GetNFTRegistryMethods(),
+		NFTRegistryEvents:              c.GetNFTRegistryEvents(),
+		NFTMintGasPadding:              c.GetNFTMintGasPadding(),
+		NFTPrebindRegistries:           c.GetNFTPrebindRegistries(),
+		NFTReadCacheTTL:                c.GetNFTReadCacheTTL(),
+		NFTRelayerURL:                  c.GetNFTRelayerURL(),
+		NFTRelayerSigningScheme:        c.GetNFTRelayerSigningScheme(),
 		CentChainMaxRetries:            c.GetCentChainMaxRetries(),
 		CentChainIntervalRetry:         c.GetCentChainIntervalRetry(),
 		CentChainAnchorLifespan:        c.GetCentChainAnchorLifespan(),
@@ -424,6 +711,10 @@ type Account struct {
 	EthereumDefaultAccountName       string
 	EthereumContextWaitTimeout       time.Duration
 	ReceiveEventNotificationEndpoint string
+	ReceiveEventNotificationHeaders  map[string]string
+	ReceiveEventNotificationSecret   string
+	ReceiveEventNotificationVersion  int
+	ReceiveEventNotificationTemplate string
 	IdentityID                       []byte
 	SigningKeyPair                   KeyPair
 	P2PKeyPair                       KeyPair
@@ -457,6 +748,26 @@ func (acc *Account) GetReceiveEventNotificationEndpoint() string {
 	return acc.ReceiveEventNotificationEndpoint
 }
 
+// GetReceiveEventNotificationHeaders gets ReceiveEventNotificationHeaders
+func (acc *Account) GetReceiveEventNotificationHeaders() map[string]string {
+	return acc.ReceiveEventNotificationHeaders
+}
+
+// GetReceiveEventNotificationSecret gets ReceiveEventNotificationSecret
+func (acc *Account) GetReceiveEventNotificationSecret() string {
+	return acc.ReceiveEventNotificationSecret
+}
+
+// GetReceiveEventNotificationVersion gets ReceiveEventNotificationVersion
+func (acc *Account) GetReceiveEventNotificationVersion() int {
+	return acc.ReceiveEventNotificationVersion
+}
+
+// GetReceiveEventNotificationTemplate gets ReceiveEventNotificationTemplate
+func (acc *Account) GetReceiveEventNotificationTemplate() string {
+	return acc.ReceiveEventNotificationTemplate
+}
+
 // GetIdentityID gets IdentityID
 func (acc *Account) GetIdentityID() []byte {
 	return acc.IdentityID
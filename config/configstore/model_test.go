@@ -26,11 +26,61 @@ func (m *mockConfig) IsDebugLogEnabled() bool {
 	return args.Get(0).(bool)
 }
 
+func (m *mockConfig) GetDebugLogLevels() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
 func (m *mockConfig) GetLowEntropyNFTTokenEnabled() bool {
 	args := m.Called()
 	return args.Get(0).(bool)
 }
 
+func (m *mockConfig) GetNFTRegistryProperties() map[string][]string {
+	args := m.Called()
+	return args.Get(0).(map[string][]string)
+}
+
+func (m *mockConfig) GetNFTRegistryAttestationSchemes() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *mockConfig) GetNFTRegistryMethods() map[string][]string {
+	args := m.Called()
+	return args.Get(0).(map[string][]string)
+}
+
+func (m *mockConfig) GetNFTRegistryEvents() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *mockConfig) GetNFTMintGasPadding() float64 {
+	args := m.Called()
+	return args.Get(0).(float64)
+}
+
+func (m *mockConfig) GetNFTPrebindRegistries() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *mockConfig) GetNFTReadCacheTTL() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetNFTRelayerURL() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetNFTRelayerSigningScheme() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
 func (m *mockConfig) GetPrecommitEnabled() bool {
 	args := m.Called()
 	return args.Get(0).(bool)
@@ -163,6 +213,161 @@ func (m *mockConfig) GetWorkerWaitTimeMS() int {
 	return args.Get(0).(int)
 }
 
+func (m *mockConfig) GetTaskAckMode() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetTaskTimeouts() map[string]time.Duration {
+	args := m.Called()
+	return args.Get(0).(map[string]time.Duration)
+}
+
+func (m *mockConfig) GetTaskStoreResults() map[string]bool {
+	args := m.Called()
+	return args.Get(0).(map[string]bool)
+}
+
+func (m *mockConfig) GetTaskScheduleCatchUp() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetTaskDedupWindow() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetTaskMaxQueueDepth() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetTaskQueueFullTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetTaskEnqueueOnly() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *mockConfig) GetTaskReconcilePolicy() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetTaskReplayEnabled() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *mockConfig) GetJobHistoryEnabled() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *mockConfig) GetJobRecoveryPolicies() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *mockConfig) GetJobMaxLogs() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetJobReferenceKey() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetJobSerializationFormat() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetJobPollInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetJobPollInitial() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetJobPollMax() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetJobShutdownGracePeriod() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetJobContextClosedPolicy() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetJobRetryAfter() map[string]time.Duration {
+	args := m.Called()
+	return args.Get(0).(map[string]time.Duration)
+}
+
+func (m *mockConfig) GetJobLogArchiveDir() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetJobLogArchiveThreshold() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetMaxConcurrentJobs() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetJobCapacityPolicy() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetNotificationDialTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetNotificationTLSHandshakeTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetNotificationResponseHeaderTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetNotificationOrderedDelivery() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *mockConfig) GetNotificationMaxPayloadSize() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetNotificationOptInEvents() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
 func (m *mockConfig) GetEthereumNodeURL() string {
 	args := m.Called()
 	return args.Get(0).(string)
@@ -342,12 +547,53 @@ func createMockConfig() *mockConfig {
 	c.On("GetContractAddress", mock.Anything).Return(common.Address{})
 	c.On("IsPProfEnabled", mock.Anything).Return(true)
 	c.On("IsDebugLogEnabled", mock.Anything).Return(true)
+	c.On("GetDebugLogLevels").Return(map[string]string{"queue-server": "debug"}).Once()
 	c.On("GetLowEntropyNFTTokenEnabled", mock.Anything).Return(true)
+	c.On("GetNFTRegistryProperties").Return(map[string][]string{}).Once()
+	c.On("GetNFTRegistryAttestationSchemes").Return(map[string]string{}).Once()
+	c.On("GetNFTRegistryMethods").Return(map[string][]string{}).Once()
+	c.On("GetNFTRegistryEvents").Return(map[string]string{}).Once()
+	c.On("GetNFTMintGasPadding").Return(1.2).Once()
+	c.On("GetNFTPrebindRegistries").Return(true).Once()
+	c.On("GetNFTReadCacheTTL").Return(time.Minute).Once()
+	c.On("GetNFTRelayerURL").Return("http://localhost:8090/relay").Once()
+	c.On("GetNFTRelayerSigningScheme").Return("eth_sign").Once()
 	c.On("GetCentChainAccount").Return(config.CentChainAccount{}, nil).Once()
 	c.On("GetCentChainIntervalRetry").Return(time.Second).Once()
 	c.On("GetCentChainAnchorLifespan").Return(time.Second).Once()
 	c.On("GetCentChainMaxRetries").Return(1).Once()
 	c.On("GetCentChainNodeURL").Return("dummyNode").Once()
 	c.On("GetTaskValidDuration").Return(time.Minute).Once()
+	c.On("GetTaskAckMode").Return("after").Once()
+	c.On("GetTaskTimeouts").Return(map[string]time.Duration{"anchortask": time.Hour}).Once()
+	c.On("GetTaskStoreResults").Return(map[string]bool{"anchortask": false}).Once()
+	c.On("GetTaskScheduleCatchUp").Return("skip").Once()
+	c.On("GetTaskDedupWindow").Return(time.Duration(0)).Once()
+	c.On("GetTaskMaxQueueDepth").Return(0).Once()
+	c.On("GetTaskQueueFullTimeout").Return(time.Duration(0)).Once()
+	c.On("GetTaskEnqueueOnly").Return(false).Once()
+	c.On("GetTaskReconcilePolicy").Return("store").Once()
+	c.On("GetTaskReplayEnabled").Return(false).Once()
+	c.On("GetJobHistoryEnabled").Return(false).Once()
+	c.On("GetJobRecoveryPolicies").Return(map[string]string{"minting nft": "resume"}).Once()
+	c.On("GetJobMaxLogs").Return(100).Once()
+	c.On("GetJobReferenceKey").Return("request_id").Once()
+	c.On("GetJobSerializationFormat").Return("json").Once()
+	c.On("GetJobPollInterval").Return(10 * time.Millisecond).Once()
+	c.On("GetJobPollInitial").Return(10 * time.Millisecond).Once()
+	c.On("GetJobPollMax").Return(time.Second).Once()
+	c.On("GetJobShutdownGracePeriod").Return(10 * time.Second).Once()
+	c.On("GetJobContextClosedPolicy").Return("pending").Once()
+	c.On("GetJobRetryAfter").Return(map[string]time.Duration{"transient": 10 * time.Second}).Once()
+	c.On("GetJobLogArchiveDir").Return("").Once()
+	c.On("GetJobLogArchiveThreshold").Return(1000).Once()
+	c.On("GetMaxConcurrentJobs").Return(0).Once()
+	c.On("GetJobCapacityPolicy").Return("block").Once()
+	c.On("GetNotificationDialTimeout").Return(10 * time.Second).Once()
+	c.On("GetNotificationTLSHandshakeTimeout").Return(10 * time.Second).Once()
+	c.On("GetNotificationResponseHeaderTimeout").Return(30 * time.Second).Once()
+	c.On("GetNotificationOrderedDelivery").Return(true).Once()
+	c.On("GetNotificationMaxPayloadSize").Return(65536).Once()
+	c.On("GetNotificationOptInEvents").Return([]string{"job_retrying"}).Once()
 	return c
 }
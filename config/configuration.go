@@ -121,6 +121,37 @@ type Configuration interface {
 	GetNumWorkers() int
 	GetWorkerWaitTimeMS() int
 	GetTaskValidDuration() time.Duration
+	GetTaskAckMode() string
+	GetTaskTimeouts() map[string]time.Duration
+	GetTaskStoreResults() map[string]bool
+	GetTaskScheduleCatchUp() string
+	GetTaskDedupWindow() time.Duration
+	GetTaskMaxQueueDepth() int
+	GetTaskQueueFullTimeout() time.Duration
+	GetTaskEnqueueOnly() bool
+	GetTaskReconcilePolicy() string
+	GetTaskReplayEnabled() bool
+	GetJobHistoryEnabled() bool
+	GetJobRecoveryPolicies() map[string]string
+	GetJobMaxLogs() int
+	GetJobReferenceKey() string
+	GetJobSerializationFormat() string
+	GetJobPollInterval() time.Duration
+	GetJobPollInitial() time.Duration
+	GetJobPollMax() time.Duration
+	GetJobShutdownGracePeriod() time.Duration
+	GetJobContextClosedPolicy() string
+	GetJobRetryAfter() map[string]time.Duration
+	GetJobLogArchiveDir() string
+	GetJobLogArchiveThreshold() int
+	GetMaxConcurrentJobs() int
+	GetJobCapacityPolicy() string
+	GetNotificationDialTimeout() time.Duration
+	GetNotificationTLSHandshakeTimeout() time.Duration
+	GetNotificationResponseHeaderTimeout() time.Duration
+	GetNotificationOrderedDelivery() bool
+	GetNotificationMaxPayloadSize() int
+	GetNotificationOptInEvents() []string
 	GetEthereumNodeURL() string
 	GetEthereumContextReadWaitTimeout() time.Duration
 	GetEthereumContextWaitTimeout() time.Duration
@@ -153,10 +184,40 @@ type Configuration interface {
 	// NFT with large enough NFTRegistries (>100'000 tokens). It is not recommended to use this option.
 	GetLowEntropyNFTTokenEnabled() bool
 
+	// GetNFTRegistryProperties returns the token property slots expected by the NFT registries keyed by the lower cased registry address.
+	GetNFTRegistryProperties() map[string][]string
+
+	// GetNFTRegistryAttestationSchemes returns the mint attestation signing schemes of the NFT registries keyed by the lower cased registry address.
+	GetNFTRegistryAttestationSchemes() map[string]string
+
+	// GetNFTRegistryMethods returns the registry methods the node may call keyed by the lower cased registry address.
+	GetNFTRegistryMethods() map[string][]string
+
+	// GetNFTRegistryEvents returns the ABI of the mint events of the NFT registries keyed by the lower cased registry address.
+	GetNFTRegistryEvents() map[string]string
+
+	// GetNFTMintGasPadding returns the multiplier applied to the gas estimated for the mint transactions, 0 disables the estimation.
+	GetNFTMintGasPadding() float64
+
+	// GetNFTPrebindRegistries returns true if the contracts of the configured NFT registries are bound at startup.
+	GetNFTPrebindRegistries() bool
+
+	// GetNFTReadCacheTTL returns how long the last known values of the NFT chain reads are served while the chain is unreachable.
+	GetNFTReadCacheTTL() time.Duration
+
+	// GetNFTRelayerURL returns the endpoint of the meta-transaction relayer the mints are submitted through.
+	GetNFTRelayerURL() string
+
+	// GetNFTRelayerSigningScheme returns the scheme the meta-transactions posted to the relayer are signed with.
+	GetNFTRelayerSigningScheme() string
+
 	// debug specific methods
 	IsPProfEnabled() bool
 	IsDebugLogEnabled() bool
 
+	// GetDebugLogLevels returns the log levels of the subsystems keyed by the logger name.
+	GetDebugLogLevels() map[string]string
+
 	// CentChain specific details.
 	GetCentChainAccount() (CentChainAccount, error)
 	GetCentChainIntervalRetry() time.Duration
@@ -173,6 +234,10 @@ type Account interface {
 	GetEthereumAccount() *AccountConfig
 	GetEthereumDefaultAccountName() string
 	GetReceiveEventNotificationEndpoint() string
+	GetReceiveEventNotificationHeaders() map[string]string
+	GetReceiveEventNotificationSecret() string
+	GetReceiveEventNotificationVersion() int
+	GetReceiveEventNotificationTemplate() string
 	GetIdentityID() []byte
 	GetP2PKeyPair() (pub, priv string)
 	GetSigningKeyPair() (pub, priv string)
@@ -340,6 +405,51 @@ func (c *configuration) GetReceiveEventNotificationEndpoint() string {
 	return c.GetString("notifications.endpoint")
 }
 
+// GetJobRecoveryPolicies returns the recovery policies of the jobs pending at node start keyed by the lower cased job description.
+func (c *configuration) GetJobRecoveryPolicies() map[string]string {
+	policies := make(map[string]string)
+	for desc, policy := range cast.ToStringMapString(c.get("jobs.recovery")) {
+		policies[strings.ToLower(desc)] = policy
+	}
+
+	return policies
+}
+
+// GetNotificationDialTimeout returns the timeout to connect to the webhook endpoint, including the DNS resolution.
+func (c *configuration) GetNotificationDialTimeout() time.Duration {
+	return c.GetDuration("notifications.dialTimeout")
+}
+
+// GetNotificationTLSHandshakeTimeout returns the timeout for the TLS handshake with the webhook endpoint.
+func (c *configuration) GetNotificationTLSHandshakeTimeout() time.Duration {
+	return c.GetDuration("notifications.tlsHandshakeTimeout")
+}
+
+// GetNotificationResponseHeaderTimeout returns the timeout to wait for the webhook endpoint response headers.
+func (c *configuration) GetNotificationResponseHeaderTimeout() time.Duration {
+	return c.GetDuration("notifications.responseHeaderTimeout")
+}
+
+// GetNotificationOrderedDelivery returns true if the job notifications of an account are delivered one at a time in the order they are sent.
+func (c *configuration) GetNotificationOrderedDelivery() bool {
+	return c.GetBool("notifications.orderedDelivery")
+}
+
+// GetNotificationMaxPayloadSize returns the max size in bytes of the webhook payloads, 0 means no limit.
+func (c *configuration) GetNotificationMaxPayloadSize() int {
+	return c.GetInt("notifications.maxPayloadSize")
+}
+
+// GetNotificationOptInEvents returns the names of the opt-in notification events sent to the webhooks.
+func (c *configuration) GetNotificationOptInEvents() []string {
+	var events []string
+	for _, event := range cast.ToStringSlice(c.get("notifications.optInEvents")) {
+		events = append(events, strings.ToLower(event))
+	}
+
+	return events
+}
+
 // GetServerPort returns the defined server port in the config.
 func (c *configuration) GetServerPort() int {
 	return c.GetInt("nodePort")
@@ -364,6 +474,142 @@ func (c *configuration) GetTaskValidDuration() time.Duration {
 	return c.GetDuration("queue.ValidFor")
 }
 
+// GetTaskAckMode returns when the queue tasks are acknowledged to the broker, before or after their execution.
+func (c *configuration) GetTaskAckMode() string {
+	return c.GetString("queue.ackMode")
+}
+
+// GetTaskTimeouts returns the durations overriding the task valid duration keyed by the lower cased task type name.
+func (c *configuration) GetTaskTimeouts() map[string]time.Duration {
+	timeouts := make(map[string]time.Duration)
+	for name, timeout := range cast.ToStringMapString(c.get("queue.taskTimeouts")) {
+		timeouts[strings.ToLower(name)] = cast.ToDuration(timeout)
+	}
+
+	return timeouts
+}
+
+// GetTaskStoreResults returns whether the results of the tasks are stored keyed by the lower cased task type name.
+func (c *configuration) GetTaskStoreResults() map[string]bool {
+	store := make(map[string]bool)
+	for name, v := range cast.ToStringMap(c.get("queue.taskStoreResults")) {
+		store[strings.ToLower(name)] = cast.ToBool(v)
+	}
+
+	return store
+}
+
+// GetTaskScheduleCatchUp returns what happens to the runs of the scheduled tasks missed while the node was down.
+func (c *configuration) GetTaskScheduleCatchUp() string {
+	return c.GetString("queue.scheduleCatchUp")
+}
+
+// GetTaskDedupWindow returns the window within which the enqueues of a task with the same dedup key return the first result.
+func (c *configuration) GetTaskDedupWindow() time.Duration {
+	return c.GetDuration("queue.dedupWindow")
+}
+
+// GetTaskMaxQueueDepth returns the maximum number of tasks waiting in the queue, 0 is unbounded.
+func (c *configuration) GetTaskMaxQueueDepth() int {
+	return c.GetInt("queue.maxDepth")
+}
+
+// GetTaskQueueFullTimeout returns how long an enqueue waits for room in a full queue before failing.
+func (c *configuration) GetTaskQueueFullTimeout() time.Duration {
+	return c.GetDuration("queue.fullTimeout")
+}
+
+// GetTaskEnqueueOnly returns true if the node only enqueues the tasks and leaves running them to other nodes.
+func (c *configuration) GetTaskEnqueueOnly() bool {
+	return c.GetBool("queue.enqueueOnly")
+}
+
+// GetTaskReconcilePolicy returns how the discrepancies between the jobs and the queued tasks are resolved at start.
+// Empty disables the reconciliation.
+func (c *configuration) GetTaskReconcilePolicy() string {
+	return c.GetString("queue.reconcilePolicy")
+}
+
+// GetTaskReplayEnabled returns true if the tasks enqueued for the jobs are recorded so that they can be replayed.
+func (c *configuration) GetTaskReplayEnabled() bool {
+	return c.GetBool("queue.replayEnabled")
+}
+
+// GetJobHistoryEnabled returns true if the status transitions of the jobs are recorded.
+func (c *configuration) GetJobHistoryEnabled() bool {
+	return c.GetBool("jobs.historyEnabled")
+}
+
+// GetJobMaxLogs returns the max number of logs kept per job. 0 keeps all the logs.
+func (c *configuration) GetJobMaxLogs() int {
+	return c.GetInt("jobs.maxLogs")
+}
+
+// GetJobReferenceKey returns the metadata key the jobs are indexed by.
+func (c *configuration) GetJobReferenceKey() string {
+	return c.GetString("jobs.referenceKey")
+}
+
+// GetJobSerializationFormat returns the format the jobs are saved in, "json" or "msgpack".
+func (c *configuration) GetJobSerializationFormat() string {
+	return c.GetString("jobs.serializationFormat")
+}
+
+// GetJobPollInterval returns how often the job status is checked while waiting for a job.
+func (c *configuration) GetJobPollInterval() time.Duration {
+	return c.GetDuration("jobs.pollInterval")
+}
+
+// GetJobPollInitial returns how long WaitForJob waits before checking the job status again the first time.
+func (c *configuration) GetJobPollInitial() time.Duration {
+	return c.GetDuration("jobs.pollInitial")
+}
+
+// GetJobPollMax returns the longest WaitForJob waits between two checks of the job status.
+func (c *configuration) GetJobPollMax() time.Duration {
+	return c.GetDuration("jobs.pollMax")
+}
+
+// GetJobShutdownGracePeriod returns how long the running jobs are given to complete when the node shuts down.
+func (c *configuration) GetJobShutdownGracePeriod() time.Duration {
+	return c.GetDuration("jobs.shutdownGracePeriod")
+}
+
+// GetJobContextClosedPolicy returns what happens to the pending jobs whose context is closed before their work completes.
+func (c *configuration) GetJobContextClosedPolicy() string {
+	return strings.ToLower(c.GetString("jobs.contextClosedPolicy"))
+}
+
+// GetJobRetryAfter returns how long the clients are asked to wait before retrying a failed job keyed by the failure category.
+func (c *configuration) GetJobRetryAfter() map[string]time.Duration {
+	retryAfter := make(map[string]time.Duration)
+	for category, d := range cast.ToStringMapString(c.get("jobs.retryAfter")) {
+		retryAfter[strings.ToLower(category)] = cast.ToDuration(d)
+	}
+
+	return retryAfter
+}
+
+// GetJobLogArchiveDir returns the directory the oldest logs of the jobs are archived to. Empty disables the archive.
+func (c *configuration) GetJobLogArchiveDir() string {
+	return c.GetString("jobs.logArchive.dir")
+}
+
+// GetJobLogArchiveThreshold returns the number of logs a job holds before its oldest logs are archived.
+func (c *configuration) GetJobLogArchiveThreshold() int {
+	return c.GetInt("jobs.logArchive.threshold")
+}
+
+// GetMaxConcurrentJobs returns the max number of jobs running on the node at once. 0 doesn't limit the jobs.
+func (c *configuration) GetMaxConcurrentJobs() int {
+	return c.GetInt("jobs.maxConcurrent")
+}
+
+// GetJobCapacityPolicy returns what happens to the jobs submitted while the node runs the max number of jobs.
+func (c *configuration) GetJobCapacityPolicy() string {
+	return strings.ToLower(c.GetString("jobs.capacityPolicy"))
+}
+
 // GetEthereumNodeURL returns the URL of the Ethereum Node.
 func (c *configuration) GetEthereumNodeURL() string {
 	return c.GetString("ethereum.nodeURL")
@@ -527,6 +773,11 @@ func (c *configuration) IsDebugLogEnabled() bool {
 	return c.GetBool("debug.log")
 }
 
+// GetDebugLogLevels returns the log levels of the subsystems keyed by the logger name.
+func (c *configuration) GetDebugLogLevels() map[string]string {
+	return cast.ToStringMapString(c.get("debug.logLevels"))
+}
+
 // GetPrecommitEnabled returns true if precommit for anchors is enabled
 func (c *configuration) GetPrecommitEnabled() bool {
 	return c.GetBool("anchoring.precommit")
@@ -537,6 +788,76 @@ func (c *configuration) GetLowEntropyNFTTokenEnabled() bool {
 	return c.GetBool("nft.lowEntropyTokenIDEnabled")
 }
 
+// GetNFTRegistryProperties returns the token property slots expected by the NFT registries keyed by the lower cased registry address.
+func (c *configuration) GetNFTRegistryProperties() map[string][]string {
+	props := make(map[string][]string)
+	for registry, slots := range cast.ToStringMapStringSlice(c.get("nft.registryProperties")) {
+		props[strings.ToLower(registry)] = slots
+	}
+
+	return props
+}
+
+// GetNFTRegistryAttestationSchemes returns the mint attestation signing schemes of the NFT registries keyed by the lower cased registry address.
+func (c *configuration) GetNFTRegistryAttestationSchemes() map[string]string {
+	schemes := make(map[string]string)
+	for registry, scheme := range cast.ToStringMapString(c.get("nft.registryAttestations")) {
+		schemes[strings.ToLower(registry)] = strings.ToLower(scheme)
+	}
+
+	return schemes
+}
+
+// GetNFTRegistryMethods returns the registry methods the node may call keyed by the lower cased registry address.
+func (c *configuration) GetNFTRegistryMethods() map[string][]string {
+	methods := make(map[string][]string)
+	for registry, names := range cast.ToStringMapStringSlice(c.get("nft.registryMethods")) {
+		var lowered []string
+		for _, name := range names {
+			lowered = append(lowered, strings.ToLower(name))
+		}
+
+		methods[strings.ToLower(registry)] = lowered
+	}
+
+	return methods
+}
+
+// GetNFTRegistryEvents returns the ABI of the mint events of the NFT registries keyed by the lower cased registry address.
+func (c *configuration) GetNFTRegistryEvents() map[string]string {
+	events := make(map[string]string)
+	for registry, eventABI := range cast.ToStringMapString(c.get("nft.registryEvents")) {
+		events[strings.ToLower(registry)] = eventABI
+	}
+
+	return events
+}
+
+// GetNFTMintGasPadding returns the multiplier applied to the gas estimated for the mint transactions, 0 disables the estimation.
+func (c *configuration) GetNFTMintGasPadding() float64 {
+	return cast.ToFloat64(c.get("nft.mintGasPadding"))
+}
+
+// GetNFTPrebindRegistries returns true if the contracts of the configured NFT registries are bound at startup.
+func (c *configuration) GetNFTPrebindRegistries() bool {
+	return c.GetBool("nft.prebindRegistries")
+}
+
+// GetNFTReadCacheTTL returns how long the last known values of the NFT chain reads are served while the chain is unreachable.
+func (c *configuration) GetNFTReadCacheTTL() time.Duration {
+	return c.GetDuration("nft.readCacheTTL")
+}
+
+// GetNFTRelayerURL returns the endpoint of the meta-transaction relayer the mints are submitted through.
+func (c *configuration) GetNFTRelayerURL() string {
+	return c.GetString("nft.relayer.url")
+}
+
+// GetNFTRelayerSigningScheme returns the scheme the meta-transactions posted to the relayer are signed with.
+func (c *configuration) GetNFTRelayerSigningScheme() string {
+	return strings.ToLower(c.GetString("nft.relayer.signingScheme"))
+}
+
 // LoadConfiguration loads the configuration from the given file.
 func LoadConfiguration(configFile string) Configuration {
 	cfg := &configuration{configFile: configFile, mu: sync.RWMutex{}}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/stretchr/testify/assert"
@@ -56,6 +57,22 @@ func TestConfiguration_CreateConfigFile(t *testing.T) {
 
 	cfg := c.(*configuration)
 	assert.NotNil(t, cfg.GetP2PResponseDelay())
+	assert.Len(t, cfg.GetTaskTimeouts(), 0)
+	cfg.Set("queue.taskTimeouts", map[string]interface{}{"SlowTask": "1h"})
+	assert.Equal(t, map[string]time.Duration{"slowtask": time.Hour}, cfg.GetTaskTimeouts())
+	assert.Len(t, cfg.GetTaskStoreResults(), 0)
+	cfg.Set("queue.taskStoreResults", map[string]interface{}{"NoisyTask": false})
+	assert.Equal(t, map[string]bool{"noisytask": false}, cfg.GetTaskStoreResults())
+	assert.Equal(t, map[string]time.Duration{"transient": 10 * time.Second, "rate_limited": time.Minute}, cfg.GetJobRetryAfter())
+	assert.Len(t, cfg.GetNFTRegistryProperties(), 0)
+	cfg.Set("nft.registryProperties", map[string]interface{}{"0xABC": []string{"amount", "due_date"}})
+	assert.Equal(t, map[string][]string{"0xabc": {"amount", "due_date"}}, cfg.GetNFTRegistryProperties())
+	assert.Len(t, cfg.GetNFTRegistryMethods(), 0)
+	cfg.Set("nft.registryMethods", map[string]interface{}{"0xABC": []string{"Mint", "transfer"}})
+	assert.Equal(t, map[string][]string{"0xabc": {"mint", "transfer"}}, cfg.GetNFTRegistryMethods())
+	assert.Len(t, cfg.GetNFTRegistryEvents(), 0)
+	cfg.Set("nft.registryEvents", map[string]interface{}{"0xABC": `[{"name":"Mint","type":"event"}]`})
+	assert.Equal(t, map[string]string{"0xabc": `[{"name":"Mint","type":"event"}]`}, cfg.GetNFTRegistryEvents())
 
 	assert.NoError(t, os.RemoveAll(targetDir))
 }
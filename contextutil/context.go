@@ -27,8 +27,26 @@ const (
 	job = contextKey("job")
 
 	nonce = contextKey("nonce")
+
+	request = contextKey("request")
+
+	document = contextKey("document")
 )
 
+// RequestInfo holds the details of the API request that triggered an action.
+// Headers and body are deliberately left out so that nothing sensitive ends up in jobs or notifications.
+type RequestInfo struct {
+	Method    string
+	Path      string
+	RequestID string
+}
+
+// DocumentVersion identifies the document version whose change triggered an action.
+type DocumentVersion struct {
+	DocumentID []byte
+	VersionID  []byte
+}
+
 // New creates new instance of the request headers.
 func New(ctx context.Context, cfg config.Account) (context.Context, error) {
 	return context.WithValue(ctx, self, cfg), nil
@@ -44,6 +62,28 @@ func WithNonce(ctx context.Context, n uint32) context.Context {
 	return context.WithValue(ctx, nonce, n)
 }
 
+// WithRequest returns a context with the originating API request details
+func WithRequest(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, request, info)
+}
+
+// Request returns the originating API request details if present.
+func Request(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(request).(RequestInfo)
+	return info, ok
+}
+
+// WithDocumentVersion returns a context with the document version whose change triggered the action
+func WithDocumentVersion(ctx context.Context, documentID, versionID []byte) context.Context {
+	return context.WithValue(ctx, document, DocumentVersion{DocumentID: documentID, VersionID: versionID})
+}
+
+// Document returns the document version whose change triggered the action if present.
+func Document(ctx context.Context) (DocumentVersion, bool) {
+	dv, ok := ctx.Value(document).(DocumentVersion)
+	return dv, ok
+}
+
 // Job returns current jobID
 func Job(ctx context.Context) jobs.JobID {
 	jobID, ok := ctx.Value(job).(jobs.JobID)
@@ -114,6 +154,8 @@ func Copy(ctx context.Context) context.Context {
 	nctx := context.WithValue(context.Background(), self, ctx.Value(self))
 	nctx = context.WithValue(nctx, job, ctx.Value(job))
 	nctx = context.WithValue(nctx, nonce, ctx.Value(nonce))
+	nctx = context.WithValue(nctx, request, ctx.Value(request))
+	nctx = context.WithValue(nctx, document, ctx.Value(document))
 	return nctx
 }
 
@@ -31,3 +31,19 @@ func TestDIDFromContext(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, did, ddid)
 }
+
+func TestDocument(t *testing.T) {
+	// missing document
+	_, ok := Document(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithDocumentVersion(context.Background(), []byte{1}, []byte{2})
+	dv, ok := Document(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, DocumentVersion{DocumentID: []byte{1}, VersionID: []byte{2}}, dv)
+
+	// copied along
+	dv, ok = Document(Copy(ctx))
+	assert.True(t, ok)
+	assert.Equal(t, []byte{2}, dv.VersionID)
+}
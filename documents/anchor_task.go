@@ -20,9 +20,11 @@ const (
 	DocumentIDParam = "documentID"
 
 	// AccountIDParam maps to account ID in the kwargs
-	AccountIDParam = "accountID"
+	AccountIDParam = jobs.AccountIDParam
 
 	documentAnchorTaskName = "Document Anchoring"
+
+	anchorJobDescription = "anchor document"
 )
 
 var log = logging.Logger("anchor_task")
@@ -138,10 +140,19 @@ func initDocumentAnchorTask(jobMan jobs.Manager, tq queue.TaskQueuer, accountID
 	return tr, nil
 }
 
-// CreateAnchorJob creates a job for anchoring a document using jobs manager
-func CreateAnchorJob(parentCtx context.Context, jobsMan jobs.Manager, tq queue.TaskQueuer, self identity.DID, jobID jobs.JobID, documentID []byte) (jobs.JobID, chan error, error) {
-	jobID, done, err := jobsMan.ExecuteWithinJob(contextutil.Copy(parentCtx), self, jobID, "anchor document", func(accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error) {
-		tr, err := initDocumentAnchorTask(jobsMan, tq, accountID, documentID, jobID)
+// CreateAnchorJob creates a job for anchoring the version of the document using jobs manager.
+// The jobs created are tagged with the document version so that they can be looked up by it.
+func CreateAnchorJob(parentCtx context.Context, jobsMan jobs.Manager, tq queue.TaskQueuer, self identity.DID, jobID jobs.JobID, documentID, versionID []byte) (jobs.JobID, chan error, error) {
+	ctx := contextutil.WithDocumentVersion(contextutil.Copy(parentCtx), documentID, versionID)
+	jobID, done, err := jobsMan.ExecuteWithinJob(ctx, self, jobID, anchorJobDescription, func(accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error) {
+		// the version is recorded so that the job can be retried
+		err := jobsMan.UpdateJobWithValue(accountID, jobID, DocumentIDParam, versionID)
+		if err != nil {
+			errChan <- err
+			return
+		}
+
+		tr, err := initDocumentAnchorTask(jobsMan, tq, accountID, versionID, jobID)
 		if err != nil {
 			errChan <- err
 			return
@@ -155,3 +166,18 @@ func CreateAnchorJob(parentCtx context.Context, jobsMan jobs.Manager, tq queue.T
 	})
 	return jobID, done, err
 }
+
+// anchorJobRetrier returns the retrier re-running the failed anchor jobs for the document recorded in the job.
+func anchorJobRetrier(jobsMan jobs.Manager, tq queue.TaskQueuer) jobs.Retrier {
+	return func(ctx context.Context, job *jobs.Job) (jobs.JobID, error) {
+		v, ok := job.Values[DocumentIDParam]
+		if !ok {
+			return jobs.NilJobID(), errors.New("job %s has no document recorded", job.ID.String())
+		}
+
+		// the document ID is only known if the retried job was tagged with it
+		documentID, _ := hexutil.Decode(job.Metadata[jobs.DocumentIDKey])
+		jobID, _, err := CreateAnchorJob(ctx, jobsMan, tq, job.DID, jobs.NilJobID(), documentID, v.Value)
+		return jobID, err
+	}
+}
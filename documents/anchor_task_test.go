@@ -3,13 +3,16 @@
 package documents
 
 import (
+	"context"
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDocumentAnchorTask_ParseKwargs(t *testing.T) {
@@ -84,3 +87,23 @@ func TestDocumentAnchorTask_ParseKwargs(t *testing.T) {
 		})
 	}
 }
+
+func TestAnchorJobRetrier(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	job := jobs.NewJob(did, anchorJobDescription)
+	jobMan := testingjobs.MockJobManager{}
+
+	// no document recorded
+	_, err := anchorJobRetrier(jobMan, nil)(context.Background(), job)
+	assert.Error(t, err)
+
+	// new anchor job
+	docID := utils.RandomSlice(32)
+	job.Values[DocumentIDParam] = jobs.JobValue{Key: DocumentIDParam, Value: docID}
+	newID := jobs.NewJobID()
+	jobMan.On("ExecuteWithinJob", mock.Anything, did, jobs.NilJobID(), anchorJobDescription, mock.Anything).Return(newID, make(chan error), nil).Once()
+	id, err := anchorJobRetrier(jobMan, nil)(context.Background(), job)
+	assert.NoError(t, err)
+	assert.Equal(t, newID, id)
+	jobMan.AssertExpectations(t)
+}
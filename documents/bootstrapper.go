@@ -64,6 +64,8 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("transaction service not initialised")
 	}
 
+	jobs.RegisterFailureClassifier(classifyFailure)
+	jobManager.RegisterRetrier(anchorJobDescription, anchorJobRetrier(jobManager, queueSrv))
 	ctx[BootstrappedDocumentService] = DefaultService(cfg, repo, anchorSrv, registry, didService, queueSrv, jobManager)
 	ctx[BootstrappedRegistry] = registry
 	ctx[BootstrappedDocumentRepository] = repo
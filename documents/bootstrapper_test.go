@@ -7,6 +7,7 @@ import (
 
 	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
@@ -39,4 +40,5 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	assert.NotNil(t, ctx[BootstrappedRegistry])
 	_, ok := ctx[BootstrappedRegistry].(*ServiceRegistry)
 	assert.True(t, ok)
+	assert.Equal(t, jobs.FailureValidation, jobs.ClassifyFailure(errors.NewTypedError(ErrDocumentValidation, errors.New("invalid"))))
 }
@@ -24,7 +24,6 @@ import (
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
-	"github.com/centrifuge/gocelery"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -35,7 +34,7 @@ func getServiceWithMockedLayers() (testingcommons.MockIdentityService, *testingc
 	idService := testingcommons.MockIdentityService{}
 	idService.On("IsSignedWithPurpose", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Once()
 	queueSrv := new(testingutils.MockQueue)
-	queueSrv.On("EnqueueJob", mock.Anything, mock.Anything).Return(&gocelery.AsyncResult{}, nil)
+	queueSrv.On("EnqueueJob", mock.Anything, mock.Anything).Return(new(testingutils.MockTaskResult), nil)
 	idFactory := new(testingcommons.MockIdentityFactory)
 	repo := testRepo()
 	anchorSrv := &testinganchors.MockAnchorService{}
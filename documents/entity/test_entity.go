@@ -77,6 +77,13 @@ func (m *MockEntityRelationService) GetEntityRelationships(ctx context.Context,
 	return rs, args.Error(1)
 }
 
+// GetRelationshipsSharedWithSelf returns a list of the latest versions of the entity relationships shared with the account
+func (m *MockEntityRelationService) GetRelationshipsSharedWithSelf(ctx context.Context) ([]documents.Model, error) {
+	args := m.Called(ctx)
+	rs, _ := args.Get(0).([]documents.Model)
+	return rs, args.Error(1)
+}
+
 type MockService struct {
 	Service
 	mock.Mock
@@ -18,6 +18,9 @@ type repository interface {
 
 	// ListAllRelationships returns a list of all relationships in which a given entity is involved
 	ListAllRelationships(entityIdentifier []byte, ownerDID identity.DID) (map[string][]byte, error)
+
+	// ListRelationshipsSharedWith returns a list of all relationships in which the given DID is the target
+	ListRelationshipsSharedWith(targetDID identity.DID) (map[string][]byte, error)
 }
 
 type repo struct {
@@ -84,3 +87,23 @@ func (r *repo) ListAllRelationships(entityIdentifier []byte, ownerDID identity.D
 
 	return relationships, nil
 }
+
+// ListRelationshipsSharedWith returns a list of all entity relationship identifiers in which the targetDID is the target
+func (r *repo) ListRelationshipsSharedWith(targetDID identity.DID) (map[string][]byte, error) {
+	allDocuments, err := r.db.GetAllByPrefix(documents.DocPrefix + hexutil.Encode(targetDID[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	relationships := make(map[string][]byte)
+	for _, r := range allDocuments {
+		e, ok := r.(*EntityRelationship)
+		if !ok || e.Data.TargetIdentity == nil || !targetDID.Equal(*e.Data.TargetIdentity) {
+			continue
+		}
+
+		relationships[string(e.Document.DocumentIdentifier)] = e.Document.DocumentIdentifier
+	}
+
+	return relationships, nil
+}
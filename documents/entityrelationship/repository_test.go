@@ -63,3 +63,26 @@ func TestRepo_ListAllRelationships(t *testing.T) {
 	r, err = repo.ListAllRelationships(id, did)
 	assert.Len(t, r, 1)
 }
+
+func TestRepo_ListRelationshipsSharedWith(t *testing.T) {
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	repo := testEntityRepo()
+	tid := testingidentity.GenerateRandomDID()
+
+	// no relationships shared
+	r, err := repo.ListRelationshipsSharedWith(tid)
+	assert.NoError(t, err)
+	assert.Len(t, r, 0)
+
+	// relationship shared with target and one that isn't
+	m := CreateRelationship(t, ctxh)
+	m.Data.TargetIdentity = &tid
+	assert.NoError(t, repo.Create(tid[:], m.ID(), m))
+	m2 := CreateRelationship(t, ctxh)
+	assert.NoError(t, repo.Create(tid[:], m2.ID(), m2))
+
+	r, err = repo.ListRelationshipsSharedWith(tid)
+	assert.NoError(t, err)
+	assert.Len(t, r, 1)
+	assert.Equal(t, m.ID(), r[string(m.ID())])
+}
@@ -20,6 +20,9 @@ type Service interface {
 
 	// GetEntityRelationships returns a list of the latest versions of the relevant entity relationship based on an entity id
 	GetEntityRelationships(ctx context.Context, entityID []byte) ([]documents.Model, error)
+
+	// GetRelationshipsSharedWithSelf returns a list of the latest versions of the entity relationships shared with the account
+	GetRelationshipsSharedWithSelf(ctx context.Context) ([]documents.Model, error)
 }
 
 // service implements Service and handles all entity related persistence and validations
@@ -104,7 +107,7 @@ func (s service) Create(ctx context.Context, relationship documents.Model) (docu
 	}
 
 	jobID := contextutil.Job(ctx)
-	jobID, done, err := documents.CreateAnchorJob(ctx, s.jobManager, s.queueSrv, selfDID, jobID, relationship.CurrentVersion())
+	jobID, done, err := documents.CreateAnchorJob(ctx, s.jobManager, s.queueSrv, selfDID, jobID, relationship.ID(), relationship.CurrentVersion())
 	if err != nil {
 		return nil, jobs.NilJobID(), nil, err
 	}
@@ -130,7 +133,7 @@ func (s service) Update(ctx context.Context, updated documents.Model) (documents
 	}
 
 	jobID := contextutil.Job(ctx)
-	jobID, done, err := documents.CreateAnchorJob(ctx, s.jobManager, s.queueSrv, selfDID, jobID, updated.CurrentVersion())
+	jobID, done, err := documents.CreateAnchorJob(ctx, s.jobManager, s.queueSrv, selfDID, jobID, updated.ID(), updated.CurrentVersion())
 	if err != nil {
 		return nil, jobs.NilJobID(), nil, err
 	}
@@ -169,6 +172,30 @@ func (s service) GetEntityRelationships(ctx context.Context, entityID []byte) ([
 	return relationships, nil
 }
 
+// GetRelationshipsSharedWithSelf returns the latest versions of the entity relationships in which the account is the target
+func (s service) GetRelationshipsSharedWithSelf(ctx context.Context) ([]documents.Model, error) {
+	selfDID, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, errors.New("failed to get self ID")
+	}
+
+	relevant, err := s.repo.ListRelationshipsSharedWith(selfDID)
+	if err != nil {
+		return nil, err
+	}
+
+	var relationships []documents.Model
+	for _, v := range relevant {
+		r, err := s.GetCurrentVersion(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		relationships = append(relationships, r)
+	}
+
+	return relationships, nil
+}
+
 // CreateModel creates entity relationship from the payload, validates, persists, and returns the document.
 func (s service) CreateModel(ctx context.Context, payload documents.CreatePayload) (documents.Model, jobs.JobID, error) {
 	e := new(EntityRelationship)
@@ -190,7 +217,7 @@ func (s service) CreateModel(ctx context.Context, payload documents.CreatePayloa
 	}
 
 	jobID := contextutil.Job(ctx)
-	jobID, _, err = documents.CreateAnchorJob(ctx, s.jobManager, s.queueSrv, did, jobID, e.CurrentVersion())
+	jobID, _, err = documents.CreateAnchorJob(ctx, s.jobManager, s.queueSrv, did, jobID, e.ID(), e.CurrentVersion())
 	return e, jobID, err
 }
 
@@ -232,7 +259,7 @@ func (s service) UpdateModel(ctx context.Context, payload documents.UpdatePayloa
 	}
 
 	jobID := contextutil.Job(ctx)
-	jobID, _, err = documents.CreateAnchorJob(ctx, s.jobManager, s.queueSrv, did, jobID, er.CurrentVersion())
+	jobID, _, err = documents.CreateAnchorJob(ctx, s.jobManager, s.queueSrv, did, jobID, er.ID(), er.CurrentVersion())
 	return er, jobID, err
 }
 
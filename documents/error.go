@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
 )
 
 const (
@@ -192,3 +193,12 @@ func NewError(key, msg string) error {
 	err := errors.New(msg)
 	return Error{key: key, err: err}
 }
+
+// classifyFailure recognises the document validation errors as failures that happen again when retried.
+func classifyFailure(err error) (jobs.FailureCategory, bool) {
+	if errors.IsOfType(ErrDocumentValidation, err) || errors.IsOfType(ErrDocumentInvalid, err) {
+		return jobs.FailureValidation, true
+	}
+
+	return "", false
+}
@@ -16,7 +16,6 @@ import (
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
-	"github.com/centrifuge/gocelery"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -49,7 +48,7 @@ func getServiceWithMockedLayers() (testingcommons.MockIdentityService, documents
 	idService := testingcommons.MockIdentityService{}
 	idService.On("IsSignedWithPurpose", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Once()
 	queueSrv := new(testingutils.MockQueue)
-	queueSrv.On("EnqueueJob", mock.Anything, mock.Anything).Return(&gocelery.AsyncResult{}, nil)
+	queueSrv.On("EnqueueJob", mock.Anything, mock.Anything).Return(new(testingutils.MockTaskResult), nil)
 
 	repo := testRepo()
 	anchorSrv := &testinganchors.MockAnchorService{}
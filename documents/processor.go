@@ -11,6 +11,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,6 +23,7 @@ type Config interface {
 	GetIdentityID() ([]byte, error)
 	GetP2PConnectionTimeout() time.Duration
 	GetContractAddress(contractName config.ContractName) common.Address
+	notification.Config
 }
 
 // DocumentRequestProcessor offers methods to interact with the p2p layer to request documents.
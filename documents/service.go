@@ -128,7 +128,7 @@ func DefaultService(
 		config:     config,
 		repo:       repo,
 		anchorSrv:  anchorSrv,
-		notifier:   notification.NewWebhookSender(),
+		notifier:   notification.NewWebhookSender(config, nil),
 		registry:   registry,
 		idService:  idService,
 		queueSrv:   queueSrv,
@@ -497,7 +497,7 @@ func (s service) Commit(ctx context.Context, model Model) (jobs.JobID, error) {
 	}
 
 	jobID := contextutil.Job(ctx)
-	jobID, _, err = CreateAnchorJob(ctx, s.jobManager, s.queueSrv, did, jobID, model.CurrentVersion())
+	jobID, _, err = CreateAnchorJob(ctx, s.jobManager, s.queueSrv, did, jobID, model.ID(), model.CurrentVersion())
 	if err != nil {
 		return jobs.NilJobID(), err
 	}
@@ -0,0 +1,55 @@
+package ethereum
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TransactionHashKey is the job value key of the hash of the ethereum transaction sent within the job.
+const TransactionHashKey = "eth_tx_hash"
+
+// ChainStatus is the state of a transaction on chain.
+type ChainStatus string
+
+const (
+	// ChainStatusPending is set when the transaction is known to the node but not yet mined
+	ChainStatusPending ChainStatus = "pending"
+
+	// ChainStatusMined is set when the transaction is mined successfully
+	ChainStatusMined ChainStatus = "mined"
+
+	// ChainStatusReverted is set when the transaction is mined but reverted
+	ChainStatusReverted ChainStatus = "reverted"
+
+	// ChainStatusNotFound is set when the transaction is unknown to the node
+	ChainStatusNotFound ChainStatus = "not_found"
+)
+
+// TransactionChainStatus queries the chain for the current state of the transaction.
+func TransactionChainStatus(ctx context.Context, client Client, txHash common.Hash) (ChainStatus, error) {
+	_, isPending, err := client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		if err == ethereum.NotFound {
+			return ChainStatusNotFound, nil
+		}
+
+		return "", err
+	}
+
+	if isPending {
+		return ChainStatusPending, nil
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return "", err
+	}
+
+	if receipt.Status != TransactionStatusSuccess {
+		return ChainStatusReverted, nil
+	}
+
+	return ChainStatusMined, nil
+}
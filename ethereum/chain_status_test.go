@@ -0,0 +1,53 @@
+// +build unit
+
+package ethereum
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransactionChainStatus(t *testing.T) {
+	ctx := context.Background()
+	txHash := common.HexToHash("0x1212")
+
+	// not found
+	client := new(MockEthClient)
+	client.On("TransactionByHash", mock.Anything, txHash).Return(&types.Transaction{}, false, ethereum.NotFound).Once()
+	status, err := TransactionChainStatus(ctx, client, txHash)
+	assert.NoError(t, err)
+	assert.Equal(t, ChainStatusNotFound, status)
+
+	// node error
+	client.On("TransactionByHash", mock.Anything, txHash).Return(&types.Transaction{}, false, errors.New("connection refused")).Once()
+	_, err = TransactionChainStatus(ctx, client, txHash)
+	assert.Error(t, err)
+
+	// pending
+	client.On("TransactionByHash", mock.Anything, txHash).Return(&types.Transaction{}, true, nil).Once()
+	status, err = TransactionChainStatus(ctx, client, txHash)
+	assert.NoError(t, err)
+	assert.Equal(t, ChainStatusPending, status)
+
+	// reverted
+	client.On("TransactionByHash", mock.Anything, txHash).Return(&types.Transaction{}, false, nil).Once()
+	client.On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: 0}, nil).Once()
+	status, err = TransactionChainStatus(ctx, client, txHash)
+	assert.NoError(t, err)
+	assert.Equal(t, ChainStatusReverted, status)
+
+	// mined
+	client.On("TransactionByHash", mock.Anything, txHash).Return(&types.Transaction{}, false, nil).Once()
+	client.On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: TransactionStatusSuccess}, nil).Once()
+	status, err = TransactionChainStatus(ctx, client, txHash)
+	assert.NoError(t, err)
+	assert.Equal(t, ChainStatusMined, status)
+	client.AssertExpectations(t)
+}
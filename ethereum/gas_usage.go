@@ -0,0 +1,55 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// TransactionGasUsedKey is the job value key of the gas used by the mined ethereum transaction.
+	TransactionGasUsedKey = "eth_gas_used"
+
+	// TransactionGasPriceKey is the job value key of the effective gas price paid by the mined ethereum transaction.
+	TransactionGasPriceKey = "eth_effective_gas_price"
+)
+
+// GasUsage holds the gas consumed by a mined transaction and the price paid per unit of gas.
+type GasUsage struct {
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+}
+
+// NewGasUsage returns the gas usage of the mined transaction.
+// The client only supports legacy transactions, so the effective gas price is the gas price of the transaction.
+func NewGasUsage(tx *types.Transaction, receipt *types.Receipt) GasUsage {
+	return GasUsage{
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: tx.GasPrice(),
+	}
+}
+
+// Fee returns the fee paid for the transaction in wei.
+func (g GasUsage) Fee() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(g.GasUsed), g.EffectiveGasPrice)
+}
+
+// JobGasUsage returns the gas usage recorded on the job.
+// False is returned if the job has no mined transaction recorded.
+func JobGasUsage(job *jobs.Job) (GasUsage, bool) {
+	gasUsed, ok := job.Values[TransactionGasUsedKey]
+	if !ok {
+		return GasUsage{}, false
+	}
+
+	gasPrice, ok := job.Values[TransactionGasPriceKey]
+	if !ok {
+		return GasUsage{}, false
+	}
+
+	return GasUsage{
+		GasUsed:           new(big.Int).SetBytes(gasUsed.Value).Uint64(),
+		EffectiveGasPrice: new(big.Int).SetBytes(gasPrice.Value),
+	}, true
+}
@@ -0,0 +1,37 @@
+// +build unit
+
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGasUsage(t *testing.T) {
+	tx := types.NewTransaction(1, common.Address{}, big.NewInt(0), 300000, big.NewInt(20000000000), nil)
+	usage := NewGasUsage(tx, &types.Receipt{Status: TransactionStatusSuccess, GasUsed: 120000})
+	assert.Equal(t, uint64(120000), usage.GasUsed)
+	assert.Equal(t, big.NewInt(20000000000), usage.EffectiveGasPrice)
+	assert.Equal(t, "2400000000000000", usage.Fee().String())
+
+	// nothing recorded
+	job := jobs.NewJob(testingidentity.GenerateRandomDID(), "Minting NFT")
+	_, ok := JobGasUsage(job)
+	assert.False(t, ok)
+
+	// gas price missing
+	job.Values[TransactionGasUsedKey] = jobs.JobValue{Key: TransactionGasUsedKey, Value: big.NewInt(120000).Bytes()}
+	_, ok = JobGasUsage(job)
+	assert.False(t, ok)
+
+	job.Values[TransactionGasPriceKey] = jobs.JobValue{Key: TransactionGasPriceKey, Value: usage.EffectiveGasPrice.Bytes()}
+	recorded, ok := JobGasUsage(job)
+	assert.True(t, ok)
+	assert.Equal(t, usage, recorded)
+}
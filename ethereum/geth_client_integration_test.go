@@ -36,7 +36,7 @@ func registerMockedTransactionTask() {
 	mockClient := &ethereum.MockEthClient{}
 
 	// txHash: 0x1 -> successful
-	mockClient.On("TransactionByHash", mock.Anything, common.HexToHash("0x1")).Return(&types.Transaction{}, false, nil).Once()
+	mockClient.On("TransactionByHash", mock.Anything, common.HexToHash("0x1")).Return(types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil), false, nil).Once()
 	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0x1")).Return(&types.Receipt{Status: 1}, nil).Once()
 
 	// txHash: 0x2 -> fail
@@ -2,6 +2,7 @@ package ethereum
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -181,17 +182,27 @@ func (tst *TransactionStatusTask) getEventValueFromTransactionReceipt(ctx contex
 	return nil, errors.NewTypedError(ErrEthTransaction, errors.New("Event [%s] with value idx [%d] not found", event, idxValue))
 }
 
-func (tst *TransactionStatusTask) isTransactionSuccessful(ctx context.Context, txHash string) error {
+func (tst *TransactionStatusTask) isTransactionSuccessful(ctx context.Context, txHash string) (*types.Receipt, error) {
 	receipt, err := tst.transactionReceipt(ctx, common.HexToHash(txHash))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if receipt.Status != TransactionStatusSuccess {
-		return ErrTransactionFailed
+		return nil, ErrTransactionFailed
 	}
 
-	return nil
+	return receipt, nil
+}
+
+// recordGasUsage records the gas used by the mined transaction and the gas price paid on the job.
+func (tst *TransactionStatusTask) recordGasUsage(usage GasUsage) error {
+	err := tst.JobManager.UpdateJobWithValue(tst.accountID, tst.JobID, TransactionGasUsedKey, new(big.Int).SetUint64(usage.GasUsed).Bytes())
+	if err != nil {
+		return err
+	}
+
+	return tst.JobManager.UpdateJobWithValue(tst.accountID, tst.JobID, TransactionGasPriceKey, usage.EffectiveGasPrice.Bytes())
 }
 
 // RunTask calls listens to events from geth related to MintingConfirmationTask#TokenID and records result.
@@ -203,7 +214,7 @@ func (tst *TransactionStatusTask) RunTask() (resp interface{}, err error) {
 		err = tst.UpdateJobWithValue(tst.accountID, tst.TaskTypeName(), err, jobValue)
 	}()
 
-	_, isPending, err := tst.transactionByHash(ctx, common.HexToHash(tst.txHash))
+	tx, isPending, err := tst.transactionByHash(ctx, common.HexToHash(tst.txHash))
 	if err != nil {
 		// if the tx is not propagated, this will error out with "Not found"
 		// lets retry in this scenario as well
@@ -217,7 +228,7 @@ func (tst *TransactionStatusTask) RunTask() (resp interface{}, err error) {
 		return nil, gocelery.ErrTaskRetryable
 	}
 
-	err = tst.isTransactionSuccessful(ctx, tst.txHash)
+	receipt, err := tst.isTransactionSuccessful(ctx, tst.txHash)
 	if err != nil {
 		if err != ErrTransactionFailed {
 			err = gocelery.ErrTaskRetryable
@@ -225,6 +236,11 @@ func (tst *TransactionStatusTask) RunTask() (resp interface{}, err error) {
 		return nil, err
 	}
 
+	// gas usage is informational, failing to record it does not fail the transaction
+	if err := tst.recordGasUsage(NewGasUsage(tx, receipt)); err != nil {
+		log.Warningf("failed to record gas usage of transaction %s: %v", tst.txHash, err)
+	}
+
 	if tst.eventName != "" {
 		v, err := tst.getEventValueFromTransactionReceipt(ctx, tst.txHash, tst.eventName, tst.eventValueIdx)
 		if err != nil {
@@ -4,11 +4,14 @@ package ethereum
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -182,3 +185,27 @@ func TestGetEventValueFromTransactionReceipt(t *testing.T) {
 	assert.Equal(t, []byte{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1, 0x2, 0x3, 0x4}, v)
 
 }
+
+func TestTransactionStatusTask_RunTask_recordsGasUsage(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	jobID := jobs.NewJobID()
+	txHash := common.HexToHash("0x1")
+	tx := types.NewTransaction(1, common.Address{}, big.NewInt(0), 300000, big.NewInt(20000000000), nil)
+	mockClient := &MockEthClient{}
+	mockClient.On("TransactionByHash", mock.Anything, txHash).Return(tx, false, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: TransactionStatusSuccess, GasUsed: 120000}, nil)
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("UpdateJobWithValue", did, jobID, TransactionGasUsedKey, big.NewInt(120000).Bytes()).Return(nil).Once()
+	jobMan.On("UpdateJobWithValue", did, jobID, TransactionGasPriceKey, big.NewInt(20000000000).Bytes()).Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Success, EthTXStatusTaskName, "").Return(nil).Twice()
+	jobMan.On("UpdateJobWithValue", did, jobID, TransactionGasUsedKey, big.NewInt(120000).Bytes()).Return(errors.New("failed to save job")).Once()
+	task := NewTransactionStatusTask(200*time.Millisecond, jobMan, mockClient.TransactionByHash, mockClient.TransactionReceipt, DefaultWaitForTransactionMiningContext)
+	task.JobID, task.accountID, task.txHash = jobID, did, txHash.Hex()
+	_, err := task.RunTask()
+	assert.NoError(t, err)
+
+	// failing to record the gas usage does not fail the task
+	_, err = task.RunTask()
+	assert.NoError(t, err)
+	jobMan.AssertExpectations(t)
+}
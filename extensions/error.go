@@ -24,4 +24,13 @@ const (
 
 	// ErrAttrSetSignature must be used if a signature on an attribute set is invalid
 	ErrAttrSetSignature = errors.Error("stored signature on attribute set in document has an error")
+
+	// ErrAttrSetSigner must be used if the account signing an attribute set is not a party of it
+	ErrAttrSetSigner = errors.Error("account is not authorized to sign the attribute set")
+
+	// ErrSignatureValidity must be used if the validity window requested for a signature is invalid
+	ErrSignatureValidity = errors.Error("invalid signature validity window")
+
+	// ErrEmptyImport must be used if no attribute sets are given to import
+	ErrEmptyImport = errors.Error("no attribute sets to import")
 )
@@ -1,5 +1,7 @@
 package funding
 
+import "time"
+
 // Data is the default funding extension schema.
 type Data struct {
 	AgreementID           string `json:"agreement_id,omitempty" attr:"bytes"`
@@ -23,4 +25,28 @@ type Signature struct {
 	OutdatedSignature string `json:"outdated_signature"`
 	Identity          string `json:"identity"`
 	SignedVersion     string `json:"signed_version"`
+
+	// SignedAt is the RFC3339 timestamp of the document version holding the signature, empty if unknown
+	SignedAt string `json:"signed_at,omitempty"`
+
+	// NotBefore is the RFC3339 start of the window the signature is valid within, empty if unbounded
+	NotBefore string `json:"not_before,omitempty"`
+
+	// NotAfter is the RFC3339 end of the window the signature is valid within, empty if unbounded.
+	// Valid is false outside of the window.
+	NotAfter string `json:"not_after,omitempty"`
+}
+
+// Validity is the window a signature is valid within. Zero bounds leave the window open on that side.
+type Validity struct {
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// signedData is the value signed by a funding agreement signature, the agreement and the validity of the signature.
+// The signed value of the signatures without validity bounds is the JSON of the agreement only.
+type signedData struct {
+	Data
+	NotBefore string `json:"not_before,omitempty"`
+	NotAfter  string `json:"not_after,omitempty"`
 }
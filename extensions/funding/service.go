@@ -3,6 +3,7 @@ package funding
 import (
 	"context"
 	"reflect"
+	"strconv"
 
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -27,8 +28,17 @@ type Service interface {
 	// GetDataAndSignatures return the funding Data and Signatures associated with the FundingID or funding index.
 	GetDataAndSignatures(ctx context.Context, model documents.Model, fundingID string, idx string) (Data, []Signature, error)
 
-	// SignFundingAgreement adds the signature to the given funding agreement.
-	SignFundingAgreement(ctx context.Context, docID, fundingID []byte) (documents.Model, jobs.JobID, error)
+	// SignFundingAgreement adds the signature valid within the validity window to the given funding agreement.
+	SignFundingAgreement(ctx context.Context, docID, fundingID []byte, validity Validity) (documents.Model, jobs.JobID, error)
+
+	// SignFundingAgreements adds the signature to each of the given funding agreements in a single document version.
+	SignFundingAgreements(ctx context.Context, docID []byte, fundingIDs [][]byte) (documents.Model, jobs.JobID, []error, error)
+
+	// ImportFundingAgreements creates the funding agreements in a single document version.
+	ImportFundingAgreements(ctx context.Context, docID []byte, data []Data) (documents.Model, jobs.JobID, error)
+
+	// GetFundingAgreementCount returns the number of funding agreements in the document without deriving them.
+	GetFundingAgreementCount(model documents.Model) (uint64, error)
 }
 
 // service implements Service and handles all funding related persistence and validations
@@ -108,39 +118,89 @@ func (s service) CreateFundingAgreement(ctx context.Context, docID []byte, data
 		return nil, jobs.NilJobID(), documents.ErrDocumentNotFound
 	}
 
-	data.AgreementID = extensions.NewAttributeSetID()
-	attributes, err := extensions.CreateAttributesList(model, *data, fundingFieldKey, AttrFundingLabel)
+	err = addFundingAgreement(model, data)
 	if err != nil {
 		return nil, jobs.NilJobID(), err
 	}
 
-	var collabs []identity.DID
-	for _, id := range []string{data.BorrowerID, data.FunderID} {
-		did, err := identity.NewDIDFromString(id)
+	model, jobID, _, err := s.docSrv.Update(ctx, model)
+	if err != nil {
+		return nil, jobs.NilJobID(), err
+	}
+
+	return model, jobID, nil
+}
+
+// ImportFundingAgreements creates the funding agreements in a single update of the document and anchors it.
+// The agreement IDs are set on the given data. Nothing is created if any of the agreements is invalid.
+func (s service) ImportFundingAgreements(ctx context.Context, docID []byte, data []Data) (documents.Model, jobs.JobID, error) {
+	if len(data) < 1 {
+		return nil, jobs.NilJobID(), extensions.ErrEmptyImport
+	}
+
+	// parties are checked upfront so that an invalid agreement doesn't leave the document half updated
+	for i := range data {
+		if _, err := fundingParties(data[i]); err != nil {
+			return nil, jobs.NilJobID(), errors.New("funding agreement %d: %v", i, err)
+		}
+	}
+
+	model, err := s.docSrv.GetCurrentVersion(ctx, docID)
+	if err != nil {
+		return nil, jobs.NilJobID(), documents.ErrDocumentNotFound
+	}
+
+	for i := range data {
+		err = addFundingAgreement(model, &data[i])
 		if err != nil {
-			return nil, jobs.NilJobID(), err
+			return nil, jobs.NilJobID(), errors.New("funding agreement %d: %v", i, err)
 		}
+	}
 
-		collabs = append(collabs, did)
+	model, jobID, _, err := s.docSrv.Update(ctx, model)
+	if err != nil {
+		return nil, jobs.NilJobID(), err
 	}
 
-	err = model.AddAttributes(
+	return model, jobID, nil
+}
+
+// addFundingAgreement adds the funding agreement with a new agreement ID to the document
+// and grants its borrower and funder write access.
+func addFundingAgreement(model documents.Model, data *Data) error {
+	data.AgreementID = extensions.NewAttributeSetID()
+	attributes, err := extensions.CreateAttributesList(model, *data, fundingFieldKey, AttrFundingLabel)
+	if err != nil {
+		return err
+	}
+
+	collabs, err := fundingParties(*data)
+	if err != nil {
+		return err
+	}
+
+	return model.AddAttributes(
 		documents.CollaboratorsAccess{
 			ReadWriteCollaborators: collabs,
 		},
 		true,
 		attributes...,
 	)
-	if err != nil {
-		return nil, jobs.NilJobID(), err
-	}
+}
 
-	model, jobID, _, err := s.docSrv.Update(ctx, model)
-	if err != nil {
-		return nil, jobs.NilJobID(), err
+// fundingParties returns the DIDs of the borrower and the funder of the funding agreement.
+func fundingParties(data Data) ([]identity.DID, error) {
+	var dids []identity.DID
+	for _, id := range []string{data.BorrowerID, data.FunderID} {
+		did, err := identity.NewDIDFromString(id)
+		if err != nil {
+			return nil, err
+		}
+
+		dids = append(dids, did)
 	}
 
-	return model, jobID, nil
+	return dids, nil
 }
 
 // UpdateFundingAgreement updates a given funding agreement with the data passed.
@@ -198,9 +258,9 @@ func (s service) UpdateFundingAgreement(ctx context.Context, docID, fundingID []
 	return model, jobID, nil
 }
 
-// SignFundingAgreement adds the signature to the given funding agreement.
-func (s service) SignFundingAgreement(ctx context.Context, docID, fundingID []byte) (documents.Model, jobs.JobID, error) {
-	m, err := s.Sign(ctx, hexutil.Encode(fundingID), docID)
+// SignFundingAgreement adds the signature valid within the validity window to the given funding agreement.
+func (s service) SignFundingAgreement(ctx context.Context, docID, fundingID []byte, validity Validity) (documents.Model, jobs.JobID, error) {
+	m, err := s.sign(ctx, hexutil.Encode(fundingID), docID, validity)
 	if err != nil {
 		return nil, jobs.NilJobID(), err
 	}
@@ -234,3 +294,28 @@ func (s service) GetDataAndSignatures(ctx context.Context, model documents.Model
 
 	return data, sigs, nil
 }
+
+// GetFundingAgreementCount returns the number of funding agreements in the document.
+// The count is derived from the latest index of the funding agreement array.
+func (s service) GetFundingAgreementCount(model documents.Model) (uint64, error) {
+	key, err := documents.AttrKeyFromLabel(AttrFundingLabel)
+	if err != nil {
+		return 0, err
+	}
+
+	if !model.AttributeExists(key) {
+		return 0, nil
+	}
+
+	lastIdx, err := extensions.GetArrayLatestIDX(model, AttrFundingLabel)
+	if err != nil {
+		return 0, err
+	}
+
+	idx, err := strconv.ParseUint(lastIdx.String(), 10, 64)
+	if err != nil {
+		return 0, errors.NewTypedError(extensions.ErrArrayIndex, err)
+	}
+
+	return idx + 1, nil
+}
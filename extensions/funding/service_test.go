@@ -318,7 +318,7 @@ func TestService_SignFundingAgreement(t *testing.T) {
 	s := DefaultService(docSrv, nil)
 	docID := g.ID()
 	docSrv.On("GetCurrentVersion", docID).Return(g, nil)
-	_, _, err := s.SignFundingAgreement(ctx, docID, utils.RandomSlice(32))
+	_, _, err := s.SignFundingAgreement(ctx, docID, utils.RandomSlice(32), Validity{})
 	assert.Error(t, err)
 	assert.True(t, errors.IsOfType(extensions.ErrAttributeSetNotFound, err))
 
@@ -331,15 +331,99 @@ func TestService_SignFundingAgreement(t *testing.T) {
 	fundingID, err := hexutil.Decode(data.AgreementID)
 	assert.NoError(t, err)
 	docSrv.On("Update", ctx, g).Return(nil, nil, errors.New("failed to update")).Once()
-	_, _, err = s.SignFundingAgreement(ctx, docID, fundingID)
+	_, _, err = s.SignFundingAgreement(ctx, docID, fundingID, Validity{})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to update")
 
+	// expired validity window
+	_, _, err = s.SignFundingAgreement(ctx, docID, fundingID, Validity{NotAfter: time.Now().Add(-time.Hour)})
+	assert.True(t, errors.IsOfType(extensions.ErrSignatureValidity, err))
+
 	// success
 	docSrv.On("Update", ctx, g).Return(g, jobs.NewJobID(), nil)
-	m, _, err := s.SignFundingAgreement(ctx, docID, fundingID)
+	m, _, err := s.SignFundingAgreement(ctx, docID, fundingID, Validity{NotAfter: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, g, m)
+	docSrv.AssertExpectations(t)
+}
+
+func TestService_SignFundingAgreements(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	g, _ := generic.CreateGenericWithEmbedCD(t, ctx, did, nil)
+	docSrv := new(testingdocuments.MockService)
+	s := DefaultService(docSrv, nil)
+	docID := g.ID()
+
+	// missing document
+	docSrv.On("GetCurrentVersion", docID).Return(nil, errors.New("missing")).Once()
+	_, _, _, err := s.SignFundingAgreements(ctx, docID, [][]byte{utils.RandomSlice(32)})
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// agreement of the account, agreement of other parties
+	var fundingIDs [][]byte
+	for _, borrower := range []string{did.String(), testingidentity.GenerateRandomDID().String()} {
+		data := CreateData()
+		data.BorrowerID = borrower
+		attrs, err := extensions.CreateAttributesList(g, data, fundingFieldKey, AttrFundingLabel)
+		assert.NoError(t, err)
+		assert.NoError(t, g.AddAttributes(documents.CollaboratorsAccess{}, false, attrs...))
+		fundingID, err := hexutil.Decode(data.AgreementID)
+		assert.NoError(t, err)
+		fundingIDs = append(fundingIDs, fundingID)
+	}
+	docSrv.On("GetCurrentVersion", docID).Return(g, nil)
+
+	// nothing signed
+	m, _, errs, err := s.SignFundingAgreements(ctx, docID, [][]byte{utils.RandomSlice(32), fundingIDs[1]})
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+	assert.True(t, errors.IsOfType(extensions.ErrAttributeSetNotFound, errs[0]))
+	assert.True(t, errors.IsOfType(extensions.ErrAttrSetSigner, errs[1]))
+
+	// signed in a single update
+	docSrv.On("Update", ctx, g).Return(g, jobs.NewJobID(), nil).Once()
+	m, _, errs, err = s.SignFundingAgreements(ctx, docID, fundingIDs)
+	assert.NoError(t, err)
+	assert.Equal(t, g, m)
+	assert.NoError(t, errs[0])
+	assert.True(t, errors.IsOfType(extensions.ErrAttrSetSigner, errs[1]))
+	docSrv.AssertExpectations(t)
+}
+
+func TestService_ImportFundingAgreements(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	g, _ := generic.CreateGenericWithEmbedCD(t, ctx, did, nil)
+	docSrv := new(testingdocuments.MockService)
+	s := DefaultService(docSrv, nil)
+	docID := g.ID()
+
+	// nothing to import
+	_, _, err := s.ImportFundingAgreements(ctx, docID, nil)
+	assert.Equal(t, extensions.ErrEmptyImport, err)
+
+	// missing document
+	docSrv.On("GetCurrentVersion", docID).Return(nil, errors.New("missing")).Once()
+	_, _, err = s.ImportFundingAgreements(ctx, docID, []Data{CreateData()})
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// invalid agreement
+	docSrv.On("GetCurrentVersion", docID).Return(g, nil)
+	_, _, err = s.ImportFundingAgreements(ctx, docID, []Data{CreateData(), invalidData()})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "funding agreement 1")
+
+	// created in a single update
+	data := []Data{CreateData(), CreateData()}
+	docSrv.On("Update", ctx, g).Return(g, jobs.NewJobID(), nil).Once()
+	m, jobID, err := s.ImportFundingAgreements(ctx, docID, data)
 	assert.NoError(t, err)
 	assert.Equal(t, g, m)
+	assert.False(t, jobs.JobIDEqual(jobs.NilJobID(), jobID))
+	for _, d := range data {
+		fd, err := s.(service).findFunding(g, d.AgreementID)
+		assert.NoError(t, err)
+		assert.Equal(t, d, fd)
+	}
 	docSrv.AssertExpectations(t)
 }
 
@@ -365,3 +449,23 @@ func TestService_GetDataAndSignatures(t *testing.T) {
 	assert.Equal(t, data, data1)
 	assert.Len(t, sigs, 0)
 }
+
+func TestService_GetFundingAgreementCount(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	g, _ := generic.CreateGenericWithEmbedCD(t, ctx, did, nil)
+	srv := DefaultService(new(testingdocuments.MockService), nil)
+
+	// no funding agreements
+	count, err := srv.GetFundingAgreementCount(g)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	for i := 1; i <= 2; i++ {
+		attrs, err := extensions.CreateAttributesList(g, CreateData(), fundingFieldKey, AttrFundingLabel)
+		assert.NoError(t, err)
+		assert.NoError(t, g.AddAttributes(documents.CollaboratorsAccess{}, false, attrs...))
+		count, err = srv.GetFundingAgreementCount(g)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(i), count)
+	}
+}
@@ -3,6 +3,7 @@ package funding
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
@@ -10,19 +11,25 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/extensions"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // TODO: move to generic signed attributes
-func (s service) createSignAttrs(model documents.Model, idxFunding string, selfDID identity.DID, account config.Account) ([]documents.Attribute, error) {
+func (s service) createSignAttrs(model documents.Model, idxFunding string, selfDID identity.DID, account config.Account, validity Validity) ([]documents.Attribute, error) {
 	var attributes []documents.Attribute
 	data, err := s.deriveFundingData(model, idxFunding)
 	if err != nil {
 		return nil, err
 	}
 
-	signMsg, err := json.Marshal(data)
+	// the bounds are signed along with the agreement so that they cannot be changed without invalidating the signature
+	signMsg, err := json.Marshal(signedData{
+		Data:      data,
+		NotBefore: formatBound(validity.NotBefore),
+		NotAfter:  formatBound(validity.NotAfter),
+	})
 	if err != nil {
 		return nil, extensions.ErrJSON
 	}
@@ -49,6 +56,16 @@ func (s service) createSignAttrs(model documents.Model, idxFunding string, selfD
 // TODO: move to generic signed attributes
 // Sign adds a signature to an existing document
 func (s service) Sign(ctx context.Context, agreementID string, identifier []byte) (documents.Model, error) {
+	return s.sign(ctx, agreementID, identifier, Validity{})
+}
+
+// sign adds a signature valid within the validity window to an existing document.
+func (s service) sign(ctx context.Context, agreementID string, identifier []byte, validity Validity) (documents.Model, error) {
+	err := validateValidity(validity, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	selfDID, err := contextutil.AccountDID(ctx)
 	if err != nil {
 		return nil, errors.NewTypedError(documents.ErrDocumentConfigAccountID, err)
@@ -69,7 +86,7 @@ func (s service) Sign(ctx context.Context, agreementID string, identifier []byte
 		return nil, extensions.ErrAttributeSetNotFound
 	}
 
-	attributes, err := s.createSignAttrs(model, idxFunding, selfDID, account)
+	attributes, err := s.createSignAttrs(model, idxFunding, selfDID, account, validity)
 	if err != nil {
 		return nil, err
 	}
@@ -82,12 +99,148 @@ func (s service) Sign(ctx context.Context, agreementID string, identifier []byte
 	return model, nil
 }
 
+// SignFundingAgreements adds the signature to each of the funding agreements of the document and anchors
+// a single new version holding all the signatures. The account must be the borrower or the funder of every
+// agreement it signs. Returns the errors of the agreements not signed in the order of fundingIDs.
+// No version is created if none of the agreements was signed.
+func (s service) SignFundingAgreements(ctx context.Context, docID []byte, fundingIDs [][]byte) (documents.Model, jobs.JobID, []error, error) {
+	selfDID, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, jobs.NilJobID(), nil, errors.NewTypedError(documents.ErrDocumentConfigAccountID, err)
+	}
+
+	account, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, jobs.NilJobID(), nil, errors.NewTypedError(documents.ErrDocumentConfigAccountID, err)
+	}
+
+	model, err := s.docSrv.GetCurrentVersion(ctx, docID)
+	if err != nil {
+		return nil, jobs.NilJobID(), nil, documents.ErrDocumentNotFound
+	}
+
+	errs := make([]error, len(fundingIDs))
+	var signed int
+	for i, fundingID := range fundingIDs {
+		errs[i] = s.signAsParty(model, hexutil.Encode(fundingID), selfDID, account)
+		if errs[i] == nil {
+			signed++
+		}
+	}
+
+	if signed == 0 {
+		return nil, jobs.NilJobID(), errs, nil
+	}
+
+	model, jobID, _, err := s.docSrv.Update(ctx, model)
+	if err != nil {
+		return nil, jobs.NilJobID(), errs, err
+	}
+
+	return model, jobID, errs, nil
+}
+
+// signAsParty adds the signature to the funding agreement if the account is its borrower or funder.
+func (s service) signAsParty(model documents.Model, agreementID string, selfDID identity.DID, account config.Account) error {
+	idxFunding, err := extensions.FindAttributeSetIDX(model, agreementID, AttrFundingLabel, agreementIDLabel, fundingFieldKey)
+	if err != nil {
+		return extensions.ErrAttributeSetNotFound
+	}
+
+	data, err := s.deriveFundingData(model, idxFunding)
+	if err != nil {
+		return err
+	}
+
+	var party bool
+	for _, id := range []string{data.BorrowerID, data.FunderID} {
+		did, err := identity.NewDIDFromString(id)
+		if err == nil && did.Equal(selfDID) {
+			party = true
+			break
+		}
+	}
+
+	if !party {
+		return extensions.ErrAttrSetSigner
+	}
+
+	attributes, err := s.createSignAttrs(model, idxFunding, selfDID, account, Validity{})
+	if err != nil {
+		return err
+	}
+
+	return model.AddAttributes(documents.CollaboratorsAccess{}, true, attributes...)
+}
+
+// validateValidity returns an error if the validity window is empty or over by now.
+func validateValidity(validity Validity, now time.Time) error {
+	if !validity.NotBefore.IsZero() && !validity.NotAfter.IsZero() && !validity.NotAfter.After(validity.NotBefore) {
+		return errors.NewTypedError(extensions.ErrSignatureValidity, errors.New("not_after must be after not_before"))
+	}
+
+	if !validity.NotAfter.IsZero() && !validity.NotAfter.After(now) {
+		return errors.NewTypedError(extensions.ErrSignatureValidity, errors.New("not_after is in the past"))
+	}
+
+	return nil
+}
+
+// formatBound returns the RFC3339 bound of a validity window, empty if unbounded.
+func formatBound(bound time.Time) string {
+	if bound.IsZero() {
+		return ""
+	}
+
+	return bound.UTC().Format(time.RFC3339)
+}
+
+// decodeSignedData returns the agreement and the validity bounds signed by the signature.
+// False is returned if the signed value is not a signed agreement.
+func decodeSignedData(signAttr documents.Attribute) (sd signedData, ok bool) {
+	err := json.Unmarshal(signAttr.Value.Signed.Value, &sd)
+	return sd, err == nil
+}
+
+// validateValueOfSignAttr returns true if the signature signed the funding agreement, regardless of its validity window.
 func (s service) validateValueOfSignAttr(funding Data, signAttr documents.Attribute) (bool, error) {
+	sd, ok := decodeSignedData(signAttr)
+	if !ok {
+		return false, nil
+	}
+
 	value, err := json.Marshal(funding)
 	if err != nil {
 		return false, extensions.ErrJSON
 	}
-	return utils.IsSameByteSlice(value, signAttr.Value.Signed.Value), nil
+
+	signed, err := json.Marshal(sd.Data)
+	if err != nil {
+		return false, extensions.ErrJSON
+	}
+
+	return utils.IsSameByteSlice(value, signed), nil
+}
+
+// withinValidity sets the validity window signed by the signature and invalidates the signature outside of it.
+// Bounds that cannot be parsed invalidate the signature.
+func withinValidity(sig Signature, sd signedData, now time.Time) Signature {
+	sig.NotBefore, sig.NotAfter = sd.NotBefore, sd.NotAfter
+	if sd.NotBefore != "" {
+		notBefore, err := time.Parse(time.RFC3339, sd.NotBefore)
+		if err != nil || now.Before(notBefore) {
+			sig.Valid = "false"
+		}
+	}
+
+	if sd.NotAfter != "" {
+		notAfter, err := time.Parse(time.RFC3339, sd.NotAfter)
+		if err != nil || !now.Before(notAfter) {
+			sig.Valid = "false"
+		}
+	}
+
+	return sig
 }
 
 func (s service) validateSignedFundingVersion(ctx context.Context, identifier []byte, fundingID string, signAttr documents.Attribute) (sig Signature, err error) {
@@ -107,12 +260,41 @@ func (s service) validateSignedFundingVersion(ctx context.Context, identifier []
 		return sig, err
 	}
 
+	sig = Signature{Valid: "false", SignedVersion: hexutil.Encode(identifier), Identity: did.String(), OutdatedSignature: "true"}
 	if valid {
 		// the value of the older funding version signature is correct
-		return Signature{Valid: "true", SignedVersion: hexutil.Encode(identifier), Identity: did.String(), OutdatedSignature: "true"}, nil
+		sig.Valid = "true"
+	}
+
+	sig.SignedAt = formatSignedAt(signedDocVersion)
+	return sig, nil
+}
+
+// signedAt returns the timestamp of the document version holding the signature.
+// An empty string is returned if the version cannot be found.
+func (s service) signedAt(ctx context.Context, current documents.Model, signAttr documents.Attribute) string {
+	version := signAttr.Value.Signed.DocumentVersion
+	if utils.IsSameByteSlice(current.CurrentVersion(), version) {
+		return formatSignedAt(current)
+	}
+
+	signedDocVersion, err := s.docSrv.GetVersion(ctx, current.ID(), version)
+	if err != nil {
+		log.Warningf("failed to get the signed version %s: %v", hexutil.Encode(version), err)
+		return ""
 	}
 
-	return Signature{Valid: "false", SignedVersion: hexutil.Encode(identifier), Identity: did.String(), OutdatedSignature: "true"}, nil
+	return formatSignedAt(signedDocVersion)
+}
+
+// formatSignedAt returns the RFC3339 timestamp of the model, empty if the model has no timestamp.
+func formatSignedAt(model documents.Model) string {
+	ts, err := model.Timestamp()
+	if err != nil {
+		return ""
+	}
+
+	return ts.UTC().Format(time.RFC3339)
 }
 
 func (s service) signAttrToClientData(ctx context.Context, current documents.Model, funding Data, signAttr documents.Attribute) (sig Signature, err error) {
@@ -121,6 +303,8 @@ func (s service) signAttrToClientData(ctx context.Context, current documents.Mod
 	}
 
 	did := signAttr.Value.Signed.Identity
+	// values other than a signed agreement have no bounds and don't match the agreement
+	sd, _ := decodeSignedData(signAttr)
 	valid, err := s.validateValueOfSignAttr(funding, signAttr)
 	if err != nil {
 		return sig, err
@@ -128,10 +312,17 @@ func (s service) signAttrToClientData(ctx context.Context, current documents.Mod
 
 	// value correct (funding data didn't change since signing)
 	if valid {
-		return Signature{Valid: "true", SignedVersion: hexutil.Encode(current.ID()), Identity: did.String(), OutdatedSignature: "false"}, nil
+		sig = Signature{Valid: "true", SignedVersion: hexutil.Encode(current.ID()), Identity: did.String(), OutdatedSignature: "false"}
+		sig.SignedAt = s.signedAt(ctx, current, signAttr)
+		return withinValidity(sig, sd, time.Now()), nil
+	}
+
+	sig, err = s.validateSignedFundingVersion(ctx, current.ID(), funding.AgreementID, signAttr)
+	if err != nil {
+		return sig, err
 	}
 
-	return s.validateSignedFundingVersion(ctx, current.ID(), funding.AgreementID, signAttr)
+	return withinValidity(sig, sd, time.Now()), nil
 }
 
 func (s service) deriveFundingSignatures(ctx context.Context, model documents.Model, funding Data, idxFunding string) ([]Signature, error) {
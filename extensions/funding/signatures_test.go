@@ -6,12 +6,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/extensions"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
@@ -115,12 +117,16 @@ func TestService_SignVerify(t *testing.T) {
 	// add signature
 	model, err = srv.Sign(ctx, fundingID, utils.RandomSlice(32))
 	assert.NoError(t, err)
+	assert.NoError(t, model.AddUpdateLog(testingidentity.GenerateRandomDID()))
+	ts, err := model.Timestamp()
+	assert.NoError(t, err)
 
 	// funding current version: valid
 	data, signatures, err := srv.GetDataAndSignatures(ctx, model, fundingID, "")
 	assert.NoError(t, err)
 	assert.Equal(t, "true", signatures[0].Valid)
 	assert.Equal(t, "false", signatures[0].OutdatedSignature)
+	assert.Equal(t, ts.Format(time.RFC3339), signatures[0].SignedAt)
 
 	// update funding after signature
 	oldCD, err := model.PackCoreDocument()
@@ -143,6 +149,7 @@ func TestService_SignVerify(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "true", signatures[0].Valid)
 	assert.Equal(t, "true", signatures[0].OutdatedSignature)
+	assert.Equal(t, ts.Format(time.RFC3339), signatures[0].SignedAt)
 
 	// older funding version signed: invalid
 	invalidValue, err := hexutil.Decode("0x1234")
@@ -158,3 +165,68 @@ func TestService_SignVerify(t *testing.T) {
 	assert.Equal(t, "false", signatures[0].Valid)
 	assert.Equal(t, "true", signatures[0].OutdatedSignature)
 }
+
+func TestValidateValidity(t *testing.T) {
+	now := time.Now()
+	assert.NoError(t, validateValidity(Validity{}, now))
+	assert.NoError(t, validateValidity(Validity{NotBefore: now.Add(-time.Hour)}, now))
+	assert.NoError(t, validateValidity(Validity{NotBefore: now.Add(time.Hour), NotAfter: now.Add(2 * time.Hour)}, now))
+
+	err := validateValidity(Validity{NotBefore: now.Add(2 * time.Hour), NotAfter: now.Add(time.Hour)}, now)
+	assert.True(t, errors.IsOfType(extensions.ErrSignatureValidity, err))
+
+	err = validateValidity(Validity{NotAfter: now}, now)
+	assert.True(t, errors.IsOfType(extensions.ErrSignatureValidity, err))
+}
+
+func TestWithinValidity(t *testing.T) {
+	now := time.Now().UTC()
+	valid := Signature{Valid: "true"}
+	sd := signedData{NotBefore: now.Add(-time.Hour).Format(time.RFC3339), NotAfter: now.Add(time.Hour).Format(time.RFC3339)}
+	sig := withinValidity(valid, sd, now)
+	assert.Equal(t, "true", sig.Valid)
+	assert.Equal(t, sd.NotBefore, sig.NotBefore)
+	assert.Equal(t, sd.NotAfter, sig.NotAfter)
+
+	// unbounded
+	assert.Equal(t, valid, withinValidity(valid, signedData{}, now))
+
+	// not yet valid, expired and unparsable bounds
+	assert.Equal(t, "false", withinValidity(valid, sd, now.Add(-2*time.Hour)).Valid)
+	assert.Equal(t, "false", withinValidity(valid, sd, now.Add(time.Hour)).Valid)
+	assert.Equal(t, "false", withinValidity(valid, signedData{NotAfter: "tomorrow"}, now).Valid)
+
+	// invalid signatures stay invalid within the window
+	assert.Equal(t, "false", withinValidity(Signature{Valid: "false"}, sd, now).Valid)
+}
+
+func TestService_SignVerify_validity(t *testing.T) {
+	srv, docSrv, model, fundingID := setupFundingForTesting(t, 1)
+	docSrv.On("GetVersion", mock.Anything, mock.Anything).Return(model, nil)
+	acc := new(mockAccount)
+	acc.On("GetIdentityID").Return(utils.RandomSlice(20), nil)
+	acc.On("SignMsg", mock.Anything).Return(&coredocumentpb.Signature{Signature: utils.RandomSlice(32), PublicKey: utils.RandomSlice(64)}, nil)
+	ctx, err := contextutil.New(context.Background(), acc)
+	assert.NoError(t, err)
+
+	// the bounds are signed with the agreement and shown with the signature
+	notBefore := time.Now().Add(time.Hour).UTC()
+	model, err = srv.(service).sign(ctx, fundingID, utils.RandomSlice(32), Validity{NotBefore: notBefore})
+	assert.NoError(t, err)
+	_, signatures, err := srv.GetDataAndSignatures(ctx, model, fundingID, "")
+	assert.NoError(t, err)
+	assert.Len(t, signatures, 1)
+	assert.Equal(t, "false", signatures[0].Valid)
+	assert.Equal(t, "false", signatures[0].OutdatedSignature)
+	assert.Equal(t, notBefore.Format(time.RFC3339), signatures[0].NotBefore)
+	assert.Empty(t, signatures[0].NotAfter)
+
+	// valid within the window
+	model, err = srv.(service).sign(ctx, fundingID, utils.RandomSlice(32), Validity{NotAfter: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	_, signatures, err = srv.GetDataAndSignatures(ctx, model, fundingID, "")
+	assert.NoError(t, err)
+	assert.Len(t, signatures, 2)
+	assert.Equal(t, "true", signatures[1].Valid)
+	assert.NotEmpty(t, signatures[1].NotAfter)
+}
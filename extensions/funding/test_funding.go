@@ -53,8 +53,8 @@ func (m *MockService) UpdateFundingAgreement(ctx context.Context, docID, funding
 	return model, jobID, args.Error(2)
 }
 
-func (m *MockService) SignFundingAgreement(ctx context.Context, docID, fundingID []byte) (documents.Model, jobs.JobID, error) {
-	args := m.Called(ctx, docID, fundingID)
+func (m *MockService) SignFundingAgreement(ctx context.Context, docID, fundingID []byte, validity Validity) (documents.Model, jobs.JobID, error) {
+	args := m.Called(ctx, docID, fundingID, validity)
 	model, _ := args.Get(0).(documents.Model)
 	jobID, _ := args.Get(1).(jobs.JobID)
 	return model, jobID, args.Error(2)
@@ -67,6 +67,27 @@ func (m *MockService) GetDataAndSignatures(ctx context.Context, model documents.
 	return d, sigs, args.Error(2)
 }
 
+func (m *MockService) SignFundingAgreements(ctx context.Context, docID []byte, fundingIDs [][]byte) (documents.Model, jobs.JobID, []error, error) {
+	args := m.Called(ctx, docID, fundingIDs)
+	model, _ := args.Get(0).(documents.Model)
+	jobID, _ := args.Get(1).(jobs.JobID)
+	errs, _ := args.Get(2).([]error)
+	return model, jobID, errs, args.Error(3)
+}
+
+func (m *MockService) ImportFundingAgreements(ctx context.Context, docID []byte, data []Data) (documents.Model, jobs.JobID, error) {
+	args := m.Called(ctx, docID, data)
+	model, _ := args.Get(0).(documents.Model)
+	jobID, _ := args.Get(1).(jobs.JobID)
+	return model, jobID, args.Error(2)
+}
+
+func (m *MockService) GetFundingAgreementCount(model documents.Model) (uint64, error) {
+	args := m.Called(model)
+	count, _ := args.Get(0).(uint64)
+	return count, args.Error(1)
+}
+
 func CreateData() Data {
 	fundingId := extensions.NewAttributeSetID()
 	return Data{
@@ -40,7 +40,7 @@ var cfg config.Configuration
 var did = testingidentity.GenerateRandomDID()
 
 func newCoreAPIService(docSrv documents.Service) coreapi.Service {
-	return coreapi.NewService(docSrv, nil, nil, nil)
+	return coreapi.NewService(docSrv, nil, nil, nil, nil, nil)
 }
 
 func TestMain(m *testing.M) {
@@ -16,6 +16,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/notification"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
@@ -354,9 +355,25 @@ func TestHandler_CreateAccount(t *testing.T) {
 	assert.Equal(t, w.Code, http.StatusBadRequest)
 	assert.Contains(t, w.Body.String(), "Identity ID cannot be empty")
 
-	// create account failed
+	// unknown notification schema version
 	id := hexutil.Bytes(utils.RandomSlice(20))
 	data["identity_id"] = id.String()
+	data["receive_event_notification_version"] = notification.LatestSchemaVersion + 1
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(marshall(t, data)))
+	h.CreateAccount(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), "unknown notification schema version")
+
+	// invalid payload template
+	data["receive_event_notification_version"] = notification.SchemaV1
+	data["receive_event_notification_template"] = `{"text": {{json .Message}`
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(marshall(t, data)))
+	h.CreateAccount(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), "invalid notification payload template")
+
+	// create account failed
+	data["receive_event_notification_template"] = `{"text": {{json .Message}}}`
 	srv := new(configstore.MockService)
 	srv.On("CreateAccount", mock.Anything).Return(nil, errors.New("failed to create account")).Once()
 	h.srv.accountsSrv = srv
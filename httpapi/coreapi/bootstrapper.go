@@ -5,8 +5,10 @@ import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/queue"
 )
 
 // BootstrappedCoreAPIService key maps to the Service implementation in Bootstrap context.
@@ -37,11 +39,23 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("failed to get %s", config.BootstrappedConfigStorage)
 	}
 
+	ethClient, ok := ctx[ethereum.BootstrappedEthereumClient].(ethereum.Client)
+	if !ok {
+		return errors.New("failed to get %s", ethereum.BootstrappedEthereumClient)
+	}
+
+	queueSrv, ok := ctx[bootstrap.BootstrappedQueueServer].(*queue.Server)
+	if !ok {
+		return errors.New("failed to get %s", bootstrap.BootstrappedQueueServer)
+	}
+
 	ctx[BootstrappedCoreAPIService] = Service{
 		docSrv:      docSrv,
 		jobsSrv:     jobsMan,
 		nftSrv:      nftSrv,
 		accountsSrv: accountSrv,
+		ethClient:   ethClient,
+		queueSrv:    queueSrv,
 	}
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/queue"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
 	testingnfts "github.com/centrifuge/go-centrifuge/testingutils/nfts"
 	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
@@ -43,7 +45,19 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), config.BootstrappedConfigStorage)
 
-	// success
+	// missing ethereum client
 	ctx[config.BootstrappedConfigStorage] = new(configstore.MockService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ethereum.BootstrappedEthereumClient)
+
+	// missing queue server
+	ctx[ethereum.BootstrappedEthereumClient] = new(ethereum.MockEthClient)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), bootstrap.BootstrappedQueueServer)
+
+	// success
+	ctx[bootstrap.BootstrappedQueueServer] = new(queue.Server)
 	assert.NoError(t, b.Bootstrap(ctx))
 }
@@ -34,7 +34,12 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	r.Get("/documents/{"+DocumentIDParam+"}/versions/{"+VersionIDParam+"}", h.GetDocumentVersion)
 	r.Post("/documents/{"+DocumentIDParam+"}/proofs", h.GenerateProofs)
 	r.Post("/documents/{"+DocumentIDParam+"}/versions/{"+VersionIDParam+"}/proofs", h.GenerateProofsForVersion)
+	r.Post("/jobs/retry-failed", h.RetryFailedJobs)
 	r.Get("/jobs/{"+jobIDParam+"}", h.GetJobStatus)
+	r.Get("/jobs/{"+jobIDParam+"}/chain-status", h.GetJobChainStatus)
+	r.Post("/jobs/{"+jobIDParam+"}/annotations", h.AnnotateJob)
+	r.Put("/jobs/{"+jobIDParam+"}/status", h.ForceJobStatus)
+	r.Get("/node/effective-config", h.GetEffectiveConfig)
 	r.Post("/nfts/registries/{"+registryAddressParam+"}/mint", h.MintNFT)
 	r.Post("/nfts/registries/{"+registryAddressParam+"}/tokens/{"+tokenIDParam+"}/transfer", h.TransferNFT)
 	r.Get("/nfts/registries/{"+registryAddressParam+"}/tokens/{"+tokenIDParam+"}/owner", h.OwnerOfNFT)
@@ -18,7 +18,7 @@ func TestRegister(t *testing.T) {
 		bootstrap.BootstrappedNFTService: new(testingnfts.MockNFTService),
 	}
 	Register(ctx, r)
-	assert.Len(t, r.Routes(), 13)
+	assert.Len(t, r.Routes(), 18)
 	assert.Equal(t, r.Routes()[0].Pattern, "/accounts")
 	assert.Len(t, r.Routes()[0].Handlers, 2)
 	assert.NotNil(t, r.Routes()[0].Handlers["GET"])
@@ -42,12 +42,22 @@ func TestRegister(t *testing.T) {
 	assert.NotNil(t, r.Routes()[7].Handlers["GET"])
 	assert.Equal(t, r.Routes()[8].Pattern, "/documents/{document_id}/versions/{version_id}/proofs")
 	assert.NotNil(t, r.Routes()[8].Handlers["POST"])
-	assert.Equal(t, r.Routes()[9].Pattern, "/jobs/{job_id}")
-	assert.NotNil(t, r.Routes()[9].Handlers["GET"])
-	assert.Equal(t, r.Routes()[10].Pattern, "/nfts/registries/{registry_address}/mint")
-	assert.NotNil(t, r.Routes()[10].Handlers["POST"])
-	assert.Equal(t, r.Routes()[11].Pattern, "/nfts/registries/{registry_address}/tokens/{token_id}/owner")
-	assert.NotNil(t, r.Routes()[11].Handlers["GET"])
-	assert.Equal(t, r.Routes()[12].Pattern, "/nfts/registries/{registry_address}/tokens/{token_id}/transfer")
-	assert.NotNil(t, r.Routes()[12].Handlers["POST"])
+	assert.Equal(t, r.Routes()[9].Pattern, "/jobs/retry-failed")
+	assert.NotNil(t, r.Routes()[9].Handlers["POST"])
+	assert.Equal(t, r.Routes()[10].Pattern, "/jobs/{job_id}")
+	assert.NotNil(t, r.Routes()[10].Handlers["GET"])
+	assert.Equal(t, r.Routes()[11].Pattern, "/jobs/{job_id}/annotations")
+	assert.NotNil(t, r.Routes()[11].Handlers["POST"])
+	assert.Equal(t, r.Routes()[12].Pattern, "/jobs/{job_id}/chain-status")
+	assert.NotNil(t, r.Routes()[12].Handlers["GET"])
+	assert.Equal(t, r.Routes()[13].Pattern, "/jobs/{job_id}/status")
+	assert.NotNil(t, r.Routes()[13].Handlers["PUT"])
+	assert.Equal(t, r.Routes()[14].Pattern, "/nfts/registries/{registry_address}/mint")
+	assert.NotNil(t, r.Routes()[14].Handlers["POST"])
+	assert.Equal(t, r.Routes()[15].Pattern, "/nfts/registries/{registry_address}/tokens/{token_id}/owner")
+	assert.NotNil(t, r.Routes()[15].Handlers["GET"])
+	assert.Equal(t, r.Routes()[16].Pattern, "/nfts/registries/{registry_address}/tokens/{token_id}/transfer")
+	assert.NotNil(t, r.Routes()[16].Handlers["POST"])
+	assert.Equal(t, r.Routes()[17].Pattern, "/node/effective-config")
+	assert.NotNil(t, r.Routes()[17].Handlers["GET"])
 }
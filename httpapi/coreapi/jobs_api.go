@@ -1,7 +1,12 @@
 package coreapi
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -17,11 +22,15 @@ const (
 
 	// ErrJobNotFound is a sentinel error when job associated with job_id is not found.
 	ErrJobNotFound = errors.Error("Job not found")
+
+	// ErrJobTransactionNotFound is a sentinel error when no ethereum transaction is recorded on the job.
+	ErrJobTransactionNotFound = errors.Error("No ethereum transaction recorded for the job")
 )
 
 // GetJobStatus returns the status of a given job.
 // @summary Returns the status of a given Job.
 // @description Returns the status of a given Job.
+// @description Failed jobs that can be retried carry a retry_after hint, also returned as the Retry-After header in seconds.
 // @id get_job_status
 // @tags Jobs
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
@@ -61,6 +70,239 @@ func (h handler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setRetryAfter(w, resp.RetryAfter)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// setRetryAfter sets the Retry-After header to the hint rounded up to whole seconds.
+// No header is set for an empty or invalid hint.
+func setRetryAfter(w http.ResponseWriter, retryAfter string) {
+	d, err := time.ParseDuration(retryAfter)
+	if err != nil || d <= 0 {
+		return
+	}
+
+	w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10))
+}
+
+// RetryFailedJobs re-runs the failed jobs of the account.
+// @summary Retries the failed jobs of the account.
+// @description Re-runs the failed jobs of the account that failed with a transient error such as a timeout.
+// @description Jobs failed with a validation error and jobs already retried are skipped.
+// @id retry_failed_jobs
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 202 {object} coreapi.RetryFailedJobsResponse
+// @router /v1/jobs/retry-failed [post]
+func (h handler) RetryFailedJobs(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	ids, err := h.srv.RetryFailedJobs(r.Context(), account)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, toRetryFailedJobsResponse(ids))
+}
+
+// GetJobChainStatus returns the chain state of the ethereum transaction of a given job.
+// @summary Returns the chain state of the ethereum transaction of a given Job.
+// @description Queries the chain for the current state of the ethereum transaction sent within the job, such as the mint transaction.
+// @description Mismatch is set if the chain state contradicts the recorded job status, e.g. a pending job whose transaction is already mined.
+// @id get_job_chain_status
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param job_id path string true "Job ID"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} coreapi.JobChainStatusResponse
+// @router /v1/jobs/{job_id}/chain-status [get]
+func (h handler) GetJobChainStatus(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	jobID, err := jobs.FromString(chi.URLParam(r, jobIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidJobID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		log.Error(err)
+		err = ErrJobNotFound
+		code = http.StatusNotFound
+		return
+	}
+
+	resp, err := h.srv.GetJobChainStatus(r.Context(), account, jobID)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if errors.IsOfType(ErrJobNotFound, err) || errors.IsOfType(ErrJobTransactionNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// AnnotateJob attaches an operator note to a given job.
+// @summary Attaches an operator note to a given Job.
+// @description Attaches a timestamped note to the job, e.g. the ticket a stuck job was escalated to.
+// @description Annotations are returned with the job status and don't affect the job.
+// @id annotate_job
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param job_id path string true "Job ID"
+// @param body body coreapi.AnnotateJobRequest true "Annotation"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 201 {object} jobs.StatusResponse
+// @router /v1/jobs/{job_id}/annotations [post]
+func (h handler) AnnotateJob(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	jobID, err := jobs.FromString(chi.URLParam(r, jobIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidJobID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		log.Error(err)
+		err = ErrJobNotFound
+		code = http.StatusNotFound
+		return
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	var req AnnotateJobRequest
+	err = json.Unmarshal(data, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp, err := h.srv.AnnotateJob(account, jobID, req.Note)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if errors.IsOfType(jobs.ErrInvalidAnnotation, err) {
+			code = http.StatusBadRequest
+		} else if errors.IsOfType(jobs.ErrJobsMissing, err) {
+			err = ErrJobNotFound
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+// ForceJobStatus moves a given job to another status on operator request.
+// @summary Forces a given Job to another status.
+// @description Moves the job to success or failed, e.g. when the outcome of a stuck job was verified by other means.
+// @description The override is logged and annotated with the account and the reason. The work still running within the job is not interrupted but its outcome is ignored.
+// @id force_job_status
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param job_id path string true "Job ID"
+// @param body body coreapi.ForceJobStatusRequest true "Status override"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} jobs.StatusResponse
+// @router /v1/jobs/{job_id}/status [put]
+func (h handler) ForceJobStatus(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	jobID, err := jobs.FromString(chi.URLParam(r, jobIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidJobID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		log.Error(err)
+		err = ErrJobNotFound
+		code = http.StatusNotFound
+		return
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	var req ForceJobStatusRequest
+	err = json.Unmarshal(data, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp, err := h.srv.ForceJobStatus(account, jobID, req.Status, req.Reason)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if errors.IsOfType(jobs.ErrInvalidStatusTransition, err) || errors.IsOfType(jobs.ErrMissingStatusReason, err) {
+			code = http.StatusBadRequest
+		} else if errors.IsOfType(jobs.ErrJobsMissing, err) {
+			err = ErrJobNotFound
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
@@ -4,18 +4,25 @@ package coreapi
 
 import (
 	"context"
+	"encoding/json"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/go-chi/chi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_GetJobStatus(t *testing.T) {
@@ -74,5 +81,278 @@ func TestService_GetJobStatus(t *testing.T) {
 	assert.Equal(t, w.Code, http.StatusOK)
 	assert.Contains(t, w.Body.String(), jobID.String())
 	assert.Contains(t, w.Body.String(), tt.Format(time.RFC3339Nano))
+	assert.Empty(t, w.Header().Get("Retry-After"))
 	jobMan.AssertExpectations(t)
+
+	// retriable failure
+	w, r = getHTTPReqAndResp(ctx)
+	jobMan = testingjobs.MockJobManager{}
+	jobMan.On("GetJobStatus", did, jobID).Return(jobs.StatusResponse{
+		JobID:      jobID.String(),
+		Status:     string(jobs.Failed),
+		Retriable:  true,
+		RetryAfter: "1m0.5s",
+	}, nil)
+	h = handler{srv: Service{jobsSrv: jobMan}}
+	h.GetJobStatus(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, "61", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), `"retry_after":"1m0.5s"`)
+	jobMan.AssertExpectations(t)
+}
+
+func TestHandler_RetryFailedJobs(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/jobs/retry-failed", nil).WithContext(ctx)
+	}
+
+	// missing account
+	w, r := getHTTPReqAndResp(context.Background())
+	h := handler{}
+	h.RetryFailedJobs(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// failed retry
+	did := testingidentity.GenerateRandomDID()
+	ctx := context.WithValue(context.Background(), config.AccountHeaderKey, did.String())
+	w, r = getHTTPReqAndResp(ctx)
+	jobMan := testingjobs.MockJobManager{}
+	jobMan.On("RetryFailedJobs", mock.Anything, did).Return(nil, errors.New("failed to iterate jobs")).Once()
+	h = handler{srv: Service{jobsSrv: jobMan}}
+	h.RetryFailedJobs(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// success
+	jobID := jobs.NewJobID()
+	w, r = getHTTPReqAndResp(ctx)
+	jobMan.On("RetryFailedJobs", mock.Anything, did).Return([]jobs.JobID{jobID}, nil).Once()
+	h = handler{srv: Service{jobsSrv: jobMan}}
+	h.RetryFailedJobs(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), jobID.String())
+	jobMan.AssertExpectations(t)
+}
+
+func TestHandler_AnnotateJob(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/jobs/{job_id}/annotations", strings.NewReader(body)).WithContext(ctx)
+	}
+
+	// invalid jobID
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("job_id", "invalid value")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, `{"note": "escalated"}`)
+	h := handler{}
+	h.AnnotateJob(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidJobID.Error())
+
+	// missing account
+	jobID := jobs.NewJobID()
+	rctx.URLParams.Values[0] = jobID.String()
+	w, r = getHTTPReqAndResp(ctx, `{"note": "escalated"}`)
+	h.AnnotateJob(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// invalid body
+	did := testingidentity.GenerateRandomDID()
+	ctx = context.WithValue(ctx, config.AccountHeaderKey, did.String())
+	w, r = getHTTPReqAndResp(ctx, `{"note": `)
+	h.AnnotateJob(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	jobMan := testingjobs.MockJobManager{}
+	jobMan.On("AnnotateJob", did, jobID, "").Return(jobs.ErrInvalidAnnotation).Once()
+	jobMan.On("AnnotateJob", did, jobID, "missing").Return(errors.NewTypedError(jobs.ErrJobsMissing, errors.New("not found"))).Once()
+	jobMan.On("AnnotateJob", did, jobID, "escalated").Return(nil).Once()
+	annotation := jobs.Annotation{Note: "escalated", CreatedAt: time.Now().UTC()}
+	jobMan.On("GetJobStatus", did, jobID).Return(jobs.StatusResponse{JobID: jobID.String(), Annotations: []jobs.Annotation{annotation}}, nil).Once()
+	h = handler{srv: Service{jobsSrv: jobMan}}
+
+	// empty note
+	w, r = getHTTPReqAndResp(ctx, `{"note": ""}`)
+	h.AnnotateJob(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing job
+	w, r = getHTTPReqAndResp(ctx, `{"note": "missing"}`)
+	h.AnnotateJob(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrJobNotFound.Error())
+
+	// success
+	w, r = getHTTPReqAndResp(ctx, `{"note": "escalated"}`)
+	h.AnnotateJob(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp jobs.StatusResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Annotations, 1)
+	assert.Equal(t, "escalated", resp.Annotations[0].Note)
+	jobMan.AssertExpectations(t)
+}
+
+func TestHandler_ForceJobStatus(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("PUT", "/jobs/{job_id}/status", strings.NewReader(body)).WithContext(ctx)
+	}
+
+	// invalid jobID
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("job_id", "invalid value")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, `{"status": "failed", "reason": "stuck"}`)
+	h := handler{}
+	h.ForceJobStatus(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidJobID.Error())
+
+	// missing account
+	jobID := jobs.NewJobID()
+	rctx.URLParams.Values[0] = jobID.String()
+	w, r = getHTTPReqAndResp(ctx, `{"status": "failed", "reason": "stuck"}`)
+	h.ForceJobStatus(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// invalid body
+	did := testingidentity.GenerateRandomDID()
+	ctx = context.WithValue(ctx, config.AccountHeaderKey, did.String())
+	w, r = getHTTPReqAndResp(ctx, `{"status": `)
+	h.ForceJobStatus(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	jobMan := testingjobs.MockJobManager{}
+	jobMan.On("ForceStatus", did, jobID, jobs.Pending, "stuck").Return(errors.NewTypedError(jobs.ErrInvalidStatusTransition, errors.New("status: pending"))).Once()
+	jobMan.On("ForceStatus", did, jobID, jobs.Failed, "").Return(jobs.ErrMissingStatusReason).Once()
+	jobMan.On("ForceStatus", did, jobID, jobs.Failed, "missing").Return(errors.NewTypedError(jobs.ErrJobsMissing, errors.New("not found"))).Once()
+	jobMan.On("ForceStatus", did, jobID, jobs.Failed, "stuck").Return(nil).Once()
+	jobMan.On("GetJobStatus", did, jobID).Return(jobs.StatusResponse{JobID: jobID.String(), Status: string(jobs.Failed)}, nil).Once()
+	h = handler{srv: Service{jobsSrv: jobMan}}
+
+	// invalid transition
+	w, r = getHTTPReqAndResp(ctx, `{"status": "pending", "reason": "stuck"}`)
+	h.ForceJobStatus(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing reason
+	w, r = getHTTPReqAndResp(ctx, `{"status": "failed"}`)
+	h.ForceJobStatus(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing job
+	w, r = getHTTPReqAndResp(ctx, `{"status": "failed", "reason": "missing"}`)
+	h.ForceJobStatus(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrJobNotFound.Error())
+
+	// success
+	w, r = getHTTPReqAndResp(ctx, `{"status": "failed", "reason": "stuck"}`)
+	h.ForceJobStatus(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp jobs.StatusResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, string(jobs.Failed), resp.Status)
+	jobMan.AssertExpectations(t)
+}
+
+func TestHandler_GetJobChainStatus(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/jobs/{job_id}/chain-status", nil).WithContext(ctx)
+	}
+
+	// invalid jobID
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("job_id", "invalid value")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.GetJobChainStatus(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidJobID.Error())
+
+	// missing account
+	jobID := jobs.NewJobID()
+	rctx.URLParams.Values[0] = jobID.String()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetJobChainStatus(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// missing job
+	did := testingidentity.GenerateRandomDID()
+	ctx = context.WithValue(ctx, config.AccountHeaderKey, did.String())
+	jobMan := testingjobs.MockJobManager{}
+	jobMan.On("GetJob", did, jobID).Return(nil, errors.New("missing job")).Once()
+	ethClient := new(ethereum.MockEthClient)
+	h = handler{srv: Service{jobsSrv: jobMan, ethClient: ethClient}}
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetJobChainStatus(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrJobNotFound.Error())
+
+	// no transaction recorded
+	job := jobs.NewJob(did, "Minting NFT")
+	job.Status = jobs.Pending
+	jobMan.On("GetJob", did, jobID).Return(job, nil)
+	h = handler{srv: Service{jobsSrv: jobMan, ethClient: ethClient}}
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetJobChainStatus(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrJobTransactionNotFound.Error())
+
+	// chain query failed
+	txHash := common.HexToHash("0x1212")
+	job.Values[ethereum.TransactionHashKey] = jobs.JobValue{Key: ethereum.TransactionHashKey, Value: txHash.Bytes()}
+	ethClient.On("TransactionByHash", mock.Anything, txHash).Return(&types.Transaction{}, false, errors.New("connection refused")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetJobChainStatus(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// pending job with a mined transaction
+	ethClient.On("TransactionByHash", mock.Anything, txHash).Return(&types.Transaction{}, false, nil).Once()
+	ethClient.On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: ethereum.TransactionStatusSuccess}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetJobChainStatus(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp JobChainStatusResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, JobChainStatusResponse{
+		JobID:       jobID.String(),
+		JobStatus:   string(jobs.Pending),
+		TxHash:      txHash.Hex(),
+		ChainStatus: string(ethereum.ChainStatusMined),
+		Mismatch:    true,
+	}, resp)
+
+	// gas usage recorded
+	job.Status = jobs.Success
+	job.Values[ethereum.TransactionGasUsedKey] = jobs.JobValue{Key: ethereum.TransactionGasUsedKey, Value: big.NewInt(120000).Bytes()}
+	job.Values[ethereum.TransactionGasPriceKey] = jobs.JobValue{Key: ethereum.TransactionGasPriceKey, Value: big.NewInt(20000000000).Bytes()}
+	ethClient.On("TransactionByHash", mock.Anything, txHash).Return(&types.Transaction{}, false, nil).Once()
+	ethClient.On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: ethereum.TransactionStatusSuccess}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetJobChainStatus(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp = JobChainStatusResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, JobChainStatusResponse{
+		JobID:             jobID.String(),
+		JobStatus:         string(jobs.Success),
+		TxHash:            txHash.Hex(),
+		ChainStatus:       string(ethereum.ChainStatusMined),
+		GasUsed:           120000,
+		EffectiveGasPrice: "20000000000",
+		Fee:               "2400000000000000",
+	}, resp)
+	jobMan.AssertExpectations(t)
+	ethClient.AssertExpectations(t)
+}
+
+func TestChainStatusMismatch(t *testing.T) {
+	assert.False(t, chainStatusMismatch(jobs.Success, ethereum.ChainStatusMined))
+	assert.True(t, chainStatusMismatch(jobs.Success, ethereum.ChainStatusNotFound))
+	assert.False(t, chainStatusMismatch(jobs.Pending, ethereum.ChainStatusPending))
+	assert.True(t, chainStatusMismatch(jobs.Pending, ethereum.ChainStatusReverted))
+	assert.False(t, chainStatusMismatch(jobs.Failed, ethereum.ChainStatusReverted))
+	assert.False(t, chainStatusMismatch(jobs.Failed, ethereum.ChainStatusNotFound))
+	assert.True(t, chainStatusMismatch(jobs.Failed, ethereum.ChainStatusMined))
 }
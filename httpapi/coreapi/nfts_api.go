@@ -34,6 +34,7 @@ const (
 // @Failure 403 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
 // @success 202 {object} coreapi.MintNFTResponse
 // @router /v1/nfts/registries/{registry_address}/mint [post]
 func (h handler) MintNFT(w http.ResponseWriter, r *http.Request) {
@@ -68,6 +69,9 @@ func (h handler) MintNFT(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.srv.MintNFT(ctx, toNFTMintRequest(req, registry))
 	if err != nil {
 		code = http.StatusBadRequest
+		if errors.IsOfType(nft.ErrMintInProgress, err) {
+			code = http.StatusConflict
+		}
 		log.Error(err)
 		return
 	}
@@ -186,7 +190,7 @@ func (h handler) OwnerOfNFT(w http.ResponseWriter, r *http.Request) {
 	}
 
 	registry := common.HexToAddress(chi.URLParam(r, registryAddressParam))
-	owner, err := h.srv.OwnerOfNFT(registry, tokenID)
+	owner, stale, err := h.srv.OwnerOfNFT(registry, tokenID)
 	if err != nil {
 		code = http.StatusBadRequest
 		log.Error(err)
@@ -198,5 +202,6 @@ func (h handler) OwnerOfNFT(w http.ResponseWriter, r *http.Request) {
 		TokenID:         tokenID.String(),
 		RegistryAddress: registry,
 		Owner:           owner,
+		Stale:           stale,
 	})
 }
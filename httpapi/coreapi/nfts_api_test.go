@@ -102,6 +102,16 @@ func TestHandler_MintNFT(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "failed to mint nft")
 	srv.AssertExpectations(t)
 
+	// mint in progress
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	srv = new(testingnfts.MockNFTService)
+	srv.On("MintNFT", ctx, mock.Anything).Return(nil, nil, errors.NewTypedError(nft.ErrMintInProgress, errors.New("pending job"))).Once()
+	h.srv.nftSrv = srv
+	h.MintNFT(w, r)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "pending job")
+	srv.AssertExpectations(t)
+
 	// success
 	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
 	srv = new(testingnfts.MockNFTService)
@@ -196,7 +206,7 @@ func TestHandler_OwnerOfNFT(t *testing.T) {
 
 	// owner failed
 	srv := new(testingnfts.MockNFTService)
-	srv.On("OwnerOf", mock.Anything, mock.Anything).Return(nil, errors.New("failed to get owner")).Once()
+	srv.On("CachedOwnerOf", mock.Anything, mock.Anything).Return(nil, false, errors.New("failed to get owner")).Once()
 	h.srv.nftSrv = srv
 	w, r := getHTTPReqAndResp(ctx)
 	h.OwnerOfNFT(w, r)
@@ -207,11 +217,20 @@ func TestHandler_OwnerOfNFT(t *testing.T) {
 	// success
 	owner := common.BytesToAddress(utils.RandomSlice(20))
 	srv = new(testingnfts.MockNFTService)
-	srv.On("OwnerOf", mock.Anything, mock.Anything).Return(owner, nil).Once()
+	srv.On("CachedOwnerOf", mock.Anything, mock.Anything).Return(owner, false, nil).Once()
 	h.srv.nftSrv = srv
 	w, r = getHTTPReqAndResp(ctx)
 	h.OwnerOfNFT(w, r)
 	assert.Equal(t, w.Code, http.StatusOK)
 	assert.Contains(t, w.Body.String(), strings.ToLower(owner.String()))
+	assert.Contains(t, w.Body.String(), `"stale":false`)
+
+	// last known owner while the chain is unreachable
+	srv.On("CachedOwnerOf", mock.Anything, mock.Anything).Return(owner, true, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.OwnerOfNFT(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Contains(t, w.Body.String(), strings.ToLower(owner.String()))
+	assert.Contains(t, w.Body.String(), `"stale":true`)
 	srv.AssertExpectations(t)
 }
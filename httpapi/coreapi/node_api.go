@@ -0,0 +1,22 @@
+package coreapi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// GetEffectiveConfig returns the queue and jobs settings in use by the node.
+// @summary Returns the queue and jobs settings in use by the node.
+// @description Returns the queue and jobs settings in use by the node after the defaults and the runtime overrides are applied,
+// @description such as the number of workers set at runtime. The settings may differ from the config file.
+// @id get_effective_config
+// @tags Node
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @success 200 {object} coreapi.EffectiveConfigResponse
+// @router /v1/node/effective-config [get]
+func (h handler) GetEffectiveConfig(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, h.srv.GetEffectiveConfig())
+}
@@ -0,0 +1,60 @@
+// +build unit
+
+package coreapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/stretchr/testify/assert"
+)
+
+type queueConfig queue.EffectiveConfig
+
+func (c queueConfig) EffectiveConfig() queue.EffectiveConfig {
+	return queue.EffectiveConfig(c)
+}
+
+func TestHandler_GetEffectiveConfig(t *testing.T) {
+	jobMan := testingjobs.MockJobManager{}
+	jobMan.On("EffectiveConfig").Return(jobs.EffectiveConfig{
+		PollInterval:        10 * time.Millisecond,
+		PollMax:             time.Second,
+		ContextClosedPolicy: jobs.ContextClosedPending,
+		RetryAfter:          map[string]time.Duration{string(jobs.FailureTransient): 10 * time.Second},
+		MaxConcurrentJobs:   8,
+		CapacityPolicy:      jobs.CapacityBlock,
+	}).Once()
+	qc := queueConfig{
+		NumWorkers:     3,
+		WorkerWaitTime: time.Millisecond,
+		TaskTimeouts:   map[string]time.Duration{"anchortask": time.Hour},
+		AckModes:       map[string]queue.AckMode{"anchortask": queue.AckAfter},
+		EnqueueOnly:    true,
+	}
+	h := handler{srv: NewService(nil, jobMan, nil, nil, nil, qc)}
+	w := httptest.NewRecorder()
+	h.GetEffectiveConfig(w, httptest.NewRequest("GET", "/node/effective-config", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp EffectiveConfigResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.Queue.NumWorkers)
+	assert.Equal(t, "1ms", resp.Queue.WorkerWaitTime)
+	assert.Equal(t, map[string]string{"anchortask": "1h0m0s"}, resp.Queue.TaskTimeouts)
+	assert.Equal(t, map[string]string{"anchortask": "after"}, resp.Queue.AckModes)
+	assert.True(t, resp.Queue.EnqueueOnly)
+	assert.Equal(t, "10ms", resp.Jobs.PollInterval)
+	assert.Equal(t, "1s", resp.Jobs.PollMax)
+	assert.Equal(t, jobs.ContextClosedPending, resp.Jobs.ContextClosedPolicy)
+	assert.Equal(t, map[string]string{"transient": "10s"}, resp.Jobs.RetryAfter)
+	assert.Equal(t, 8, resp.Jobs.MaxConcurrentJobs)
+	assert.Equal(t, jobs.CapacityBlock, resp.Jobs.CapacityPolicy)
+	jobMan.AssertExpectations(t)
+}
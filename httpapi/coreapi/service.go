@@ -6,28 +6,40 @@ import (
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // NewService returns the new CoreAPI Service.
-func NewService(docSrv documents.Service, jobsSrv jobs.Manager, nftSrv nft.Service, accountsSrv config.Service) Service {
+func NewService(docSrv documents.Service, jobsSrv jobs.Manager, nftSrv nft.Service, accountsSrv config.Service, ethClient ethereum.Client, queueSrv QueueConfigProvider) Service {
 	return Service{
 		docSrv:      docSrv,
 		jobsSrv:     jobsSrv,
 		nftSrv:      nftSrv,
 		accountsSrv: accountsSrv,
+		ethClient:   ethClient,
+		queueSrv:    queueSrv,
 	}
 }
 
+// QueueConfigProvider provides the queue settings in use, implemented by queue.Server.
+type QueueConfigProvider interface {
+	EffectiveConfig() queue.EffectiveConfig
+}
+
 // Service defines the functionality for the CoreAPI service.
 type Service struct {
 	docSrv      documents.Service
 	jobsSrv     jobs.Manager
 	nftSrv      nft.Service
 	accountsSrv config.Service
+	ethClient   ethereum.Client
+	queueSrv    QueueConfigProvider
 }
 
 // CreateDocument creates the document from the payload and anchors it.
@@ -45,6 +57,93 @@ func (s Service) GetJobStatus(account identity.DID, id jobs.JobID) (jobs.StatusR
 	return s.jobsSrv.GetJobStatus(account, id)
 }
 
+// ListJobs returns the jobs of the account matching the filter, latest first.
+func (s Service) ListJobs(account identity.DID, filter jobs.JobFilter) ([]*jobs.Job, error) {
+	return s.jobsSrv.ListJobs(account, filter)
+}
+
+// ListJobsPage returns at most limit of the jobs of the account matching the filter, latest first, skipping
+// the offset latest ones, and the number of jobs matching the filter.
+func (s Service) ListJobsPage(account identity.DID, filter jobs.JobFilter, offset, limit int) ([]*jobs.Job, int, error) {
+	return s.jobsSrv.ListJobsPage(account, filter, offset, limit)
+}
+
+// RetryFailedJobs re-runs the retriable failed jobs of the account.
+func (s Service) RetryFailedJobs(ctx context.Context, account identity.DID) ([]jobs.JobID, error) {
+	return s.jobsSrv.RetryFailedJobs(ctx, account)
+}
+
+// AnnotateJob attaches the operator note to the job and returns the job status.
+func (s Service) AnnotateJob(account identity.DID, id jobs.JobID, note string) (jobs.StatusResponse, error) {
+	if err := s.jobsSrv.AnnotateJob(account, id, note); err != nil {
+		return jobs.StatusResponse{}, err
+	}
+
+	return s.jobsSrv.GetJobStatus(account, id)
+}
+
+// ForceJobStatus moves the job to the status on operator request and returns the job status.
+func (s Service) ForceJobStatus(account identity.DID, id jobs.JobID, status jobs.Status, reason string) (jobs.StatusResponse, error) {
+	if err := s.jobsSrv.ForceStatus(account, id, status, reason); err != nil {
+		return jobs.StatusResponse{}, err
+	}
+
+	return s.jobsSrv.GetJobStatus(account, id)
+}
+
+// GetEffectiveConfig returns the queue and jobs settings in use by the node.
+func (s Service) GetEffectiveConfig() EffectiveConfigResponse {
+	return toEffectiveConfigResponse(s.queueSrv.EffectiveConfig(), s.jobsSrv.EffectiveConfig())
+}
+
+// GetJobChainStatus returns the recorded status of the job along with the chain state of its ethereum transaction.
+func (s Service) GetJobChainStatus(ctx context.Context, account identity.DID, id jobs.JobID) (JobChainStatusResponse, error) {
+	job, err := s.jobsSrv.GetJob(account, id)
+	if err != nil {
+		return JobChainStatusResponse{}, errors.NewTypedError(ErrJobNotFound, err)
+	}
+
+	v, ok := job.Values[ethereum.TransactionHashKey]
+	if !ok {
+		return JobChainStatusResponse{}, ErrJobTransactionNotFound
+	}
+
+	txHash := common.BytesToHash(v.Value)
+	chainStatus, err := ethereum.TransactionChainStatus(ctx, s.ethClient, txHash)
+	if err != nil {
+		return JobChainStatusResponse{}, err
+	}
+
+	resp := JobChainStatusResponse{
+		JobID:       id.String(),
+		JobStatus:   string(job.Status),
+		TxHash:      txHash.Hex(),
+		ChainStatus: string(chainStatus),
+		Mismatch:    chainStatusMismatch(job.Status, chainStatus),
+	}
+
+	if usage, ok := ethereum.JobGasUsage(job); ok {
+		resp.GasUsed = usage.GasUsed
+		resp.EffectiveGasPrice = usage.EffectiveGasPrice.String()
+		resp.Fee = usage.Fee().String()
+	}
+
+	return resp, nil
+}
+
+// chainStatusMismatch returns true if the chain state of the transaction contradicts the job status.
+// Pending jobs are expected to have a pending transaction, so a job catching up with a just mined transaction is flagged as well.
+func chainStatusMismatch(status jobs.Status, chainStatus ethereum.ChainStatus) bool {
+	switch status {
+	case jobs.Success:
+		return chainStatus != ethereum.ChainStatusMined
+	case jobs.Pending:
+		return chainStatus != ethereum.ChainStatusPending
+	default:
+		return chainStatus == ethereum.ChainStatusMined || chainStatus == ethereum.ChainStatusPending
+	}
+}
+
 // GetDocument returns the latest version of the document.
 func (s Service) GetDocument(ctx context.Context, docID []byte) (documents.Model, error) {
 	return s.docSrv.GetCurrentVersion(ctx, docID)
@@ -77,9 +176,9 @@ func (s Service) TransferNFT(ctx context.Context, to, registry common.Address, t
 	return resp, err
 }
 
-// OwnerOfNFT returns the owner of the NFT.
-func (s Service) OwnerOfNFT(registry common.Address, tokenID nft.TokenID) (common.Address, error) {
-	return s.nftSrv.OwnerOf(registry, tokenID[:])
+// OwnerOfNFT returns the owner of the NFT, stale is true if the last known owner is returned as the chain is unreachable.
+func (s Service) OwnerOfNFT(registry common.Address, tokenID nft.TokenID) (owner common.Address, stale bool, err error) {
+	return s.nftSrv.CachedOwnerOf(registry, tokenID[:])
 }
 
 // SignPayload uses the accountID's secret key to sign the payload and returns the signature
@@ -14,6 +14,8 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/ethereum/go-ethereum/common"
@@ -319,7 +321,136 @@ type MintNFTRequest struct {
 	DocumentID          byteutils.HexBytes    `json:"document_id" swaggertype:"primitive,string"`
 	DepositAddress      common.Address        `json:"deposit_address" swaggertype:"primitive,string"`
 	AssetManagerAddress byteutils.OptionalHex `json:"asset_manager_address" swaggertype:"primitive,string"`
-	ProofFields         []string              `json:"proof_fields"`
+	ProofFields         []string              `json:"proof_fields"`                            // document fields the token commits to, the other fields are not disclosed
+	Deadline            time.Time             `json:"deadline" swaggertype:"primitive,string"` // RFC3339. The mint fails if not confirmed by then.
+	PropertyMapping     map[string]string     `json:"property_mapping"`                        // token property slot of the registry -> document field
+	AnchorDocument      bool                  `json:"anchor_document"`                         // anchors the document within the mint job if it isn't anchored yet, else the mint fails
+}
+
+// AnnotateJobRequest holds the operator note attached to a job.
+type AnnotateJobRequest struct {
+	Note string `json:"note"`
+}
+
+// ForceJobStatusRequest holds the status a job is forced to and the reason for the override.
+type ForceJobStatusRequest struct {
+	Status jobs.Status `json:"status" swaggertype:"primitive,string" enums:"success,failed"`
+	Reason string      `json:"reason"`
+}
+
+// RetryFailedJobsResponse holds the IDs of the jobs re-running the failed jobs.
+type RetryFailedJobsResponse struct {
+	JobIDs []string `json:"job_ids"`
+}
+
+func toRetryFailedJobsResponse(ids []jobs.JobID) RetryFailedJobsResponse {
+	resp := RetryFailedJobsResponse{JobIDs: make([]string, 0, len(ids))}
+	for _, id := range ids {
+		resp.JobIDs = append(resp.JobIDs, id.String())
+	}
+
+	return resp
+}
+
+// JobChainStatusResponse holds the recorded status of a job and the chain state of its ethereum transaction.
+type JobChainStatusResponse struct {
+	JobID       string `json:"job_id"`
+	JobStatus   string `json:"job_status"`
+	TxHash      string `json:"tx_hash"`
+	ChainStatus string `json:"chain_status" enums:"pending,mined,reverted,not_found"`
+	Mismatch    bool   `json:"mismatch"` // true if the chain state contradicts the job status
+
+	// gas usage of the mined transaction, fee and gas price are in wei
+	GasUsed           uint64 `json:"gas_used,omitempty"`
+	EffectiveGasPrice string `json:"effective_gas_price,omitempty"`
+	Fee               string `json:"fee,omitempty"`
+}
+
+// QueueConfig holds the queue settings in use. Durations are formatted such as "1m30s".
+type QueueConfig struct {
+	NumWorkers        int               `json:"num_workers"`
+	WorkerWaitTime    string            `json:"worker_wait_time"`
+	TaskValidDuration string            `json:"task_valid_duration"`
+	TaskTimeouts      map[string]string `json:"task_timeouts"`
+	AckModes          map[string]string `json:"ack_modes"`
+	StoreResults      map[string]bool   `json:"store_results"`
+	ScheduleCatchUp   string            `json:"schedule_catch_up"`
+	DedupWindow       string            `json:"dedup_window"`
+	MaxQueueDepth     int               `json:"max_queue_depth"`
+	QueueFullTimeout  string            `json:"queue_full_timeout"`
+	EnqueueOnly       bool              `json:"enqueue_only"`
+	ReconcilePolicy   string            `json:"reconcile_policy"`
+}
+
+// JobsConfig holds the jobs settings in use. Durations are formatted such as "1m30s".
+type JobsConfig struct {
+	TaskValidDuration   string            `json:"task_valid_duration"`
+	HistoryEnabled      bool              `json:"history_enabled"`
+	RecoveryPolicies    map[string]string `json:"recovery_policies"`
+	MaxLogs             int               `json:"max_logs"`
+	ReferenceKey        string            `json:"reference_key"`
+	PollInterval        string            `json:"poll_interval"`
+	PollInitial         string            `json:"poll_initial"`
+	PollMax             string            `json:"poll_max"`
+	ShutdownGracePeriod string            `json:"shutdown_grace_period"`
+	ContextClosedPolicy string            `json:"context_closed_policy"`
+	RetryAfter          map[string]string `json:"retry_after"`
+	MaxConcurrentJobs   int               `json:"max_concurrent_jobs"`
+	CapacityPolicy      string            `json:"capacity_policy"`
+}
+
+// EffectiveConfigResponse holds the queue and jobs settings in use by the node.
+type EffectiveConfigResponse struct {
+	Queue QueueConfig `json:"queue"`
+	Jobs  JobsConfig  `json:"jobs"`
+}
+
+func toEffectiveConfigResponse(qc queue.EffectiveConfig, jc jobs.EffectiveConfig) EffectiveConfigResponse {
+	ackModes := make(map[string]string, len(qc.AckModes))
+	for name, mode := range qc.AckModes {
+		ackModes[name] = string(mode)
+	}
+
+	return EffectiveConfigResponse{
+		Queue: QueueConfig{
+			NumWorkers:        qc.NumWorkers,
+			WorkerWaitTime:    qc.WorkerWaitTime.String(),
+			TaskValidDuration: qc.TaskValidDuration.String(),
+			TaskTimeouts:      durationStrings(qc.TaskTimeouts),
+			AckModes:          ackModes,
+			StoreResults:      qc.StoreResults,
+			ScheduleCatchUp:   qc.ScheduleCatchUp,
+			DedupWindow:       qc.DedupWindow.String(),
+			MaxQueueDepth:     qc.MaxQueueDepth,
+			QueueFullTimeout:  qc.QueueFullTimeout.String(),
+			EnqueueOnly:       qc.EnqueueOnly,
+			ReconcilePolicy:   qc.ReconcilePolicy,
+		},
+		Jobs: JobsConfig{
+			TaskValidDuration:   jc.TaskValidDuration.String(),
+			HistoryEnabled:      jc.HistoryEnabled,
+			RecoveryPolicies:    jc.RecoveryPolicies,
+			MaxLogs:             jc.MaxLogs,
+			ReferenceKey:        jc.ReferenceKey,
+			PollInterval:        jc.PollInterval.String(),
+			PollInitial:         jc.PollInitial.String(),
+			PollMax:             jc.PollMax.String(),
+			ShutdownGracePeriod: jc.ShutdownGracePeriod.String(),
+			ContextClosedPolicy: jc.ContextClosedPolicy,
+			RetryAfter:          durationStrings(jc.RetryAfter),
+			MaxConcurrentJobs:   jc.MaxConcurrentJobs,
+			CapacityPolicy:      jc.CapacityPolicy,
+		},
+	}
+}
+
+func durationStrings(durations map[string]time.Duration) map[string]string {
+	strs := make(map[string]string, len(durations))
+	for k, d := range durations {
+		strs[k] = d.String()
+	}
+
+	return strs
 }
 
 // NFTResponseHeader holds the NFT mint job ID.
@@ -346,6 +477,9 @@ func toNFTMintRequest(req MintNFTRequest, registryAddress common.Address) nft.Mi
 		AssetManagerAddress:      common.HexToAddress(req.AssetManagerAddress.String()),
 		SubmitNFTReadAccessProof: false,
 		SubmitTokenProof:         true,
+		Deadline:                 req.Deadline,
+		PropertyMapping:          req.PropertyMapping,
+		AnchorDocument:           req.AnchorDocument,
 	}
 }
 
@@ -367,6 +501,7 @@ type NFTOwnerResponse struct {
 	TokenID         string         `json:"token_id"`
 	RegistryAddress common.Address `json:"registry_address" swaggertype:"primitive,string"`
 	Owner           common.Address `json:"owner" swaggertype:"primitive,string"`
+	Stale           bool           `json:"stale"` // true if the chain is unreachable and the last known owner is returned
 }
 
 // SignRequest holds the payload to be signed.
@@ -400,6 +535,10 @@ type Account struct {
 	EthereumAccount                  EthAccount              `json:"eth_account"`
 	EthereumDefaultAccountName       string                  `json:"eth_default_account_name"`
 	ReceiveEventNotificationEndpoint string                  `json:"receive_event_notification_endpoint"`
+	ReceiveEventNotificationHeaders  map[string]string       `json:"receive_event_notification_headers,omitempty"`
+	ReceiveEventNotificationSecret   string                  `json:"receive_event_notification_secret,omitempty"`
+	ReceiveEventNotificationVersion  int                     `json:"receive_event_notification_version,omitempty"`
+	ReceiveEventNotificationTemplate string                  `json:"receive_event_notification_template,omitempty"`
 	IdentityID                       byteutils.HexBytes      `json:"identity_id" swaggertype:"primitive,string"`
 	SigningKeyPair                   KeyPair                 `json:"signing_key_pair"`
 	P2PKeyPair                       KeyPair                 `json:"p2p_key_pair"`
@@ -442,6 +581,9 @@ func toClientAccount(acc config.Account) (Account, error) {
 		},
 		IdentityID:                       acc.GetIdentityID(),
 		ReceiveEventNotificationEndpoint: acc.GetReceiveEventNotificationEndpoint(),
+		ReceiveEventNotificationHeaders:  acc.GetReceiveEventNotificationHeaders(),
+		ReceiveEventNotificationVersion:  acc.GetReceiveEventNotificationVersion(),
+		ReceiveEventNotificationTemplate: acc.GetReceiveEventNotificationTemplate(),
 		EthereumDefaultAccountName:       acc.GetEthereumDefaultAccountName(),
 		P2PKeyPair:                       p2pkp,
 		SigningKeyPair:                   signingkp,
@@ -496,7 +638,19 @@ func fromClientAccount(cacc Account) (config.Account, error) {
 		return nil, errors.New("Identity ID cannot be empty")
 	}
 
+	if !notification.ValidSchemaVersion(cacc.ReceiveEventNotificationVersion) {
+		return nil, errors.New("unknown notification schema version %d", cacc.ReceiveEventNotificationVersion)
+	}
+
+	if _, err := notification.ParsePayloadTemplate(cacc.ReceiveEventNotificationTemplate); err != nil {
+		return nil, err
+	}
+
 	acc.IdentityID = cacc.IdentityID
 	acc.ReceiveEventNotificationEndpoint = cacc.ReceiveEventNotificationEndpoint
+	acc.ReceiveEventNotificationHeaders = cacc.ReceiveEventNotificationHeaders
+	acc.ReceiveEventNotificationSecret = cacc.ReceiveEventNotificationSecret
+	acc.ReceiveEventNotificationVersion = cacc.ReceiveEventNotificationVersion
+	acc.ReceiveEventNotificationTemplate = cacc.ReceiveEventNotificationTemplate
 	return acc, nil
 }
@@ -11,6 +11,7 @@ import (
 // config defines required methods for Health handler
 type config interface {
 	GetNetworkString() string
+	GetTaskEnqueueOnly() bool
 }
 
 // handler handles the Health APIs
@@ -22,12 +23,15 @@ type handler struct {
 type Pong struct {
 	Version string `json:"version"`
 	Network string `json:"network"`
+
+	// EnqueueOnly is true if the node intentionally runs no task workers
+	EnqueueOnly bool `json:"enqueue_only"`
 }
 
 // Ping responds with node version and network
 
 // @summary Health check for Node
-// @description returns node version and network
+// @description returns node version, network and whether the node runs the task workers
 // @id ping
 // @tags Health
 // @produce json
@@ -36,8 +40,9 @@ type Pong struct {
 func (h handler) Ping(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, Pong{
-		Version: version.GetVersion().String(),
-		Network: h.c.GetNetworkString(),
+		Version:     version.GetVersion().String(),
+		Network:     h.c.GetNetworkString(),
+		EnqueueOnly: h.c.GetTaskEnqueueOnly(),
 	})
 }
 
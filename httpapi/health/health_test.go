@@ -18,6 +18,10 @@ func (mockConfig) GetNetworkString() string {
 	return "test network"
 }
 
+func (mockConfig) GetTaskEnqueueOnly() bool {
+	return true
+}
+
 func TestHandler_Ping(t *testing.T) {
 	h := handler{mockConfig{}}
 	r := httptest.NewRequest("GET", "/ping", nil)
@@ -28,6 +32,7 @@ func TestHandler_Ping(t *testing.T) {
 	var pong Pong
 	assert.NoError(t, dec.Decode(&pong))
 	assert.Equal(t, pong.Network, "test network")
+	assert.True(t, pong.EnqueueOnly)
 }
 
 func TestRegister(t *testing.T) {
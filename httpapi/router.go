@@ -12,12 +12,14 @@ import (
 	"github.com/centrifuge/go-centrifuge/httpapi/health"
 	"github.com/centrifuge/go-centrifuge/httpapi/userapi"
 	v2 "github.com/centrifuge/go-centrifuge/httpapi/v2"
+	"github.com/centrifuge/go-centrifuge/metrics"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-centrifuge/utils/httputils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Router returns the http mux for the server.
@@ -52,10 +54,17 @@ func Router(ctx context.Context) (*chi.Mux, error) {
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.DefaultLogger)
 	r.Use(auth(configSrv))
+	r.Use(middleware.RequestID)
+	r.Use(requestInfo)
 
 	// health check
 	health.Register(r, cfg)
 
+	// metrics of the jobs, queue and nft subsystems, one scrape target for the node
+	if reg, ok := cctx[metrics.BootstrappedRegistry].(*prometheus.Registry); ok {
+		r.Handle("/metrics", metrics.Handler(reg))
+	}
+
 	r.Route("/v1", func(r chi.Router) {
 		// core apis
 		coreapi.Register(cctx, r)
@@ -75,12 +84,14 @@ func Router(ctx context.Context) (*chi.Mux, error) {
 // this will be the super set for the configs defined in sub packages
 type Config interface {
 	GetNetworkString() string
+	GetTaskEnqueueOnly() bool
 }
 
 func auth(configSrv config.Service) func(handler http.Handler) http.Handler {
 	// TODO(ved): regex would be a better alternative
 	skippedURLs := []string{
 		"/ping",
+		"/metrics",
 		"/accounts", // since we use default account DID for endpoints
 	}
 	return func(handler http.Handler) http.Handler {
@@ -109,3 +120,16 @@ func auth(configSrv config.Service) func(handler http.Handler) http.Handler {
 		})
 	}
 }
+
+// requestInfo records the method, path and request ID of the API call in the context
+// so that the jobs kicked off by it can be traced back to this request.
+func requestInfo(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := contextutil.WithRequest(r.Context(), contextutil.RequestInfo{
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			RequestID: middleware.GetReqID(r.Context()),
+		})
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
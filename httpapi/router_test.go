@@ -11,12 +11,16 @@ import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/httpapi/userapi"
 	v2 "github.com/centrifuge/go-centrifuge/httpapi/v2"
+	"github.com/centrifuge/go-centrifuge/metrics"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
 	testingnfts "github.com/centrifuge/go-centrifuge/testingutils/nfts"
+	"github.com/go-chi/chi/middleware"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -71,6 +75,24 @@ func TestRouter_auth(t *testing.T) {
 	cfgSrv.AssertExpectations(t)
 }
 
+func TestRouter_requestInfo(t *testing.T) {
+	r := httptest.NewRequest("POST", "/v1/entities", nil)
+	r.Header.Set(middleware.RequestIDHeader, "some-request-id")
+	w := httptest.NewRecorder()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, ok := contextutil.Request(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, contextutil.RequestInfo{
+			Method:    "POST",
+			Path:      "/v1/entities",
+			RequestID: "some-request-id",
+		}, info)
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware.RequestID(requestInfo(next)).ServeHTTP(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+}
+
 func TestRouter(t *testing.T) {
 	cctx := map[string]interface{}{
 		coreapi.BootstrappedCoreAPIService: coreapi.Service{},
@@ -84,12 +106,19 @@ func TestRouter(t *testing.T) {
 	ctx := context.WithValue(context.Background(), bootstrap.NodeObjRegistry, cctx)
 	r, err := Router(ctx)
 	assert.NoError(t, err)
-	assert.Len(t, r.Middlewares(), 3)
+	assert.Len(t, r.Middlewares(), 5)
 	assert.Len(t, r.Routes(), 3)
 	// health pattern
 	assert.Equal(t, "/ping", r.Routes()[0].Pattern)
 	// v1 routes
-	assert.Len(t, r.Routes()[1].SubRoutes.Routes(), 25)
+	assert.Len(t, r.Routes()[1].SubRoutes.Routes(), 37)
 	// v2 routes
 	assert.Len(t, r.Routes()[2].SubRoutes.Routes(), 14)
+
+	// metrics
+	cctx[metrics.BootstrappedRegistry] = prometheus.NewRegistry()
+	r, err = Router(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, r.Routes(), 4)
+	assert.Equal(t, "/metrics", r.Routes()[0].Pattern)
 }
@@ -55,6 +55,7 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		entitySrv:              eSrv,
 		fundingSrv:             fundingSrv,
 		config:                 configSrv,
+		fundingImports:         newFundingImports(),
 	}
 	return nil
 }
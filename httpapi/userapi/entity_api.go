@@ -346,3 +346,37 @@ func (h handler) GetEntityThroughRelationship(w http.ResponseWriter, r *http.Req
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
+
+// GetRelationshipsSharedWithMe returns the entity relationships shared with the account.
+// @summary Returns the entity relationships shared with the account.
+// @description Returns the entity relationships in which the account is the target identity.
+// @id get_relationships_shared_with_me
+// @tags Entities
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} userapi.SharedRelationshipsResponse
+// @router /v1/relationships/shared-with-me [get]
+func (h handler) GetRelationshipsSharedWithMe(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	models, err := h.srv.GetRelationshipsSharedWithSelf(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	resp, err := toSharedRelationshipsResponse(models)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
@@ -521,3 +521,48 @@ func TestHandler_GetEntityThroughRelationship(t *testing.T) {
 	eSrv.AssertExpectations(t)
 	m.AssertExpectations(t)
 }
+
+func TestHandler_GetRelationshipsSharedWithMe(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/relationships/shared-with-me", nil).WithContext(ctx)
+	}
+
+	ctx := context.Background()
+	erSrv := new(entity.MockEntityRelationService)
+	h := handler{srv: Service{entityRelationshipSrv: erSrv}}
+
+	// failed to get relationships
+	erSrv.On("GetRelationshipsSharedWithSelf", ctx).Return(nil, errors.New("failed to get relationships")).Once()
+	w, r := getHTTPReqAndResp(ctx)
+	h.GetRelationshipsSharedWithMe(w, r)
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+	assert.Contains(t, w.Body.String(), "failed to get relationships")
+
+	// success
+	owner := testingidentity.GenerateRandomDID()
+	target := testingidentity.GenerateRandomDID()
+	eid := utils.RandomSlice(32)
+	rid := utils.RandomSlice(32)
+	er := &entityrelationship.EntityRelationship{
+		CoreDocument: &documents.CoreDocument{
+			Document: coredocumentpb.CoreDocument{DocumentIdentifier: rid},
+		},
+		Data: entityrelationship.Data{
+			TargetIdentity:   &target,
+			OwnerIdentity:    &owner,
+			EntityIdentifier: eid,
+		},
+	}
+	erSrv.On("GetRelationshipsSharedWithSelf", ctx).Return([]documents.Model{er}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetRelationshipsSharedWithMe(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	var resp SharedRelationshipsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, byteutils.HexBytes(rid), resp.Data[0].RelationshipIdentifier)
+	assert.Equal(t, byteutils.HexBytes(eid), resp.Data[0].Relationship.EntityIdentifier)
+	assert.Equal(t, owner, resp.Data[0].Relationship.OwnerIdentity)
+	assert.Equal(t, target, resp.Data[0].Relationship.TargetIdentity)
+	erSrv.AssertExpectations(t)
+}
@@ -1,11 +1,18 @@
 package userapi
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/extensions"
+	"github.com/centrifuge/go-centrifuge/extensions/funding"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils/httputils"
@@ -17,6 +24,12 @@ import (
 // ErrInvalidAgreementID is a sentinel error when the agreement ID is invalid.
 const ErrInvalidAgreementID = errors.Error("Invalid funding agreement ID")
 
+// ErrEmptySignBatch is a sentinel error when a batch signing request has no items.
+const ErrEmptySignBatch = errors.Error("no funding agreements to sign")
+
+// fundingExportFlushEvery is the number of funding agreements written between flushes of the export stream.
+const fundingExportFlushEvery = 50
+
 // CreateFundingAgreement creates a new funding agreement on the document associated with document_id.
 // @summary Creates a new funding agreement on the document.
 // @description Creates a new funding agreement on the document.
@@ -182,6 +195,66 @@ func (h handler) GetFundingAgreement(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, resp)
 }
 
+// GetFundingAgreementSignatures returns the signatures on the funding agreement associated with agreement_id in the document.
+// @summary Returns the signatures on the funding agreement associated with agreement_id in the document.
+// @description Returns the signer, the signing time and the validity of each signature on the funding agreement in the latest version of the document.
+// @description An unsigned funding agreement has no signatures.
+// @id get_funding_agreement_signatures
+// @tags Funding Agreements
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param agreement_id path string true "Funding agreement Identifier"
+// @produce json
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 200 {object} userapi.FundingSignaturesResponse
+// @router /v1/documents/{document_id}/funding_agreements/{agreement_id}/signatures [get]
+func (h handler) GetFundingAgreementSignatures(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	_, err = hexutil.Decode(chi.URLParam(r, agreementIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidAgreementID
+		return
+	}
+
+	ctx := r.Context()
+	m, err := h.srv.coreAPISrv.GetDocument(ctx, docID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	resp, err := toFundingSignaturesResponse(ctx, h.srv.fundingSrv, m, chi.URLParam(r, agreementIDParam), h.tokenRegistry)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if errors.IsOfType(extensions.ErrAttributeSetNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
 // UpdateFundingAgreement updates the funding agreement associated with agreement_id in the document.
 // @summary Updates the funding agreement associated with agreement_id in the document.
 // @description Updates the funding agreement associated with agreement_id in the document.
@@ -256,13 +329,14 @@ func (h handler) UpdateFundingAgreement(w http.ResponseWriter, r *http.Request)
 
 // SignFundingAgreement signs the funding agreement associated with agreement_id.
 // @summary Signs the funding agreement associated with agreement_id.
-// @description Signs the funding agreement associated with agreement_id.
+// @description Signs the funding agreement associated with agreement_id. The optional body bounds the window the signature is valid within.
 // @id sign_funding_agreement
 // @tags Funding Agreements
 // @accept json
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
 // @param document_id path string true "Document Identifier"
 // @param agreement_id path string true "Funding agreement Identifier"
+// @param body body userapi.FundingSignRequest false "Validity window of the signature"
 // @produce json
 // @Failure 500 {object} httputils.HTTPError
 // @Failure 400 {object} httputils.HTTPError
@@ -291,10 +365,34 @@ func (h handler) SignFundingAgreement(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	// the body is optional, signatures without bounds are valid indefinitely
+	var request FundingSignRequest
+	if len(data) > 0 {
+		err = json.Unmarshal(data, &request)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			return
+		}
+	}
+
 	ctx := r.Context()
-	m, jobID, err := h.srv.fundingSrv.SignFundingAgreement(ctx, docID, fundingID)
+	m, jobID, err := h.srv.fundingSrv.SignFundingAgreement(ctx, docID, fundingID, funding.Validity{
+		NotBefore: request.NotBefore,
+		NotAfter:  request.NotAfter,
+	})
 	if err != nil {
 		code = http.StatusNotFound
+		if errors.IsOfType(extensions.ErrSignatureValidity, err) {
+			code = http.StatusBadRequest
+		}
 		log.Error(err)
 		return
 	}
@@ -310,6 +408,127 @@ func (h handler) SignFundingAgreement(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, resp)
 }
 
+// SignFundingAgreements signs funding agreements across documents.
+// @summary Signs funding agreements across documents.
+// @description Signs the funding agreements of every document in a single new document version and returns the outcome of every item.
+// @id sign_funding_agreements_batch
+// @tags Funding Agreements
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body userapi.FundingSignBatchRequest true "Funding agreements to sign"
+// @produce json
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 202 {object} userapi.FundingSignBatchResponse
+// @router /v1/funding_agreements/sign-batch [post]
+func (h handler) SignFundingAgreements(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	var request FundingSignBatchRequest
+	err = json.Unmarshal(data, &request)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	if len(request.Items) < 1 {
+		code = http.StatusBadRequest
+		err = ErrEmptySignBatch
+		return
+	}
+
+	resp := signFundingAgreementBatch(r.Context(), h.srv.fundingSrv, request.Items)
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, resp)
+}
+
+// ImportFundingAgreements creates a chunk of the funding agreements imported into the document associated with document_id.
+// @summary Imports the funding agreements into the document in chunks.
+// @description Creates the funding agreements of the chunk in a new document version and returns the token the next chunk is sent with.
+// @description The first chunk is sent without a token. Resending a chunk with its token returns its outcome without creating the agreements again, unless its job failed in which case the chunk is created anew.
+// @description The next chunk is refused while the job of the previous chunk is pending or after it failed. Imports expire a day after their last chunk.
+// @id import_funding_agreements
+// @tags Funding Agreements
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param body body userapi.FundingImportRequest true "Chunk of funding agreements"
+// @produce json
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @success 202 {object} userapi.FundingImportResponse
+// @router /v1/documents/{document_id}/funding_agreements/import [post]
+func (h handler) ImportFundingAgreements(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	ctx := r.Context()
+	account, err := contextutil.DIDFromContext(ctx)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	var request FundingImportRequest
+	err = json.Unmarshal(data, &request)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	if len(request.Agreements) < 1 {
+		code = http.StatusBadRequest
+		err = extensions.ErrEmptyImport
+		return
+	}
+
+	resp, err := h.srv.ImportFundingAgreements(ctx, account, docID, request)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(ErrFundingImportNotFound, err) {
+			code = http.StatusNotFound
+		} else if errors.IsOfType(ErrFundingImportPending, err) || errors.IsOfType(ErrFundingImportChunkFailed, err) {
+			code = http.StatusConflict
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, resp)
+}
+
 // GetFundingAgreementFromVersion returns the funding agreement from a specific version of the document.
 // @summary Returns the funding agreement from a specific version of the document.
 // @description Returns the funding agreement from a specific version of the document.
@@ -423,3 +642,177 @@ func (h handler) GetFundingAgreementsFromVersion(w http.ResponseWriter, r *http.
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
+
+// GetFundingAgreementCountFromVersion returns the number of funding agreements in a specific version of the document.
+// @summary Returns the number of funding agreements in a specific version of the document.
+// @description Returns the number of funding agreements in a specific version of the document without the agreements.
+// @id get_funding_agreement_count_version
+// @tags Funding Agreements
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id path string true "Document Version Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} userapi.FundingCountResponse
+// @router /v1/documents/{document_id}/versions/{version_id}/funding_agreements/count [get]
+func (h handler) GetFundingAgreementCountFromVersion(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	ids := make([][]byte, 2, 2)
+	for i, idStr := range []string{chi.URLParam(r, coreapi.DocumentIDParam), chi.URLParam(r, coreapi.VersionIDParam)} {
+		var id []byte
+		id, err = hexutil.Decode(idStr)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = coreapi.ErrInvalidDocumentID
+			return
+		}
+
+		ids[i] = id
+	}
+
+	model, err := h.srv.coreAPISrv.GetDocumentVersion(r.Context(), ids[0], ids[1])
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	resp, err := toFundingAgreementCountResponse(h.srv.fundingSrv, model, h.tokenRegistry)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// ExportFundingAgreements returns all the funding agreements in the document as a single downloadable bundle.
+// @summary Exports all the funding agreements in the document associated with document_id.
+// @description Exports all the funding agreements of the latest or the given version of the document as a self describing JSON bundle.
+// @description The agreements are streamed as they are read, an error while streaming truncates the bundle and leaves it as invalid JSON.
+// @id export_funding_agreements
+// @tags Funding Agreements
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id query string false "Document Version Identifier"
+// @produce json
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 200 {object} userapi.FundingExportResponse
+// @router /v1/documents/{document_id}/funding_agreements/export [get]
+func (h handler) ExportFundingAgreements(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	var versionID []byte
+	if v := r.URL.Query().Get(coreapi.VersionIDParam); v != "" {
+		versionID, err = hexutil.Decode(v)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = coreapi.ErrInvalidDocumentID
+			return
+		}
+	}
+
+	ctx := r.Context()
+	var m documents.Model
+	if versionID != nil {
+		m, err = h.srv.coreAPISrv.GetDocumentVersion(ctx, docID, versionID)
+	} else {
+		m, err = h.srv.coreAPISrv.GetDocument(ctx, docID)
+	}
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	header, err := coreapi.DeriveResponseHeader(h.tokenRegistry, m, jobs.NilJobID())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"funding_agreements_%s_%s.json\"", header.DocumentID, header.VersionID))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	// the status is sent, errors from here on can only truncate the export
+	if err := streamFundingExport(ctx, w, h.srv.fundingSrv, m, header); err != nil {
+		log.Errorf("funding agreements export of document %s truncated: %v", header.DocumentID, err)
+	}
+}
+
+// streamFundingExport writes the FundingExportResponse of the document to w, one agreement at a time,
+// flushing every fundingExportFlushEvery agreements.
+func streamFundingExport(ctx context.Context, w http.ResponseWriter, fundingSrv funding.Service, doc documents.Model, header coreapi.ResponseHeader) error {
+	hd, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	exportedAt, err := json.Marshal(time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `{"header":%s,"exported_at":%s,"data":[`, hd, exportedAt)
+	if err != nil {
+		return err
+	}
+
+	flusher, _ := w.(http.Flusher)
+	var n int
+	err = iterateFundingAgreements(ctx, fundingSrv, doc, func(data FundingDataResponse) error {
+		d, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+
+		if _, err := w.Write(d); err != nil {
+			return err
+		}
+
+		n++
+		if flusher != nil && n%fundingExportFlushEvery == 0 {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write([]byte("]}"))
+	return err
+}
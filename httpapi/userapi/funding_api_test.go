@@ -9,16 +9,22 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/extensions"
 	"github.com/centrifuge/go-centrifuge/extensions/funding"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -213,6 +219,83 @@ func TestHandler_GetFundingAgreement(t *testing.T) {
 	docSrv.AssertExpectations(t)
 }
 
+func TestHandler_GetFundingAgreementSignatures(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/funding_agreements/{agreement_id}/signatures", nil).WithContext(ctx)
+	}
+	// empty document_id and invalid id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = make([]string, 2, 2)
+	rctx.URLParams.Values = make([]string, 2, 2)
+	rctx.URLParams.Keys[0] = "document_id"
+	rctx.URLParams.Keys[1] = "agreement_id"
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	for _, id := range []string{"", "invalid"} {
+		rctx.URLParams.Values[0] = id
+		w, r := getHTTPReqAndResp(ctx)
+		h.GetFundingAgreementSignatures(w, r)
+		assert.Equal(t, w.Code, http.StatusBadRequest)
+		assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+	}
+
+	id := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = byteutils.HexBytes(id).String()
+	rctx.URLParams.Values[1] = "invalid"
+	w, r := getHTTPReqAndResp(ctx)
+	h.GetFundingAgreementSignatures(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), ErrInvalidAgreementID.Error())
+
+	// missing Doc
+	fundingID := hexutil.Encode(utils.RandomSlice(32))
+	rctx.URLParams.Values[1] = fundingID
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", id).Return(nil, errors.New("doc not found")).Once()
+	h.srv.coreAPISrv = newCoreAPIService(docSrv)
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetFundingAgreementSignatures(w, r)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// missing agreement
+	fundingSrv := new(funding.MockService)
+	h.srv.fundingSrv = fundingSrv
+	m := new(testingdocuments.MockModel)
+	docSrv.On("GetCurrentVersion", id).Return(m, nil)
+	m.On("ID").Return(utils.RandomSlice(32))
+	m.On("CurrentVersion").Return(utils.RandomSlice(32))
+	m.On("Author").Return(nil, errors.New("somerror"))
+	m.On("Timestamp").Return(nil, errors.New("somerror"))
+	m.On("NFTs").Return(nil)
+	m.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil)
+	m.On("CalculateTransitionRulesFingerprint").Return(utils.RandomSlice(32), nil)
+	fundingSrv.On("GetDataAndSignatures", mock.Anything, mock.Anything, fundingID).Return(funding.Data{}, nil, extensions.ErrAttributeSetNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetFundingAgreementSignatures(w, r)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+
+	// unsigned agreement
+	fundingSrv.On("GetDataAndSignatures", mock.Anything, mock.Anything, fundingID).Return(funding.Data{}, nil, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetFundingAgreementSignatures(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Contains(t, w.Body.String(), `"signatures":[]`)
+
+	// signed agreement
+	sigs := []funding.Signature{{Valid: "true", OutdatedSignature: "false", Identity: testingidentity.GenerateRandomDID().String(), SignedAt: "2020-01-31T10:20:30Z"}}
+	fundingSrv.On("GetDataAndSignatures", mock.Anything, mock.Anything, fundingID).Return(funding.Data{}, sigs, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetFundingAgreementSignatures(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	var resp FundingSignaturesResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, sigs, resp.Signatures)
+	m.AssertExpectations(t)
+	fundingSrv.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
+
 func TestHandler_UpdateFundingAgreement(t *testing.T) {
 	getHTTPReqAndResp := func(ctx context.Context, body io.Reader) (*httptest.ResponseRecorder, *http.Request) {
 		return httptest.NewRecorder(), httptest.NewRequest("PUT", "/documents/{document_id}/funding_agreements/{agreement_id}", body).WithContext(ctx)
@@ -286,6 +369,9 @@ func TestHandler_SignFundingAgreement(t *testing.T) {
 	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
 		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/funding_agreements/{agreement_id}/sign", nil).WithContext(ctx)
 	}
+	getHTTPReqAndRespWithBody := func(ctx context.Context, body string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/funding_agreements/{agreement_id}/sign", strings.NewReader(body)).WithContext(ctx)
+	}
 	// empty document_id and invalid id
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Keys = make([]string, 2, 2)
@@ -318,14 +404,28 @@ func TestHandler_SignFundingAgreement(t *testing.T) {
 	fundingSrv := new(funding.MockService)
 	h.srv.fundingSrv = fundingSrv
 	g, _ := generic.CreateGenericWithEmbedCD(t, testingconfig.CreateAccountContext(t, cfg), did, nil)
-	fundingSrv.On("SignFundingAgreement", mock.Anything, id, fundingID).Return(nil, nil, errors.New("failed to sign")).Once()
+	fundingSrv.On("SignFundingAgreement", mock.Anything, id, fundingID, funding.Validity{}).Return(nil, nil, errors.New("failed to sign")).Once()
 	w, r := getHTTPReqAndResp(ctx)
 	h.SignFundingAgreement(w, r)
 	assert.Equal(t, w.Code, http.StatusNotFound)
 	assert.Contains(t, w.Body.String(), "failed to sign")
 
+	// invalid body
+	w, r = getHTTPReqAndRespWithBody(ctx, `{"not_after": "tomorrow"}`)
+	h.SignFundingAgreement(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+
+	// invalid validity window
+	notAfter := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fundingSrv.On("SignFundingAgreement", mock.Anything, id, fundingID, funding.Validity{NotAfter: notAfter}).
+		Return(nil, nil, errors.NewTypedError(extensions.ErrSignatureValidity, errors.New("not_after is in the past"))).Once()
+	w, r = getHTTPReqAndRespWithBody(ctx, `{"not_after": "2020-01-01T00:00:00Z"}`)
+	h.SignFundingAgreement(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), "not_after is in the past")
+
 	// success
-	fundingSrv.On("SignFundingAgreement", mock.Anything, id, fundingID).Return(g, jobs.NewJobID(), nil).Once()
+	fundingSrv.On("SignFundingAgreement", mock.Anything, id, fundingID, funding.Validity{}).Return(g, jobs.NewJobID(), nil).Once()
 	fundingSrv.On("GetDataAndSignatures", mock.Anything, mock.Anything, mock.Anything).Return(funding.Data{}, nil, nil)
 	w, r = getHTTPReqAndResp(ctx)
 	h.SignFundingAgreement(w, r)
@@ -334,6 +434,127 @@ func TestHandler_SignFundingAgreement(t *testing.T) {
 	fundingSrv.AssertExpectations(t)
 }
 
+func TestHandler_SignFundingAgreements(t *testing.T) {
+	getHTTPReqAndResp := func(body string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/funding_agreements/sign-batch", bytes.NewReader([]byte(body)))
+	}
+
+	// invalid body and no items
+	h := handler{}
+	for _, body := range []string{"invalid", `{"items":[{"document_id":"invalid"}]}`, `{"items":[]}`} {
+		w, r := getHTTPReqAndResp(body)
+		h.SignFundingAgreements(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	docID, otherDocID := utils.RandomSlice(32), utils.RandomSlice(32)
+	agreementID, otherAgreementID := utils.RandomSlice(32), utils.RandomSlice(32)
+	fundingSrv := new(funding.MockService)
+	h.srv.fundingSrv = fundingSrv
+	inv, _ := funding.CreateDocumentWithFunding(t, testingconfig.CreateAccountContext(t, cfg), did)
+	jobID := jobs.NewJobID()
+	fundingSrv.On("SignFundingAgreements", mock.Anything, docID, [][]byte{agreementID, otherAgreementID}).
+		Return(inv, jobID, []error{nil, errors.New("not a party")}, nil).Once()
+	fundingSrv.On("SignFundingAgreements", mock.Anything, otherDocID, [][]byte{agreementID}).
+		Return(nil, nil, nil, errors.New("missing document")).Once()
+	req := FundingSignBatchRequest{Items: []FundingSignBatchItem{
+		{DocumentID: docID, AgreementID: agreementID},
+		{DocumentID: otherDocID, AgreementID: agreementID},
+		{DocumentID: docID, AgreementID: otherAgreementID},
+		{DocumentID: docID, AgreementID: agreementID},
+		{DocumentID: docID},
+	}}
+	d, err := json.Marshal(req)
+	assert.NoError(t, err)
+	w, r := getHTTPReqAndResp(string(d))
+	h.SignFundingAgreements(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp FundingSignBatchResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 5)
+	for _, i := range []int{0, 3} {
+		assert.Equal(t, jobID.String(), resp.Items[i].JobID)
+		assert.Equal(t, hexutil.Encode(inv.CurrentVersion()), resp.Items[i].VersionID)
+		assert.Empty(t, resp.Items[i].Error)
+	}
+	assert.Equal(t, "missing document", resp.Items[1].Error)
+	assert.Equal(t, "not a party", resp.Items[2].Error)
+	assert.Empty(t, resp.Items[2].JobID)
+	assert.Equal(t, ErrInvalidAgreementID.Error(), resp.Items[4].Error)
+	fundingSrv.AssertExpectations(t)
+}
+
+func TestHandler_ImportFundingAgreements(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/funding_agreements/import", strings.NewReader(body)).WithContext(ctx)
+	}
+
+	// invalid document_id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("document_id", "invalid")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, `{"agreements": [{}]}`)
+	h.ImportFundingAgreements(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing account
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx, `{"agreements": [{}]}`)
+	h.ImportFundingAgreements(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid body and empty chunk
+	ctx = context.WithValue(ctx, config.AccountHeaderKey, did.String())
+	for _, body := range []string{`{"agreements": `, `{"agreements": []}`} {
+		w, r = getHTTPReqAndResp(ctx, body)
+		h.ImportFundingAgreements(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	inv, _ := funding.CreateDocumentWithFunding(t, testingconfig.CreateAccountContext(t, cfg), did)
+	fundingSrv := new(funding.MockService)
+	jobMan := &testingjobs.MockJobManager{}
+	h.srv = Service{
+		coreAPISrv:     coreapi.NewService(nil, jobMan, nil, nil, nil, nil),
+		fundingSrv:     fundingSrv,
+		fundingImports: newFundingImports(),
+	}
+
+	// unknown token
+	w, r = getHTTPReqAndResp(ctx, `{"token": "0x01", "agreements": [{}]}`)
+	h.ImportFundingAgreements(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// invalid agreement
+	fundingSrv.On("ImportFundingAgreements", mock.Anything, docID, mock.Anything).Return(nil, jobs.NilJobID(), errors.New("invalid agreement")).Once()
+	w, r = getHTTPReqAndResp(ctx, `{"agreements": [{}]}`)
+	h.ImportFundingAgreements(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	jobID := jobs.NewJobID()
+	fundingSrv.On("ImportFundingAgreements", mock.Anything, docID, mock.Anything).Return(inv, jobID, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, `{"agreements": [{}, {}]}`)
+	h.ImportFundingAgreements(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp FundingImportResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Token)
+	assert.Equal(t, 2, resp.Imported)
+	assert.Equal(t, jobID.String(), resp.JobID)
+
+	// next chunk while the previous one is pending
+	jobMan.On("GetJobStatus", did, jobID).Return(jobs.StatusResponse{Status: string(jobs.Pending)}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, `{"token": "`+resp.Token+`", "agreements": [{}]}`)
+	h.ImportFundingAgreements(w, r)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	fundingSrv.AssertExpectations(t)
+	jobMan.AssertExpectations(t)
+}
+
 func TestHandler_GetFundingAgreementFromVersion(t *testing.T) {
 	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
 		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions/{version_id}/funding_agreements/{agreement_id}", nil).WithContext(ctx)
@@ -453,3 +674,126 @@ func TestHandler_GetFundingAgreementsFromVersion(t *testing.T) {
 	docSrv.AssertExpectations(t)
 	fundingSrv.AssertExpectations(t)
 }
+
+func TestHandler_GetFundingAgreementCountFromVersion(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions/{version_id}/funding_agreements/count", nil).WithContext(ctx)
+	}
+
+	// empty document_id and invalid
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = make([]string, 2, 2)
+	rctx.URLParams.Values = make([]string, 2, 2)
+	rctx.URLParams.Keys[0] = "document_id"
+	rctx.URLParams.Keys[1] = "version_id"
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+
+	for _, id := range []string{"", "invalid"} {
+		rctx.URLParams.Values[0] = id
+		rctx.URLParams.Values[1] = id
+		w, r := getHTTPReqAndResp(ctx)
+		h.GetFundingAgreementCountFromVersion(w, r)
+		assert.Equal(t, w.Code, http.StatusBadRequest)
+		assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+	}
+
+	// missing document
+	id := utils.RandomSlice(32)
+	vid := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	rctx.URLParams.Values[1] = hexutil.Encode(vid)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetVersion", id, vid).Return(nil, errors.New("missing document")).Once()
+	w, r := getHTTPReqAndResp(ctx)
+	h.srv.coreAPISrv = newCoreAPIService(docSrv)
+	h.GetFundingAgreementCountFromVersion(w, r)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// failed count
+	fundingSrv := new(funding.MockService)
+	h.srv.fundingSrv = fundingSrv
+	inv, _ := funding.CreateDocumentWithFunding(t, testingconfig.CreateAccountContext(t, cfg), did)
+	docSrv.On("GetVersion", id, vid).Return(inv, nil)
+	fundingSrv.On("GetFundingAgreementCount", inv).Return(nil, errors.New("failed count")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetFundingAgreementCountFromVersion(w, r)
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+
+	// success
+	fundingSrv.On("GetFundingAgreementCount", inv).Return(uint64(1), nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetFundingAgreementCountFromVersion(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Contains(t, w.Body.String(), `"count":1`)
+	docSrv.AssertExpectations(t)
+	fundingSrv.AssertExpectations(t)
+}
+
+func TestHandler_ExportFundingAgreements(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/funding_agreements/export"+query, nil).WithContext(ctx)
+	}
+
+	// empty document_id and invalid
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = make([]string, 1, 1)
+	rctx.URLParams.Values = make([]string, 1, 1)
+	rctx.URLParams.Keys[0] = "document_id"
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	for _, id := range []string{"", "invalid"} {
+		rctx.URLParams.Values[0] = id
+		w, r := getHTTPReqAndResp(ctx, "")
+		h.ExportFundingAgreements(w, r)
+		assert.Equal(t, w.Code, http.StatusBadRequest)
+		assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+	}
+
+	// invalid version
+	id := utils.RandomSlice(32)
+	vid := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	w, r := getHTTPReqAndResp(ctx, "?version_id=invalid")
+	h.ExportFundingAgreements(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+
+	// missing document
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", id).Return(nil, errors.New("missing document")).Once()
+	h.srv.coreAPISrv = newCoreAPIService(docSrv)
+	w, r = getHTTPReqAndResp(ctx, "")
+	h.ExportFundingAgreements(w, r)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// success for the given version
+	fundingSrv := new(funding.MockService)
+	h.srv.fundingSrv = fundingSrv
+	fundingSrv.On("GetDataAndSignatures", mock.Anything, mock.Anything, mock.Anything).Return(funding.Data{}, nil, nil)
+	inv, _ := funding.CreateDocumentWithFunding(t, testingconfig.CreateAccountContext(t, cfg), did)
+	docSrv.On("GetVersion", id, vid).Return(inv, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "?version_id="+hexutil.Encode(vid))
+	h.ExportFundingAgreements(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+	var resp FundingExportResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, hexutil.Encode(inv.ID()), resp.Header.DocumentID)
+	assert.Len(t, resp.Data, 1)
+	assert.False(t, resp.ExportedAt.IsZero())
+
+	// failure while streaming truncates the export
+	fundingSrv = new(funding.MockService)
+	h.srv.fundingSrv = fundingSrv
+	fundingSrv.On("GetDataAndSignatures", mock.Anything, mock.Anything, mock.Anything).Return(funding.Data{}, nil, errors.New("failed to get agreement")).Once()
+	docSrv.On("GetVersion", id, vid).Return(inv, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "?version_id="+hexutil.Encode(vid))
+	h.ExportFundingAgreements(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.True(t, strings.HasSuffix(w.Body.String(), `"data":[`))
+	assert.Error(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	docSrv.AssertExpectations(t)
+	fundingSrv.AssertExpectations(t)
+}
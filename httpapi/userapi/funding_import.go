@@ -0,0 +1,164 @@
+package userapi
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrFundingImportNotFound is a sentinel error when the resume token of a funding agreements import is unknown or expired.
+	ErrFundingImportNotFound = errors.Error("funding agreements import not found")
+
+	// ErrFundingImportPending is a sentinel error when the next chunk of an import is sent while the previous one is anchored.
+	ErrFundingImportPending = errors.Error("previous chunk of the funding agreements import is still pending")
+
+	// ErrFundingImportChunkFailed is a sentinel error when the next chunk of an import is sent after the previous one failed.
+	ErrFundingImportChunkFailed = errors.Error("previous chunk of the funding agreements import failed, resend it with its token")
+
+	// fundingImportTTL is how long an import can be resumed after its last chunk.
+	fundingImportTTL = 24 * time.Hour
+)
+
+// fundingImport is the progress of a chunked funding agreements import.
+type fundingImport struct {
+	// mu serializes the chunks of the import
+	mu sync.Mutex
+
+	account   identity.DID
+	docID     []byte
+	chunks    int
+	imported  int
+	updatedAt time.Time
+
+	// next is the token the next chunk is sent with
+	next string
+
+	// last is the token the last chunk was sent with, resending it replays lastResp
+	last     string
+	lastJob  jobs.JobID
+	lastResp FundingImportResponse
+}
+
+// newFundingImport returns a new import of the funding agreements of the document.
+func newFundingImport(account identity.DID, docID []byte) *fundingImport {
+	return &fundingImport{account: account, docID: docID, updatedAt: time.Now()}
+}
+
+// fundingImports tracks the imports by the tokens they can be resumed with, kept in memory.
+type fundingImports struct {
+	mu      sync.Mutex
+	imports map[string]*fundingImport
+}
+
+func newFundingImports() *fundingImports {
+	return &fundingImports{imports: make(map[string]*fundingImport)}
+}
+
+// get returns the import resumed by the token, dropping the imports past their TTL.
+func (fi *fundingImports) get(token string) (*fundingImport, bool) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	for t, imp := range fi.imports {
+		if time.Since(imp.updatedAt) > fundingImportTTL {
+			delete(fi.imports, t)
+		}
+	}
+
+	imp, ok := fi.imports[token]
+	return imp, ok
+}
+
+// advance records the chunk sent with the token and returns the token of the next chunk.
+// The import can only be resumed with the returned token or the one the chunk was sent with.
+func (fi *fundingImports) advance(imp *fundingImport, token string, jobID jobs.JobID, resp FundingImportResponse) string {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	for _, t := range []string{imp.next, imp.last} {
+		if t != "" && t != token {
+			delete(fi.imports, t)
+		}
+	}
+
+	imp.next = hexutil.Encode(utils.RandomSlice(32))
+	imp.last = token
+	imp.lastJob = jobID
+	imp.updatedAt = time.Now()
+	fi.imports[imp.next] = imp
+	fi.imports[imp.last] = imp
+	resp.Token = imp.next
+	imp.lastResp = resp
+	return imp.next
+}
+
+// ImportFundingAgreements creates the chunk of funding agreements of the import resumed by the token,
+// or of a new import if the token is empty. The response holds the token the next chunk is sent with.
+// The chunk sent with the token of the last chunk is not created again, its response is returned instead,
+// unless the job of the last chunk failed in which case the chunk replaces it.
+func (s Service) ImportFundingAgreements(ctx context.Context, account identity.DID, docID []byte, req FundingImportRequest) (resp FundingImportResponse, err error) {
+	token := req.Token
+	imp := newFundingImport(account, docID)
+	if token == "" {
+		token = hexutil.Encode(utils.RandomSlice(32))
+	} else {
+		var ok bool
+		imp, ok = s.fundingImports.get(token)
+		if !ok || !imp.account.Equal(account) || !bytes.Equal(imp.docID, docID) {
+			return resp, ErrFundingImportNotFound
+		}
+	}
+
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+	// the token may have been superseded by a concurrent chunk
+	if req.Token != "" && token != imp.next && token != imp.last {
+		return resp, ErrFundingImportNotFound
+	}
+
+	if imp.last != "" {
+		status, err := s.coreAPISrv.GetJobStatus(account, imp.lastJob)
+		if err != nil {
+			return resp, err
+		}
+
+		switch {
+		case token == imp.last && status.Status != string(jobs.Failed):
+			return imp.lastResp, nil
+		case token == imp.last:
+			// the failed chunk is replaced
+			imp.chunks--
+			imp.imported -= len(imp.lastResp.AgreementIDs)
+		case status.Status == string(jobs.Pending):
+			return resp, ErrFundingImportPending
+		case status.Status == string(jobs.Failed):
+			return resp, ErrFundingImportChunkFailed
+		}
+	}
+
+	model, jobID, err := s.fundingSrv.ImportFundingAgreements(ctx, docID, req.Agreements)
+	if err != nil {
+		return resp, err
+	}
+
+	imp.chunks++
+	imp.imported += len(req.Agreements)
+	resp = FundingImportResponse{
+		JobID:     jobID.String(),
+		VersionID: hexutil.Encode(model.CurrentVersion()),
+		Chunk:     imp.chunks,
+		Imported:  imp.imported,
+	}
+	for _, data := range req.Agreements {
+		resp.AgreementIDs = append(resp.AgreementIDs, data.AgreementID)
+	}
+
+	resp.Token = s.fundingImports.advance(imp, token, jobID, resp)
+	return resp, nil
+}
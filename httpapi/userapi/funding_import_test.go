@@ -0,0 +1,103 @@
+// +build unit
+
+package userapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/extensions/funding"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_ImportFundingAgreements(t *testing.T) {
+	ctx := context.Background()
+	docID := utils.RandomSlice(32)
+	inv, _ := funding.CreateDocumentWithFunding(t, testingconfig.CreateAccountContext(t, cfg), did)
+	fundingSrv := new(funding.MockService)
+	jobMan := &testingjobs.MockJobManager{}
+	srv := Service{
+		coreAPISrv:     coreapi.NewService(nil, jobMan, nil, nil, nil, nil),
+		fundingSrv:     fundingSrv,
+		fundingImports: newFundingImports(),
+	}
+
+	importChunk := func(err error) jobs.JobID {
+		jobID := jobs.NewJobID()
+		fundingSrv.On("ImportFundingAgreements", ctx, docID, mock.Anything).Run(func(args mock.Arguments) {
+			data := args.Get(2).([]funding.Data)
+			for i := range data {
+				data[i].AgreementID = "agreement"
+			}
+		}).Return(inv, jobID, err).Once()
+		return jobID
+	}
+	chunk := func(token string, n int) FundingImportRequest {
+		return FundingImportRequest{Token: token, Agreements: make([]funding.Data, n)}
+	}
+
+	// unknown token
+	_, err := srv.ImportFundingAgreements(ctx, did, docID, chunk("0x01", 1))
+	assert.Equal(t, ErrFundingImportNotFound, err)
+
+	// failed first chunk
+	importChunk(errors.New("invalid agreement"))
+	_, err = srv.ImportFundingAgreements(ctx, did, docID, chunk("", 2))
+	assert.Error(t, err)
+
+	// first chunk
+	firstJob := importChunk(nil)
+	first, err := srv.ImportFundingAgreements(ctx, did, docID, chunk("", 2))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, first.Token)
+	assert.Equal(t, 1, first.Chunk)
+	assert.Equal(t, 2, first.Imported)
+	assert.Equal(t, []string{"agreement", "agreement"}, first.AgreementIDs)
+	assert.Equal(t, firstJob.String(), first.JobID)
+
+	// token of another document or account
+	_, err = srv.ImportFundingAgreements(ctx, did, utils.RandomSlice(32), chunk(first.Token, 1))
+	assert.Equal(t, ErrFundingImportNotFound, err)
+
+	// first chunk still pending
+	jobMan.On("GetJobStatus", did, firstJob).Return(jobs.StatusResponse{Status: string(jobs.Pending)}, nil).Once()
+	_, err = srv.ImportFundingAgreements(ctx, did, docID, chunk(first.Token, 1))
+	assert.Equal(t, ErrFundingImportPending, err)
+
+	// second chunk
+	jobMan.On("GetJobStatus", did, firstJob).Return(jobs.StatusResponse{Status: string(jobs.Success)}, nil).Once()
+	secondJob := importChunk(nil)
+	second, err := srv.ImportFundingAgreements(ctx, did, docID, chunk(first.Token, 3))
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Token, second.Token)
+	assert.Equal(t, 2, second.Chunk)
+	assert.Equal(t, 5, second.Imported)
+
+	// resent second chunk is replayed
+	jobMan.On("GetJobStatus", did, secondJob).Return(jobs.StatusResponse{Status: string(jobs.Pending)}, nil).Once()
+	resp, err := srv.ImportFundingAgreements(ctx, did, docID, chunk(first.Token, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, second, resp)
+
+	// failed second chunk refuses the next chunk and is created anew when resent, superseding the next token
+	jobMan.On("GetJobStatus", did, secondJob).Return(jobs.StatusResponse{Status: string(jobs.Failed)}, nil).Twice()
+	_, err = srv.ImportFundingAgreements(ctx, did, docID, chunk(second.Token, 1))
+	assert.Equal(t, ErrFundingImportChunkFailed, err)
+	importChunk(nil)
+	resp, err = srv.ImportFundingAgreements(ctx, did, docID, chunk(first.Token, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.Chunk)
+	assert.Equal(t, 5, resp.Imported)
+	assert.NotEqual(t, second.Token, resp.Token)
+	_, err = srv.ImportFundingAgreements(ctx, did, docID, chunk(second.Token, 1))
+	assert.Equal(t, ErrFundingImportNotFound, err)
+	fundingSrv.AssertExpectations(t)
+	jobMan.AssertExpectations(t)
+}
@@ -0,0 +1,139 @@
+package userapi
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/render"
+)
+
+const (
+	// defaultJobsPerPage is the number of jobs listed per page unless requested otherwise.
+	defaultJobsPerPage = 20
+
+	// maxJobsPerPage bounds the number of jobs listed per page.
+	maxJobsPerPage = 100
+
+	// maxJobsPage bounds the page so that the offset of the page doesn't overflow.
+	maxJobsPage = 100000
+)
+
+// ErrInvalidPagination is a sentinel error when the page or the page size is not a positive number.
+const ErrInvalidPagination = errors.Error("Invalid pagination")
+
+// ErrInvalidJobLabel is a sentinel error when the job label filter is not in the key:value form.
+const ErrInvalidJobLabel = errors.Error("Invalid job label")
+
+// ListJobs lists a page of the jobs of the account.
+// @summary Lists a page of the jobs of the account.
+// @description Lists the jobs of the account, latest first, optionally filtered by status, by a case insensitive description substring and by labels.
+// @description Labels match the job metadata, such as the request_method of the API request that created the job.
+// @description Page size defaults to 20 and is capped at 100.
+// @id list_jobs
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param page query int false "Page, starting at 1"
+// @param per_page query int false "Jobs per page"
+// @param status query string false "Job status" Enums(pending, success, failed)
+// @param description query string false "Substring of the job description"
+// @param label query []string false "Job label as key:value, repeat to match every label" collectionFormat(multi)
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} userapi.ListJobsResponse
+// @router /v1/jobs [get]
+func (h handler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	query := r.URL.Query()
+	page, perPage, err := parsePagination(query)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	labels, err := parseJobLabels(query["label"])
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	filter := jobs.JobFilter{
+		Status:              jobs.Status(query.Get("status")),
+		DescriptionContains: query.Get("description"),
+		Labels:              labels,
+	}
+	list, total, err := h.srv.ListJobs(account, filter, page, perPage)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if errors.IsOfType(jobs.ErrInvalidJobFilter, err) || errors.IsOfType(ErrInvalidPagination, err) {
+			code = http.StatusBadRequest
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toListJobsResponse(list, page, perPage, total))
+}
+
+// parsePagination returns the page and the page size of the query.
+// The page size is capped at maxJobsPerPage.
+func parsePagination(query url.Values) (page, perPage int, err error) {
+	page, perPage = 1, defaultJobsPerPage
+	if v := query.Get("page"); v != "" {
+		page, err = strconv.Atoi(v)
+		if err != nil || page < 1 || page > maxJobsPage {
+			return 0, 0, errors.NewTypedError(ErrInvalidPagination, errors.New("page %s", v))
+		}
+	}
+
+	if v := query.Get("per_page"); v != "" {
+		perPage, err = strconv.Atoi(v)
+		if err != nil || perPage < 1 {
+			return 0, 0, errors.NewTypedError(ErrInvalidPagination, errors.New("per_page %s", v))
+		}
+	}
+
+	if perPage > maxJobsPerPage {
+		perPage = maxJobsPerPage
+	}
+
+	return page, perPage, nil
+}
+
+// parseJobLabels returns the labels of the key:value pairs.
+func parseJobLabels(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.NewTypedError(ErrInvalidJobLabel, errors.New("label %s", pair))
+		}
+
+		labels[kv[0]] = kv[1]
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,122 @@
+// +build unit
+
+package userapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ListJobs(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/jobs"+query, nil).WithContext(ctx)
+	}
+
+	// missing account
+	w, r := getHTTPReqAndResp(context.Background(), "")
+	h := handler{}
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid pagination
+	did := testingidentity.GenerateRandomDID()
+	ctx := context.WithValue(context.Background(), config.AccountHeaderKey, did.String())
+	for _, query := range []string{"?page=0", "?page=first", "?per_page=-1", "?page=184467440737095517"} {
+		w, r = getHTTPReqAndResp(ctx, query)
+		h.ListJobs(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrInvalidPagination.Error())
+	}
+
+	// invalid label
+	w, r = getHTTPReqAndResp(ctx, "?label=request_method")
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidJobLabel.Error())
+
+	// invalid status
+	jobMan := testingjobs.MockJobManager{}
+	jobMan.On("ListJobsPage", did, jobs.JobFilter{Status: "done"}, 0, defaultJobsPerPage).Return(nil, 0, errors.NewTypedError(jobs.ErrInvalidJobFilter, errors.New("unknown status done"))).Once()
+	filter := jobs.JobFilter{
+		Status:              jobs.Failed,
+		DescriptionContains: "mint",
+		Labels:              map[string]string{jobs.RequestMethodKey: "POST", jobs.RequestPathKey: "/v1/nfts"},
+	}
+	jobMan.On("ListJobsPage", did, filter, 2, 2).Return(nil, 0, errors.New("failed to iterate jobs")).Once()
+	var list []*jobs.Job
+	for i := 0; i < 3; i++ {
+		job := jobs.NewJob(did, "Minting NFT")
+		job.Status = jobs.Failed
+		list = append(list, job)
+	}
+	jobMan.On("ListJobsPage", did, filter, 2, 2).Return(list[2:], 3, nil).Once()
+	h = handler{srv: Service{coreAPISrv: coreapi.NewService(nil, jobMan, nil, nil, nil, nil)}}
+	w, r = getHTTPReqAndResp(ctx, "?status=done")
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// failed listing
+	query := "?status=failed&description=mint&label=request_method:POST&label=request_path:/v1/nfts&page=2&per_page=2"
+	w, r = getHTTPReqAndResp(ctx, query)
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// success
+	w, r = getHTTPReqAndResp(ctx, query)
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp ListJobsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Page)
+	assert.Equal(t, 2, resp.PerPage)
+	assert.Equal(t, 3, resp.Total)
+	assert.Len(t, resp.Jobs, 1)
+	assert.Equal(t, list[2].ID.String(), resp.Jobs[0].JobID)
+	assert.Equal(t, "Minting NFT", resp.Jobs[0].Description)
+	assert.Equal(t, string(jobs.Failed), resp.Jobs[0].Status)
+	assert.False(t, resp.Jobs[0].UpdatedAt.IsZero())
+	jobMan.AssertExpectations(t)
+}
+
+func TestParsePagination(t *testing.T) {
+	page, perPage, err := parsePagination(map[string][]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, page)
+	assert.Equal(t, defaultJobsPerPage, perPage)
+
+	page, perPage, err = parsePagination(map[string][]string{"page": {"3"}, "per_page": {"1000"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page)
+	assert.Equal(t, maxJobsPerPage, perPage)
+
+	_, _, err = parsePagination(map[string][]string{"per_page": {"0"}})
+	assert.True(t, errors.IsOfType(ErrInvalidPagination, err))
+
+	// pages past the max would overflow the offset
+	_, _, err = parsePagination(map[string][]string{"page": {"184467440737095517"}})
+	assert.True(t, errors.IsOfType(ErrInvalidPagination, err))
+}
+
+func TestParseJobLabels(t *testing.T) {
+	labels, err := parseJobLabels(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, labels)
+
+	labels, err = parseJobLabels([]string{"request_path:/v1/nfts:mint", "request_id:"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"request_path": "/v1/nfts:mint", "request_id": ""}, labels)
+
+	_, err = parseJobLabels([]string{":POST"})
+	assert.True(t, errors.IsOfType(ErrInvalidJobLabel, err))
+}
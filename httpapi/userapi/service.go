@@ -7,9 +7,11 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/entity"
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/extensions/funding"
 	"github.com/centrifuge/go-centrifuge/extensions/transferdetails"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 )
 
@@ -21,6 +23,7 @@ type Service struct {
 	entitySrv              entity.Service
 	fundingSrv             funding.Service
 	config                 config.Service
+	fundingImports         *fundingImports
 }
 
 // TODO: this can be refactored into a generic Service which handles all kinds of custom attributes
@@ -162,7 +165,22 @@ func (s Service) GetEntityByRelationship(ctx context.Context, docID []byte) (doc
 	return s.entitySrv.GetEntityByRelationship(ctx, docID)
 }
 
+// GetRelationshipsSharedWithSelf returns the entity relationships shared with the account.
+func (s Service) GetRelationshipsSharedWithSelf(ctx context.Context) ([]documents.Model, error) {
+	return s.entityRelationshipSrv.GetRelationshipsSharedWithSelf(ctx)
+}
+
 // CreateFundingAgreement creates a new funding agreement on a document and anchors the document.
 func (s Service) CreateFundingAgreement(ctx context.Context, docID []byte, data *funding.Data) (documents.Model, jobs.JobID, error) {
 	return s.fundingSrv.CreateFundingAgreement(ctx, docID, data)
 }
+
+// ListJobs returns the page of the jobs of the account matching the filter, latest first, and the number of matching jobs.
+// Pages start at 1, a page past the last one is empty.
+func (s Service) ListJobs(account identity.DID, filter jobs.JobFilter, page, perPage int) ([]*jobs.Job, int, error) {
+	if page < 1 || perPage < 1 || page > maxJobsPage {
+		return nil, 0, errors.NewTypedError(ErrInvalidPagination, errors.New("page %d per_page %d", page, perPage))
+	}
+
+	return s.coreAPISrv.ListJobsPage(account, filter, (page-1)*perPage, perPage)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/testingutils/documents"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/stretchr/testify/assert"
@@ -56,7 +57,7 @@ func (m *MockTransferService) DeriveTransferList(ctx context.Context, model docu
 }
 
 func newCoreAPIService(docSrv documents.Service) coreapi.Service {
-	return coreapi.NewService(docSrv, nil, nil, nil)
+	return coreapi.NewService(docSrv, nil, nil, nil, nil, nil)
 }
 
 func TestService_CreateTransferDetail(t *testing.T) {
@@ -287,3 +288,32 @@ func TestService_RevokeRelationship(t *testing.T) {
 	m.AssertExpectations(t)
 	docSrv.AssertExpectations(t)
 }
+
+func TestService_ListJobs(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	jobMan := testingjobs.MockJobManager{}
+	jobMan.On("ListJobsPage", did, jobs.JobFilter{}, 0, 2).Return(nil, 0, errors.New("failed to iterate jobs")).Once()
+	list := []*jobs.Job{jobs.NewJob(did, "a"), jobs.NewJob(did, "b"), jobs.NewJob(did, "c")}
+	jobMan.On("ListJobsPage", did, jobs.JobFilter{}, 0, 2).Return(list[:2], 3, nil)
+	jobMan.On("ListJobsPage", did, jobs.JobFilter{}, 2, 2).Return(list[2:], 3, nil)
+	srv := Service{coreAPISrv: coreapi.NewService(nil, jobMan, nil, nil, nil, nil)}
+	_, _, err := srv.ListJobs(did, jobs.JobFilter{}, 1, 2)
+	assert.Error(t, err)
+
+	page, total, err := srv.ListJobs(did, jobs.JobFilter{}, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, list[:2], page)
+
+	page, total, err = srv.ListJobs(did, jobs.JobFilter{}, 2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, list[2:], page)
+
+	// pages whose offset would overflow are refused
+	for _, p := range []int{0, maxJobsPage + 1, 184467440737095517} {
+		_, _, err = srv.ListJobs(did, jobs.JobFilter{}, p, 2)
+		assert.True(t, errors.IsOfType(ErrInvalidPagination, err))
+	}
+	jobMan.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ package userapi
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
@@ -18,11 +19,12 @@ import (
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // TODO: think: generic custom attribute set creation?
 
-//CreateTransferDetailRequest is the request body for creating a Transfer Detail
+// CreateTransferDetailRequest is the request body for creating a Transfer Detail
 type CreateTransferDetailRequest struct {
 	DocumentID string               `json:"document_id"`
 	Data       transferdetails.Data `json:"data"`
@@ -198,6 +200,40 @@ func getEntityRelationships(ctx context.Context, erSrv entityrelationship.Servic
 	return relationships, nil
 }
 
+// SharedRelationship holds an entity relationship shared with the account.
+type SharedRelationship struct {
+	RelationshipIdentifier byteutils.HexBytes `json:"relationship_identifier" swaggertype:"primitive,string"`
+	Relationship           Relationship       `json:"relationship"`
+}
+
+// SharedRelationshipsResponse holds the entity relationships shared with the account.
+type SharedRelationshipsResponse struct {
+	Data []SharedRelationship `json:"data"`
+}
+
+func toSharedRelationshipsResponse(models []documents.Model) (resp SharedRelationshipsResponse, err error) {
+	resp.Data = []SharedRelationship{}
+	for _, m := range models {
+		tokens, err := m.GetAccessTokens()
+		if err != nil {
+			return resp, err
+		}
+
+		d := m.GetData().(entityrelationship.Data)
+		resp.Data = append(resp.Data, SharedRelationship{
+			RelationshipIdentifier: m.ID(),
+			Relationship: Relationship{
+				TargetIdentity:   *d.TargetIdentity,
+				OwnerIdentity:    *d.OwnerIdentity,
+				EntityIdentifier: d.EntityIdentifier,
+				Active:           len(tokens) != 0,
+			},
+		})
+	}
+
+	return resp, nil
+}
+
 func toEntityResponse(ctx context.Context, erSrv entityrelationship.Service, model documents.Model, tokenRegistry documents.TokenRegistry, jobID jobs.JobID) (resp EntityResponse, err error) {
 	docResp, err := coreapi.GetDocumentResponse(model, tokenRegistry, jobID)
 	if err != nil {
@@ -236,12 +272,79 @@ type FundingResponse struct {
 	Data   FundingDataResponse    `json:"data"`
 }
 
+// FundingSignaturesResponse holds the signatures on a funding agreement.
+type FundingSignaturesResponse struct {
+	Header     coreapi.ResponseHeader `json:"header"`
+	Signatures []funding.Signature    `json:"signatures"`
+}
+
 // FundingListResponse holds the response for funding agreements.
 type FundingListResponse struct {
 	Header coreapi.ResponseHeader `json:"header"`
 	Data   []FundingDataResponse  `json:"data"`
 }
 
+// FundingExportResponse is the self describing bundle of all the funding agreements in a document version.
+type FundingExportResponse struct {
+	Header     coreapi.ResponseHeader `json:"header"`
+	Data       []FundingDataResponse  `json:"data"`
+	ExportedAt time.Time              `json:"exported_at" swaggertype:"primitive,string"`
+}
+
+// FundingImportRequest is a chunk of the funding agreements imported into a document.
+type FundingImportRequest struct {
+	Token      string         `json:"token"` // token returned for the previous chunk, empty to start a new import
+	Agreements []funding.Data `json:"agreements"`
+}
+
+// FundingImportResponse holds the outcome of a chunk of a funding agreements import.
+type FundingImportResponse struct {
+	Token        string   `json:"token"`         // token the next chunk is sent with
+	Chunk        int      `json:"chunk"`         // number of the chunk in the import, starting at 1
+	Imported     int      `json:"imported"`      // number of the funding agreements imported so far, including the chunk
+	AgreementIDs []string `json:"agreement_ids"` // IDs of the funding agreements of the chunk, in the request order
+	JobID        string   `json:"job_id"`
+	VersionID    string   `json:"version_id"`
+}
+
+// FundingSignRequest is the optional request body bounding the window the funding agreement signature is valid within.
+type FundingSignRequest struct {
+	NotBefore time.Time `json:"not_before" swaggertype:"primitive,string"` // RFC3339. The signature isn't valid before then.
+	NotAfter  time.Time `json:"not_after" swaggertype:"primitive,string"`  // RFC3339. The signature isn't valid from then on.
+}
+
+// FundingSignBatchItem identifies a funding agreement to sign.
+type FundingSignBatchItem struct {
+	DocumentID  byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	AgreementID byteutils.HexBytes `json:"agreement_id" swaggertype:"primitive,string"`
+}
+
+// FundingSignBatchRequest is the request body for signing funding agreements across documents.
+type FundingSignBatchRequest struct {
+	Items []FundingSignBatchItem `json:"items"`
+}
+
+// FundingSignBatchItemResponse holds the outcome of signing a funding agreement in a batch.
+// VersionID and JobID are set if the agreement was signed, Error otherwise.
+type FundingSignBatchItemResponse struct {
+	DocumentID  string `json:"document_id"`
+	AgreementID string `json:"agreement_id"`
+	VersionID   string `json:"version_id,omitempty"`
+	JobID       string `json:"job_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// FundingSignBatchResponse holds the outcome of every item of a batch signing in the request order.
+type FundingSignBatchResponse struct {
+	Items []FundingSignBatchItemResponse `json:"items"`
+}
+
+// FundingCountResponse holds the number of funding agreements in a document version.
+type FundingCountResponse struct {
+	Header coreapi.ResponseHeader `json:"header"`
+	Count  uint64                 `json:"count"`
+}
+
 func toFundingAgreementResponse(
 	ctx context.Context,
 	fundingSrv funding.Service,
@@ -269,6 +372,34 @@ func toFundingAgreementResponse(
 	}, nil
 }
 
+func toFundingSignaturesResponse(
+	ctx context.Context,
+	fundingSrv funding.Service,
+	doc documents.Model,
+	fundingID string,
+	tokenRegistry documents.TokenRegistry) (resp FundingSignaturesResponse, err error) {
+
+	header, err := coreapi.DeriveResponseHeader(tokenRegistry, doc, jobs.NilJobID())
+	if err != nil {
+		return resp, err
+	}
+
+	_, sigs, err := fundingSrv.GetDataAndSignatures(ctx, doc, fundingID, "")
+	if err != nil {
+		return resp, err
+	}
+
+	// unsigned agreements are listed with no signatures
+	if sigs == nil {
+		sigs = []funding.Signature{}
+	}
+
+	return FundingSignaturesResponse{
+		Header:     header,
+		Signatures: sigs,
+	}, nil
+}
+
 func toFundingAgreementListResponse(ctx context.Context,
 	fundingSrv funding.Service,
 	doc documents.Model,
@@ -280,41 +411,166 @@ func toFundingAgreementListResponse(ctx context.Context,
 	}
 	resp.Header = header
 
+	err = iterateFundingAgreements(ctx, fundingSrv, doc, func(data FundingDataResponse) error {
+		resp.Data = append(resp.Data, data)
+		return nil
+	})
+	return resp, err
+}
+
+// iterateFundingAgreements calls fn with each funding agreement of the document in order.
+// Iteration stops at the first error.
+func iterateFundingAgreements(ctx context.Context, fundingSrv funding.Service, doc documents.Model, fn func(data FundingDataResponse) error) error {
 	fl, err := documents.AttrKeyFromLabel(funding.AttrFundingLabel)
 	if err != nil {
-		return resp, err
+		return err
 	}
 
 	if !doc.AttributeExists(fl) {
-		return resp, nil
+		return nil
 	}
 
 	lastIdx, err := extensions.GetArrayLatestIDX(doc, funding.AttrFundingLabel)
 	if err != nil {
-		return resp, err
+		return err
 	}
 
 	i, err := documents.NewInt256("0")
 	if err != nil {
-		return resp, err
+		return err
 	}
 
 	for i.Cmp(lastIdx) != 1 {
 		data, sigs, err := fundingSrv.GetDataAndSignatures(ctx, doc, "", i.String())
 		if err != nil {
-			return resp, err
+			return err
 		}
 
-		resp.Data = append(resp.Data, FundingDataResponse{
+		err = fn(FundingDataResponse{
 			Funding:    data,
 			Signatures: sigs,
 		})
+		if err != nil {
+			return err
+		}
 
 		i, err = i.Inc()
 		if err != nil {
-			return resp, err
+			return err
 		}
 	}
 
-	return resp, nil
+	return nil
+}
+
+func toFundingAgreementCountResponse(
+	fundingSrv funding.Service,
+	doc documents.Model,
+	tokenRegistry documents.TokenRegistry) (resp FundingCountResponse, err error) {
+
+	header, err := coreapi.DeriveResponseHeader(tokenRegistry, doc, jobs.NilJobID())
+	if err != nil {
+		return resp, err
+	}
+	resp.Header = header
+
+	resp.Count, err = fundingSrv.GetFundingAgreementCount(doc)
+	return resp, err
+}
+
+// signFundingAgreementBatch signs the agreements of every document in the batch in a single document version.
+// Repeated items share the outcome of their first occurrence.
+func signFundingAgreementBatch(ctx context.Context, fundingSrv funding.Service, items []FundingSignBatchItem) FundingSignBatchResponse {
+	type docAgreements struct {
+		docID []byte
+		ids   [][]byte
+	}
+
+	resp := FundingSignBatchResponse{Items: make([]FundingSignBatchItemResponse, len(items))}
+	var docs []*docAgreements
+	byDoc := make(map[string]*docAgreements)
+	byItem := make(map[string]int)
+	for i, item := range items {
+		resp.Items[i] = FundingSignBatchItemResponse{DocumentID: item.DocumentID.String(), AgreementID: item.AgreementID.String()}
+		if len(item.DocumentID) == 0 || len(item.AgreementID) == 0 {
+			resp.Items[i].Error = ErrInvalidAgreementID.Error()
+			continue
+		}
+
+		key := resp.Items[i].DocumentID + resp.Items[i].AgreementID
+		if _, ok := byItem[key]; ok {
+			continue
+		}
+
+		d, ok := byDoc[resp.Items[i].DocumentID]
+		if !ok {
+			d = &docAgreements{docID: item.DocumentID}
+			byDoc[resp.Items[i].DocumentID] = d
+			docs = append(docs, d)
+		}
+
+		byItem[key] = len(d.ids)
+		d.ids = append(d.ids, item.AgreementID)
+	}
+
+	for _, d := range docs {
+		docID := hexutil.Encode(d.docID)
+		m, jobID, errs, err := fundingSrv.SignFundingAgreements(ctx, d.docID, d.ids)
+		for i := range resp.Items {
+			item := &resp.Items[i]
+			idx, ok := byItem[item.DocumentID+item.AgreementID]
+			if item.DocumentID != docID || !ok || item.Error != "" {
+				continue
+			}
+
+			switch {
+			case err != nil:
+				item.Error = err.Error()
+			case idx < len(errs) && errs[idx] != nil:
+				item.Error = errs[idx].Error()
+			default:
+				item.VersionID = hexutil.Encode(m.CurrentVersion())
+				item.JobID = jobID.String()
+			}
+		}
+	}
+
+	return resp
+}
+
+// JobSummary holds the summary of a listed job.
+type JobSummary struct {
+	JobID       string    `json:"job_id"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at" swaggertype:"primitive,string"`
+	UpdatedAt   time.Time `json:"updated_at" swaggertype:"primitive,string"`
+}
+
+// ListJobsResponse holds a page of the listed jobs, latest first.
+type ListJobsResponse struct {
+	Jobs    []JobSummary `json:"jobs"`
+	Page    int          `json:"page"`
+	PerPage int          `json:"per_page"`
+	Total   int          `json:"total"` // number of jobs matching the filter across all the pages
+}
+
+func toListJobsResponse(list []*jobs.Job, page, perPage, total int) ListJobsResponse {
+	resp := ListJobsResponse{
+		Jobs:    make([]JobSummary, 0, len(list)),
+		Page:    page,
+		PerPage: perPage,
+		Total:   total,
+	}
+	for _, job := range list {
+		resp.Jobs = append(resp.Jobs, JobSummary{
+			JobID:       job.ID.String(),
+			Status:      string(job.Status),
+			Description: job.Description,
+			CreatedAt:   job.CreatedAt.UTC(),
+			UpdatedAt:   job.UpdatedAt().UTC(),
+		})
+	}
+
+	return resp
 }
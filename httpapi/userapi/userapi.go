@@ -34,13 +34,22 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	r.Post("/entities/{"+coreapi.DocumentIDParam+"}/share", h.ShareEntity)
 	r.Post("/entities/{"+coreapi.DocumentIDParam+"}/revoke", h.RevokeEntity)
 	r.Get("/relationships/{"+coreapi.DocumentIDParam+"}/entity", h.GetEntityThroughRelationship)
+	r.Get("/relationships/shared-with-me", h.GetRelationshipsSharedWithMe)
 
 	// funding api
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/funding_agreements", h.CreateFundingAgreement)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/funding_agreements", h.GetFundingAgreements)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/funding_agreements/export", h.ExportFundingAgreements)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/funding_agreements/import", h.ImportFundingAgreements)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/funding_agreements/{"+agreementIDParam+"}", h.GetFundingAgreement)
 	r.Put("/documents/{"+coreapi.DocumentIDParam+"}/funding_agreements/{"+agreementIDParam+"}", h.UpdateFundingAgreement)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/funding_agreements/{"+agreementIDParam+"}/sign", h.SignFundingAgreement)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/funding_agreements/{"+agreementIDParam+"}/signatures", h.GetFundingAgreementSignatures)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}/funding_agreements/{"+agreementIDParam+"}", h.GetFundingAgreementFromVersion)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}/funding_agreements", h.GetFundingAgreementsFromVersion)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}/funding_agreements/count", h.GetFundingAgreementCountFromVersion)
+	r.Post("/funding_agreements/sign-batch", h.SignFundingAgreements)
+
+	// jobs api
+	r.Get("/jobs", h.ListJobs)
 }
@@ -18,42 +18,61 @@ func TestRegister(t *testing.T) {
 		bootstrap.BootstrappedNFTService: new(testingnfts.MockNFTService),
 	}
 	Register(ctx, r)
-	assert.Len(t, r.Routes(), 12)
+	assert.Len(t, r.Routes(), 19)
 	assert.Equal(t, r.Routes()[0].Pattern, "/documents/{document_id}/funding_agreements")
 	assert.Len(t, r.Routes()[0].Handlers, 2)
 	assert.NotNil(t, r.Routes()[0].Handlers["POST"])
 	assert.NotNil(t, r.Routes()[0].Handlers["GET"])
-	assert.Equal(t, r.Routes()[1].Pattern, "/documents/{document_id}/funding_agreements/{agreement_id}")
-	assert.Len(t, r.Routes()[1].Handlers, 2)
+	assert.Equal(t, r.Routes()[1].Pattern, "/documents/{document_id}/funding_agreements/export")
+	assert.Len(t, r.Routes()[1].Handlers, 1)
 	assert.NotNil(t, r.Routes()[1].Handlers["GET"])
-	assert.NotNil(t, r.Routes()[1].Handlers["PUT"])
-	assert.Equal(t, r.Routes()[2].Pattern, "/documents/{document_id}/funding_agreements/{agreement_id}/sign")
+	assert.Equal(t, r.Routes()[2].Pattern, "/documents/{document_id}/funding_agreements/import")
 	assert.Len(t, r.Routes()[2].Handlers, 1)
 	assert.NotNil(t, r.Routes()[2].Handlers["POST"])
-	assert.Equal(t, r.Routes()[3].Pattern, "/documents/{document_id}/transfer_details")
+	assert.Equal(t, r.Routes()[3].Pattern, "/documents/{document_id}/funding_agreements/{agreement_id}")
 	assert.Len(t, r.Routes()[3].Handlers, 2)
-	assert.NotNil(t, r.Routes()[3].Handlers["POST"])
 	assert.NotNil(t, r.Routes()[3].Handlers["GET"])
-	assert.Equal(t, r.Routes()[4].Pattern, "/documents/{document_id}/transfer_details/{transfer_id}")
-	assert.Len(t, r.Routes()[4].Handlers, 2)
-	assert.NotNil(t, r.Routes()[4].Handlers["PUT"])
-	assert.NotNil(t, r.Routes()[4].Handlers["GET"])
-	assert.Equal(t, r.Routes()[5].Pattern, "/documents/{document_id}/versions/{version_id}/funding_agreements")
+	assert.NotNil(t, r.Routes()[3].Handlers["PUT"])
+	assert.Equal(t, r.Routes()[4].Pattern, "/documents/{document_id}/funding_agreements/{agreement_id}/sign")
+	assert.Len(t, r.Routes()[4].Handlers, 1)
+	assert.NotNil(t, r.Routes()[4].Handlers["POST"])
+	assert.Equal(t, r.Routes()[5].Pattern, "/documents/{document_id}/funding_agreements/{agreement_id}/signatures")
+	assert.Len(t, r.Routes()[5].Handlers, 1)
 	assert.NotNil(t, r.Routes()[5].Handlers["GET"])
-	assert.Equal(t, r.Routes()[6].Pattern, "/documents/{document_id}/versions/{version_id}/funding_agreements/{agreement_id}")
+	assert.Equal(t, r.Routes()[6].Pattern, "/documents/{document_id}/transfer_details")
+	assert.Len(t, r.Routes()[6].Handlers, 2)
+	assert.NotNil(t, r.Routes()[6].Handlers["POST"])
 	assert.NotNil(t, r.Routes()[6].Handlers["GET"])
-	assert.Equal(t, r.Routes()[7].Pattern, "/entities")
-	assert.Len(t, r.Routes()[7].Handlers, 1)
-	assert.NotNil(t, r.Routes()[7].Handlers["POST"])
-	assert.Equal(t, r.Routes()[8].Pattern, "/entities/{document_id}")
-	assert.Len(t, r.Routes()[8].Handlers, 2)
-	assert.NotNil(t, r.Routes()[8].Handlers["PUT"])
+	assert.Equal(t, r.Routes()[7].Pattern, "/documents/{document_id}/transfer_details/{transfer_id}")
+	assert.Len(t, r.Routes()[7].Handlers, 2)
+	assert.NotNil(t, r.Routes()[7].Handlers["PUT"])
+	assert.NotNil(t, r.Routes()[7].Handlers["GET"])
+	assert.Equal(t, r.Routes()[8].Pattern, "/documents/{document_id}/versions/{version_id}/funding_agreements")
 	assert.NotNil(t, r.Routes()[8].Handlers["GET"])
-	assert.Equal(t, r.Routes()[9].Pattern, "/entities/{document_id}/revoke")
-	assert.Len(t, r.Routes()[9].Handlers, 1)
-	assert.NotNil(t, r.Routes()[9].Handlers["POST"])
-	assert.Equal(t, r.Routes()[10].Pattern, "/entities/{document_id}/share")
-	assert.NotNil(t, r.Routes()[10].Handlers["POST"])
-	assert.Equal(t, r.Routes()[11].Pattern, "/relationships/{document_id}/entity")
-	assert.NotNil(t, r.Routes()[11].Handlers["GET"])
+	assert.Equal(t, r.Routes()[9].Pattern, "/documents/{document_id}/versions/{version_id}/funding_agreements/count")
+	assert.NotNil(t, r.Routes()[9].Handlers["GET"])
+	assert.Equal(t, r.Routes()[10].Pattern, "/documents/{document_id}/versions/{version_id}/funding_agreements/{agreement_id}")
+	assert.NotNil(t, r.Routes()[10].Handlers["GET"])
+	assert.Equal(t, r.Routes()[11].Pattern, "/entities")
+	assert.Len(t, r.Routes()[11].Handlers, 1)
+	assert.NotNil(t, r.Routes()[11].Handlers["POST"])
+	assert.Equal(t, r.Routes()[12].Pattern, "/entities/{document_id}")
+	assert.Len(t, r.Routes()[12].Handlers, 2)
+	assert.NotNil(t, r.Routes()[12].Handlers["PUT"])
+	assert.NotNil(t, r.Routes()[12].Handlers["GET"])
+	assert.Equal(t, r.Routes()[13].Pattern, "/entities/{document_id}/revoke")
+	assert.Len(t, r.Routes()[13].Handlers, 1)
+	assert.NotNil(t, r.Routes()[13].Handlers["POST"])
+	assert.Equal(t, r.Routes()[14].Pattern, "/entities/{document_id}/share")
+	assert.NotNil(t, r.Routes()[14].Handlers["POST"])
+	assert.Equal(t, r.Routes()[15].Pattern, "/funding_agreements/sign-batch")
+	assert.Len(t, r.Routes()[15].Handlers, 1)
+	assert.NotNil(t, r.Routes()[15].Handlers["POST"])
+	assert.Equal(t, r.Routes()[16].Pattern, "/jobs")
+	assert.Len(t, r.Routes()[16].Handlers, 1)
+	assert.NotNil(t, r.Routes()[16].Handlers["GET"])
+	assert.Equal(t, r.Routes()[17].Pattern, "/relationships/shared-with-me")
+	assert.NotNil(t, r.Routes()[17].Handlers["GET"])
+	assert.Equal(t, r.Routes()[18].Pattern, "/relationships/{document_id}/entity")
+	assert.NotNil(t, r.Routes()[18].Handlers["GET"])
 }
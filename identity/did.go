@@ -212,6 +212,9 @@ type Service interface {
 	// RawExecute calls the execute method on the identity contract
 	RawExecute(ctx context.Context, to common.Address, data []byte, gasLimit uint64) (txID IDTX, done chan error, err error)
 
+	// EstimateRawExecuteGas estimates the gas used by the execute method on the identity contract
+	EstimateRawExecuteGas(ctx context.Context, to common.Address, data []byte) (uint64, error)
+
 	// Execute creates the abi encoding and calls the execute method on the identity contract
 	Execute(ctx context.Context, to common.Address, contractAbi, methodName string, args ...interface{}) (txID IDTX, done chan error, err error)
 
@@ -17,6 +17,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/utils"
+	goethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -214,6 +215,12 @@ func (i service) ethereumTX(opts *bind.TransactOpts, contractMethod interface{},
 		}
 		logTxHash(ethTX)
 
+		// recorded so that the job can be reconciled with the chain
+		err = txMan.UpdateJobWithValue(accountID, txID, ethereum.TransactionHashKey, ethTX.Hash().Bytes())
+		if err != nil {
+			log.Errorf("failed to record the transaction hash on job %s: %v", txID.String(), err)
+		}
+
 		res, err := ethereum.QueueEthTXStatusTask(accountID, txID, ethTX.Hash(), i.queue)
 		if err != nil {
 			errOut <- err
@@ -263,6 +270,38 @@ func (i service) RawExecute(ctx context.Context, to common.Address, data []byte,
 	return i.jobManager.ExecuteWithinJob(contextutil.Copy(ctx), did, jobID, "Check Job for execute", i.ethereumTX(opts, contract.Execute, to, value, data))
 }
 
+// EstimateRawExecuteGas estimates the gas used by the execute method on the identity contract calling the contract at to with the data
+func (i service) EstimateRawExecuteGas(ctx context.Context, to common.Address, data []byte) (uint64, error) {
+	did, err := NewDIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tc, err := contextutil.Account(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	opts, err := i.client.GetTxOpts(ctx, tc.GetEthereumDefaultAccountName())
+	if err != nil {
+		return 0, err
+	}
+
+	abiObj, err := abi.JSON(strings.NewReader(IdentityContractABI))
+	if err != nil {
+		return 0, err
+	}
+
+	// default: no ether should be send
+	input, err := abiObj.Pack("execute", to, big.NewInt(0), data)
+	if err != nil {
+		return 0, err
+	}
+
+	idAddr := did.ToAddress()
+	return i.client.GetEthClient().EstimateGas(ctx, goethereum.CallMsg{From: opts.From, To: &idAddr, Data: input})
+}
+
 // Execute creates the abi encoding an calls the execute method on the identity contract
 // TODO once we clean up transaction to not use higher level deps we can change back the return to be transactions.txID
 func (i service) Execute(ctx context.Context, to common.Address, contractAbi, methodName string, args ...interface{}) (txID id.IDTX, done chan error, err error) {
@@ -10,6 +10,84 @@ const (
 	// ErrJobsMissing error when job doesn't exist in Repository.
 	ErrJobsMissing = errors.Error("job doesn't exist")
 
+	// ErrJobsRepository error when the job cannot be read from the Repository for a reason other than not found.
+	ErrJobsRepository = errors.Error("failed to read job from the repository")
+
 	// ErrKeyConstructionFailed error when the key construction failed.
 	ErrKeyConstructionFailed = errors.Error("failed to construct job key")
+
+	// ErrJobHistoryDisabled error when the job history is requested but not recorded.
+	ErrJobHistoryDisabled = errors.Error("job history is not enabled")
+
+	// ErrJobHashMissing error when the job was saved without a hash and cannot be verified.
+	ErrJobHashMissing = errors.Error("job hash is missing")
+
+	// ErrJobReferenceNotIndexed error when the jobs are looked up by a metadata key that is not indexed.
+	ErrJobReferenceNotIndexed = errors.Error("jobs are not indexed by the metadata key")
+
+	// ErrInvalidCancelReason error when a job is cancelled with an unknown reason.
+	ErrInvalidCancelReason = errors.Error("invalid cancel reason")
+
+	// ErrJobSupersedesItself error when a job is superseded by itself.
+	ErrJobSupersedesItself = errors.Error("job cannot supersede itself")
+
+	// ErrJobNotPending error when a job that is not pending is cancelled.
+	ErrJobNotPending = errors.Error("job is not pending")
+
+	// ErrJobCancelled error when the job was cancelled before its work completed.
+	ErrJobCancelled = errors.Error("job cancelled")
+
+	// ErrJobInterrupted error when the job was left pending by a node restart.
+	ErrJobInterrupted = errors.Error("job was interrupted by node restart")
+
+	// ErrJobNotResumed error when the job resumed after a node restart didn't complete in time.
+	ErrJobNotResumed = errors.Error("job didn't complete after node restart")
+
+	// ErrInvalidLogCount error when less than one log entry is requested.
+	ErrInvalidLogCount = errors.Error("log count must be positive")
+
+	// ErrInvalidLogRetention error when the logs of a job are compacted to a negative number of logs.
+	ErrInvalidLogRetention = errors.Error("log retention must not be negative")
+
+	// ErrInvalidJobFilter error when the jobs are listed with an invalid filter.
+	ErrInvalidJobFilter = errors.Error("invalid job filter")
+
+	// ErrInvalidAnnotation error when a job is annotated with an empty note.
+	ErrInvalidAnnotation = errors.Error("annotation note must not be empty")
+
+	// ErrInvalidStatusTransition error when a job is forced to an undefined status, to pending, or to the status it has.
+	ErrInvalidStatusTransition = errors.Error("invalid job status transition")
+
+	// ErrMissingStatusReason error when a job is forced to another status without a reason.
+	ErrMissingStatusReason = errors.Error("reason for the status override must not be empty")
+
+	// ErrJobStatusForced error when the work of a job completes after the job was forced to failed.
+	ErrJobStatusForced = errors.Error("job status was forced")
+
+	// ErrJobTaskNotRecorded error when the task of a job is replayed but the job has no task recorded.
+	ErrJobTaskNotRecorded = errors.Error("job has no task recorded")
+
+	// ErrInvalidReconcilePolicy error when the jobs are reconciled with an unknown policy.
+	ErrInvalidReconcilePolicy = errors.Error("invalid reconcile policy")
+
+	// ErrJobTaskLost error when the task of a pending job is no longer queued.
+	ErrJobTaskLost = errors.Error("job task is no longer queued")
+
+	// ErrJobTaskStillQueued error when a finished job still has a queued task.
+	ErrJobTaskStillQueued = errors.Error("finished job has a queued task")
+
+	// ErrJobDependencyFailed error when the job a job depends on failed.
+	ErrJobDependencyFailed = errors.Error("job dependency failed")
+
+	// ErrJobLogArchive error when the archived logs of a job cannot be read or written.
+	ErrJobLogArchive = errors.Error("failed to access the job log archive")
+
+	// ErrJobCapacityReached error when a job is rejected because the node runs the max number of jobs.
+	ErrJobCapacityReached = errors.Error("max concurrent jobs reached")
+
+	// ErrJobExists error when an imported job is already saved and overwriting it is not requested.
+	ErrJobExists = errors.Error("job already exists")
+
+	// ErrInvalidJob error when an imported job is missing its ID or account or has an unknown status.
+	ErrInvalidJob = errors.Error("invalid job")
 )
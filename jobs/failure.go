@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+)
+
+// FailureCategory classifies the error a job failed with.
+type FailureCategory string
+
+const (
+	// FailureUnknown is the category of the errors no classifier recognises.
+	FailureUnknown FailureCategory = "unknown"
+
+	// FailureTransient is the category of the errors that may not happen again when the job is retried.
+	FailureTransient FailureCategory = "transient"
+
+	// FailureValidation is the category of the errors that happen again when the same job is retried.
+	FailureValidation FailureCategory = "validation"
+
+	// FailureRateLimited is the category of the errors caused by the node or the chain refusing more work for a while.
+	FailureRateLimited FailureCategory = "rate_limited"
+)
+
+// Retriable returns true if retrying a job failed with the category is likely to succeed.
+func (c FailureCategory) Retriable() bool {
+	return c == FailureTransient || c == FailureRateLimited
+}
+
+// FailureClassifier returns the category of the error and true if it recognises the error.
+type FailureClassifier func(err error) (FailureCategory, bool)
+
+var classifiers = struct {
+	mu   sync.RWMutex
+	list []FailureClassifier
+}{list: []FailureClassifier{classifyTransient}}
+
+// RegisterFailureClassifier registers a classifier for the errors of a package.
+// Classifiers registered later are consulted first.
+func RegisterFailureClassifier(classifier FailureClassifier) {
+	classifiers.mu.Lock()
+	defer classifiers.mu.Unlock()
+	classifiers.list = append([]FailureClassifier{classifier}, classifiers.list...)
+}
+
+// ClassifyFailure returns the category of the error from the first classifier recognising it.
+func ClassifyFailure(err error) FailureCategory {
+	classifiers.mu.RLock()
+	defer classifiers.mu.RUnlock()
+	for _, classify := range classifiers.list {
+		if category, ok := classify(err); ok {
+			return category
+		}
+	}
+
+	return FailureUnknown
+}
+
+// classifyTransient recognises timeouts, retryable task errors and jobs interrupted by a node restart as transient.
+func classifyTransient(err error) (FailureCategory, bool) {
+	if errors.IsOfType(context.DeadlineExceeded, err) || errors.IsOfType(gocelery.ErrTaskRetryable, err) ||
+		errors.IsOfType(ErrJobInterrupted, err) || errors.IsOfType(ErrJobNotResumed, err) {
+		return FailureTransient, true
+	}
+
+	if te, ok := err.(interface{ Timeout() bool }); ok && te.Timeout() {
+		return FailureTransient, true
+	}
+
+	return "", false
+}
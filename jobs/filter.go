@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// JobFilter selects the jobs listed by ListJobs. Empty fields match every job.
+type JobFilter struct {
+	// Status matches the jobs with the status
+	Status Status
+
+	// DescriptionContains matches the jobs whose description contains it, case insensitive
+	DescriptionContains string
+
+	// Labels matches the jobs whose metadata holds every label
+	Labels map[string]string
+}
+
+// Validate returns an error if the filter status is not a job status.
+func (f JobFilter) Validate() error {
+	switch f.Status {
+	case "", Pending, Success, Failed:
+		return nil
+	default:
+		return errors.NewTypedError(ErrInvalidJobFilter, errors.New("unknown status %s", f.Status))
+	}
+}
+
+// Match returns true if the job matches every criterion of the filter.
+func (f JobFilter) Match(job *Job) bool {
+	if f.Status != "" && job.Status != f.Status {
+		return false
+	}
+
+	for k, v := range f.Labels {
+		if mv, ok := job.Metadata[k]; !ok || mv != v {
+			return false
+		}
+	}
+
+	return strings.Contains(strings.ToLower(job.Description), strings.ToLower(f.DescriptionContains))
+}
@@ -2,7 +2,9 @@ package jobs
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"io"
 	"reflect"
 	"time"
 
@@ -27,6 +29,9 @@ const (
 	// JobIDParam maps job ID in the kwargs.
 	JobIDParam = "jobID"
 
+	// AccountIDParam maps the account ID of the job in the kwargs.
+	AccountIDParam = "accountID"
+
 	// BootstrappedRepo is the key mapped to jobs.Repository.
 	BootstrappedRepo = "BootstrappedRepo"
 
@@ -35,13 +40,130 @@ const (
 
 	// JobDataTypeURL is the type of the job data
 	JobDataTypeURL = "http://github.com/centrifuge/go-centrifuge/jobs/#Job"
+
+	// RequestMethodKey is the metadata key for the HTTP method of the API request that created the job.
+	RequestMethodKey = "request_method"
+
+	// RequestPathKey is the metadata key for the HTTP path of the API request that created the job.
+	RequestPathKey = "request_path"
+
+	// RequestIDKey is the metadata key for the ID of the API request that created the job.
+	RequestIDKey = "request_id"
+
+	// DocumentIDKey is the metadata key for the hex encoded ID of the document whose change created the job.
+	DocumentIDKey = "document_id"
+
+	// DocumentVersionKey is the metadata key for the hex encoded version of the document whose change created the job.
+	DocumentVersionKey = "document_version"
+
+	// RecoveryResume keeps the job pending at node start so that it can be completed by the resumed task.
+	RecoveryResume = "resume"
+
+	// RecoveryFail fails the job left pending at node start.
+	RecoveryFail = "fail"
+
+	// ContextClosedPending leaves the job pending when its context is closed before the work completes.
+	ContextClosedPending = "pending"
+
+	// ContextClosedFail fails the job when its context is closed before the work completes.
+	ContextClosedFail = "fail"
+
+	// ContextClosedCancel cancels the job when its context is closed before the work completes.
+	ContextClosedCancel = "cancel"
+
+	// CapacityBlock waits for a running job to complete when a job is submitted while the node runs the max number of jobs.
+	CapacityBlock = "block"
+
+	// CapacityReject rejects the job submitted while the node runs the max number of jobs.
+	CapacityReject = "reject"
+
+	// CancelReasonKey is the notification metadata key for the reason the job was cancelled.
+	CancelReasonKey = "cancel_reason"
+
+	// RetriedByKey is the metadata key for the ID of the job re-running the failed job.
+	RetriedByKey = "retried_by"
+
+	// ReplayOfKey is the value key for the ID of the job whose task the job replays.
+	ReplayOfKey = "replay_of"
+
+	// SupersededByKey is the metadata key for the ID of the job superseding the job.
+	SupersededByKey = "superseded_by"
+
+	// RetryTaskKey is the notification metadata key for the name of the task scheduled to run again.
+	RetryTaskKey = "retry_task"
+
+	// RetryAttemptKey is the notification metadata key for the attempt number of the task scheduled to run again.
+	RetryAttemptKey = "retry_attempt"
+
+	// RetryDelayKey is the notification metadata key for how long the task waits before it runs again.
+	RetryDelayKey = "retry_delay"
+
+	// SupersedesKey is the metadata key for the ID of the job superseded by the job.
+	SupersedesKey = "supersedes"
+
+	// StatusForcedByKey is the metadata key for the hex encoded ID of the account the status of the job was forced with.
+	StatusForcedByKey = "status_forced_by"
 )
 
+// CancelReason is the reason a job was cancelled.
+type CancelReason string
+
+const (
+	// CancelUserRequest is the reason for a job cancelled on user request.
+	CancelUserRequest CancelReason = "user_request"
+
+	// CancelTimeout is the reason for a job cancelled because it took too long.
+	CancelTimeout CancelReason = "timeout"
+
+	// CancelShutdown is the reason for a job cancelled because the node is shutting down.
+	CancelShutdown CancelReason = "shutdown"
+
+	// CancelSuperseded is the reason for a job cancelled because a newer job replaces it.
+	CancelSuperseded CancelReason = "superseded"
+
+	// CancelContextClosed is the reason for a job cancelled because its context was closed before the work completed.
+	CancelContextClosed CancelReason = "context_closed"
+)
+
+// Valid returns true if the reason is one of the defined cancel reasons.
+func (r CancelReason) Valid() bool {
+	switch r {
+	case CancelUserRequest, CancelTimeout, CancelShutdown, CancelSuperseded, CancelContextClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Valid returns true if the status is one of the defined job statuses.
+func (s Status) Valid() bool {
+	switch s {
+	case Success, Failed, Pending:
+		return true
+	default:
+		return false
+	}
+}
+
 // Log represents a single task in a job.
 type Log struct {
 	Action    string
 	Message   string
 	CreatedAt time.Time
+
+	// Repeated is the number of identical consecutive logs collapsed into this log
+	Repeated int `json:",omitempty"`
+	// LastRepeatedAt is when the last of the Repeated logs was collapsed, nil if the log wasn't repeated
+	LastRepeatedAt *time.Time `json:",omitempty"`
+}
+
+// LastAt returns when the log last occurred, the time of the last repeat if the log was repeated.
+func (l Log) LastAt() time.Time {
+	if l.LastRepeatedAt != nil {
+		return *l.LastRepeatedAt
+	}
+
+	return l.CreatedAt
 }
 
 // NewLog constructs a new log with action and message
@@ -53,6 +175,44 @@ func NewLog(action, message string) Log {
 	}
 }
 
+// Annotation is a note attached to a job by an operator. Annotations don't affect the job.
+type Annotation struct {
+	Note      string    `json:"note"`
+	Author    string    `json:"author,omitempty"` // hex encoded ID of the account the note was made with, if recorded
+	CreatedAt time.Time `json:"created_at" swaggertype:"primitive,string"`
+}
+
+// NotificationReceipt records a delivery of a notification of the job to a webhook and its outcome.
+type NotificationReceipt struct {
+	Event      string    `json:"event"`
+	URL        string    `json:"url"`
+	StatusCode int       `json:"status_code,omitempty"` // HTTP status the webhook responded with, if it responded
+	Delivered  bool      `json:"delivered"`
+	Error      string    `json:"error,omitempty"`
+	SentAt     time.Time `json:"sent_at" swaggertype:"primitive,string"`
+}
+
+// JobTask is a task enqueued for a job with the kwargs it was enqueued with.
+type JobTask struct {
+	Name   string
+	Params map[string]interface{}
+}
+
+// StatusTransition represents a single change of the overall status of a job.
+type StatusTransition struct {
+	From      Status
+	To        Status
+	Actor     string
+	CreatedAt time.Time
+}
+
+// StatusChangeFunc is called with a copy of the job after it moved from one status to another.
+// Callbacks must not modify the job.
+type StatusChangeFunc func(job *Job, from, to Status)
+
+// Retrier re-runs the work of the failed job in a new job and returns the ID of the new job.
+type Retrier func(ctx context.Context, job *Job) (JobID, error)
+
 // JobID is a centrifuge job ID. Internally represented by a UUID. Externally visible as a byte slice or a hex encoded string.
 type JobID uuid.UUID
 
@@ -116,11 +276,92 @@ type Job struct {
 	TaskStatus map[string]Status
 
 	// Logs are Job log messages
-	Logs      []Log
-	CreatedAt time.Time
+	Logs []Log
+	// DroppedLogs is the number of oldest logs dropped to keep the logs under the configured max
+	DroppedLogs int `json:",omitempty"`
+	// ArchivedLogs is the number of oldest logs moved to the log archive of the job
+	ArchivedLogs int `json:",omitempty"`
+	// LogArchive is the reference of the log archive holding the ArchivedLogs
+	LogArchive string `json:",omitempty"`
+	CreatedAt  time.Time
 
 	// Values retrieved from events
 	Values map[string]JobValue
+	// Metadata holds additional details about the job such as the originating API request
+	Metadata map[string]string
+	// History of the status transitions of the job, only recorded when enabled in the config
+	History []StatusTransition `json:",omitempty"`
+	// FailureCategory classifies the error the job failed with
+	FailureCategory FailureCategory `json:",omitempty"`
+	// CancelReason is set if the job was cancelled
+	CancelReason CancelReason `json:",omitempty"`
+	// Annotations are the notes attached to the job by the operators, oldest first
+	Annotations []Annotation `json:",omitempty"`
+	// NotificationReceipts are the deliveries of the notifications of the job, oldest first
+	NotificationReceipts []NotificationReceipt `json:",omitempty"`
+	// Task is the last task enqueued for the job, recorded so that the task can be replayed
+	Task *JobTask `json:",omitempty"`
+	// StartedAt is when the first task of the job reported its status, zero until then
+	StartedAt time.Time
+	// Hash of the status, description and logs of the job, used to detect corruption in the repository
+	Hash []byte `json:",omitempty"`
+}
+
+// AppendLog appends the log to the job. A log identical to the last one is collapsed into it.
+// Oldest logs are dropped once the job holds more than maxLogs logs. maxLogs of 0 keeps all the logs.
+func (t *Job) AppendLog(l Log, maxLogs int) {
+	if n := len(t.Logs); n > 0 && t.Logs[n-1].Action == l.Action && t.Logs[n-1].Message == l.Message {
+		createdAt := l.CreatedAt
+		t.Logs[n-1].Repeated++
+		t.Logs[n-1].LastRepeatedAt = &createdAt
+		return
+	}
+
+	t.Logs = append(t.Logs, l)
+	if maxLogs > 0 {
+		t.CompactLogs(maxLogs)
+	}
+}
+
+// CompactLogs drops the oldest logs so that the job keeps at most the last keepLast logs.
+// The dropped logs are counted in DroppedLogs.
+func (t *Job) CompactLogs(keepLast int) {
+	if keepLast < 0 || len(t.Logs) <= keepLast {
+		return
+	}
+
+	dropped := len(t.Logs) - keepLast
+	t.Logs = append([]Log(nil), t.Logs[dropped:]...)
+	t.DroppedLogs += dropped
+}
+
+// UpdatedAt returns when the job last changed, the time of the latest log, including its repeats, or status transition.
+func (t *Job) UpdatedAt() time.Time {
+	updated := t.CreatedAt
+	if n := len(t.Logs); n > 0 && t.Logs[n-1].LastAt().After(updated) {
+		updated = t.Logs[n-1].LastAt()
+	}
+
+	if n := len(t.History); n > 0 && t.History[n-1].CreatedAt.After(updated) {
+		updated = t.History[n-1].CreatedAt
+	}
+
+	return updated
+}
+
+// CalculateHash returns the hash of the status, description and logs of the job.
+func (t *Job) CalculateHash() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		Status      Status
+		Description string
+		Logs        []Log
+	}{t.Status, t.Description, t.Logs})
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.Sum256(data)
+	return h[:], nil
 }
 
 // JSON returns json marshaled job.
@@ -148,6 +389,7 @@ func NewJob(identity identity.DID, description string) *Job {
 		TaskStatus:  make(map[string]Status),
 		CreatedAt:   time.Now().UTC(),
 		Values:      make(map[string]JobValue),
+		Metadata:    make(map[string]string),
 	}
 }
 
@@ -164,27 +406,207 @@ type StatusResponse struct {
 	Status      string    `json:"status"`
 	Message     string    `json:"message"`
 	LastUpdated time.Time `json:"last_updated" swaggertype:"primitive,string"`
+	// Retriable is true if the job failed with an error that may not happen again when retried
+	Retriable bool `json:"retriable"`
+	// Annotations are the notes attached to the job by the operators, oldest first
+	Annotations []Annotation `json:"annotations,omitempty"`
+	// NotificationReceipts are the deliveries of the notifications of the job, oldest first
+	NotificationReceipts []NotificationReceipt `json:"notification_receipts,omitempty"`
+	// PendingDuration is how long the job waited for its first task, only set if the job history is enabled
+	PendingDuration string `json:"pending_duration,omitempty"`
+	// RunningDuration is how long the job ran since its first task, only set if the job history is enabled
+	RunningDuration string `json:"running_duration,omitempty"`
+	// RetryAfter is how long to wait before retrying the failed job, only set if the job is retriable
+	RetryAfter string `json:"retry_after,omitempty"`
+}
+
+// EffectiveConfig holds the jobs settings in use by the manager, after the defaults are applied.
+type EffectiveConfig struct {
+	// TaskValidDuration is how long the tasks of a job are valid and how long the resumed jobs are kept pending
+	TaskValidDuration   time.Duration
+	HistoryEnabled      bool
+	RecoveryPolicies    map[string]string
+	MaxLogs             int
+	ReferenceKey        string
+	PollInterval        time.Duration
+	PollInitial         time.Duration
+	PollMax             time.Duration
+	ShutdownGracePeriod time.Duration
+	ContextClosedPolicy string
+	RetryAfter          map[string]time.Duration
+	MaxConcurrentJobs   int
+	CapacityPolicy      string
+}
+
+// ShutdownReport summarizes what happened to the jobs running on the node when it shut down.
+type ShutdownReport struct {
+	// Completed is the number of jobs completed within the grace period
+	Completed int
+
+	// Cancelled is the number of jobs cancelled once the grace period elapsed
+	Cancelled int
+
+	// Pending is the number of jobs left pending as they could not be cancelled
+	Pending int
 }
 
 // Config is the config interface for jobs package
 type Config interface {
 	GetTaskValidDuration() time.Duration
+	GetJobHistoryEnabled() bool
+	GetJobRecoveryPolicies() map[string]string
+	GetJobMaxLogs() int
+	GetJobReferenceKey() string
+	GetJobPollInterval() time.Duration
+	GetJobPollInitial() time.Duration
+	GetJobPollMax() time.Duration
+	GetJobShutdownGracePeriod() time.Duration
+	GetJobContextClosedPolicy() string
+	GetJobRetryAfter() map[string]time.Duration
+	GetJobLogArchiveThreshold() int
+	GetMaxConcurrentJobs() int
+	GetJobCapacityPolicy() string
+
+	// webhook notification settings
+	GetReceiveEventNotificationEndpoint() string
+	GetNotificationDialTimeout() time.Duration
+	GetNotificationTLSHandshakeTimeout() time.Duration
+	GetNotificationResponseHeaderTimeout() time.Duration
+	GetNotificationMaxPayloadSize() int
+	GetNotificationOptInEvents() []string
 }
 
 // Manager is a manager for centrifuge Jobs.
 type Manager interface {
 	// ExecuteWithinJob executes the given unit of work within a Job
 	ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingJobID JobID, desc string, work func(accountID identity.DID, jobID JobID, jobManager Manager, err chan<- error)) (jobID JobID, done chan error, err error)
+
+	// ExecuteAfter executes the given unit of work within a new Job once the job it depends on succeeds.
+	// The new job fails without running the work if the dependency fails.
+	ExecuteAfter(ctx context.Context, accountID identity.DID, dependsOn JobID, desc string, work func(accountID identity.DID, jobID JobID, jobManager Manager, err chan<- error)) (jobID JobID, done chan error, err error)
 	GetJob(accountID identity.DID, id JobID) (*Job, error)
 	UpdateJobWithValue(accountID identity.DID, id JobID, key string, value []byte) error
 	UpdateTaskStatus(accountID identity.DID, id JobID, status Status, taskName, message string) error
 	GetJobStatus(accountID identity.DID, id JobID) (StatusResponse, error)
 	WaitForJob(accountID identity.DID, txID JobID) error
+
+	// WaitForJobWithContext blocks until the job is no longer pending or the context is closed,
+	// in which case the error of the context is returned.
+	WaitForJobWithContext(ctx context.Context, accountID identity.DID, txID JobID) error
+
 	GetDefaultTaskTimeout() time.Duration
+	GetJobHistory(accountID identity.DID, id JobID) ([]StatusTransition, error)
+
+	// JobTimings returns how long the job waited for its first task and how long it ran since.
+	// Requires the job history to be enabled.
+	JobTimings(accountID identity.DID, id JobID) (pendingDuration, runningDuration time.Duration, err error)
+	VerifyJob(accountID identity.DID, id JobID) (bool, error)
+
+	// GetRecentLogs returns the last n log entries of the job, oldest first.
+	GetRecentLogs(accountID identity.DID, id JobID, n int) ([]Log, error)
+
+	// CompactJobLogs drops the oldest logs of the job so that it keeps at most the last keepLast logs.
+	// Safe to call while the job is running.
+	CompactJobLogs(accountID identity.DID, id JobID, keepLast int) error
+
+	// GetLogTrail returns all the logs of the job, oldest first, including the logs moved to the log archive.
+	GetLogTrail(accountID identity.DID, id JobID) ([]Log, error)
+
+	// GetJobByReference returns the latest job of the account with the given value for the indexed metadata key.
+	GetJobByReference(accountID identity.DID, key, value string) (*Job, error)
+
+	// ListJobs returns the jobs of the account matching the filter, latest first.
+	ListJobs(accountID identity.DID, filter JobFilter) ([]*Job, error)
+
+	// ListJobsPage returns at most limit of the jobs of the account matching the filter, latest first, skipping
+	// the offset latest ones, and the number of jobs matching the filter.
+	ListJobsPage(accountID identity.DID, filter JobFilter, offset, limit int) (list []*Job, total int, err error)
+
+	// GetJobsForDocumentVersion returns the jobs of the account created by the change of the document version, latest first.
+	GetJobsForDocumentVersion(accountID identity.DID, documentID, versionID []byte) ([]*Job, error)
+
+	// IndexJob indexes the job by the key and value regardless of the configured reference key.
+	IndexJob(accountID identity.DID, id JobID, key, value string) error
+
+	// GetIndexedJob returns the latest job of the account indexed by the key and value with IndexJob.
+	GetIndexedJob(accountID identity.DID, key, value string) (*Job, error)
+
+	// CancelJob fails the pending job and records the reason it was cancelled.
+	CancelJob(ctx context.Context, accountID identity.DID, id JobID, reason CancelReason) error
+
+	// OnStatusChange registers a callback invoked after a job moved to another status is saved.
+	OnStatusChange(fn StatusChangeFunc)
+
+	// OnJobComplete invokes the callback once, in its own routine, when the job reaches a terminal state.
+	// The callback is not invoked if the context is closed first.
+	OnJobComplete(ctx context.Context, accountID identity.DID, id JobID, cb func(StatusResponse))
+
+	// RegisterRetrier registers how RetryFailedJobs re-runs the failed jobs with the description.
+	RegisterRetrier(desc string, retrier Retrier)
+
+	// RetryFailedJobs re-runs the failed jobs of the account with a retriable failure and returns the IDs of the new jobs.
+	// Failed jobs without a registered retrier are skipped.
+	RetryFailedJobs(ctx context.Context, accountID identity.DID) ([]JobID, error)
+
+	// AnnotateJob attaches the operator note to the job. The annotations are kept apart from the logs of the job.
+	AnnotateJob(accountID identity.DID, id JobID, note string) error
+
+	// ForceStatus moves the job to the status on operator request and records the override with the reason,
+	// apart from the transitions made by the job itself. Jobs can only be forced to another terminal status.
+	ForceStatus(accountID identity.DID, id JobID, status Status, reason string) error
+
+	// SupersedeJob records that the pending job id is superseded by the job by, on both jobs.
+	// The superseded job keeps running but completes with a superseded notification instead of the completed one.
+	SupersedeJob(accountID identity.DID, id, by JobID) error
+
+	// NotifyJobRetrying sends the opt-in JobRetrying notification of the pending job whose failed task is scheduled
+	// to run again after the delay. The attempt is the number of the scheduled run of the task, starting at 2.
+	NotifyJobRetrying(ctx context.Context, accountID identity.DID, id JobID, taskName string, attempt int, delay time.Duration)
+
+	// GetNotificationReceipts returns the receipts of the deliveries of the notifications of the job, oldest first.
+	GetNotificationReceipts(accountID identity.DID, id JobID) ([]NotificationReceipt, error)
+
+	// RecordJobTask records the task enqueued for the job, replacing the task recorded before.
+	RecordJobTask(accountID identity.DID, id JobID, task JobTask) error
+
+	// ReconcileJobs cross-checks the jobs with the tasks queued in the backend and resolves the discrepancies
+	// per the reconcile policy. Only the pending jobs with a recorded task are expected to have a queued task.
+	ReconcileJobs(backend TaskBackend, policy string) (ReconcileReport, error)
+
+	// EffectiveConfig returns the jobs settings in use by the manager.
+	EffectiveConfig() EffectiveConfig
+}
+
+// LogSink archives the oldest logs of the jobs outside of the job records.
+type LogSink interface {
+	// Archive appends the logs to the log archive of the job and returns the reference of the archive.
+	Archive(accountID identity.DID, id JobID, logs []Log) (ref string, err error)
+
+	// Load returns the logs of the archive, oldest first.
+	Load(ref string) ([]Log, error)
 }
 
 // Repository can be implemented by a type that handles storage for Jobs.
 type Repository interface {
 	Get(did identity.DID, id JobID) (*Job, error)
 	Save(job *Job) error
+
+	// StreamAllJobs writes every job across all accounts to w as newline delimited JSON, followed by their references.
+	StreamAllJobs(w io.Writer) error
+
+	// ImportJobs restores the jobs and their references from newline delimited JSON as written by StreamAllJobs.
+	// The import stops at the first job or reference already saved.
+	ImportJobs(r io.Reader) error
+
+	// ImportJobsOverwrite restores the jobs as ImportJobs does but overwrites the jobs and references already saved.
+	ImportJobsOverwrite(r io.Reader) error
+
+	// IterateJobs calls fn for every job across all accounts. Iteration stops at the first error.
+	IterateJobs(fn func(job *Job) error) error
+
+	// SaveReference indexes the job by the metadata key and value.
+	SaveReference(did identity.DID, key, value string, id JobID) error
+
+	// GetByReference returns the job indexed by the metadata key and value.
+	GetByReference(did identity.DID, key, value string) (*Job, error)
 }
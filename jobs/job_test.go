@@ -2,8 +2,12 @@ package jobs
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -26,3 +30,109 @@ func TestNilJobID(t *testing.T) {
 	id = NilJobID()
 	assert.Empty(t, id.String())
 }
+
+func TestJob_AppendLog(t *testing.T) {
+	job := new(Job)
+
+	// identical consecutive logs are collapsed
+	job.AppendLog(NewLog("action", "retrying"), 3)
+	job.AppendLog(NewLog("action", "retrying"), 3)
+	job.AppendLog(NewLog("action", "retrying"), 3)
+	assert.Len(t, job.Logs, 1)
+	assert.Equal(t, 2, job.Logs[0].Repeated)
+
+	// the repeats advance the update time of the job
+	first := job.Logs[0].CreatedAt
+	updated := job.UpdatedAt()
+	later := NewLog("action", "retrying")
+	later.CreatedAt = updated.Add(time.Minute)
+	job.AppendLog(later, 3)
+	assert.Len(t, job.Logs, 1)
+	assert.Equal(t, first, job.Logs[0].CreatedAt)
+	assert.Equal(t, later.CreatedAt, job.Logs[0].LastAt())
+	assert.Equal(t, later.CreatedAt, job.UpdatedAt())
+
+	// oldest logs are dropped once the max is reached
+	job.AppendLog(NewLog("action", "message 1"), 3)
+	job.AppendLog(NewLog("action", "message 2"), 3)
+	job.AppendLog(NewLog("action", "message 3"), 3)
+	assert.Len(t, job.Logs, 3)
+	assert.Equal(t, "message 1", job.Logs[0].Message)
+	assert.Equal(t, "message 3", job.Logs[2].Message)
+	assert.Equal(t, 1, job.DroppedLogs)
+
+	// no max
+	for i := 0; i < 5; i++ {
+		job.AppendLog(NewLog("action", fmt.Sprintf("unbounded %d", i)), 0)
+	}
+	assert.Len(t, job.Logs, 8)
+	assert.Equal(t, 1, job.DroppedLogs)
+}
+
+func TestJob_CompactLogs(t *testing.T) {
+	job := new(Job)
+	for i := 0; i < 5; i++ {
+		job.AppendLog(NewLog("action", fmt.Sprintf("message %d", i)), 0)
+	}
+
+	// nothing to drop
+	job.CompactLogs(5)
+	assert.Len(t, job.Logs, 5)
+	assert.Equal(t, 0, job.DroppedLogs)
+
+	job.CompactLogs(2)
+	assert.Len(t, job.Logs, 2)
+	assert.Equal(t, "message 3", job.Logs[0].Message)
+	assert.Equal(t, 3, job.DroppedLogs)
+
+	job.CompactLogs(0)
+	assert.Empty(t, job.Logs)
+	assert.Equal(t, 5, job.DroppedLogs)
+}
+
+func TestClassifyFailure(t *testing.T) {
+	assert.Equal(t, FailureUnknown, ClassifyFailure(errors.New("some error")))
+	assert.Equal(t, FailureTransient, ClassifyFailure(context.DeadlineExceeded))
+	assert.Equal(t, FailureTransient, ClassifyFailure(ErrJobInterrupted))
+
+	errValidation := errors.Error("invalid request")
+	RegisterFailureClassifier(func(err error) (FailureCategory, bool) {
+		return FailureValidation, errors.IsOfType(errValidation, err)
+	})
+	assert.Equal(t, FailureValidation, ClassifyFailure(errors.NewTypedError(errValidation, errors.New("missing field"))))
+	assert.True(t, FailureTransient.Retriable())
+	assert.True(t, FailureRateLimited.Retriable())
+	assert.False(t, FailureValidation.Retriable())
+	assert.False(t, FailureUnknown.Retriable())
+}
+
+func TestJobFilter(t *testing.T) {
+	assert.NoError(t, JobFilter{}.Validate())
+	assert.NoError(t, JobFilter{Status: Failed}.Validate())
+	assert.True(t, errors.IsOfType(ErrInvalidJobFilter, JobFilter{Status: "done"}.Validate()))
+
+	job := &Job{Description: "Minting NFT", Status: Failed}
+	assert.True(t, JobFilter{}.Match(job))
+	assert.True(t, JobFilter{Status: Failed, DescriptionContains: "mint"}.Match(job))
+	assert.True(t, JobFilter{DescriptionContains: "NFT"}.Match(job))
+	assert.False(t, JobFilter{Status: Pending, DescriptionContains: "mint"}.Match(job))
+	assert.False(t, JobFilter{DescriptionContains: "anchor"}.Match(job))
+
+	job.Metadata = map[string]string{RequestMethodKey: "POST", RequestPathKey: "/v1/nfts"}
+	assert.True(t, JobFilter{Labels: map[string]string{RequestMethodKey: "POST"}}.Match(job))
+	assert.True(t, JobFilter{Labels: map[string]string{RequestMethodKey: "POST", RequestPathKey: "/v1/nfts"}}.Match(job))
+	assert.False(t, JobFilter{Labels: map[string]string{RequestMethodKey: "PUT"}}.Match(job))
+	assert.False(t, JobFilter{Labels: map[string]string{RequestIDKey: ""}}.Match(job))
+}
+
+func TestJob_UpdatedAt(t *testing.T) {
+	created := time.Now().UTC()
+	job := &Job{CreatedAt: created}
+	assert.Equal(t, created, job.UpdatedAt())
+
+	job.Logs = []Log{{CreatedAt: created.Add(time.Second)}}
+	assert.Equal(t, created.Add(time.Second), job.UpdatedAt())
+
+	job.History = []StatusTransition{{CreatedAt: created.Add(2 * time.Second)}}
+	assert.Equal(t, created.Add(2*time.Second), job.UpdatedAt())
+}
@@ -2,7 +2,9 @@ package jobsv1
 
 import (
 	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -21,10 +23,37 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return jobs.ErrJobsBootstrap
 	}
 
-	jobsRepo := NewRepository(repo)
+	jobsRepo, err := NewRepositoryWithFormat(repo, cfg.GetJobSerializationFormat())
+	if err != nil {
+		return errors.NewTypedError(jobs.ErrJobsBootstrap, err)
+	}
+
 	ctx[jobs.BootstrappedRepo] = jobsRepo
 
-	jobsMan := NewManager(cfg, jobsRepo)
+	jobsMan := newManager(cfg, jobsRepo)
+	// job notifications are sent to the webhook of the account the job belongs to
+	if configdb, ok := ctx[storage.BootstrappedConfigDB].(storage.Repository); ok {
+		jobsMan.notifier = notification.NewWebhookSender(cfg, configstore.NewDBRepository(configdb))
+	}
+
+	// consumers processing the job notifications of an account in order opt in to the ordered delivery
+	if jobsMan.notifier != nil && cfg.GetNotificationOrderedDelivery() {
+		jobsMan.notifier = notification.NewOrderedSender(jobsMan.notifier)
+	}
+
+	// the oldest logs of the jobs are archived instead of dropped if the archive is enabled
+	if dir := cfg.GetJobLogArchiveDir(); dir != "" {
+		jobsMan.logSink, err = NewFileLogSink(dir)
+		if err != nil {
+			return errors.NewTypedError(jobs.ErrJobsBootstrap, err)
+		}
+	}
+
+	err = jobsMan.recoverJobs()
+	if err != nil {
+		return err
+	}
+
 	ctx[jobs.BootstrappedService] = jobsMan
 	return nil
 }
@@ -3,12 +3,14 @@
 package jobsv1
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -24,10 +26,18 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	randomPath := leveldb.GetRandomTestStoragePath()
 	db, err := leveldb.NewLevelDBStorage(randomPath)
 	assert.Nil(t, err)
-	ctx[bootstrap.BootstrappedConfig] = &testingconfig.MockConfig{}
+	cfg := new(testingconfig.MockConfig)
+	cfg.On("GetJobRecoveryPolicies").Return(map[string]string{}).Once()
+	cfg.On("GetJobSerializationFormat").Return(FormatMsgpack).Once()
+	cfg.On("GetNotificationOrderedDelivery").Return(true).Once()
+	cfg.On("GetJobLogArchiveDir").Return(filepath.Join(randomPath, "logs")).Once()
+	ctx[bootstrap.BootstrappedConfig] = cfg
 	ctx[storage.BootstrappedDB] = leveldb.NewLevelDBRepository(db)
 	err = b.Bootstrap(ctx)
 	assert.Nil(t, err)
 	assert.NotNil(t, ctx[jobs.BootstrappedRepo])
 	assert.NotNil(t, ctx[jobs.BootstrappedService])
+	assert.IsType(t, notification.NewOrderedSender(nil), ctx[jobs.BootstrappedService].(*manager).notifier)
+	assert.NotNil(t, ctx[jobs.BootstrappedService].(*manager).logSink)
+	cfg.AssertExpectations(t)
 }
@@ -0,0 +1,89 @@
+package jobsv1
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+)
+
+// fileLogSink archives the logs of each job to its own file in the directory, one JSON encoded log per line.
+type fileLogSink struct {
+	dir string
+
+	// mu serializes the appends to the archive files
+	mu sync.Mutex
+}
+
+// NewFileLogSink returns the log sink archiving the logs of the jobs to the files in dir.
+// The directory is created if missing.
+func NewFileLogSink(dir string) (jobs.LogSink, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileLogSink{dir: dir}, nil
+}
+
+// Archive appends the logs to the archive file of the job and returns the name of the file as the reference.
+func (s *fileLogSink) Archive(accountID identity.DID, id jobs.JobID, logs []jobs.Log) (ref string, err error) {
+	ref = fmt.Sprintf("%s_%s.log", accountID.String(), id.String())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(filepath.Join(s.dir, ref), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, l := range logs {
+		err = enc.Encode(l)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return "", err
+	}
+
+	return ref, f.Sync()
+}
+
+// Load returns the logs of the archive file.
+func (s *fileLogSink) Load(ref string) ([]jobs.Log, error) {
+	// references are file names, anything else would read outside of the directory
+	if ref == "" || filepath.Base(ref) != ref {
+		return nil, errors.New("invalid log archive reference %q", ref)
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, ref))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var logs []jobs.Log
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var l jobs.Log
+		err = dec.Decode(&l)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, l)
+	}
+
+	return logs, nil
+}
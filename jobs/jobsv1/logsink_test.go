@@ -0,0 +1,53 @@
+// +build unit
+
+package jobsv1
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileLogSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "job-logs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sink, err := NewFileLogSink(filepath.Join(dir, "archive"))
+	assert.NoError(t, err)
+
+	did := testingidentity.GenerateRandomDID()
+	id := jobs.NewJobID()
+	ref, err := sink.Archive(did, id, []jobs.Log{jobs.NewLog("task", "first"), jobs.NewLog("task", "second")})
+	assert.NoError(t, err)
+
+	// appends to the same archive
+	ref2, err := sink.Archive(did, id, []jobs.Log{jobs.NewLog("task", "third")})
+	assert.NoError(t, err)
+	assert.Equal(t, ref, ref2)
+
+	logs, err := sink.Load(ref)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 3)
+	assert.Equal(t, "first", logs[0].Message)
+	assert.Equal(t, "third", logs[2].Message)
+
+	// other job
+	ref2, err = sink.Archive(did, jobs.NewJobID(), []jobs.Log{jobs.NewLog("task", "other")})
+	assert.NoError(t, err)
+	assert.NotEqual(t, ref, ref2)
+
+	// missing archive
+	_, err = sink.Load("missing.log")
+	assert.Error(t, err)
+
+	// references outside of the directory
+	for _, ref := range []string{"", "../" + ref, filepath.Join(dir, "archive", ref)} {
+		_, err = sink.Load(ref)
+		assert.Error(t, err)
+	}
+}
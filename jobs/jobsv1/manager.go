@@ -1,31 +1,83 @@
 package jobsv1
 
 import (
+	"bytes"
+	"container/heap"
 	"context"
 	"fmt"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 const (
 	managerLogPrefix = "manager"
+
+	// statusChangeTimeout is how long the job routine waits for a status change callback before moving on.
+	statusChangeTimeout = 5 * time.Second
+
+	// defaultJobPollInterval is how often the job status is checked while waiting for a job if not configured.
+	defaultJobPollInterval = 10 * time.Millisecond
+
+	// defaultJobPollInitial is how long WaitForJob waits before checking the job status again the first time if not configured.
+	defaultJobPollInitial = 10 * time.Millisecond
+
+	// defaultJobPollMax is the longest WaitForJob waits between two checks of the job status if not configured.
+	defaultJobPollMax = time.Second
+
+	// maxNotificationReceipts is the number of notification receipts kept per job, the oldest are dropped.
+	maxNotificationReceipts = 50
 )
 
 // NewManager returns a JobManager implementation.
 func NewManager(config jobs.Config, repo jobs.Repository) jobs.Manager {
-	return &manager{config: config, repo: repo, notifier: notification.NewWebhookSender()}
+	return newManager(config, repo)
+}
+
+func newManager(config jobs.Config, repo jobs.Repository) *manager {
+	return &manager{config: config, repo: repo, notifier: notification.NewWebhookSender(config, nil), sleep: sleep}
 }
 
-// manager implements JobManager.
-// TODO [JobManager] convert this into an implementation of node.Server and start it at node start so that we can bring down transaction go routines cleanly
+// manager implements JobManager and node.Server.
 type manager struct {
 	config   jobs.Config
 	repo     jobs.Repository
 	notifier notification.Sender
+
+	// logSink archives the oldest logs of the jobs, nil if the log archive is disabled
+	logSink jobs.LogSink
+
+	callbacksMu sync.RWMutex
+	callbacks   []jobs.StatusChangeFunc
+
+	// doneChans holds the job routines running on this node, their done channel is closed once the job is done.
+	doneMu    sync.Mutex
+	doneChans map[string]*jobRoutine
+
+	// jobLocks serialize the read-modify-write of a job record by the routines working on the job.
+	jobLocksMu sync.Mutex
+	jobLocks   map[string]*jobLock
+
+	// slots bound the jobs running on the node, nil if the max concurrent jobs is not configured.
+	slotsOnce sync.Once
+	slots     chan struct{}
+
+	// retriers re-run the failed jobs keyed by the lower cased job description.
+	retriersMu sync.RWMutex
+	retriers   map[string]jobs.Retrier
+
+	// sleep waits for the duration or until the context is closed, replaced by the tests to follow the waits.
+	sleep func(ctx context.Context, d time.Duration) error
 }
 
 func (s *manager) GetDefaultTaskTimeout() time.Duration {
@@ -33,87 +85,200 @@ func (s *manager) GetDefaultTaskTimeout() time.Duration {
 }
 
 func (s *manager) UpdateJobWithValue(accountID identity.DID, id jobs.JobID, key string, value []byte) error {
-	tx, err := s.GetJob(accountID, id)
-	if err != nil {
-		return err
-	}
-	tx.Values[key] = jobs.JobValue{Key: key, Value: value}
-	return s.saveJob(tx)
+	return s.updateJob(accountID, id, func(tx *jobs.Job) error {
+		tx.Values[key] = jobs.JobValue{Key: key, Value: value}
+		return nil
+	})
 }
 
 func (s *manager) UpdateTaskStatus(accountID identity.DID, id jobs.JobID, status jobs.Status, taskName, message string) error {
-	tx, err := s.GetJob(accountID, id)
+	return s.updateJob(accountID, id, func(tx *jobs.Job) error {
+		// status particular to the task
+		tx.TaskStatus[taskName] = status
+		if tx.StartedAt.IsZero() {
+			tx.StartedAt = time.Now().UTC()
+		}
+		tx.AppendLog(jobs.NewLog(taskName, message), s.maxLogs())
+		return nil
+	})
+}
+
+// updateJob applies the update to the latest saved job and saves it while holding the job lock.
+// The job is not saved if the update returns an error.
+func (s *manager) updateJob(accountID identity.DID, id jobs.JobID, update func(job *jobs.Job) error) error {
+	defer s.lockJob(accountID, id)()
+	job, err := s.GetJob(accountID, id)
 	if err != nil {
 		return err
 	}
 
-	// status particular to the task
-	tx.TaskStatus[taskName] = status
-	tx.Logs = append(tx.Logs, jobs.NewLog(taskName, message))
-	return s.saveJob(tx)
+	if err := update(job); err != nil {
+		return err
+	}
+
+	return s.saveJob(job)
 }
 
 // ExecuteWithinJob executes a task within a Job.
+// A new job is created if existingJobID is nil or the job doesn't exist. Other errors reading the job are returned
+// so that a failing repository doesn't spawn duplicate jobs.
+// New jobs take one of the node-wide job slots for as long as their routine runs.
 func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingJobID jobs.JobID, desc string, work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
-	job, err := s.repo.Get(accountID, existingJobID)
-	if err != nil {
+	return s.executeWithinJob(ctx, accountID, existingJobID, desc, true, work)
+}
+
+// executeWithinJob executes the work within the job, new jobs take a job slot for their routine if takeSlot is set.
+func (s *manager) executeWithinJob(ctx context.Context, accountID identity.DID, existingJobID jobs.JobID, desc string, takeSlot bool, work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
+	var job *jobs.Job
+	if !jobs.JobIDEqual(existingJobID, jobs.NilJobID()) {
+		job, err = s.repo.Get(accountID, existingJobID)
+		if err != nil && !errors.IsOfType(jobs.ErrJobsMissing, err) {
+			return jobs.NilJobID(), nil, err
+		}
+	}
+
+	release := func() {}
+	if job == nil {
+		if takeSlot {
+			release, err = s.acquireSlot(ctx)
+			if err != nil {
+				return jobs.NilJobID(), nil, err
+			}
+		}
+
 		job = jobs.NewJob(accountID, desc)
+		tagJobWithRequest(ctx, job)
+		tagJobWithDocument(ctx, job)
 		err := s.saveJob(job)
 		if err != nil {
+			release()
+			return jobs.NilJobID(), nil, err
+		}
+
+		err = s.indexJob(job)
+		if err != nil {
+			release()
 			return jobs.NilJobID(), nil, err
 		}
+
+		jobsCreated.Inc()
 	}
 	// set capacity to one so that any late listener won't block this routine.
 	done = make(chan error, 1)
+	routineDone := s.registerDone(accountID, job.ID)
 	go func(ctx context.Context) {
-		err := make(chan error)
-		go work(accountID, job.ID, s, err)
+		// the slot is free by the time the jobs waiting on this one are signalled.
+		// Routines that panicked signal them on return without the outcome of the job.
+		var freeOnce sync.Once
+		free := func() { freeOnce.Do(release) }
+		defer routineDone(nil)
+		defer free()
+		action := fmt.Sprintf("%s[%s]", managerLogPrefix, desc)
+		defer func() {
+			if r := recover(); r != nil {
+				e := errors.New("%s panicked: %v", action, r)
+				log.Errorf("%v\n%s", e, debug.Stack())
+				s.failJob(accountID, job.ID, action, e)
+				select {
+				case done <- e:
+				default:
+				}
+			}
+		}()
+
+		// buffered so that the work, or its recovered panic, doesn't block once the job stopped waiting for it.
+		err := make(chan error, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Errorf("job %s panicked: %v\n%s", job.ID.String(), r, debug.Stack())
+					err <- errors.New("job panicked: %v", r)
+				}
+			}()
+
+			work(accountID, job.ID, s, err)
+		}()
 
 		var mJob *jobs.Job
 		var doneErr error
+		var changed bool
+		var changedFrom jobs.Status
 		select {
 		case e := <-err:
-			tempJob, err := s.repo.Get(accountID, job.ID)
-			if err != nil {
-				log.Error(e, err)
-				doneErr = errors.AppendError(e, err)
-				break
-			}
-			// update job success status only if this wasn't an existing job.
-			// Otherwise it might update an existing tx pending status to success without actually being a success,
-			// It is assumed that status update is already handled per task in that case.
-			// Checking individual task success is upto the transaction manager users.
-			if e == nil && jobs.JobIDEqual(existingJobID, jobs.NilJobID()) {
-				tempJob.Status = jobs.Success
-			} else if e != nil {
-				log.Error(e)
-				action := fmt.Sprintf("%s[%s]", managerLogPrefix, desc)
-				doneErr = fmt.Errorf(fmt.Sprintf("%s %s", action, e.Error()))
-				tempJob.Logs = append(tempJob.Logs, jobs.NewLog(action, e.Error()))
-				tempJob.Status = jobs.Failed
-			}
-			es := s.saveJob(tempJob)
-			if es != nil {
-				log.Error(e, es)
-				doneErr = errors.AppendError(e, es)
-			}
-			mJob = tempJob
+			func() {
+				defer s.lockJob(accountID, job.ID)()
+				tempJob, err := s.repo.Get(accountID, job.ID)
+				if err != nil {
+					log.Error(e, err)
+					doneErr = errors.AppendError(e, err)
+					return
+				}
+
+				// cancelled job is already failed and notified, the outcome of the work is ignored.
+				if tempJob.CancelReason != "" {
+					doneErr = errors.NewTypedError(jobs.ErrJobCancelled, errors.New("reason: %s", tempJob.CancelReason))
+					return
+				}
+
+				// job forced to a terminal status is already notified, the outcome of the work is ignored.
+				if tempJob.Metadata[jobs.StatusForcedByKey] != "" && tempJob.Status != jobs.Pending {
+					if tempJob.Status == jobs.Failed {
+						doneErr = errors.NewTypedError(jobs.ErrJobStatusForced, errors.New("status: %s", tempJob.Status))
+					}
+					return
+				}
+
+				from := tempJob.Status
+				// update job success status only if this wasn't an existing job.
+				// Otherwise it might update an existing tx pending status to success without actually being a success,
+				// It is assumed that status update is already handled per task in that case.
+				// Checking individual task success is upto the transaction manager users.
+				if e == nil && jobs.JobIDEqual(existingJobID, jobs.NilJobID()) {
+					s.setStatus(tempJob, jobs.Success, action)
+				} else if e != nil {
+					log.Error(e)
+					doneErr = fmt.Errorf(fmt.Sprintf("%s %s", action, e.Error()))
+					tempJob.AppendLog(jobs.NewLog(action, e.Error()), s.maxLogs())
+					tempJob.FailureCategory = jobs.ClassifyFailure(e)
+					s.setStatus(tempJob, jobs.Failed, action)
+				}
+				es := s.saveJob(tempJob)
+				if es != nil {
+					log.Error(e, es)
+					doneErr = errors.AppendError(e, es)
+				} else {
+					changed, changedFrom = true, from
+				}
+				mJob = tempJob
+			}()
 		case <-ctx.Done():
-			msg := fmt.Sprintf("Job %s for account %s with description \"%s\" is stopped because of context close", job.ID.String(), job.DID, job.Description)
-			log.Warningf(msg)
-			tempJob, err := s.repo.Get(accountID, job.ID)
-			if err != nil {
-				log.Error(err)
-				doneErr = err
-				break
-			}
-			tempJob.Logs = append(tempJob.Logs, jobs.NewLog("context closed", msg))
-			e := s.saveJob(tempJob)
-			if e != nil {
-				log.Error(e)
-				doneErr = e
-			}
-			mJob = tempJob
+			func() {
+				defer s.lockJob(accountID, job.ID)()
+				msg := fmt.Sprintf("Job %s for account %s with description \"%s\" is stopped because of context close", job.ID.String(), job.DID, job.Description)
+				log.Warningf(msg)
+				tempJob, err := s.repo.Get(accountID, job.ID)
+				if err != nil {
+					log.Error(err)
+					doneErr = err
+					return
+				}
+				tempJob.AppendLog(jobs.NewLog("context closed", msg), s.maxLogs())
+				from := tempJob.Status
+				doneErr = s.closeJob(tempJob, action, ctx.Err())
+				e := s.saveJob(tempJob)
+				if e != nil {
+					log.Error(e)
+					doneErr = e
+				} else if tempJob.Status != from {
+					changed, changedFrom = true, from
+				}
+				mJob = tempJob
+			}()
+		}
+
+		// callbacks are run without holding the job lock so that they can update the job
+		if changed {
+			s.statusChanged(mJob, changedFrom)
 		}
 
 		// non blocking send
@@ -124,38 +289,936 @@ func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID,
 			log.Error("job done channel capacity breach")
 		}
 
+		// the waiters are signalled with the outcome before the completion is notified, which may take a while
+		free()
+		routineDone(mJob)
+
 		if mJob != nil && jobs.JobIDEqual(existingJobID, jobs.NilJobID()) {
-			notificationMsg := notification.Message{
-				EventType:    notification.JobCompleted,
-				AccountID:    accountID.String(),
-				Recorded:     time.Now().UTC(),
-				DocumentType: jobs.JobDataTypeURL,
-				DocumentID:   mJob.ID.String(),
-				Status:       string(mJob.Status),
+			s.notifyJobCompleted(ctx, mJob)
+		}
+
+	}(ctx)
+	return job.ID, done, nil
+}
+
+// ExecuteAfter executes the work within a new job once the dependency job succeeds.
+// The dependency is waited for in the routine of the new job so that no queue worker is held while waiting.
+// The new job fails without running the work if the dependency fails and is stopped if the context is closed.
+// The job slot is only taken once the dependency succeeded so that the waiting jobs don't starve the running ones.
+func (s *manager) ExecuteAfter(ctx context.Context, accountID identity.DID, dependsOn jobs.JobID, desc string, work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
+	_, err = s.GetJob(accountID, dependsOn)
+	if err != nil {
+		return jobs.NilJobID(), nil, err
+	}
+
+	return s.executeWithinJob(ctx, accountID, jobs.NilJobID(), desc, false, func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		err := s.waitForDependency(ctx, accountID, dependsOn)
+		if err != nil {
+			// the closed context is handled by the job routine, which no longer reads the outcome of the work
+			if ctx.Err() == nil {
+				errOut <- err
 			}
-			if len(mJob.Logs) > 0 {
-				notificationMsg.Message = mJob.Logs[len(mJob.Logs)-1].Message
+			return
+		}
+
+		release, err := s.acquireSlot(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				errOut <- err
 			}
-			// Send Job notification webhook
-			_, err := s.notifier.Send(ctx, notificationMsg)
-			if err != nil {
-				log.Error(err)
+			return
+		}
+		defer release()
+
+		work(accountID, txID, txMan, errOut)
+	})
+}
+
+// waitForDependency blocks until the dependency job succeeds, fails or the context is closed.
+// Dependencies running on this node are waited for on their done channel before the status is checked,
+// other jobs are polled.
+func (s *manager) waitForDependency(ctx context.Context, accountID identity.DID, dependsOn jobs.JobID) error {
+	if ch := s.routineDone(accountID, dependsOn); ch != nil {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	interval := s.pollInterval()
+	for {
+		resp, err := s.GetJobStatus(accountID, dependsOn)
+		if err != nil {
+			return err
+		}
+
+		switch jobs.Status(resp.Status) {
+		case jobs.Failed:
+			return errors.NewTypedError(jobs.ErrJobDependencyFailed, errors.New("job %s: %s", resp.JobID, resp.Message))
+		case jobs.Success:
+			return nil
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// acquireSlot takes one of the node-wide job slots and returns the function releasing it.
+// If all the slots are taken, the job is rejected or waits for a slot as per the capacity policy.
+func (s *manager) acquireSlot(ctx context.Context) (release func(), err error) {
+	s.slotsOnce.Do(func() {
+		if n := s.config.GetMaxConcurrentJobs(); n > 0 {
+			s.slots = make(chan struct{}, n)
+		}
+	})
+
+	if s.slots == nil {
+		return func() {}, nil
+	}
+
+	release = func() { <-s.slots }
+	select {
+	case s.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	if s.config.GetJobCapacityPolicy() == jobs.CapacityReject {
+		return nil, errors.NewTypedError(jobs.ErrJobCapacityReached, errors.New("max: %d", cap(s.slots)))
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// notifyJobCompleted sends the job completed notification webhook.
+// Notifications are disabled if the manager has no notifier.
+func (s *manager) notifyJobCompleted(ctx context.Context, job *jobs.Job) {
+	if s.notifier == nil {
+		return
+	}
+
+	eventType := notification.JobCompleted
+	if job.Metadata[jobs.SupersededByKey] != "" {
+		eventType = notification.JobSuperseded
+	}
+
+	notificationMsg := notification.Message{
+		EventType:    eventType,
+		AccountID:    job.DID.String(),
+		Recorded:     time.Now().UTC(),
+		DocumentType: jobs.JobDataTypeURL,
+		DocumentID:   job.ID.String(),
+		Status:       string(job.Status),
+		Metadata:     job.Metadata,
+	}
+	if len(job.Logs) > 0 {
+		notificationMsg.Message = job.Logs[len(job.Logs)-1].Message
+	}
+
+	if job.CancelReason != "" {
+		metadata := make(map[string]string)
+		for k, v := range job.Metadata {
+			metadata[k] = v
+		}
+		metadata[jobs.CancelReasonKey] = string(job.CancelReason)
+		notificationMsg.Metadata = metadata
+	}
+
+	// Send Job notification webhook
+	s.send(ctx, job.DID, job.ID, notificationMsg)
+}
+
+// send delivers the notification of the job and records the receipt of the delivery on the job,
+// if the notifier reports receipts.
+func (s *manager) send(ctx context.Context, accountID identity.DID, id jobs.JobID, msg notification.Message) {
+	rs, ok := s.notifier.(notification.ReceiptSender)
+	if !ok {
+		_, err := s.notifier.Send(ctx, msg)
+		if err != nil {
+			log.Error(err)
+		}
+		return
+	}
+
+	receipt, err := rs.SendWithReceipt(ctx, msg)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if receipt == nil {
+		return
+	}
+
+	err = s.updateJob(accountID, id, func(job *jobs.Job) error {
+		job.NotificationReceipts = append(job.NotificationReceipts, jobs.NotificationReceipt{
+			Event:      eventName(msg.EventType),
+			URL:        receipt.URL,
+			StatusCode: receipt.StatusCode,
+			Delivered:  receipt.Delivered,
+			Error:      receipt.Error,
+			SentAt:     receipt.SentAt,
+		})
+		if n := len(job.NotificationReceipts) - maxNotificationReceipts; n > 0 {
+			job.NotificationReceipts = job.NotificationReceipts[n:]
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("failed to record the notification receipt of job %s: %v", id.String(), err)
+	}
+}
+
+// eventName returns the name the notification event is recorded with in the receipts.
+func eventName(event notification.EventType) string {
+	switch event {
+	case notification.JobCompleted:
+		return "job_completed"
+	case notification.JobSuperseded:
+		return "job_superseded"
+	case notification.JobRetrying:
+		return notification.OptInJobRetrying
+	default:
+		return strconv.Itoa(int(event))
+	}
+}
+
+// GetNotificationReceipts returns the receipts of the deliveries of the notifications of the job, oldest first.
+func (s *manager) GetNotificationReceipts(accountID identity.DID, id jobs.JobID) ([]jobs.NotificationReceipt, error) {
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return job.NotificationReceipts, nil
+}
+
+// NotifyJobRetrying sends the JobRetrying notification if the event is opted in.
+// The notification carries the task, the attempt and the delay in its metadata.
+func (s *manager) NotifyJobRetrying(ctx context.Context, accountID identity.DID, id jobs.JobID, taskName string, attempt int, delay time.Duration) {
+	if s.notifier == nil || !s.optedIn(notification.OptInJobRetrying) {
+		return
+	}
+
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		log.Errorf("failed to notify the retry of job %s: %v", id.String(), err)
+		return
+	}
+
+	metadata := make(map[string]string)
+	for k, v := range job.Metadata {
+		metadata[k] = v
+	}
+	metadata[jobs.RetryTaskKey] = taskName
+	metadata[jobs.RetryAttemptKey] = strconv.Itoa(attempt)
+	metadata[jobs.RetryDelayKey] = delay.String()
+
+	s.send(ctx, accountID, id, notification.Message{
+		EventType:    notification.JobRetrying,
+		AccountID:    job.DID.String(),
+		Recorded:     time.Now().UTC(),
+		DocumentType: jobs.JobDataTypeURL,
+		DocumentID:   job.ID.String(),
+		Status:       string(job.Status),
+		Message:      fmt.Sprintf("task %s failed, attempt %d in %s", taskName, attempt, delay),
+		Metadata:     metadata,
+	})
+}
+
+// optedIn returns true if the opt-in notification event is enabled in the config.
+func (s *manager) optedIn(event string) bool {
+	for _, e := range s.config.GetNotificationOptInEvents() {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CancelJob fails the pending job and records the reason it was cancelled.
+// The work running within the job is not interrupted but its outcome is ignored.
+func (s *manager) CancelJob(ctx context.Context, accountID identity.DID, id jobs.JobID, reason jobs.CancelReason) error {
+	if !reason.Valid() {
+		return errors.NewTypedError(jobs.ErrInvalidCancelReason, errors.New("reason: %s", reason))
+	}
+
+	var job *jobs.Job
+	err := s.updateJob(accountID, id, func(j *jobs.Job) error {
+		if j.Status != jobs.Pending {
+			return errors.NewTypedError(jobs.ErrJobNotPending, errors.New("status: %s", j.Status))
+		}
+
+		action := fmt.Sprintf("%s[cancel]", managerLogPrefix)
+		j.CancelReason = reason
+		j.AppendLog(jobs.NewLog(action, fmt.Sprintf("job cancelled: %s", reason)), s.maxLogs())
+		s.setStatus(j, jobs.Failed, action)
+		job = j
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.statusChanged(job, jobs.Pending)
+	s.notifyJobCompleted(ctx, job)
+	return nil
+}
+
+// closeJob applies the configured context closed policy to the pending job whose context closed with cause.
+// The returned error is the outcome reported on the done channel of the job, nil if the job is left pending.
+func (s *manager) closeJob(job *jobs.Job, action string, cause error) error {
+	if job.Status != jobs.Pending {
+		return nil
+	}
+
+	switch policy := s.config.GetJobContextClosedPolicy(); policy {
+	case jobs.ContextClosedFail:
+		job.FailureCategory = jobs.ClassifyFailure(cause)
+		s.setStatus(job, jobs.Failed, action)
+		return errors.New("%s %v", action, cause)
+	case jobs.ContextClosedCancel:
+		job.CancelReason = jobs.CancelContextClosed
+		job.AppendLog(jobs.NewLog(action, fmt.Sprintf("job cancelled: %s", jobs.CancelContextClosed)), s.maxLogs())
+		s.setStatus(job, jobs.Failed, action)
+		return errors.NewTypedError(jobs.ErrJobCancelled, errors.New("reason: %s", jobs.CancelContextClosed))
+	case "", jobs.ContextClosedPending:
+		return nil
+	default:
+		log.Warningf("unknown context closed policy %s, leaving job %s pending", policy, job.ID.String())
+		return nil
+	}
+}
+
+// failJob marks the job as failed with the given error.
+func (s *manager) failJob(accountID identity.DID, id jobs.JobID, action string, e error) {
+	var job *jobs.Job
+	var from jobs.Status
+	err := s.updateJob(accountID, id, func(j *jobs.Job) error {
+		from = j.Status
+		j.AppendLog(jobs.NewLog(action, e.Error()), s.maxLogs())
+		j.FailureCategory = jobs.ClassifyFailure(e)
+		s.setStatus(j, jobs.Failed, action)
+		job = j
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	s.statusChanged(job, from)
+}
+
+// recoverJobs applies the configured recovery policy to the jobs left pending by a previous run of the node.
+// Resumed jobs are failed if they are still pending once the task valid duration elapses so that none hangs forever.
+func (s *manager) recoverJobs() error {
+	var pending []*jobs.Job
+	err := s.repo.IterateJobs(func(job *jobs.Job) error {
+		if job.Status == jobs.Pending {
+			pending = append(pending, job)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	action := fmt.Sprintf("%s[recovery]", managerLogPrefix)
+	policies := s.config.GetJobRecoveryPolicies()
+	for _, job := range pending {
+		if policies[strings.ToLower(job.Description)] == jobs.RecoveryResume {
+			log.Infof("Resuming job %s for account %s with description \"%s\"", job.ID.String(), job.DID, job.Description)
+			s.expireResumed(job.DID, job.ID, action)
+			continue
+		}
+
+		log.Warningf("Failing job %s for account %s with description \"%s\" left pending by node restart", job.ID.String(), job.DID, job.Description)
+		s.failJob(job.DID, job.ID, action, jobs.ErrJobInterrupted)
+	}
+
+	return nil
+}
+
+// expireResumed fails the resumed job if it is still pending once the task valid duration elapses.
+func (s *manager) expireResumed(accountID identity.DID, id jobs.JobID, action string) {
+	time.AfterFunc(s.config.GetTaskValidDuration(), func() {
+		job, err := s.GetJob(accountID, id)
+		if err != nil || job.Status != jobs.Pending {
+			return
+		}
+
+		s.failJob(accountID, id, action, jobs.ErrJobNotResumed)
+	})
+}
+
+// RegisterRetrier registers how RetryFailedJobs re-runs the failed jobs with the description.
+func (s *manager) RegisterRetrier(desc string, retrier jobs.Retrier) {
+	s.retriersMu.Lock()
+	defer s.retriersMu.Unlock()
+	if s.retriers == nil {
+		s.retriers = make(map[string]jobs.Retrier)
+	}
+
+	s.retriers[strings.ToLower(desc)] = retrier
+}
+
+// RetryFailedJobs re-runs the failed jobs of the account with a retriable failure and returns the IDs of the new jobs.
+// Every failed job is retried once, the retried job records the ID of the new job in its metadata.
+// Jobs that couldn't be retried are logged and left for a later retry.
+func (s *manager) RetryFailedJobs(ctx context.Context, accountID identity.DID) ([]jobs.JobID, error) {
+	var failed []*jobs.Job
+	err := s.repo.IterateJobs(func(job *jobs.Job) error {
+		if job.DID.Equal(accountID) && job.Status == jobs.Failed && job.FailureCategory.Retriable() &&
+			job.Metadata[jobs.RetriedByKey] == "" {
+			failed = append(failed, job)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []jobs.JobID
+	for _, job := range failed {
+		s.retriersMu.RLock()
+		retry, ok := s.retriers[strings.ToLower(job.Description)]
+		s.retriersMu.RUnlock()
+		if !ok {
+			log.Warningf("Skipping job %s with description \"%s\": no retrier registered", job.ID.String(), job.Description)
+			continue
+		}
+
+		id, err := retry(ctx, job)
+		if err != nil {
+			log.Errorf("failed to retry job %s: %v", job.ID.String(), err)
+			continue
+		}
+
+		err = s.updateJob(accountID, job.ID, func(job *jobs.Job) error {
+			if job.Metadata == nil {
+				job.Metadata = make(map[string]string)
 			}
+
+			job.Metadata[jobs.RetriedByKey] = id.String()
+			return nil
+		})
+		if err != nil {
+			log.Errorf("failed to record the retry of job %s: %v", job.ID.String(), err)
 		}
 
-	}(ctx)
-	return job.ID, done, nil
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ListJobs returns the jobs of the account matching the filter, latest first.
+// Every job is scanned, which is fine for the operator tooling it is meant for.
+func (s *manager) ListJobs(accountID identity.DID, filter jobs.JobFilter) ([]*jobs.Job, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	var list []*jobs.Job
+	err := s.repo.IterateJobs(func(job *jobs.Job) error {
+		if job.DID.Equal(accountID) && filter.Match(job) {
+			list = append(list, job)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+// ListJobsPage returns at most limit of the jobs of the account matching the filter, latest first, skipping
+// the offset latest ones, and the number of jobs matching the filter.
+// Every job is scanned but only the offset+limit latest matching jobs are kept while scanning.
+func (s *manager) ListJobsPage(accountID identity.DID, filter jobs.JobFilter, offset, limit int) (list []*jobs.Job, total int, err error) {
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	keep := offset + limit
+	if offset < 0 || limit < 1 || keep < offset {
+		return nil, 0, errors.NewTypedError(jobs.ErrInvalidJobFilter, errors.New("offset %d limit %d", offset, limit))
+	}
+
+	var latest oldestFirst
+	err = s.repo.IterateJobs(func(job *jobs.Job) error {
+		if !job.DID.Equal(accountID) || !filter.Match(job) {
+			return nil
+		}
+
+		total++
+		switch {
+		case len(latest) < keep:
+			heap.Push(&latest, job)
+		case job.CreatedAt.After(latest[0].CreatedAt):
+			latest[0] = job
+			heap.Fix(&latest, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if offset >= len(latest) {
+		return nil, total, nil
+	}
+
+	sort.Slice(latest, func(i, j int) bool {
+		return latest[i].CreatedAt.After(latest[j].CreatedAt)
+	})
+	return latest[offset:], total, nil
+}
+
+// oldestFirst is a heap of jobs with the oldest job at the root.
+type oldestFirst []*jobs.Job
+
+func (h oldestFirst) Len() int           { return len(h) }
+func (h oldestFirst) Less(i, j int) bool { return h[i].CreatedAt.Before(h[j].CreatedAt) }
+func (h oldestFirst) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *oldestFirst) Push(x interface{}) { *h = append(*h, x.(*jobs.Job)) }
+
+func (h *oldestFirst) Pop() interface{} {
+	old := *h
+	job := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return job
+}
+
+// GetJobsForDocumentVersion returns the jobs of the account created by the change of the document version, latest first.
+func (s *manager) GetJobsForDocumentVersion(accountID identity.DID, documentID, versionID []byte) ([]*jobs.Job, error) {
+	return s.ListJobs(accountID, jobs.JobFilter{Labels: map[string]string{
+		jobs.DocumentIDKey:      hexutil.Encode(documentID),
+		jobs.DocumentVersionKey: hexutil.Encode(versionID),
+	}})
+}
+
+// tagJobWithRequest records the API request that originated the job, if any, in the job metadata.
+func tagJobWithRequest(ctx context.Context, job *jobs.Job) {
+	req, ok := contextutil.Request(ctx)
+	if !ok {
+		return
+	}
+
+	job.Metadata[jobs.RequestMethodKey] = req.Method
+	job.Metadata[jobs.RequestPathKey] = req.Path
+	job.Metadata[jobs.RequestIDKey] = req.RequestID
+	log.Infof("Job %s created by request %s [%s %s]", job.ID.String(), req.RequestID, req.Method, req.Path)
 }
 
-// saveJob saves the transaction.
+// tagJobWithDocument records the document version whose change created the job, if known, in the metadata.
+func tagJobWithDocument(ctx context.Context, job *jobs.Job) {
+	dv, ok := contextutil.Document(ctx)
+	if !ok {
+		return
+	}
+
+	job.Metadata[jobs.DocumentIDKey] = hexutil.Encode(dv.DocumentID)
+	job.Metadata[jobs.DocumentVersionKey] = hexutil.Encode(dv.VersionID)
+}
+
+// indexJob indexes the job by the value of the configured reference key if the job has one.
+func (s *manager) indexJob(job *jobs.Job) error {
+	key := s.config.GetJobReferenceKey()
+	value, ok := job.Metadata[key]
+	if key == "" || !ok || value == "" {
+		return nil
+	}
+
+	return s.repo.SaveReference(job.DID, key, value, job.ID)
+}
+
+// GetJobByReference returns the latest job of the account with the given value for the indexed metadata key.
+func (s *manager) GetJobByReference(accountID identity.DID, key, value string) (*jobs.Job, error) {
+	if key == "" || key != s.config.GetJobReferenceKey() {
+		return nil, errors.NewTypedError(jobs.ErrJobReferenceNotIndexed, errors.New("key: %s", key))
+	}
+
+	return s.repo.GetByReference(accountID, key, value)
+}
+
+// IndexJob indexes the job by the key and value regardless of the configured reference key.
+func (s *manager) IndexJob(accountID identity.DID, id jobs.JobID, key, value string) error {
+	if key == "" || value == "" {
+		return errors.New("empty index key or value")
+	}
+
+	return s.repo.SaveReference(accountID, key, value, id)
+}
+
+// GetIndexedJob returns the latest job of the account indexed by the key and value with IndexJob.
+func (s *manager) GetIndexedJob(accountID identity.DID, key, value string) (*jobs.Job, error) {
+	return s.repo.GetByReference(accountID, key, value)
+}
+
+// setStatus moves the job to the given status and records the transition if the job history is enabled.
+func (s *manager) setStatus(job *jobs.Job, status jobs.Status, actor string) {
+	from := job.Status
+	job.Status = status
+	if from == status || !s.config.GetJobHistoryEnabled() {
+		return
+	}
+
+	job.History = append(job.History, jobs.StatusTransition{
+		From:      from,
+		To:        status,
+		Actor:     actor,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// OnStatusChange registers the callback invoked after a job moved to another status is saved.
+func (s *manager) OnStatusChange(fn jobs.StatusChangeFunc) {
+	s.callbacksMu.Lock()
+	defer s.callbacksMu.Unlock()
+	s.callbacks = append(s.callbacks, fn)
+}
+
+// statusChanged invokes the status change callbacks if the job moved from the given status.
+// Every callback runs in its own routine and is waited for at most statusChangeTimeout so that
+// a slow callback cannot hold the job routine.
+func (s *manager) statusChanged(job *jobs.Job, from jobs.Status) {
+	if job.Status == from {
+		return
+	}
+
+	countCompleted(job.Status)
+	s.callbacksMu.RLock()
+	callbacks := s.callbacks
+	s.callbacksMu.RUnlock()
+	for _, fn := range callbacks {
+		done := make(chan struct{})
+		j := *job
+		go func(fn jobs.StatusChangeFunc) {
+			defer close(done)
+			defer func() {
+				if r := recover(); r != nil {
+					log.Errorf("status change callback panicked for job %s: %v", j.ID.String(), r)
+				}
+			}()
+
+			fn(&j, from, j.Status)
+		}(fn)
+
+		select {
+		case <-done:
+		case <-time.After(statusChangeTimeout):
+			log.Warningf("status change callback for job %s didn't return within %s", job.ID.String(), statusChangeTimeout)
+		}
+	}
+}
+
+// GetJobHistory returns the recorded status transitions of the job.
+func (s *manager) GetJobHistory(accountID identity.DID, id jobs.JobID) ([]jobs.StatusTransition, error) {
+	if !s.config.GetJobHistoryEnabled() {
+		return nil, jobs.ErrJobHistoryDisabled
+	}
+
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return job.History, nil
+}
+
+// JobTimings returns how long the job waited for its first task and how long it ran since.
+// The job is considered running once its first task reports its status and until its last status transition.
+// Pending jobs are timed up to now.
+func (s *manager) JobTimings(accountID identity.DID, id jobs.JobID) (pendingDuration, runningDuration time.Duration, err error) {
+	if !s.config.GetJobHistoryEnabled() {
+		return 0, 0, jobs.ErrJobHistoryDisabled
+	}
+
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	pendingDuration, runningDuration = jobTimings(job, time.Now().UTC())
+	return pendingDuration, runningDuration, nil
+}
+
+// jobTimings returns the time the job spent waiting for its first task and running as of now.
+// Jobs that completed without a recorded transition end with their last log.
+func jobTimings(job *jobs.Job, now time.Time) (pendingDuration, runningDuration time.Duration) {
+	end := now
+	if job.Status != jobs.Pending {
+		end = job.CreatedAt
+		if n := len(job.Logs); n > 0 {
+			end = job.Logs[n-1].LastAt()
+		}
+
+		if n := len(job.History); n > 0 && job.History[n-1].To == job.Status {
+			end = job.History[n-1].CreatedAt
+		}
+	}
+
+	started := job.StartedAt
+	if started.IsZero() || started.After(end) {
+		started = end
+	}
+
+	return started.Sub(job.CreatedAt), end.Sub(started)
+}
+
+// GetRecentLogs returns the last n log entries of the job.
+// Jobs are stored as a single record so the logs are trimmed once the job is loaded.
+func (s *manager) GetRecentLogs(accountID identity.DID, id jobs.JobID, n int) ([]jobs.Log, error) {
+	if n < 1 {
+		return nil, errors.NewTypedError(jobs.ErrInvalidLogCount, errors.New("count: %d", n))
+	}
+
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(job.Logs) <= n {
+		return job.Logs, nil
+	}
+
+	logs := make([]jobs.Log, n)
+	copy(logs, job.Logs[len(job.Logs)-n:])
+	return logs, nil
+}
+
+// CompactJobLogs drops the oldest logs of the job so that it keeps at most the last keepLast logs.
+// The compaction holds the job lock so it is safe to call while the job is running.
+func (s *manager) CompactJobLogs(accountID identity.DID, id jobs.JobID, keepLast int) error {
+	if keepLast < 0 {
+		return errors.NewTypedError(jobs.ErrInvalidLogRetention, errors.New("keep: %d", keepLast))
+	}
+
+	return s.updateJob(accountID, id, func(job *jobs.Job) error {
+		job.CompactLogs(keepLast)
+		return nil
+	})
+}
+
+// GetLogTrail returns the archived logs of the job followed by the logs held by the job.
+func (s *manager) GetLogTrail(accountID identity.DID, id jobs.JobID) ([]jobs.Log, error) {
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.LogArchive == "" {
+		return job.Logs, nil
+	}
+
+	if s.logSink == nil {
+		return nil, errors.NewTypedError(jobs.ErrJobLogArchive, errors.New("log archive is disabled"))
+	}
+
+	logs, err := s.logSink.Load(job.LogArchive)
+	if err != nil {
+		return nil, errors.NewTypedError(jobs.ErrJobLogArchive, err)
+	}
+
+	return append(logs, job.Logs...), nil
+}
+
+// maxLogs returns the max number of logs appended to a job before the oldest are dropped.
+// The logs are not dropped if the log archive is enabled, the oldest are archived when the job is saved instead.
+func (s *manager) maxLogs() int {
+	if s.logSink != nil && s.config.GetJobLogArchiveThreshold() > 0 {
+		return 0
+	}
+
+	return s.config.GetJobMaxLogs()
+}
+
+// archiveLogs moves all but the last maxLogs logs of the job to its log archive once the job holds more than
+// the threshold logs. The job keeps its logs if the archive fails so that they are archived on the next save.
+func (s *manager) archiveLogs(job *jobs.Job) {
+	threshold := s.config.GetJobLogArchiveThreshold()
+	if s.logSink == nil || threshold < 1 || len(job.Logs) <= threshold {
+		return
+	}
+
+	keep := s.config.GetJobMaxLogs()
+	if keep < 1 || keep > threshold {
+		keep = threshold
+	}
+
+	archived := len(job.Logs) - keep
+	ref, err := s.logSink.Archive(job.DID, job.ID, job.Logs[:archived])
+	if err != nil {
+		log.Errorf("failed to archive the logs of job %s: %v", job.ID.String(), err)
+		return
+	}
+
+	job.Logs = append([]jobs.Log(nil), job.Logs[archived:]...)
+	job.ArchivedLogs += archived
+	job.LogArchive = ref
+}
+
+// AnnotateJob appends the timestamped operator note to the annotations of the job.
+func (s *manager) AnnotateJob(accountID identity.DID, id jobs.JobID, note string) error {
+	if strings.TrimSpace(note) == "" {
+		return jobs.ErrInvalidAnnotation
+	}
+
+	return s.updateJob(accountID, id, func(job *jobs.Job) error {
+		job.Annotations = append(job.Annotations, jobs.Annotation{Note: note, CreatedAt: time.Now().UTC()})
+		return nil
+	})
+}
+
+// ForceStatus moves the job to the terminal status on operator request.
+// The override is logged and annotated with the account it was made with and the reason so that it stands out
+// from the transitions made by the job itself. Pending jobs reaching a terminal status are notified as completed,
+// the work still running within the job is not interrupted but its outcome is ignored.
+func (s *manager) ForceStatus(accountID identity.DID, id jobs.JobID, status jobs.Status, reason string) error {
+	if !status.Valid() || status == jobs.Pending {
+		return errors.NewTypedError(jobs.ErrInvalidStatusTransition, errors.New("status: %s", status))
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		return jobs.ErrMissingStatusReason
+	}
+
+	var job *jobs.Job
+	var from jobs.Status
+	err := s.updateJob(accountID, id, func(j *jobs.Job) error {
+		if j.Status == status {
+			return errors.NewTypedError(jobs.ErrInvalidStatusTransition, errors.New("job is already %s", status))
+		}
+
+		if j.Metadata == nil {
+			j.Metadata = make(map[string]string)
+		}
+
+		from = j.Status
+		action := fmt.Sprintf("%s[force_status]", managerLogPrefix)
+		j.Metadata[jobs.StatusForcedByKey] = accountID.String()
+		note := fmt.Sprintf("status forced from %s to %s: %s", from, status, reason)
+		j.AppendLog(jobs.NewLog(action, note), s.maxLogs())
+		j.Annotations = append(j.Annotations, jobs.Annotation{
+			Note:      note,
+			Author:    accountID.String(),
+			CreatedAt: time.Now().UTC(),
+		})
+		s.setStatus(j, status, fmt.Sprintf("%s by %s", action, accountID.String()))
+		job = j
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Warningf("status of job %s forced from %s to %s by %s: %s", id.String(), from, status, accountID.String(), reason)
+	s.statusChanged(job, from)
+	if from == jobs.Pending {
+		s.notifyJobCompleted(context.Background(), job)
+	}
+
+	return nil
+}
+
+// SupersedeJob records that the pending job id is superseded by the job by, on both jobs.
+// The superseded job completes with a JobSuperseded notification so that the webhooks don't act on its outcome.
+func (s *manager) SupersedeJob(accountID identity.DID, id, by jobs.JobID) error {
+	if jobs.JobIDEqual(id, by) {
+		return jobs.ErrJobSupersedesItself
+	}
+
+	if _, err := s.GetJob(accountID, by); err != nil {
+		return err
+	}
+
+	err := s.updateJob(accountID, id, func(job *jobs.Job) error {
+		if job.Status != jobs.Pending {
+			return errors.NewTypedError(jobs.ErrJobNotPending, errors.New("status: %s", job.Status))
+		}
+
+		if job.Metadata == nil {
+			job.Metadata = make(map[string]string)
+		}
+
+		job.Metadata[jobs.SupersededByKey] = by.String()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.updateJob(accountID, by, func(job *jobs.Job) error {
+		if job.Metadata == nil {
+			job.Metadata = make(map[string]string)
+		}
+
+		job.Metadata[jobs.SupersedesKey] = id.String()
+		return nil
+	})
+}
+
+// RecordJobTask records the task enqueued for the job, replacing the task recorded before.
+func (s *manager) RecordJobTask(accountID identity.DID, id jobs.JobID, task jobs.JobTask) error {
+	return s.updateJob(accountID, id, func(job *jobs.Job) error {
+		job.Task = &task
+		return nil
+	})
+}
+
+// saveJob saves the transaction along with its hash.
 func (s *manager) saveJob(tx *jobs.Job) error {
-	err := s.repo.Save(tx)
+	s.archiveLogs(tx)
+	hash, err := tx.CalculateHash()
+	if err != nil {
+		return err
+	}
+
+	tx.Hash = hash
+	err = s.repo.Save(tx)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// VerifyJob recomputes the hash of the job and compares it with the stored one.
+// Returns false if the job was modified outside of the manager or corrupted in the repository.
+func (s *manager) VerifyJob(accountID identity.DID, id jobs.JobID) (bool, error) {
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return false, err
+	}
+
+	if len(job.Hash) == 0 {
+		return false, jobs.ErrJobHashMissing
+	}
+
+	hash, err := job.CalculateHash()
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(hash, job.Hash), nil
+}
+
 // GetJob returns the job associated with identity and id.
 func (s *manager) GetJob(accountID identity.DID, id jobs.JobID) (*jobs.Job, error) {
 	return s.repo.Get(accountID, id)
@@ -170,25 +1233,266 @@ func (s *manager) createJob(accountID identity.DID, desc string) (*jobs.Job, err
 // WaitForJob blocks until job status is moved from pending state.
 // Note: use it with caution as this will block.
 func (s *manager) WaitForJob(accountID identity.DID, txID jobs.JobID) error {
-	// TODO change this to use a pre-saved done channel from ExecuteWithinJob, instead of a for loop, may require significant refactoring to handle the case of restarted node
+	return s.WaitForJobWithContext(context.Background(), accountID, txID)
+}
+
+// WaitForJobWithContext blocks until job status is moved from pending state or the context is closed.
+// The error of the context is returned if it is closed first.
+// Jobs running on this node are waited for on their done channel, which carries the outcome of the job once done.
+// The other jobs, such as the ones recovered after a restart or the existing jobs left pending by their routine,
+// are polled. The wait between two checks of the job status doubles after every check, from the initial poll up
+// to the max poll.
+func (s *manager) WaitForJobWithContext(ctx context.Context, accountID identity.DID, txID jobs.JobID) error {
+	if r := s.routine(accountID, txID); r != nil {
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if r.settled {
+			return r.err
+		}
+	}
+
+	interval, max := s.pollInitial(), s.pollMax()
 	for {
 		resp, err := s.GetJobStatus(accountID, txID)
 		if err != nil {
 			return err
 		}
 
-		switch jobs.Status(resp.Status) {
-		case jobs.Failed:
-			return errors.New("job failed: %v", resp.Message)
-		case jobs.Success:
-			return nil
-		default:
-			time.Sleep(10 * time.Millisecond)
-			continue
+		if settled, err := jobOutcome(jobs.Status(resp.Status), resp.Message); settled {
+			return err
+		}
+
+		if err := s.sleep(ctx, interval); err != nil {
+			return err
+		}
+
+		// back off so that the long running jobs are not polled as often as the short ones
+		interval *= 2
+		if interval > max {
+			interval = max
 		}
 	}
 }
 
+// sleep waits for the duration or until the context is closed, in which case the error of the context is returned.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// EffectiveConfig returns the jobs settings in use by the manager.
+func (s *manager) EffectiveConfig() jobs.EffectiveConfig {
+	policy := s.config.GetJobContextClosedPolicy()
+	if policy == "" {
+		policy = jobs.ContextClosedPending
+	}
+
+	capacityPolicy := s.config.GetJobCapacityPolicy()
+	if capacityPolicy != jobs.CapacityReject {
+		capacityPolicy = jobs.CapacityBlock
+	}
+
+	return jobs.EffectiveConfig{
+		TaskValidDuration:   s.config.GetTaskValidDuration(),
+		HistoryEnabled:      s.config.GetJobHistoryEnabled(),
+		RecoveryPolicies:    s.config.GetJobRecoveryPolicies(),
+		MaxLogs:             s.config.GetJobMaxLogs(),
+		ReferenceKey:        s.config.GetJobReferenceKey(),
+		PollInterval:        s.pollInterval(),
+		PollInitial:         s.pollInitial(),
+		PollMax:             s.pollMax(),
+		ShutdownGracePeriod: s.config.GetJobShutdownGracePeriod(),
+		ContextClosedPolicy: policy,
+		RetryAfter:          s.config.GetJobRetryAfter(),
+		MaxConcurrentJobs:   s.config.GetMaxConcurrentJobs(),
+		CapacityPolicy:      capacityPolicy,
+	}
+}
+
+// pollInterval returns the configured job poll interval or the default one if not set.
+func (s *manager) pollInterval() time.Duration {
+	if interval := s.config.GetJobPollInterval(); interval > 0 {
+		return interval
+	}
+
+	return defaultJobPollInterval
+}
+
+// pollInitial returns the configured first wait of WaitForJob or the default one if not set.
+func (s *manager) pollInitial() time.Duration {
+	if initial := s.config.GetJobPollInitial(); initial > 0 {
+		return initial
+	}
+
+	return defaultJobPollInitial
+}
+
+// pollMax returns the configured longest wait of WaitForJob or the default one if not set.
+// The first wait is returned if it is longer.
+func (s *manager) pollMax() time.Duration {
+	max := s.config.GetJobPollMax()
+	if max <= 0 {
+		max = defaultJobPollMax
+	}
+
+	if initial := s.pollInitial(); initial > max {
+		return initial
+	}
+
+	return max
+}
+
+// OnJobComplete invokes the callback in its own routine once the job reaches a terminal state.
+// The job is waited for as in WaitForJobWithContext and the callback is not invoked if the context is closed first.
+func (s *manager) OnJobComplete(ctx context.Context, accountID identity.DID, id jobs.JobID, cb func(jobs.StatusResponse)) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("job complete callback panicked for job %s: %v", id.String(), r)
+			}
+		}()
+
+		// failed jobs are reported to the callback as well, only the closed context stops the wait
+		err := s.WaitForJobWithContext(ctx, accountID, id)
+		if ctx.Err() != nil {
+			log.Warningf("stopped waiting for the completion of job %s: %v", id.String(), ctx.Err())
+			return
+		}
+
+		resp, serr := s.GetJobStatus(accountID, id)
+		if serr != nil {
+			log.Errorf("failed to wait for the completion of job %s: %v", id.String(), errors.AppendError(err, serr))
+			return
+		}
+
+		cb(resp)
+	}()
+}
+
+// jobRoutine is a job routine running on this node.
+type jobRoutine struct {
+	accountID identity.DID
+	id        jobs.JobID
+	done      chan struct{}
+	once      sync.Once
+
+	// settled is true if the job reached a terminal status within the routine, err is then the outcome of the job
+	// as returned by WaitForJob. Both are set before done is closed and read once it is.
+	settled bool
+	err     error
+}
+
+// registerDone registers the done channel of a job routine and returns the function removing it and closing
+// the channel with the outcome of the job. Only the first call has an effect, a nil job leaves the outcome unsettled.
+func (s *manager) registerDone(accountID identity.DID, id jobs.JobID) func(job *jobs.Job) {
+	key := doneKey(accountID, id)
+	r := &jobRoutine{accountID: accountID, id: id, done: make(chan struct{})}
+	s.doneMu.Lock()
+	defer s.doneMu.Unlock()
+	if s.doneChans == nil {
+		s.doneChans = make(map[string]*jobRoutine)
+	}
+
+	s.doneChans[key] = r
+	return func(job *jobs.Job) {
+		r.once.Do(func() {
+			if job != nil {
+				var msg string
+				if len(job.Logs) > 0 {
+					msg = job.Logs[len(job.Logs)-1].Message
+				}
+				r.settled, r.err = jobOutcome(job.Status, msg)
+			}
+
+			s.doneMu.Lock()
+			defer s.doneMu.Unlock()
+			if s.doneChans[key] == r {
+				delete(s.doneChans, key)
+			}
+
+			close(r.done)
+		})
+	}
+}
+
+// jobOutcome returns true and the outcome of the job as returned by WaitForJob if the status is terminal.
+func jobOutcome(status jobs.Status, msg string) (settled bool, err error) {
+	switch status {
+	case jobs.Failed:
+		return true, errors.New("job failed: %v", msg)
+	case jobs.Success:
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// routine returns the job routine running on this node, nil if the job is not running here.
+func (s *manager) routine(accountID identity.DID, id jobs.JobID) *jobRoutine {
+	s.doneMu.Lock()
+	defer s.doneMu.Unlock()
+	return s.doneChans[doneKey(accountID, id)]
+}
+
+// routineDone returns the done channel of the job routine running on this node, nil if the job is not running here.
+func (s *manager) routineDone(accountID identity.DID, id jobs.JobID) chan struct{} {
+	if r := s.routine(accountID, id); r != nil {
+		return r.done
+	}
+
+	return nil
+}
+
+// jobLock is a job record lock shared by the routines waiting for it.
+type jobLock struct {
+	sync.Mutex
+	waiters int
+}
+
+// lockJob locks the job record and returns the function unlocking it.
+// Routines running within the same job, such as the nested executions of an existing job,
+// are not serialized as a whole, only their updates of the job record are.
+func (s *manager) lockJob(accountID identity.DID, id jobs.JobID) func() {
+	key := doneKey(accountID, id)
+	s.jobLocksMu.Lock()
+	if s.jobLocks == nil {
+		s.jobLocks = make(map[string]*jobLock)
+	}
+
+	l, ok := s.jobLocks[key]
+	if !ok {
+		l = new(jobLock)
+		s.jobLocks[key] = l
+	}
+	l.waiters++
+	s.jobLocksMu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		s.jobLocksMu.Lock()
+		defer s.jobLocksMu.Unlock()
+		l.waiters--
+		if l.waiters == 0 {
+			delete(s.jobLocks, key)
+		}
+	}
+}
+
+func doneKey(accountID identity.DID, id jobs.JobID) string {
+	return accountID.String() + id.String()
+}
+
 // GetJobStatus returns the job status associated with identity and id.
 func (s *manager) GetJobStatus(accountID identity.DID, id jobs.JobID) (resp jobs.StatusResponse, err error) {
 	job, err := s.GetJob(accountID, id)
@@ -201,13 +1505,37 @@ func (s *manager) GetJobStatus(accountID identity.DID, id jobs.JobID) (resp jobs
 	if len(job.Logs) > 0 {
 		log := job.Logs[len(job.Logs)-1]
 		msg = log.Message
-		lastUpdated = log.CreatedAt.UTC()
+		lastUpdated = log.LastAt().UTC()
+	}
+
+	resp = jobs.StatusResponse{
+		JobID:                job.ID.String(),
+		Status:               string(job.Status),
+		Message:              msg,
+		LastUpdated:          lastUpdated,
+		Retriable:            job.Status == jobs.Failed && job.FailureCategory.Retriable(),
+		Annotations:          job.Annotations,
+		NotificationReceipts: job.NotificationReceipts,
+	}
+
+	if s.config.GetJobHistoryEnabled() {
+		pending, running := jobTimings(job, time.Now().UTC())
+		resp.PendingDuration, resp.RunningDuration = pending.String(), running.String()
+	}
+
+	if d := s.retryAfter(job); d > 0 {
+		resp.RetryAfter = d.String()
+	}
+
+	return resp, nil
+}
+
+// retryAfter returns how long the clients should wait before retrying the job as configured for its failure category.
+// Zero is returned unless the job failed with a retriable error.
+func (s *manager) retryAfter(job *jobs.Job) time.Duration {
+	if job.Status != jobs.Failed || !job.FailureCategory.Retriable() {
+		return 0
 	}
 
-	return jobs.StatusResponse{
-		JobID:       job.ID.String(),
-		Status:      string(job.Status),
-		Message:     msg,
-		LastUpdated: lastUpdated,
-	}, nil
+	return s.config.GetJobRetryAfter()[string(job.FailureCategory)]
 }
@@ -5,77 +5,1316 @@ package jobsv1
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 )
 
-type mockConfig struct{}
+type mockConfig struct {
+	historyEnabled bool
+	policies       map[string]string
+	validFor       time.Duration
+	maxLogs        int
+	referenceKey   string
+	pollInterval   time.Duration
+	pollInitial    time.Duration
+	pollMax        time.Duration
+	gracePeriod    time.Duration
+	closedPolicy   string
+	retryAfter     map[string]time.Duration
+	archiveAt      int
+	maxConcurrent  int
+	capacity       string
+	optIn          []string
+}
+
+func (m mockConfig) GetTaskValidDuration() time.Duration {
+	if m.validFor == 0 {
+		panic("implement me")
+	}
+
+	return m.validFor
+}
+
+func (m mockConfig) GetJobHistoryEnabled() bool {
+	return m.historyEnabled
+}
+
+func (mockConfig) GetReceiveEventNotificationEndpoint() string {
+	return ""
+}
+
+func (mockConfig) GetNotificationDialTimeout() time.Duration {
+	return 0
+}
+
+func (mockConfig) GetNotificationTLSHandshakeTimeout() time.Duration {
+	return 0
+}
+
+func (mockConfig) GetNotificationResponseHeaderTimeout() time.Duration {
+	return 0
+}
+
+func (mockConfig) GetNotificationMaxPayloadSize() int {
+	return 0
+}
+
+func (m mockConfig) GetJobRecoveryPolicies() map[string]string {
+	return m.policies
+}
+
+func (m mockConfig) GetJobMaxLogs() int {
+	return m.maxLogs
+}
+
+func (m mockConfig) GetJobReferenceKey() string {
+	return m.referenceKey
+}
+
+func (m mockConfig) GetJobPollInterval() time.Duration {
+	return m.pollInterval
+}
+
+func (m mockConfig) GetJobPollInitial() time.Duration {
+	return m.pollInitial
+}
+
+func (m mockConfig) GetJobPollMax() time.Duration {
+	return m.pollMax
+}
+
+func (m mockConfig) GetJobShutdownGracePeriod() time.Duration {
+	return m.gracePeriod
+}
+
+func (m mockConfig) GetJobContextClosedPolicy() string {
+	return m.closedPolicy
+}
+
+func (m mockConfig) GetJobRetryAfter() map[string]time.Duration {
+	return m.retryAfter
+}
+
+func (m mockConfig) GetJobLogArchiveThreshold() int {
+	return m.archiveAt
+}
+
+func (m mockConfig) GetMaxConcurrentJobs() int {
+	return m.maxConcurrent
+}
+
+func (m mockConfig) GetJobCapacityPolicy() string {
+	return m.capacity
+}
+
+func (m mockConfig) GetNotificationOptInEvents() []string {
+	return m.optIn
+}
+
+var sendChan chan notification.Message
+
+type mockSender struct{}
+
+// Send mocks failure and returns to channel
+func (mockSender) Send(ctx context.Context, ntf notification.Message) (notification.Status, error) {
+	sendChan <- ntf
+	return notification.Failure, nil
+}
+
+func TestService_ExecuteWithinTX_happy(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
+	jobID, done, err := srv.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- nil
+	})
+	assert.NoError(t, err)
+	doneErr := <-done
+	assert.NoError(t, doneErr)
+	assert.NotNil(t, jobID)
+	job, err := srv.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Success, job.Status)
+}
+
+func TestService_ExecuteWithinTX_err(t *testing.T) {
+	errStr := "dummy"
+	did := testingidentity.GenerateRandomDID()
+	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
+	msrv := srv.(*manager)
+	mngr := NewManager(msrv.config, msrv.repo)
+	omgr := mngr.(*manager)
+	omgr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message)
+	jobID, done, err := omgr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- errors.New(errStr)
+	})
+	assert.NoError(t, err)
+	doneErr := <-done
+	assert.Error(t, doneErr)
+	ntf := <-sendChan
+	assert.Equal(t, notification.JobCompleted, ntf.EventType)
+	assert.Equal(t, jobs.JobDataTypeURL, ntf.DocumentType)
+	assert.Equal(t, string(jobs.Failed), ntf.Status)
+	assert.Equal(t, errStr, ntf.Message)
+	assert.NoError(t, err)
+	assert.NotNil(t, jobID)
+	job, err := omgr.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Len(t, job.Logs, 1)
+	assert.Equal(t, fmt.Sprintf("%s[SomeTask]", managerLogPrefix), job.Logs[0].Action)
+	assert.Equal(t, errStr, job.Logs[0].Message)
+}
+
+func TestService_GetJobStatus_retriable(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{retryAfter: map[string]time.Duration{
+		string(jobs.FailureTransient):  10 * time.Second,
+		string(jobs.FailureValidation): time.Minute,
+	}}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+	for _, c := range []struct {
+		err        error
+		retriable  bool
+		retryAfter string
+	}{
+		{errors.New("dummy"), false, ""},
+		{context.DeadlineExceeded, true, "10s"},
+	} {
+		jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+			err <- c.err
+		})
+		assert.NoError(t, err)
+		assert.Error(t, <-done)
+		<-sendChan
+		resp, err := mngr.GetJobStatus(did, jobID)
+		assert.NoError(t, err)
+		assert.Equal(t, string(jobs.Failed), resp.Status)
+		assert.Equal(t, c.retriable, resp.Retriable)
+		assert.Equal(t, c.retryAfter, resp.RetryAfter)
+	}
+}
+
+func TestService_ExecuteWithinTX_panic(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
+	jobID, done, err := srv.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		panic("bad task")
+	})
+	assert.NoError(t, err)
+	doneErr := <-done
+	assert.Error(t, doneErr)
+	assert.Contains(t, doneErr.Error(), "job panicked: bad task")
+	job, err := srv.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Len(t, job.Logs, 1)
+	assert.Equal(t, "job panicked: bad task", job.Logs[0].Message)
+}
+
+func TestService_ExecuteWithinTX_requestInfo(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
+	msrv := srv.(*manager)
+	mngr := NewManager(msrv.config, msrv.repo)
+	omgr := mngr.(*manager)
+	omgr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message)
+	cctx := contextutil.WithRequest(context.Background(), contextutil.RequestInfo{
+		Method:    "POST",
+		Path:      "/v1/entities",
+		RequestID: "some-request-id",
+	})
+	jobID, done, err := omgr.ExecuteWithinJob(cctx, did, jobs.NilJobID(), "", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	ntf := <-sendChan
+	assert.Equal(t, "some-request-id", ntf.Metadata[jobs.RequestIDKey])
+
+	job, err := omgr.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", job.Metadata[jobs.RequestMethodKey])
+	assert.Equal(t, "/v1/entities", job.Metadata[jobs.RequestPathKey])
+	assert.Equal(t, "some-request-id", job.Metadata[jobs.RequestIDKey])
+}
+
+func TestService_GetJobsForDocumentVersion(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+
+	// no jobs
+	list, err := mngr.GetJobsForDocumentVersion(did, docID, versionID)
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	cctx := contextutil.WithDocumentVersion(context.Background(), docID, versionID)
+	jobID, done, err := mngr.ExecuteWithinJob(cctx, did, jobs.NilJobID(), "", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	<-sendChan
+
+	job, err := mngr.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(docID), job.Metadata[jobs.DocumentIDKey])
+	assert.Equal(t, hexutil.Encode(versionID), job.Metadata[jobs.DocumentVersionKey])
+
+	list, err = mngr.GetJobsForDocumentVersion(did, docID, versionID)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, jobID, list[0].ID)
+
+	// other version of the document
+	list, err = mngr.GetJobsForDocumentVersion(did, docID, utils.RandomSlice(32))
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+}
+
+func TestService_GetJobByReference(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{referenceKey: jobs.RequestIDKey}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+
+	// key not indexed
+	_, err := mngr.GetJobByReference(did, jobs.RequestPathKey, "/v1/entities")
+	assert.True(t, errors.IsOfType(jobs.ErrJobReferenceNotIndexed, err))
+
+	// missing job
+	_, err = mngr.GetJobByReference(did, jobs.RequestIDKey, "reference-request-id")
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	cctx := contextutil.WithRequest(context.Background(), contextutil.RequestInfo{
+		Method:    "POST",
+		Path:      "/v1/entities",
+		RequestID: "reference-request-id",
+	})
+	jobID, done, err := mngr.ExecuteWithinJob(cctx, did, jobs.NilJobID(), "", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	<-sendChan
+
+	job, err := mngr.GetJobByReference(did, jobs.RequestIDKey, "reference-request-id")
+	assert.NoError(t, err)
+	assert.Equal(t, jobID, job.ID)
+	assert.Equal(t, jobs.Success, job.Status)
+}
+
+func TestService_IndexJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+
+	// missing job
+	_, err := mngr.GetIndexedJob(did, "some_key", "some_value")
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	// empty value
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, mngr.saveJob(job))
+	assert.Error(t, mngr.IndexJob(did, job.ID, "some_key", ""))
+
+	assert.NoError(t, mngr.IndexJob(did, job.ID, "some_key", "some_value"))
+	got, err := mngr.GetIndexedJob(did, "some_key", "some_value")
+	assert.NoError(t, err)
+	assert.Equal(t, job.ID, got.ID)
+
+	// latest job wins
+	job2 := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, mngr.saveJob(job2))
+	assert.NoError(t, mngr.IndexJob(did, job2.ID, "some_key", "some_value"))
+	got, err = mngr.GetIndexedJob(did, "some_key", "some_value")
+	assert.NoError(t, err)
+	assert.Equal(t, job2.ID, got.ID)
+}
+
+func TestService_ListJobs(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+
+	_, err := mngr.ListJobs(did, jobs.JobFilter{Status: "done"})
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidJobFilter, err))
+
+	mint := jobs.NewJob(did, "Minting NFT")
+	mint.Status = jobs.Failed
+	failedMint := jobs.NewJob(did, "Minting NFT")
+	failedMint.Status = jobs.Failed
+	failedMint.CreatedAt = mint.CreatedAt.Add(time.Second)
+	anchor := jobs.NewJob(did, "Anchoring document")
+	anchor.Status = jobs.Failed
+	other := jobs.NewJob(testingidentity.GenerateRandomDID(), "Minting NFT")
+	other.Status = jobs.Failed
+	for _, job := range []*jobs.Job{mint, failedMint, anchor, other} {
+		assert.NoError(t, mngr.saveJob(job))
+	}
+
+	list, err := mngr.ListJobs(did, jobs.JobFilter{Status: jobs.Failed, DescriptionContains: "MINT"})
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, failedMint.ID, list[0].ID)
+	assert.Equal(t, mint.ID, list[1].ID)
+
+	list, err = mngr.ListJobs(did, jobs.JobFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+
+	list, err = mngr.ListJobs(did, jobs.JobFilter{Status: jobs.Success})
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestService_ListJobsPage(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+
+	_, _, err := mngr.ListJobsPage(did, jobs.JobFilter{Status: "done"}, 0, 1)
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidJobFilter, err))
+	for _, c := range [][2]int{{-1, 1}, {0, 0}, {int(^uint(0) >> 1), 1}} {
+		_, _, err = mngr.ListJobsPage(did, jobs.JobFilter{}, c[0], c[1])
+		assert.True(t, errors.IsOfType(jobs.ErrInvalidJobFilter, err))
+	}
+
+	// saved in random order, listed latest first
+	now := time.Now().UTC()
+	var created []*jobs.Job
+	for _, i := range []int{3, 0, 4, 1, 2} {
+		job := jobs.NewJob(did, "Minting NFT")
+		job.CreatedAt = now.Add(time.Duration(i) * time.Second)
+		assert.NoError(t, mngr.saveJob(job))
+		created = append(created, job)
+	}
+	other := jobs.NewJob(testingidentity.GenerateRandomDID(), "Minting NFT")
+	assert.NoError(t, mngr.saveJob(other))
+	latest := []*jobs.Job{created[2], created[0], created[4], created[3], created[1]}
+
+	list, total, err := mngr.ListJobsPage(did, jobs.JobFilter{}, 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []jobs.JobID{latest[0].ID, latest[1].ID}, jobIDs(list))
+
+	list, total, err = mngr.ListJobsPage(did, jobs.JobFilter{}, 4, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []jobs.JobID{latest[4].ID}, jobIDs(list))
+
+	// past the last page
+	list, total, err = mngr.ListJobsPage(did, jobs.JobFilter{}, 6, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, total)
+	assert.Empty(t, list)
+}
+
+func jobIDs(list []*jobs.Job) []jobs.JobID {
+	ids := make([]jobs.JobID, 0, len(list))
+	for _, job := range list {
+		ids = append(ids, job.ID)
+	}
+
+	return ids
+}
+
+func TestService_CancelJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+
+	// missing job
+	err := mngr.CancelJob(context.Background(), did, jobs.NewJobID(), jobs.CancelUserRequest)
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	release := make(chan struct{})
+	jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+
+	// invalid reason
+	err = mngr.CancelJob(context.Background(), did, jobID, jobs.CancelReason("bored"))
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidCancelReason, err))
+
+	assert.NoError(t, mngr.CancelJob(context.Background(), did, jobID, jobs.CancelSuperseded))
+	ntf := <-sendChan
+	assert.Equal(t, string(jobs.Failed), ntf.Status)
+	assert.Equal(t, string(jobs.CancelSuperseded), ntf.Metadata[jobs.CancelReasonKey])
+
+	// outcome of the work is ignored
+	close(release)
+	assert.True(t, errors.IsOfType(jobs.ErrJobCancelled, <-done))
+	job, err := mngr.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Equal(t, jobs.CancelSuperseded, job.CancelReason)
+	assert.Contains(t, job.Logs[len(job.Logs)-1].Message, string(jobs.CancelSuperseded))
+
+	// job is no longer pending
+	err = mngr.CancelJob(context.Background(), did, jobID, jobs.CancelUserRequest)
+	assert.True(t, errors.IsOfType(jobs.ErrJobNotPending, err))
+}
+
+func TestService_ForceStatus(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{historyEnabled: true}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+
+	// missing job
+	err := mngr.ForceStatus(did, jobs.NewJobID(), jobs.Failed, "stuck")
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	release := make(chan struct{})
+	jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+
+	// undefined and pending statuses, missing reason
+	err = mngr.ForceStatus(did, jobID, jobs.Status("done"), "stuck")
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidStatusTransition, err))
+	err = mngr.ForceStatus(did, jobID, jobs.Pending, "stuck")
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidStatusTransition, err))
+	assert.Equal(t, jobs.ErrMissingStatusReason, mngr.ForceStatus(did, jobID, jobs.Failed, " "))
+
+	assert.NoError(t, mngr.ForceStatus(did, jobID, jobs.Failed, "tx dropped by the chain"))
+	ntf := <-sendChan
+	assert.Equal(t, string(jobs.Failed), ntf.Status)
+	assert.Equal(t, did.String(), ntf.Metadata[jobs.StatusForcedByKey])
+
+	// outcome of the work is ignored
+	close(release)
+	assert.True(t, errors.IsOfType(jobs.ErrJobStatusForced, <-done))
+	job, err := mngr.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Len(t, job.Annotations, 1)
+	assert.Equal(t, did.String(), job.Annotations[0].Author)
+	assert.Equal(t, "status forced from pending to failed: tx dropped by the chain", job.Annotations[0].Note)
+	assert.Equal(t, job.Annotations[0].Note, job.Logs[len(job.Logs)-1].Message)
+	assert.Len(t, job.History, 1)
+	assert.Contains(t, job.History[0].Actor, did.String())
+
+	// same status
+	err = mngr.ForceStatus(did, jobID, jobs.Failed, "stuck")
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidStatusTransition, err))
+
+	// completed jobs are corrected without a notification
+	assert.NoError(t, mngr.ForceStatus(did, jobID, jobs.Success, "tx found on chain"))
+	job, err = mngr.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Success, job.Status)
+	assert.Len(t, job.Annotations, 2)
+	assert.Len(t, job.History, 2)
+	assert.Len(t, sendChan, 0)
+}
+
+// slowRepo widens the window between reading and saving a job.
+type slowRepo struct {
+	jobs.Repository
+}
+
+func (r slowRepo) Get(did identity.DID, id jobs.JobID) (*jobs.Job, error) {
+	job, err := r.Repository.Get(did, id)
+	time.Sleep(time.Millisecond)
+	return job, err
+}
+
+// failingRepo fails to read the jobs.
+type failingRepo struct {
+	jobs.Repository
+}
+
+func (r failingRepo) Get(did identity.DID, id jobs.JobID) (*jobs.Job, error) {
+	return nil, errors.NewTypedError(jobs.ErrJobsRepository, errors.New("db unavailable"))
+}
+
+func TestService_ExecuteWithinJob_existingJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	work := func(accountID identity.DID, id jobs.JobID, jobMan jobs.Manager, err chan<- error) {
+		err <- nil
+	}
+
+	// missing job creates a new job
+	missing := jobs.NewJobID()
+	jobID, done, err := msrv.ExecuteWithinJob(context.Background(), did, missing, "SomeTask", work)
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	assert.False(t, jobs.JobIDEqual(missing, jobID))
+	_, err = msrv.GetJob(did, jobID)
+	assert.NoError(t, err)
+
+	// repository failures are returned without creating a job
+	mngr := newManager(&mockConfig{}, failingRepo{msrv.repo})
+	jobID, done, err = mngr.ExecuteWithinJob(context.Background(), did, jobs.NewJobID(), "SomeTask", work)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(jobs.ErrJobsRepository, err))
+	assert.Nil(t, done)
+	assert.True(t, jobs.JobIDEqual(jobs.NilJobID(), jobID))
+}
+
+func TestService_concurrentUpdates(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, slowRepo{msrv.repo})
+	job, err := mngr.createJob(did, "SomeTask")
+	assert.NoError(t, err)
+
+	// concurrent executions of the existing job updating it
+	var dones []chan error
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("key%d", i)
+		_, done, err := mngr.ExecuteWithinJob(context.Background(), did, job.ID, "SomeTask", func(accountID identity.DID, id jobs.JobID, jobMan jobs.Manager, err chan<- error) {
+			err <- jobMan.UpdateJobWithValue(accountID, id, key, []byte{1})
+		})
+		assert.NoError(t, err)
+		dones = append(dones, done)
+	}
+
+	for _, done := range dones {
+		assert.NoError(t, <-done)
+	}
+
+	job, err = mngr.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Len(t, job.Values, 20)
+	assert.Len(t, mngr.jobLocks, 0)
+}
+
+func TestService_nilNotifier(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = nil
+	assert.NotPanics(t, func() {
+		mngr.notifyJobCompleted(context.Background(), jobs.NewJob(did, "SomeTask"))
+	})
+
+	// cancelled jobs are notified synchronously
+	job, err := mngr.createJob(did, "SomeTask")
+	assert.NoError(t, err)
+	assert.NotPanics(t, func() {
+		assert.NoError(t, mngr.CancelJob(context.Background(), did, job.ID, jobs.CancelUserRequest))
+	})
+}
+
+func TestService_OnStatusChange(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+
+	type transition struct {
+		from, to jobs.Status
+	}
+	changes := make(chan transition, 2)
+	mngr.OnStatusChange(func(job *jobs.Job, from, to jobs.Status) {
+		assert.Equal(t, to, job.Status)
+		changes <- transition{from, to}
+	})
+	mngr.OnStatusChange(func(job *jobs.Job, from, to jobs.Status) {
+		panic("callback failed")
+	})
+
+	_, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- errors.New("dummy")
+	})
+	assert.NoError(t, err)
+	assert.Error(t, <-done)
+	<-sendChan
+	assert.Equal(t, transition{jobs.Pending, jobs.Failed}, <-changes)
+	assert.Len(t, changes, 0)
+}
+
+func TestService_OnJobComplete(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+
+	// running job
+	release := make(chan struct{})
+	jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+	responses := make(chan jobs.StatusResponse, 2)
+	mngr.OnJobComplete(context.Background(), did, jobID, func(resp jobs.StatusResponse) {
+		responses <- resp
+	})
+	assert.Len(t, responses, 0)
+	close(release)
+	assert.NoError(t, <-done)
+	<-sendChan
+	resp := <-responses
+	assert.Equal(t, jobID.String(), resp.JobID)
+	assert.Equal(t, string(jobs.Success), resp.Status)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, responses, 0)
+
+	// job not running on this node is polled
+	job, err := mngr.createJob(did, "test")
+	assert.NoError(t, err)
+	mngr.OnJobComplete(context.Background(), did, job.ID, func(resp jobs.StatusResponse) {
+		responses <- resp
+	})
+	job.Status = jobs.Failed
+	assert.NoError(t, mngr.repo.Save(job))
+	resp = <-responses
+	assert.Equal(t, string(jobs.Failed), resp.Status)
+	assert.Len(t, mngr.doneChans, 0)
+
+	// closed context stops the wait
+	job, err = mngr.createJob(did, "test")
+	assert.NoError(t, err)
+	cctx, cancel := context.WithCancel(context.Background())
+	mngr.OnJobComplete(cctx, did, job.ID, func(resp jobs.StatusResponse) {
+		responses <- resp
+	})
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	job.Status = jobs.Success
+	assert.NoError(t, mngr.repo.Save(job))
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, responses, 0)
+}
+
+func TestService_NotifyJobRetrying(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+	job, err := mngr.createJob(did, "SomeTask")
+	assert.NoError(t, err)
+
+	// not sent unless opted in
+	mngr.NotifyJobRetrying(context.Background(), did, job.ID, "task", 2, 5*time.Second)
+	assert.Len(t, sendChan, 0)
+
+	mngr.config = mockConfig{optIn: []string{notification.OptInJobRetrying}}
+	mngr.NotifyJobRetrying(context.Background(), did, job.ID, "task", 2, 5*time.Second)
+	msg := <-sendChan
+	assert.Equal(t, notification.JobRetrying, msg.EventType)
+	assert.Equal(t, job.ID.String(), msg.DocumentID)
+	assert.Equal(t, string(jobs.Pending), msg.Status)
+	assert.Equal(t, "task", msg.Metadata[jobs.RetryTaskKey])
+	assert.Equal(t, "2", msg.Metadata[jobs.RetryAttemptKey])
+	assert.Equal(t, "5s", msg.Metadata[jobs.RetryDelayKey])
+
+	// missing jobs are not notified
+	mngr.NotifyJobRetrying(context.Background(), did, jobs.NewJobID(), "task", 2, 5*time.Second)
+	assert.Len(t, sendChan, 0)
+}
+
+func TestService_ExecuteWithinJob_maxConcurrent(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	release := make(chan struct{})
+	work := func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	}
+
+	// rejected over the cap
+	mngr := newManager(&mockConfig{maxConcurrent: 1, capacity: jobs.CapacityReject}, msrv.repo)
+	jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", work)
+	assert.NoError(t, err)
+	_, _, err = mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", work)
+	assert.True(t, errors.IsOfType(jobs.ErrJobCapacityReached, err))
+
+	// steps of an existing job don't take a slot
+	_, stepDone, err := mngr.ExecuteWithinJob(context.Background(), did, jobID, "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, <-stepDone)
+
+	// slot released once the job completes
+	release <- struct{}{}
+	assert.NoError(t, <-done)
+	time.Sleep(10 * time.Millisecond)
+	_, done, err = mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", work)
+	assert.NoError(t, err)
+
+	// blocked over the cap until a slot is released or the context closes
+	mngr2 := newManager(&mockConfig{maxConcurrent: 1, capacity: jobs.CapacityBlock}, msrv.repo)
+	_, done2, err := mngr2.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", work)
+	assert.NoError(t, err)
+	cctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, err = mngr2.ExecuteWithinJob(cctx, did, jobs.NilJobID(), "SomeTask", work)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	submitted := make(chan error, 1)
+	go func() {
+		_, done, err := mngr2.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", work)
+		if err == nil {
+			err = <-done
+		}
+		submitted <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, submitted, 0)
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-done2)
+	assert.NoError(t, <-submitted)
+}
+
+func TestService_ExecuteAfter(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{pollInterval: 10 * time.Millisecond}, msrv.repo)
+
+	// missing dependency
+	_, _, err := mngr.ExecuteAfter(context.Background(), did, jobs.NewJobID(), "SomeTask", nil)
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	// dependency running on this node succeeds
+	release := make(chan struct{})
+	depID, depDone, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "Dependency", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+	ran := make(chan struct{}, 1)
+	jobID, done, err := mngr.ExecuteAfter(context.Background(), did, depID, "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		ran <- struct{}{}
+		err <- nil
+	})
+	assert.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, ran, 0)
+	close(release)
+	assert.NoError(t, <-depDone)
+	assert.NoError(t, <-done)
+	assert.Len(t, ran, 1)
+	resp, err := mngr.GetJobStatus(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, string(jobs.Success), resp.Status)
+
+	// polled dependency fails
+	dep, err := mngr.createJob(did, "Dependency")
+	assert.NoError(t, err)
+	jobID, done, err = mngr.ExecuteAfter(context.Background(), did, dep.ID, "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		ran <- struct{}{}
+		err <- nil
+	})
+	assert.NoError(t, err)
+	dep.Status = jobs.Failed
+	assert.NoError(t, mngr.repo.Save(dep))
+	err = <-done
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), jobs.ErrJobDependencyFailed.Error())
+	assert.Len(t, ran, 1)
+	resp, err = mngr.GetJobStatus(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, string(jobs.Failed), resp.Status)
+
+	// closed context stops the wait
+	dep, err = mngr.createJob(did, "Dependency")
+	assert.NoError(t, err)
+	cctx, cancel := context.WithCancel(context.Background())
+	_, done, err = mngr.ExecuteAfter(cctx, did, dep.ID, "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		ran <- struct{}{}
+		err <- nil
+	})
+	assert.NoError(t, err)
+	cancel()
+	<-done
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, ran, 1)
+}
+
+func TestService_ExecuteAfter_slot(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{pollInterval: 10 * time.Millisecond, maxConcurrent: 1, capacity: jobs.CapacityReject}, msrv.repo)
+
+	// the dependency holds the only slot
+	release := make(chan struct{})
+	depID, depDone, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "Dependency", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+
+	// waiting for the dependency doesn't take a slot
+	_, done, err := mngr.ExecuteAfter(context.Background(), did, depID, "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, mngr.slots, 1)
+
+	// the slot is taken once the dependency is done
+	close(release)
+	assert.NoError(t, <-depDone)
+	assert.NoError(t, <-done)
+}
+
+func TestService_RetryFailedJobs(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	save := func(desc string, status jobs.Status, category jobs.FailureCategory) *jobs.Job {
+		job := jobs.NewJob(did, desc)
+		job.Status = status
+		job.FailureCategory = category
+		assert.NoError(t, mngr.repo.Save(job))
+		return job
+	}
+
+	transient := save("Retried Task", jobs.Failed, jobs.FailureTransient)
+	save("Retried Task", jobs.Failed, jobs.FailureValidation)
+	save("Retried Task", jobs.Success, "")
+	save("Unknown Task", jobs.Failed, jobs.FailureTransient)
+	other := jobs.NewJob(testingidentity.GenerateRandomDID(), "Retried Task")
+	other.Status, other.FailureCategory = jobs.Failed, jobs.FailureTransient
+	assert.NoError(t, mngr.repo.Save(other))
+
+	newID := jobs.NewJobID()
+	var retried []jobs.JobID
+	mngr.RegisterRetrier("retried task", func(ctx context.Context, job *jobs.Job) (jobs.JobID, error) {
+		retried = append(retried, job.ID)
+		return newID, nil
+	})
+
+	ids, err := mngr.RetryFailedJobs(context.Background(), did)
+	assert.NoError(t, err)
+	assert.Equal(t, []jobs.JobID{newID}, ids)
+	assert.Equal(t, []jobs.JobID{transient.ID}, retried)
+	job, err := mngr.GetJob(did, transient.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, newID.String(), job.Metadata[jobs.RetriedByKey])
+
+	// retried once
+	ids, err = mngr.RetryFailedJobs(context.Background(), did)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 0)
+}
+
+func TestService_GetJobHistory(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+
+	// disabled
+	mngr := NewManager(&mockConfig{}, msrv.repo)
+	_, err := mngr.GetJobHistory(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobHistoryDisabled, err))
+
+	// missing job
+	mngr = NewManager(&mockConfig{historyEnabled: true}, msrv.repo)
+	_, err = mngr.GetJobHistory(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
 
-func (mockConfig) GetTaskValidDuration() time.Duration {
-	panic("implement me")
+	mngr.(*manager).notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 1)
+	jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- errors.New("dummy")
+	})
+	assert.NoError(t, err)
+	assert.Error(t, <-done)
+	<-sendChan
+	history, err := mngr.GetJobHistory(did, jobID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, jobs.Pending, history[0].From)
+	assert.Equal(t, jobs.Failed, history[0].To)
+	assert.Equal(t, fmt.Sprintf("%s[SomeTask]", managerLogPrefix), history[0].Actor)
 }
 
-var sendChan chan notification.Message
+func TestService_JobTimings(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
 
-type mockSender struct{}
+	// disabled
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	_, _, err := mngr.JobTimings(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobHistoryDisabled, err))
 
-// Send mocks failure and returns to channel
-func (mockSender) Send(ctx context.Context, ntf notification.Message) (notification.Status, error) {
-	sendChan <- ntf
-	return notification.Failure, nil
+	// missing job
+	mngr = newManager(&mockConfig{historyEnabled: true}, msrv.repo)
+	_, _, err = mngr.JobTimings(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	// the first task report starts the job
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, mngr.saveJob(job))
+	assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Pending, "task", "running"))
+	job, err = mngr.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.False(t, job.StartedAt.IsZero())
+	started := job.StartedAt
+	assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Success, "task", ""))
+	job, err = mngr.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, started, job.StartedAt)
+	pending, running, err := mngr.JobTimings(did, job.ID)
+	assert.NoError(t, err)
+	assert.True(t, pending >= 0)
+	assert.True(t, running >= 0)
+
+	// status view
+	resp, err := mngr.GetJobStatus(did, job.ID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.PendingDuration)
+	assert.NotEmpty(t, resp.RunningDuration)
+	mngr = newManager(&mockConfig{}, msrv.repo)
+	resp, err = mngr.GetJobStatus(did, job.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.PendingDuration)
+	assert.Empty(t, resp.RunningDuration)
 }
 
-func TestService_ExecuteWithinTX_happy(t *testing.T) {
+func TestJobTimings(t *testing.T) {
+	created := time.Now().UTC()
+	now := created.Add(time.Hour)
+	job := &jobs.Job{Status: jobs.Pending, CreatedAt: created}
+
+	// waiting for the first task
+	pending, running := jobTimings(job, now)
+	assert.Equal(t, time.Hour, pending)
+	assert.Equal(t, time.Duration(0), running)
+
+	// running
+	job.StartedAt = created.Add(10 * time.Minute)
+	pending, running = jobTimings(job, now)
+	assert.Equal(t, 10*time.Minute, pending)
+	assert.Equal(t, 50*time.Minute, running)
+
+	// completed
+	job.Status = jobs.Success
+	job.History = []jobs.StatusTransition{{From: jobs.Pending, To: jobs.Success, CreatedAt: created.Add(30 * time.Minute)}}
+	pending, running = jobTimings(job, now)
+	assert.Equal(t, 10*time.Minute, pending)
+	assert.Equal(t, 20*time.Minute, running)
+
+	// completed before the history was enabled
+	job.History = nil
+	job.Logs = []jobs.Log{{CreatedAt: created.Add(40 * time.Minute)}}
+	pending, running = jobTimings(job, now)
+	assert.Equal(t, 10*time.Minute, pending)
+	assert.Equal(t, 30*time.Minute, running)
+
+	// completed without any task
+	job.StartedAt = time.Time{}
+	pending, running = jobTimings(job, now)
+	assert.Equal(t, 40*time.Minute, pending)
+	assert.Equal(t, time.Duration(0), running)
+}
+
+func TestService_VerifyJob(t *testing.T) {
 	did := testingidentity.GenerateRandomDID()
-	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
-	jobID, done, err := srv.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
-		err <- nil
-	})
+	srv := ctx[jobs.BootstrappedService].(*manager)
+
+	// missing job
+	_, err := srv.VerifyJob(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	// job saved without hash
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, srv.repo.Save(job))
+	_, err = srv.VerifyJob(did, job.ID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobHashMissing, err))
+
+	// valid job
+	job, err = srv.createJob(did, "SomeTask")
 	assert.NoError(t, err)
-	doneErr := <-done
-	assert.NoError(t, doneErr)
-	assert.NotNil(t, jobID)
-	job, err := srv.GetJob(did, jobID)
+	ok, err := srv.VerifyJob(did, job.ID)
 	assert.NoError(t, err)
-	assert.Equal(t, jobs.Success, job.Status)
+	assert.True(t, ok)
+
+	// tampered job
+	job.Status = jobs.Success
+	assert.NoError(t, srv.repo.Save(job))
+	ok, err = srv.VerifyJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.False(t, ok)
 }
 
-func TestService_ExecuteWithinTX_err(t *testing.T) {
-	errStr := "dummy"
+func TestService_UpdateTaskStatus_maxLogs(t *testing.T) {
 	did := testingidentity.GenerateRandomDID()
-	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
-	msrv := srv.(*manager)
-	mngr := NewManager(msrv.config, msrv.repo)
-	omgr := mngr.(*manager)
-	omgr.notifier = &mockSender{}
-	sendChan = make(chan notification.Message)
-	jobID, done, err := omgr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
-		err <- errors.New(errStr)
-	})
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := NewManager(&mockConfig{maxLogs: 2}, msrv.repo)
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, msrv.repo.Save(job))
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Pending, "task", "retrying"))
+	}
+	assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Pending, "task", "still retrying"))
+	assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Failed, "task", "failed"))
+
+	job, err := mngr.GetJob(did, job.ID)
 	assert.NoError(t, err)
-	doneErr := <-done
-	assert.Error(t, doneErr)
-	ntf := <-sendChan
-	assert.Equal(t, notification.JobCompleted, ntf.EventType)
-	assert.Equal(t, jobs.JobDataTypeURL, ntf.DocumentType)
-	assert.Equal(t, string(jobs.Failed), ntf.Status)
-	assert.Equal(t, errStr, ntf.Message)
+	assert.Len(t, job.Logs, 2)
+	assert.Equal(t, "still retrying", job.Logs[0].Message)
+	assert.Equal(t, "failed", job.Logs[1].Message)
+	assert.Equal(t, 1, job.DroppedLogs)
+}
+
+func TestService_GetRecentLogs(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := NewManager(&mockConfig{maxLogs: 10}, msrv.repo)
+
+	// missing job
+	_, err := mngr.GetRecentLogs(did, jobs.NewJobID(), 1)
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, msrv.repo.Save(job))
+	_, err = mngr.GetRecentLogs(did, job.ID, 0)
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidLogCount, err))
+
+	for _, msg := range []string{"first", "second", "third"} {
+		assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Pending, "task", msg))
+	}
+
+	logs, err := mngr.GetRecentLogs(did, job.ID, 2)
 	assert.NoError(t, err)
-	assert.NotNil(t, jobID)
-	job, err := omgr.GetJob(did, jobID)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "second", logs[0].Message)
+	assert.Equal(t, "third", logs[1].Message)
+
+	logs, err = mngr.GetRecentLogs(did, job.ID, 5)
 	assert.NoError(t, err)
-	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Len(t, logs, 3)
+}
+
+func TestService_GetLogTrail(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{maxLogs: 2, archiveAt: 4}, msrv.repo)
+	dir, err := ioutil.TempDir("", "job-logs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	mngr.logSink, err = NewFileLogSink(dir)
+	assert.NoError(t, err)
+
+	// missing job
+	_, err = mngr.GetLogTrail(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, mngr.saveJob(job))
+	var msgs []string
+	for i := 0; i < 9; i++ {
+		msg := fmt.Sprintf("log %d", i)
+		msgs = append(msgs, msg)
+		assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Pending, "task", msg))
+	}
+
+	// the job keeps a tail of the logs, the rest are archived
+	job, err = mngr.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Len(t, job.Logs, 3)
+	assert.Equal(t, 6, job.ArchivedLogs)
+	assert.Equal(t, 0, job.DroppedLogs)
+	assert.NotEmpty(t, job.LogArchive)
+	ok, err := mngr.VerifyJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	logs, err := mngr.GetLogTrail(did, job.ID)
+	assert.NoError(t, err)
+	assert.Len(t, logs, len(msgs))
+	for i, l := range logs {
+		assert.Equal(t, msgs[i], l.Message)
+	}
+
+	// archive no longer available
+	mngr.logSink = nil
+	_, err = mngr.GetLogTrail(did, job.ID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobLogArchive, err))
+
+	// job without archived logs
+	job = jobs.NewJob(did, "SomeTask")
+	job.AppendLog(jobs.NewLog("task", "only"), 0)
+	assert.NoError(t, mngr.saveJob(job))
+	logs, err = mngr.GetLogTrail(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, job.Logs, logs)
+}
+
+func TestService_CompactJobLogs(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+
+	// missing job
+	err := mngr.CompactJobLogs(did, jobs.NewJobID(), 1)
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, mngr.saveJob(job))
+	err = mngr.CompactJobLogs(did, job.ID, -1)
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidLogRetention, err))
+
+	for _, msg := range []string{"first", "second", "third", "fourth"} {
+		assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Pending, "task", msg))
+	}
+
+	assert.NoError(t, mngr.CompactJobLogs(did, job.ID, 2))
+	job, err = mngr.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Len(t, job.Logs, 2)
+	assert.Equal(t, "third", job.Logs[0].Message)
+	assert.Equal(t, 2, job.DroppedLogs)
+
+	// the job keeps logging after the compaction
+	assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Pending, "task", "fifth"))
+	assert.NoError(t, mngr.CompactJobLogs(did, job.ID, 0))
+	job, err = mngr.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, job.Logs)
+	assert.Equal(t, 5, job.DroppedLogs)
+	ok, err := mngr.VerifyJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestService_SupersedeJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message, 2)
+
+	release := make(chan struct{})
+	work := func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	}
+	oldID, oldDone, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", work)
+	assert.NoError(t, err)
+	newID, newDone, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", work)
+	assert.NoError(t, err)
+
+	// invalid supersede
+	assert.Equal(t, jobs.ErrJobSupersedesItself, mngr.SupersedeJob(did, oldID, oldID))
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, mngr.SupersedeJob(did, oldID, jobs.NewJobID())))
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, mngr.SupersedeJob(did, jobs.NewJobID(), newID)))
+
+	// recorded on both jobs
+	assert.NoError(t, mngr.SupersedeJob(did, oldID, newID))
+	oldJob, err := mngr.GetJob(did, oldID)
+	assert.NoError(t, err)
+	assert.Equal(t, newID.String(), oldJob.Metadata[jobs.SupersededByKey])
+	newJob, err := mngr.GetJob(did, newID)
+	assert.NoError(t, err)
+	assert.Equal(t, oldID.String(), newJob.Metadata[jobs.SupersedesKey])
+
+	// superseded job completes with the superseded notification
+	close(release)
+	assert.NoError(t, <-oldDone)
+	assert.NoError(t, <-newDone)
+	events := make(map[string]notification.EventType)
+	for i := 0; i < 2; i++ {
+		ntf := <-sendChan
+		events[ntf.DocumentID] = ntf.EventType
+	}
+	assert.Equal(t, notification.JobSuperseded, events[oldID.String()])
+	assert.Equal(t, notification.JobCompleted, events[newID.String()])
+
+	// completed jobs cannot be superseded
+	err = mngr.SupersedeJob(did, newID, oldID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobNotPending, err))
+}
+
+func TestService_AnnotateJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+
+	// missing job
+	err := mngr.AnnotateJob(did, jobs.NewJobID(), "escalated")
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, mngr.saveJob(job))
+	assert.NoError(t, mngr.UpdateTaskStatus(did, job.ID, jobs.Pending, "task", "running"))
+	assert.Equal(t, jobs.ErrInvalidAnnotation, mngr.AnnotateJob(did, job.ID, " "))
+
+	assert.NoError(t, mngr.AnnotateJob(did, job.ID, "escalated to chain team, ticket #123"))
+	assert.NoError(t, mngr.AnnotateJob(did, job.ID, "fixed by the chain team"))
+	job, err = mngr.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Len(t, job.Annotations, 2)
+	assert.Equal(t, "escalated to chain team, ticket #123", job.Annotations[0].Note)
+	assert.False(t, job.Annotations[0].CreatedAt.IsZero())
+	assert.Equal(t, "fixed by the chain team", job.Annotations[1].Note)
+
+	// annotations are kept apart from the logs
 	assert.Len(t, job.Logs, 1)
-	assert.Equal(t, fmt.Sprintf("%s[SomeTask]", managerLogPrefix), job.Logs[0].Action)
-	assert.Equal(t, errStr, job.Logs[0].Message)
+	resp, err := mngr.GetJobStatus(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "running", resp.Message)
+	assert.Equal(t, job.Annotations, resp.Annotations)
+	ok, err := mngr.VerifyJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestService_RecordJobTask(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+
+	// missing job
+	err := mngr.RecordJobTask(did, jobs.NewJobID(), jobs.JobTask{Name: "task"})
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	job := jobs.NewJob(did, "SomeTask")
+	assert.NoError(t, mngr.saveJob(job))
+	assert.NoError(t, mngr.RecordJobTask(did, job.ID, jobs.JobTask{Name: "first", Params: map[string]interface{}{"value": "1"}}))
+	assert.NoError(t, mngr.RecordJobTask(did, job.ID, jobs.JobTask{
+		Name:   "second",
+		Params: map[string]interface{}{jobs.JobIDParam: job.ID.String(), "block": 10},
+	}))
+
+	// the last task is kept with the kwargs as the task receives them
+	job, err = mngr.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", job.Task.Name)
+	assert.Equal(t, map[string]interface{}{jobs.JobIDParam: job.ID.String(), "block": float64(10)}, job.Task.Params)
 }
 
 func TestService_ExecuteWithinTX_ctxDone(t *testing.T) {
@@ -96,6 +1335,47 @@ func TestService_ExecuteWithinTX_ctxDone(t *testing.T) {
 	assert.Contains(t, job.Logs[0].Message, "stopped because of context close")
 }
 
+func TestService_ExecuteWithinTX_ctxDonePolicies(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	closeJob := func(policy string) (*jobs.Job, notification.Message, error) {
+		mngr := newManager(&mockConfig{closedPolicy: policy}, msrv.repo)
+		mngr.notifier = &mockSender{}
+		sendChan = make(chan notification.Message, 1)
+		ctx, canc := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+		jobID, done, err := mngr.ExecuteWithinJob(ctx, did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+			<-release
+		})
+		assert.NoError(t, err)
+		canc()
+		doneErr := <-done
+		job, err := mngr.GetJob(did, jobID)
+		assert.NoError(t, err)
+		return job, <-sendChan, doneErr
+	}
+
+	// unknown policy leaves the job pending
+	job, ntf, doneErr := closeJob("ignore")
+	assert.NoError(t, doneErr)
+	assert.Equal(t, jobs.Pending, job.Status)
+	assert.Equal(t, string(jobs.Pending), ntf.Status)
+
+	job, ntf, doneErr = closeJob(jobs.ContextClosedFail)
+	assert.Error(t, doneErr)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Empty(t, job.CancelReason)
+	assert.Equal(t, string(jobs.Failed), ntf.Status)
+
+	job, ntf, doneErr = closeJob(jobs.ContextClosedCancel)
+	assert.True(t, errors.IsOfType(jobs.ErrJobCancelled, doneErr))
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Equal(t, jobs.CancelContextClosed, job.CancelReason)
+	assert.Equal(t, string(jobs.Failed), ntf.Status)
+	assert.Equal(t, string(jobs.CancelContextClosed), ntf.Metadata[jobs.CancelReasonKey])
+}
+
 func TestService_GetTransaction(t *testing.T) {
 	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
 	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
@@ -165,3 +1445,356 @@ func TestService_WaitForTransaction(t *testing.T) {
 	assert.NoError(t, repo.Save(job))
 	assert.NoError(t, srv.WaitForJob(did, job.ID))
 }
+
+func TestService_WaitForJobWithContext(t *testing.T) {
+	srv := ctx[jobs.BootstrappedService].(extendedManager)
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+	did := testingidentity.GenerateRandomDID()
+	job, err := srv.createJob(did, "test")
+	assert.NoError(t, err)
+
+	// cancelled mid-wait
+	cctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.WaitForJobWithContext(cctx, did, job.ID)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.Equal(t, context.Canceled, <-errc)
+
+	// deadline elapsed
+	cctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, srv.WaitForJobWithContext(cctx, did, job.ID))
+
+	// failed
+	job.Status = jobs.Failed
+	assert.NoError(t, repo.Save(job))
+	err = srv.WaitForJobWithContext(context.Background(), did, job.ID)
+	assert.Error(t, err)
+	assert.NotEqual(t, context.Canceled, err)
+
+	// success
+	job.Status = jobs.Success
+	assert.NoError(t, repo.Save(job))
+	assert.NoError(t, srv.WaitForJobWithContext(context.Background(), did, job.ID))
+}
+
+func TestService_WaitForJob_backoff(t *testing.T) {
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	did := testingidentity.GenerateRandomDID()
+	mngr := newManager(&mockConfig{pollInitial: 10 * time.Millisecond, pollMax: 50 * time.Millisecond}, msrv.repo)
+	job, err := mngr.createJob(did, "test")
+	assert.NoError(t, err)
+
+	var waits []time.Duration
+	mngr.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		if len(waits) == 6 {
+			job.Status = jobs.Success
+			return mngr.saveJob(job)
+		}
+
+		return nil
+	}
+	assert.NoError(t, mngr.WaitForJob(did, job.ID))
+	assert.Equal(t, []time.Duration{
+		10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond,
+		50 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond,
+	}, waits)
+
+	// backoff starts over with the next wait
+	job.Status = jobs.Pending
+	assert.NoError(t, mngr.saveJob(job))
+	waits = nil
+	mngr.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return context.Canceled
+	}
+	assert.Equal(t, context.Canceled, mngr.WaitForJob(did, job.ID))
+	assert.Equal(t, []time.Duration{10 * time.Millisecond}, waits)
+}
+
+func TestService_WaitForJob_doneChannel(t *testing.T) {
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	did := testingidentity.GenerateRandomDID()
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	var polls int
+	mngr.sleep = func(ctx context.Context, d time.Duration) error {
+		polls++
+		return nil
+	}
+
+	// running on this node, waited for on its done channel
+	release := make(chan struct{})
+	jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- mngr.WaitForJob(did, jobID)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-errc)
+	assert.Zero(t, polls)
+	assert.Nil(t, mngr.routineDone(did, jobID))
+
+	// cancelled while waiting on the done channel
+	release = make(chan struct{})
+	defer close(release)
+	jobID, _, err = mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+	cctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, mngr.WaitForJobWithContext(cctx, did, jobID))
+	assert.Zero(t, polls)
+
+	// pending before a restart, polled
+	job, err := mngr.createJob(did, "test")
+	assert.NoError(t, err)
+	assert.Nil(t, mngr.routineDone(did, job.ID))
+	mngr.sleep = func(ctx context.Context, d time.Duration) error {
+		polls++
+		job.Status = jobs.Failed
+		return mngr.saveJob(job)
+	}
+	assert.Error(t, mngr.WaitForJob(did, job.ID))
+	assert.Equal(t, 1, polls)
+}
+
+func TestService_WaitForJob_outcome(t *testing.T) {
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	did := testingidentity.GenerateRandomDID()
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = &mockSender{}
+	sendChan = make(chan notification.Message)
+	var polls int
+	mngr.sleep = func(ctx context.Context, d time.Duration) error {
+		polls++
+		return nil
+	}
+
+	// the outcome is delivered before the completion is notified
+	release := make(chan struct{})
+	jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- errors.New("failed work")
+	})
+	assert.NoError(t, err)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- mngr.WaitForJob(did, jobID)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	assert.Error(t, <-done)
+	select {
+	case err = <-errc:
+	case <-time.After(time.Second):
+		t.Fatal("waiter not signalled before the notification")
+	}
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed work")
+	assert.Zero(t, polls)
+	<-sendChan
+}
+
+func TestService_pollBackoff(t *testing.T) {
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+	mngr := newManager(&mockConfig{}, repo)
+	assert.Equal(t, defaultJobPollInitial, mngr.pollInitial())
+	assert.Equal(t, defaultJobPollMax, mngr.pollMax())
+
+	mngr = newManager(&mockConfig{pollInitial: time.Millisecond, pollMax: time.Minute}, repo)
+	assert.Equal(t, time.Millisecond, mngr.pollInitial())
+	assert.Equal(t, time.Minute, mngr.pollMax())
+
+	// the first wait is never capped
+	mngr = newManager(&mockConfig{pollInitial: 2 * time.Second}, repo)
+	assert.Equal(t, 2*time.Second, mngr.pollMax())
+}
+
+func TestService_pollInterval(t *testing.T) {
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+	assert.Equal(t, defaultJobPollInterval, newManager(&mockConfig{}, repo).pollInterval())
+	assert.Equal(t, time.Second, newManager(&mockConfig{pollInterval: time.Second}, repo).pollInterval())
+}
+
+func TestService_EffectiveConfig(t *testing.T) {
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+
+	// defaults
+	cfg := newManager(&mockConfig{validFor: time.Hour}, repo).EffectiveConfig()
+	assert.Equal(t, time.Hour, cfg.TaskValidDuration)
+	assert.Equal(t, defaultJobPollInterval, cfg.PollInterval)
+	assert.Equal(t, defaultJobPollInitial, cfg.PollInitial)
+	assert.Equal(t, defaultJobPollMax, cfg.PollMax)
+	assert.Equal(t, jobs.ContextClosedPending, cfg.ContextClosedPolicy)
+	assert.Equal(t, jobs.CapacityBlock, cfg.CapacityPolicy)
+
+	cfg = newManager(&mockConfig{
+		validFor:       time.Hour,
+		historyEnabled: true,
+		maxLogs:        10,
+		referenceKey:   jobs.RequestIDKey,
+		pollInterval:   time.Second,
+		closedPolicy:   jobs.ContextClosedFail,
+		retryAfter:     map[string]time.Duration{string(jobs.FailureTransient): time.Minute},
+		maxConcurrent:  5,
+		capacity:       jobs.CapacityReject,
+	}, repo).EffectiveConfig()
+	assert.True(t, cfg.HistoryEnabled)
+	assert.Equal(t, 10, cfg.MaxLogs)
+	assert.Equal(t, jobs.RequestIDKey, cfg.ReferenceKey)
+	assert.Equal(t, time.Second, cfg.PollInterval)
+	assert.Equal(t, jobs.ContextClosedFail, cfg.ContextClosedPolicy)
+	assert.Equal(t, time.Minute, cfg.RetryAfter[string(jobs.FailureTransient)])
+	assert.Equal(t, 5, cfg.MaxConcurrentJobs)
+	assert.Equal(t, jobs.CapacityReject, cfg.CapacityPolicy)
+}
+
+func TestService_recoverJobs(t *testing.T) {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	repo := NewRepository(leveldb.NewLevelDBRepository(db))
+	did := testingidentity.GenerateRandomDID()
+	resumed := jobs.NewJob(did, "Minting NFT")
+	failed := jobs.NewJob(did, "Transfer From NFT")
+	done := jobs.NewJob(did, "Minting NFT")
+	done.Status = jobs.Success
+	for _, job := range []*jobs.Job{resumed, failed, done} {
+		assert.NoError(t, repo.Save(job))
+	}
+
+	mngr := newManager(mockConfig{policies: map[string]string{"minting nft": jobs.RecoveryResume}, validFor: 50 * time.Millisecond}, repo)
+	assert.NoError(t, mngr.recoverJobs())
+
+	job, err := mngr.GetJob(did, failed.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Equal(t, "job was interrupted by node restart", job.Logs[0].Message)
+	assert.Equal(t, jobs.FailureTransient, job.FailureCategory)
+
+	job, err = mngr.GetJob(did, done.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Success, job.Status)
+
+	// resumed job is failed once the valid duration elapses
+	job, err = mngr.GetJob(did, resumed.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Pending, job.Status)
+	assert.Error(t, mngr.WaitForJob(did, resumed.ID))
+	job, err = mngr.GetJob(did, resumed.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "job didn't complete after node restart", job.Logs[0].Message)
+}
+
+// receiptSender reports the receipts of the deliveries, the notifications of the failed jobs are rejected.
+type receiptSender struct{}
+
+func (s receiptSender) Send(ctx context.Context, ntf notification.Message) (notification.Status, error) {
+	_, err := s.SendWithReceipt(ctx, ntf)
+	if err != nil {
+		return notification.Failure, err
+	}
+
+	return notification.Success, nil
+}
+
+func (receiptSender) SendWithReceipt(ctx context.Context, ntf notification.Message) (*notification.Receipt, error) {
+	receipt := &notification.Receipt{URL: "http://webhook", StatusCode: http.StatusOK, Delivered: true, SentAt: time.Now().UTC()}
+	if ntf.Status == string(jobs.Failed) {
+		receipt.StatusCode, receipt.Delivered = http.StatusServiceUnavailable, false
+		receipt.Error = "failed to send webhook: status = 503"
+		return receipt, errors.New(receipt.Error)
+	}
+
+	return receipt, nil
+}
+
+func TestService_GetNotificationReceipts(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{optIn: []string{notification.OptInJobRetrying}}, msrv.repo)
+	mngr.notifier = receiptSender{}
+	receipts := func(id jobs.JobID, n int) []jobs.NotificationReceipt {
+		var receipts []jobs.NotificationReceipt
+		assert.Eventually(t, func() bool {
+			var err error
+			receipts, err = mngr.GetNotificationReceipts(did, id)
+			assert.NoError(t, err)
+			return len(receipts) == n
+		}, time.Second, 10*time.Millisecond)
+		return receipts
+	}
+
+	// retry and completion delivered
+	release := make(chan struct{})
+	jobID, done, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+	mngr.NotifyJobRetrying(context.Background(), did, jobID, "task", 2, time.Second)
+	close(release)
+	assert.NoError(t, <-done)
+	got := receipts(jobID, 2)
+	assert.Equal(t, notification.OptInJobRetrying, got[0].Event)
+	assert.Equal(t, "job_completed", got[1].Event)
+	assert.True(t, got[1].Delivered)
+	assert.Equal(t, http.StatusOK, got[1].StatusCode)
+	assert.Equal(t, "http://webhook", got[1].URL)
+	resp, err := mngr.GetJobStatus(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, got, resp.NotificationReceipts)
+
+	// rejected completion
+	jobID, done, err = mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		err <- errors.New("dummy")
+	})
+	assert.NoError(t, err)
+	assert.Error(t, <-done)
+	got = receipts(jobID, 1)
+	assert.False(t, got[0].Delivered)
+	assert.Equal(t, http.StatusServiceUnavailable, got[0].StatusCode)
+	assert.NotEmpty(t, got[0].Error)
+
+	// missing job
+	_, err = mngr.GetNotificationReceipts(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+}
+
+func TestService_ExecuteWithinJob_panicAfterContextClosed(t *testing.T) {
+	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
+	did := testingidentity.GenerateRandomDID()
+	cctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	_, done, err := srv.ExecuteWithinJob(cctx, did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		panicLate()
+	})
+	assert.NoError(t, err)
+	cancel()
+	<-done
+
+	// the recovered panic is not waited for anymore and doesn't leak the work routine
+	close(release)
+	assert.Eventually(t, func() bool {
+		buf := make([]byte, 1<<20)
+		stacks := string(buf[:runtime.Stack(buf, true)])
+		return !strings.Contains(stacks, "jobsv1.panicLate")
+	}, time.Second, 10*time.Millisecond)
+}
+
+// panicLate panics so that the routines still running the panicked work can be found by their stack.
+func panicLate() {
+	panic("late panic")
+}
@@ -0,0 +1,46 @@
+package jobsv1
+
+import (
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	jobsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "jobs",
+		Name:      "created_total",
+		Help:      "Number of jobs created.",
+	})
+
+	jobsCompleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "jobs",
+		Name:      "completed_total",
+		Help:      "Number of jobs completed by status.",
+	}, []string{"status"})
+)
+
+// Collectors returns the collectors of the job metrics.
+func (s *manager) Collectors() []prometheus.Collector {
+	running := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "jobs",
+		Name:      "running",
+		Help:      "Number of job routines running on the node.",
+	}, func() float64 {
+		s.doneMu.Lock()
+		defer s.doneMu.Unlock()
+		return float64(len(s.doneChans))
+	})
+
+	return []prometheus.Collector{jobsCreated, jobsCompleted, running}
+}
+
+// countCompleted counts the job if it moved to a terminal status.
+func countCompleted(status jobs.Status) {
+	if status == jobs.Success || status == jobs.Failed {
+		jobsCompleted.WithLabelValues(string(status)).Inc()
+	}
+}
@@ -0,0 +1,103 @@
+package jobsv1
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+)
+
+// ReconcileJobs cross-checks the jobs with the tasks queued in the backend and resolves the discrepancies per the policy.
+// Finished jobs must not have a queued task and pending jobs with a recorded task must have one.
+// Jobs moved back to pending or requeued are resumed: they fail if still pending once the task valid duration elapses.
+// Discrepancies that couldn't be resolved are logged and left as is.
+func (s *manager) ReconcileJobs(backend jobs.TaskBackend, policy string) (report jobs.ReconcileReport, err error) {
+	if !jobs.ValidReconcilePolicy(policy) {
+		return report, errors.NewTypedError(jobs.ErrInvalidReconcilePolicy, errors.New("policy: %s", policy))
+	}
+
+	queued, err := backend.QueuedJobs()
+	if err != nil {
+		return report, err
+	}
+
+	var finished, orphaned []*jobs.Job
+	err = s.repo.IterateJobs(func(job *jobs.Job) error {
+		_, ok := queued[job.ID]
+		switch {
+		case ok && job.Status != jobs.Pending:
+			finished = append(finished, job)
+		case !ok && job.Status == jobs.Pending && job.Task != nil:
+			orphaned = append(orphaned, job)
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	action := fmt.Sprintf("%s[reconcile]", managerLogPrefix)
+	for _, job := range finished {
+		log.Warningf("Job %s for account %s is %s but has a queued task", job.ID.String(), job.DID, job.Status)
+		if policy == jobs.ReconcileTrustQueue {
+			if err := s.reopenJob(job, action); err != nil {
+				log.Errorf("failed to reopen job %s: %v", job.ID.String(), err)
+				continue
+			}
+
+			report.Reopened++
+			continue
+		}
+
+		dropped, err := backend.DropJobTasks(job.ID)
+		report.Dropped += dropped
+		if err != nil {
+			log.Errorf("failed to drop the queued tasks of job %s: %v", job.ID.String(), err)
+			continue
+		}
+
+		if policy == jobs.ReconcileFail && job.Status != jobs.Failed {
+			s.failJob(job.DID, job.ID, action, jobs.ErrJobTaskStillQueued)
+			report.Failed++
+		}
+	}
+
+	for _, job := range orphaned {
+		log.Warningf("Job %s for account %s is pending without a queued task", job.ID.String(), job.DID)
+		if policy == jobs.ReconcileTrustStore {
+			if err := backend.RequeueJobTask(*job.Task); err != nil {
+				log.Errorf("failed to requeue task %s of job %s: %v", job.Task.Name, job.ID.String(), err)
+				continue
+			}
+
+			s.expireResumed(job.DID, job.ID, action)
+			report.Requeued++
+			continue
+		}
+
+		s.failJob(job.DID, job.ID, action, jobs.ErrJobTaskLost)
+		report.Failed++
+	}
+
+	return report, nil
+}
+
+// reopenJob moves the finished job back to pending so that its queued task completes it.
+func (s *manager) reopenJob(job *jobs.Job, action string) error {
+	var from jobs.Status
+	err := s.updateJob(job.DID, job.ID, func(j *jobs.Job) error {
+		from = j.Status
+		j.AppendLog(jobs.NewLog(action, fmt.Sprintf("job reopened from %s: task still queued", from)), s.maxLogs())
+		j.FailureCategory = ""
+		s.setStatus(j, jobs.Pending, action)
+		job = j
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.statusChanged(job, from)
+	s.expireResumed(job.DID, job.ID, action)
+	return nil
+}
@@ -0,0 +1,118 @@
+// +build unit
+
+package jobsv1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBackend struct {
+	queued   map[jobs.JobID]struct{}
+	dropped  []jobs.JobID
+	requeued []jobs.JobTask
+}
+
+func (b *mockBackend) QueuedJobs() (map[jobs.JobID]struct{}, error) {
+	return b.queued, nil
+}
+
+func (b *mockBackend) DropJobTasks(id jobs.JobID) (int, error) {
+	b.dropped = append(b.dropped, id)
+	return 1, nil
+}
+
+func (b *mockBackend) RequeueJobTask(task jobs.JobTask) error {
+	b.requeued = append(b.requeued, task)
+	return nil
+}
+
+func TestService_ReconcileJobs(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	task := &jobs.JobTask{Name: "task", Params: map[string]interface{}{"value": "1"}}
+	setup := func(t *testing.T) (mngr *manager, backend *mockBackend, done, orphaned, running, untracked *jobs.Job) {
+		db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+		assert.NoError(t, err)
+		repo := NewRepository(leveldb.NewLevelDBRepository(db))
+
+		// finished with a queued task
+		done = jobs.NewJob(did, "done")
+		done.Status = jobs.Success
+
+		// pending without a queued task
+		orphaned = jobs.NewJob(did, "orphaned")
+		orphaned.Task = task
+
+		// pending with a queued task
+		running = jobs.NewJob(did, "running")
+		running.Task = task
+
+		// pending without a recorded task
+		untracked = jobs.NewJob(did, "untracked")
+		for _, job := range []*jobs.Job{done, orphaned, running, untracked} {
+			assert.NoError(t, repo.Save(job))
+		}
+
+		backend = &mockBackend{queued: map[jobs.JobID]struct{}{done.ID: {}, running.ID: {}}}
+		return newManager(mockConfig{validFor: time.Minute}, repo), backend, done, orphaned, running, untracked
+	}
+
+	status := func(mngr *manager, job *jobs.Job) jobs.Status {
+		job, err := mngr.GetJob(did, job.ID)
+		assert.NoError(t, err)
+		return job.Status
+	}
+
+	// invalid policy
+	mngr, backend, _, _, _, _ := setup(t)
+	_, err := mngr.ReconcileJobs(backend, "ignore")
+	assert.True(t, errors.IsOfType(jobs.ErrInvalidReconcilePolicy, err))
+
+	// trust the store
+	mngr, backend, done, orphaned, running, untracked := setup(t)
+	report, err := mngr.ReconcileJobs(backend, jobs.ReconcileTrustStore)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.ReconcileReport{Dropped: 1, Requeued: 1}, report)
+	assert.Equal(t, []jobs.JobID{done.ID}, backend.dropped)
+	assert.Equal(t, []jobs.JobTask{*task}, backend.requeued)
+	assert.Equal(t, jobs.Success, status(mngr, done))
+	assert.Equal(t, jobs.Pending, status(mngr, orphaned))
+	assert.Equal(t, jobs.Pending, status(mngr, running))
+	assert.Equal(t, jobs.Pending, status(mngr, untracked))
+
+	// trust the queue
+	mngr, backend, done, orphaned, running, untracked = setup(t)
+	report, err = mngr.ReconcileJobs(backend, jobs.ReconcileTrustQueue)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.ReconcileReport{Reopened: 1, Failed: 1}, report)
+	assert.Empty(t, backend.dropped)
+	assert.Empty(t, backend.requeued)
+	assert.Equal(t, jobs.Pending, status(mngr, done))
+	job, err := mngr.GetJob(did, orphaned.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Equal(t, jobs.ErrJobTaskLost.Error(), job.Logs[0].Message)
+	assert.Equal(t, jobs.Pending, status(mngr, running))
+	assert.Equal(t, jobs.Pending, status(mngr, untracked))
+
+	// fail
+	mngr, backend, done, orphaned, running, untracked = setup(t)
+	report, err = mngr.ReconcileJobs(backend, jobs.ReconcileFail)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.ReconcileReport{Dropped: 1, Failed: 2}, report)
+	assert.Equal(t, []jobs.JobID{done.ID}, backend.dropped)
+	assert.Empty(t, backend.requeued)
+	job, err = mngr.GetJob(did, done.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Equal(t, jobs.ErrJobTaskStillQueued.Error(), job.Logs[0].Message)
+	assert.Equal(t, jobs.Failed, status(mngr, orphaned))
+	assert.Equal(t, jobs.Pending, status(mngr, running))
+	assert.Equal(t, jobs.Pending, status(mngr, untracked))
+}
@@ -1,25 +1,153 @@
 package jobsv1
 
 import (
+	"encoding/json"
+	"io"
+	"reflect"
+
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vmihailenco/msgpack"
+)
+
+const (
+	jobPrefix string = "job_"
+
+	// jobReferencePrefix must not start with jobPrefix so that the references are not iterated as jobs.
+	jobReferencePrefix string = "jobref_"
+
+	// FormatJSON saves the jobs as JSON.
+	FormatJSON = "json"
+
+	// FormatMsgpack saves the jobs as msgpack.
+	FormatMsgpack = "msgpack"
+
+	// msgpackHeader prefixes the msgpack encoded jobs.
+	msgpackHeader byte = 0x01
 )
 
-const jobPrefix string = "job_"
+// jobReference maps a metadata key and value to the job.
+// The account, key and value are recorded so that the references are streamed along the jobs.
+type jobReference struct {
+	JobID jobs.JobID
+	DID   identity.DID
+	Key   string
+	Value string
+}
+
+// streamedReference is a reference written to the stream of the jobs, after the jobs.
+type streamedReference struct {
+	Reference *jobReference `json:",omitempty"`
+}
+
+// JSON returns json marshaled reference.
+func (r *jobReference) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// FromJSON loads the data into reference.
+func (r *jobReference) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// Type returns the reflect.Type of the reference.
+func (r *jobReference) Type() reflect.Type {
+	return reflect.TypeOf(r)
+}
+
+// msgpackJob is a job saved as msgpack.
+// The storage saves the job as the msgpack header followed by the msgpack encoded job, the jobs saved as JSON
+// are told apart by their first byte.
+type msgpackJob struct {
+	job *jobs.Job
+}
+
+// BinaryHeader returns the header prefixing the msgpack encoded jobs.
+func (m *msgpackJob) BinaryHeader() byte {
+	return msgpackHeader
+}
+
+// MarshalBinary returns the msgpack encoded job.
+func (m *msgpackJob) MarshalBinary() ([]byte, error) {
+	return msgpack.Marshal(m.job)
+}
+
+// UnmarshalBinary loads the msgpack encoded job.
+func (m *msgpackJob) UnmarshalBinary(data []byte) error {
+	m.job = new(jobs.Job)
+	return msgpack.Unmarshal(data, m.job)
+}
+
+// JSON returns the json marshaled job.
+func (m *msgpackJob) JSON() ([]byte, error) {
+	return json.Marshal(m.job)
+}
+
+// FromJSON loads the json marshaled job.
+func (m *msgpackJob) FromJSON(data []byte) error {
+	m.job = new(jobs.Job)
+	return json.Unmarshal(data, m.job)
+}
+
+// Type returns the reflect.Type of the msgpack job.
+func (m *msgpackJob) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
 
 // jobRepository implements Repository.
 type jobRepository struct {
-	repo storage.Repository
+	repo   storage.Repository
+	format string
 }
 
 // NewRepository registers the the Job model and returns the an implementation
-// of the Repository.
+// of the Repository saving the jobs as JSON.
 func NewRepository(repo storage.Repository) jobs.Repository {
+	return newRepository(repo, FormatJSON)
+}
+
+// NewRepositoryWithFormat returns the Repository saving the jobs in the given format, FormatJSON if empty.
+// Jobs saved in either format are read regardless of the format.
+func NewRepositoryWithFormat(repo storage.Repository, format string) (jobs.Repository, error) {
+	switch format {
+	case "":
+		return newRepository(repo, FormatJSON), nil
+	case FormatJSON, FormatMsgpack:
+		return newRepository(repo, format), nil
+	default:
+		return nil, errors.New("unknown job serialization format %q", format)
+	}
+}
+
+func newRepository(repo storage.Repository, format string) *jobRepository {
 	repo.Register(new(jobs.Job))
-	return &jobRepository{repo: repo}
+	repo.Register(new(msgpackJob))
+	repo.Register(new(jobReference))
+	return &jobRepository{repo: repo, format: format}
+}
+
+// model returns the job as saved in the configured format.
+func (r *jobRepository) model(job *jobs.Job) storage.Model {
+	if r.format == FormatMsgpack {
+		return &msgpackJob{job: job}
+	}
+
+	return job
+}
+
+// toJob returns the job from the model saved in either format.
+func toJob(model storage.Model) (*jobs.Job, error) {
+	switch m := model.(type) {
+	case *jobs.Job:
+		return m, nil
+	case *msgpackJob:
+		return m.job, nil
+	default:
+		return nil, errors.New("unexpected model type %T", model)
+	}
 }
 
 // getKey appends identity with id.
@@ -32,6 +160,12 @@ func getKey(did identity.DID, id jobs.JobID) ([]byte, error) {
 	return append([]byte(jobPrefix), []byte(hexKey)...), nil
 }
 
+// getReferenceKey returns the key of the reference of the job by the metadata key and value for the identity.
+func getReferenceKey(did identity.DID, key, value string) []byte {
+	hexKey := hexutil.Encode(append(did[:], []byte(key+"="+value)...))
+	return append([]byte(jobReferencePrefix), []byte(hexKey)...)
+}
+
 // Get returns the job associated with identity and id.
 func (r *jobRepository) Get(did identity.DID, id jobs.JobID) (*jobs.Job, error) {
 	key, err := getKey(did, id)
@@ -41,10 +175,19 @@ func (r *jobRepository) Get(did identity.DID, id jobs.JobID) (*jobs.Job, error)
 
 	m, err := r.repo.Get(key)
 	if err != nil {
-		return nil, errors.NewTypedError(jobs.ErrJobsMissing, err)
+		return nil, repositoryError(err)
+	}
+
+	return toJob(m)
+}
+
+// repositoryError returns ErrJobsMissing if the storage didn't find the model, ErrJobsRepository otherwise.
+func repositoryError(err error) error {
+	if errors.IsOfType(storage.ErrModelRepositoryNotFound, err) {
+		return errors.NewTypedError(jobs.ErrJobsMissing, err)
 	}
 
-	return m.(*jobs.Job), nil
+	return errors.NewTypedError(jobs.ErrJobsRepository, err)
 }
 
 // Save saves the job to the repository.
@@ -55,8 +198,188 @@ func (r *jobRepository) Save(job *jobs.Job) error {
 	}
 
 	if r.repo.Exists(key) {
-		return r.repo.Update(key, job)
+		return r.repo.Update(key, r.model(job))
+	}
+
+	return r.repo.Create(key, r.model(job))
+}
+
+// SaveReference indexes the job by the metadata key and value.
+// An existing reference with the same key and value is moved to the job.
+func (r *jobRepository) SaveReference(did identity.DID, key, value string, id jobs.JobID) error {
+	ref := getReferenceKey(did, key, value)
+	model := &jobReference{JobID: id, DID: did, Key: key, Value: value}
+	if r.repo.Exists(ref) {
+		return r.repo.Update(ref, model)
+	}
+
+	return r.repo.Create(ref, model)
+}
+
+// GetByReference returns the job indexed by the metadata key and value.
+func (r *jobRepository) GetByReference(did identity.DID, key, value string) (*jobs.Job, error) {
+	m, err := r.repo.Get(getReferenceKey(did, key, value))
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+
+	return r.Get(did, m.(*jobReference).JobID)
+}
+
+// StreamAllJobs writes every job across all accounts to w as newline delimited JSON, followed by the references
+// of the jobs so that GetByReference finds the imported jobs.
+// Jobs are written as they are read from the storage instead of loading them all into memory.
+func (r *jobRepository) StreamAllJobs(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	err := r.IterateJobs(func(job *jobs.Job) error {
+		return enc.Encode(job)
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.repo.IterateByPrefix(jobReferencePrefix, func(model storage.Model) error {
+		ref, ok := model.(*jobReference)
+		if !ok {
+			return errors.New("unexpected model type %T", model)
+		}
+
+		// references saved without their key can't be restored
+		if ref.Key == "" {
+			log.Warningf("skipping the reference of job %s saved without its key", ref.JobID.String())
+			return nil
+		}
+
+		return enc.Encode(streamedReference{Reference: ref})
+	})
+}
+
+// IterateJobs calls fn for every job across all accounts. Iteration stops at the first error.
+func (r *jobRepository) IterateJobs(fn func(job *jobs.Job) error) error {
+	return r.repo.IterateByPrefix(jobPrefix, func(model storage.Model) error {
+		job, err := toJob(model)
+		if err != nil {
+			return err
+		}
+
+		return fn(job)
+	})
+}
+
+// ImportJobs restores the jobs and their references from newline delimited JSON as written by StreamAllJobs.
+// Every job and reference is validated before it is saved. The import stops at the first invalid job or reference
+// or at the first one already saved. The jobs and references imported before are kept.
+func (r *jobRepository) ImportJobs(rd io.Reader) error {
+	return r.importJobs(rd, false)
+}
+
+// ImportJobsOverwrite restores the jobs as ImportJobs does but overwrites the jobs and references already saved.
+func (r *jobRepository) ImportJobsOverwrite(rd io.Reader) error {
+	return r.importJobs(rd, true)
+}
+
+// importJobs restores the jobs and references, the ones already saved are only overwritten if overwrite is true.
+func (r *jobRepository) importJobs(rd io.Reader, overwrite bool) error {
+	dec := json.NewDecoder(rd)
+	for n := 1; ; n++ {
+		var data json.RawMessage
+		err := dec.Decode(&data)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return errors.New("failed to decode job %d: %v", n, err)
+		}
+
+		var sref streamedReference
+		err = json.Unmarshal(data, &sref)
+		if err == nil && sref.Reference != nil {
+			err = r.importReference(n, sref.Reference, overwrite)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		job := new(jobs.Job)
+		err = json.Unmarshal(data, job)
+		if err != nil {
+			return errors.New("failed to decode job %d: %v", n, err)
+		}
+
+		err = validateImportedJob(job)
+		if err != nil {
+			return errors.NewTypedError(jobs.ErrInvalidJob, errors.New("job %d: %v", n, err))
+		}
+
+		key, err := getKey(job.DID, job.ID)
+		if err != nil {
+			return errors.NewTypedError(jobs.ErrKeyConstructionFailed, err)
+		}
+
+		if !overwrite && r.repo.Exists(key) {
+			return errors.NewTypedError(jobs.ErrJobExists, errors.New("job %s of account %s", job.ID.String(), job.DID.String()))
+		}
+
+		err = r.Save(job)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// importReference saves the reference of an imported job, the job must be saved.
+func (r *jobRepository) importReference(n int, ref *jobReference, overwrite bool) error {
+	if ref.Key == "" || ref.Value == "" || ref.DID.Equal(identity.DID{}) {
+		return errors.NewTypedError(jobs.ErrInvalidJob, errors.New("reference %d: account, key or value is missing", n))
+	}
+
+	key, err := getKey(ref.DID, ref.JobID)
+	if err != nil {
+		return errors.NewTypedError(jobs.ErrInvalidJob, errors.New("reference %d: %v", n, err))
+	}
+
+	if !r.repo.Exists(key) {
+		return errors.NewTypedError(jobs.ErrInvalidJob, errors.New("reference %d: job %s is missing", n, ref.JobID.String()))
+	}
+
+	if !overwrite && r.repo.Exists(getReferenceKey(ref.DID, ref.Key, ref.Value)) {
+		return errors.NewTypedError(jobs.ErrJobExists, errors.New("reference %s=%s of account %s", ref.Key, ref.Value, ref.DID.String()))
+	}
+
+	return r.SaveReference(ref.DID, ref.Key, ref.Value, ref.JobID)
+}
+
+// validateImportedJob checks the decoded job has an ID, an account and a known status
+// and initialises the maps missing from the data.
+func validateImportedJob(job *jobs.Job) error {
+	if jobs.JobIDEqual(job.ID, jobs.NilJobID()) {
+		return errors.New("job ID is missing")
+	}
+
+	if job.DID.Equal(identity.DID{}) {
+		return errors.New("account of job %s is missing", job.ID.String())
+	}
+
+	switch job.Status {
+	case jobs.Pending, jobs.Success, jobs.Failed:
+	default:
+		return errors.New("job %s has an unknown status %q", job.ID.String(), job.Status)
+	}
+
+	if job.TaskStatus == nil {
+		job.TaskStatus = make(map[string]jobs.Status)
+	}
+
+	if job.Values == nil {
+		job.Values = make(map[string]jobs.JobValue)
+	}
+
+	if job.Metadata == nil {
+		job.Metadata = make(map[string]string)
 	}
 
-	return r.repo.Create(key, job)
+	return nil
 }
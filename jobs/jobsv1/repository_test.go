@@ -3,7 +3,10 @@
 package jobsv1
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
@@ -19,6 +22,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack"
 )
 
 var ctx = map[string]interface{}{}
@@ -82,3 +86,199 @@ func TestRepository(t *testing.T) {
 	assert.Equal(t, did, job.DID)
 	assert.Equal(t, jobs.Success, job.Status)
 }
+
+func TestRepository_serializationFormat(t *testing.T) {
+	_, err := NewRepositoryWithFormat(nil, "xml")
+	assert.Error(t, err)
+
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	storage := leveldb.NewLevelDBRepository(db)
+	jsonRepo, err := NewRepositoryWithFormat(storage, FormatJSON)
+	assert.NoError(t, err)
+	msgpackRepo, err := NewRepositoryWithFormat(storage, FormatMsgpack)
+	assert.NoError(t, err)
+
+	newJob := func() *jobs.Job {
+		job := jobs.NewJob(testingidentity.GenerateRandomDID(), "some job")
+		job.Logs = append(job.Logs, jobs.NewLog("action", "message"))
+		job.Values["key"] = jobs.JobValue{Key: "key", Value: []byte("value")}
+		job.Metadata["request_id"] = "request"
+		job.FailureCategory = jobs.FailureTransient
+		return job
+	}
+
+	// both formats are read by either repository
+	jsonJob, mpJob := newJob(), newJob()
+	assert.NoError(t, jsonRepo.Save(jsonJob))
+	assert.NoError(t, msgpackRepo.Save(mpJob))
+	for _, repo := range []jobs.Repository{jsonRepo, msgpackRepo} {
+		for _, job := range []*jobs.Job{jsonJob, mpJob} {
+			got, err := repo.Get(job.DID, job.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, job.ID, got.ID)
+			assert.Equal(t, job.DID, got.DID)
+			assert.Equal(t, job.Values, got.Values)
+			assert.Equal(t, job.Metadata, got.Metadata)
+			assert.Equal(t, job.FailureCategory, got.FailureCategory)
+			assert.True(t, job.CreatedAt.Equal(got.CreatedAt))
+			assert.Len(t, got.Logs, 1)
+			assert.Equal(t, job.Logs[0].Message, got.Logs[0].Message)
+		}
+	}
+
+	var count int
+	assert.NoError(t, jsonRepo.IterateJobs(func(job *jobs.Job) error {
+		count++
+		return nil
+	}))
+	assert.Equal(t, 2, count)
+
+	// msgpack jobs are saved as is, after their header
+	key, err := getKey(mpJob.DID, mpJob.ID)
+	assert.NoError(t, err)
+	data, err := db.Get(key, nil)
+	assert.NoError(t, err)
+	enc, err := msgpack.Marshal(mpJob)
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{msgpackHeader}, enc...), data)
+
+	// unknown header
+	assert.NoError(t, db.Put(key, append([]byte{0x02}, enc...), nil))
+	_, err = jsonRepo.Get(mpJob.DID, mpJob.ID)
+	assert.Error(t, err)
+}
+
+func TestRepository_StreamAndImportJobs(t *testing.T) {
+	newRepo := func() jobs.Repository {
+		db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+		assert.NoError(t, err)
+		return NewRepository(leveldb.NewLevelDBRepository(db))
+	}
+
+	repo := newRepo()
+	job1 := jobs.NewJob(testingidentity.GenerateRandomDID(), "job 1")
+	job1.Logs = append(job1.Logs, jobs.NewLog("action", "message"))
+	job2 := jobs.NewJob(testingidentity.GenerateRandomDID(), "job 2")
+	job2.Status = jobs.Success
+	assert.NoError(t, repo.Save(job1))
+	assert.NoError(t, repo.Save(job2))
+
+	// references are streamed after the jobs
+	assert.NoError(t, repo.SaveReference(job1.DID, "key", "value", job1.ID))
+	assert.NoError(t, repo.SaveReference(job2.DID, "nft_mint_document_id", "0x1212", job2.ID))
+
+	var buf bytes.Buffer
+	assert.NoError(t, repo.StreamAllJobs(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.Contains(t, lines[2], `"Reference"`)
+	assert.Contains(t, lines[3], `"Reference"`)
+
+	// restore into an empty repository
+	repo = newRepo()
+	dump := buf.String()
+	assert.NoError(t, repo.ImportJobs(strings.NewReader(dump)))
+	job, err := repo.Get(job1.DID, job1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, job1.Description, job.Description)
+	assert.Len(t, job.Logs, 1)
+	job, err = repo.Get(job2.DID, job2.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Success, job.Status)
+	job, err = repo.GetByReference(job1.DID, "key", "value")
+	assert.NoError(t, err)
+	assert.Equal(t, job1.ID, job.ID)
+	job, err = repo.GetByReference(job2.DID, "nft_mint_document_id", "0x1212")
+	assert.NoError(t, err)
+	assert.Equal(t, job2.ID, job.ID)
+
+	// existing jobs are only overwritten on request
+	job2.Status = jobs.Failed
+	assert.NoError(t, repo.Save(job2))
+	err = repo.ImportJobs(strings.NewReader(dump))
+	assert.True(t, errors.IsOfType(jobs.ErrJobExists, err))
+	job, err = repo.Get(job2.DID, job2.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.NoError(t, repo.ImportJobsOverwrite(strings.NewReader(dump)))
+	job, err = repo.Get(job2.DID, job2.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Success, job.Status)
+
+	// invalid data
+	err = repo.ImportJobs(strings.NewReader("not a job"))
+	assert.Error(t, err)
+	encode := func(modify func(job *jobs.Job)) *strings.Reader {
+		job := jobs.NewJob(job1.DID, "imported")
+		modify(job)
+		data, err := json.Marshal(job)
+		assert.NoError(t, err)
+		return strings.NewReader(string(data))
+	}
+	for _, modify := range []func(job *jobs.Job){
+		func(job *jobs.Job) { job.ID = jobs.NilJobID() },
+		func(job *jobs.Job) { job.DID = identity.DID{} },
+		func(job *jobs.Job) { job.Status = "unknown" },
+	} {
+		err = repo.ImportJobs(encode(modify))
+		assert.True(t, errors.IsOfType(jobs.ErrInvalidJob, err))
+	}
+
+	// invalid references
+	encodeRef := func(ref jobReference) *strings.Reader {
+		data, err := json.Marshal(streamedReference{Reference: &ref})
+		assert.NoError(t, err)
+		return strings.NewReader(string(data))
+	}
+	for _, ref := range []jobReference{
+		{JobID: job1.ID, DID: job1.DID, Value: "value"},
+		{JobID: job1.ID, Key: "key", Value: "value"},
+		{JobID: jobs.NewJobID(), DID: job1.DID, Key: "key", Value: "value"},
+	} {
+		err = repo.ImportJobs(encodeRef(ref))
+		assert.True(t, errors.IsOfType(jobs.ErrInvalidJob, err))
+	}
+	err = repo.ImportJobs(encodeRef(jobReference{JobID: job1.ID, DID: job1.DID, Key: "key", Value: "value"}))
+	assert.True(t, errors.IsOfType(jobs.ErrJobExists, err))
+
+	// missing maps are initialised
+	var id jobs.JobID
+	assert.NoError(t, repo.ImportJobs(encode(func(job *jobs.Job) {
+		id = job.ID
+		job.Values, job.TaskStatus, job.Metadata = nil, nil, nil
+	})))
+	job, err = repo.Get(job1.DID, id)
+	assert.NoError(t, err)
+	assert.NotNil(t, job.Values)
+	assert.NotNil(t, job.TaskStatus)
+	assert.NotNil(t, job.Metadata)
+}
+
+func TestRepository_Reference(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+
+	// missing reference
+	_, err := repo.GetByReference(did, "key", "value")
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	job1 := jobs.NewJob(did, "job 1")
+	assert.NoError(t, repo.Save(job1))
+	assert.NoError(t, repo.SaveReference(did, "key", "value", job1.ID))
+	job, err := repo.GetByReference(did, "key", "value")
+	assert.NoError(t, err)
+	assert.Equal(t, job1.ID, job.ID)
+
+	// reference belongs to the identity
+	_, err = repo.GetByReference(testingidentity.GenerateRandomDID(), "key", "value")
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	// reference moved to the latest job
+	job2 := jobs.NewJob(did, "job 2")
+	assert.NoError(t, repo.Save(job2))
+	assert.NoError(t, repo.SaveReference(did, "key", "value", job2.ID))
+	job, err = repo.GetByReference(did, "key", "value")
+	assert.NoError(t, err)
+	assert.Equal(t, job2.ID, job.ID)
+}
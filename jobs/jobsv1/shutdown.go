@@ -0,0 +1,72 @@
+package jobsv1
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+)
+
+// Name returns the name of the job manager server.
+func (s *manager) Name() string {
+	return "JobManager"
+}
+
+// Start waits for the node to shut down and stops the jobs running on this node.
+func (s *manager) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	<-ctx.Done()
+	s.Stop(s.config.GetJobShutdownGracePeriod())
+}
+
+// Stop waits for the jobs running on this node to complete within the grace period and cancels the rest.
+// The returned shutdown report is logged as well.
+func (s *manager) Stop(grace time.Duration) jobs.ShutdownReport {
+	s.doneMu.Lock()
+	routines := make([]*jobRoutine, 0, len(s.doneChans))
+	for _, r := range s.doneChans {
+		routines = append(routines, r)
+	}
+	s.doneMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	var report jobs.ShutdownReport
+	for _, r := range routines {
+		select {
+		case <-r.done:
+			report.Completed++
+			continue
+		case <-ctx.Done():
+		}
+
+		// the routine may have finished while the grace period elapsed
+		select {
+		case <-r.done:
+			report.Completed++
+			continue
+		default:
+		}
+
+		err := s.CancelJob(context.Background(), r.accountID, r.id, jobs.CancelShutdown)
+		switch {
+		case err == nil:
+			report.Cancelled++
+		case errors.IsOfType(jobs.ErrJobNotPending, err):
+			// the job completed, only its routine is still wrapping up
+			report.Completed++
+		default:
+			log.Errorf("failed to cancel job %s on shutdown: %v", r.id.String(), err)
+			report.Pending++
+		}
+	}
+
+	log.Infow("jobs shutdown report",
+		"grace_period", grace.String(),
+		"completed", report.Completed,
+		"cancelled", report.Cancelled,
+		"pending", report.Pending)
+	return report
+}
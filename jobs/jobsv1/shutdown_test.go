@@ -0,0 +1,74 @@
+// +build unit
+
+package jobsv1
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_Stop(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{}, msrv.repo)
+	mngr.notifier = nil
+
+	// nothing running
+	assert.Equal(t, jobs.ShutdownReport{}, mngr.Stop(time.Second))
+
+	// completes within the grace period
+	release := make(chan struct{})
+	_, quickDone, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "quick", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-release
+		err <- nil
+	})
+	assert.NoError(t, err)
+
+	// still running once the grace period elapsed
+	block := make(chan struct{})
+	slowID, slowDone, err := mngr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "slow", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		<-block
+		err <- nil
+	})
+	assert.NoError(t, err)
+
+	// job record missing, cannot be cancelled
+	missingDone := mngr.registerDone(did, jobs.NewJobID())
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	report := mngr.Stop(200 * time.Millisecond)
+	assert.Equal(t, jobs.ShutdownReport{Completed: 1, Cancelled: 1, Pending: 1}, report)
+	assert.NoError(t, <-quickDone)
+
+	job, err := mngr.GetJob(did, slowID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, job.Status)
+	assert.Equal(t, jobs.CancelShutdown, job.CancelReason)
+	close(block)
+	assert.True(t, errors.IsOfType(jobs.ErrJobCancelled, <-slowDone))
+	missingDone(nil)
+}
+
+func TestManager_Start(t *testing.T) {
+	msrv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := newManager(&mockConfig{gracePeriod: time.Second}, msrv.repo)
+	assert.Equal(t, "JobManager", mngr.Name())
+
+	cctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go mngr.Start(cctx, &wg, make(chan error))
+	cancel()
+	wg.Wait()
+}
@@ -0,0 +1,52 @@
+package jobs
+
+// Policies resolving the discrepancies found when the jobs are reconciled with the tasks queued in the backend.
+const (
+	// ReconcileTrustStore trusts the jobs. The queued tasks of the finished jobs are dropped
+	// and the pending jobs without a queued task get their recorded task queued again.
+	ReconcileTrustStore = "store"
+
+	// ReconcileTrustQueue trusts the backend. The finished jobs with a queued task are pending again
+	// so that the task completes them and the pending jobs without a queued task are failed.
+	ReconcileTrustQueue = "queue"
+
+	// ReconcileFail fails the jobs that don't match the backend and drops their queued tasks.
+	ReconcileFail = "fail"
+)
+
+// ValidReconcilePolicy returns true if the policy is one of the reconcile policies.
+func ValidReconcilePolicy(policy string) bool {
+	switch policy {
+	case ReconcileTrustStore, ReconcileTrustQueue, ReconcileFail:
+		return true
+	default:
+		return false
+	}
+}
+
+// TaskBackend is the queue backend the jobs are reconciled with.
+type TaskBackend interface {
+	// QueuedJobs returns the IDs of the jobs with at least one task queued in the backend.
+	QueuedJobs() (map[JobID]struct{}, error)
+
+	// DropJobTasks drops the queued tasks of the job and returns the number of tasks dropped.
+	DropJobTasks(id JobID) (int, error)
+
+	// RequeueJobTask queues the task of the job again.
+	RequeueJobTask(task JobTask) error
+}
+
+// ReconcileReport counts how the discrepancies between the jobs and the backend were resolved.
+type ReconcileReport struct {
+	// Dropped is the number of queued tasks dropped
+	Dropped int
+
+	// Reopened is the number of finished jobs moved back to pending
+	Reopened int
+
+	// Requeued is the number of pending jobs whose task was queued again
+	Requeued int
+
+	// Failed is the number of jobs failed
+	Failed int
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/jobs"
+)
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap registers the collectors of the job manager, the queue server and the nft service into one registry.
+// Must run after the subsystems are bootstrapped.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	var providers []Provider
+	for _, key := range []string{jobs.BootstrappedService, bootstrap.BootstrappedQueueServer, bootstrap.BootstrappedNFTService} {
+		if p, ok := ctx[key].(Provider); ok {
+			providers = append(providers, p)
+		}
+	}
+
+	reg, err := NewRegistry(providers...)
+	if err != nil {
+		return err
+	}
+
+	ctx[BootstrappedRegistry] = reg
+	return nil
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// Namespace prefixes the names of the metrics of all the subsystems.
+	Namespace = "centrifuge"
+
+	// BootstrappedRegistry is the key to the *prometheus.Registry holding the metrics of the subsystems.
+	BootstrappedRegistry = "BootstrappedMetricsRegistry"
+)
+
+// Provider is implemented by the subsystems exposing metrics.
+// The collectors are named Namespace_<subsystem>_<name> so that all the metrics are labeled consistently.
+type Provider interface {
+
+	// Collectors returns the collectors of the subsystem
+	Collectors() []prometheus.Collector
+}
+
+// NewRegistry returns a registry with the collectors of the providers registered.
+func NewRegistry(providers ...Provider) (*prometheus.Registry, error) {
+	reg := prometheus.NewRegistry()
+	for _, p := range providers {
+		for _, c := range p.Collectors() {
+			if err := reg.Register(c); err != nil {
+				return nil, errors.New("failed to register metrics collector: %v", err)
+			}
+		}
+	}
+
+	return reg, nil
+}
+
+// Handler returns the handler serving the metrics of the registry in the Prometheus exposition format.
+func Handler(reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,61 @@
+// +build unit
+
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+type provider struct {
+	collectors []prometheus.Collector
+}
+
+func (p provider) Collectors() []prometheus.Collector {
+	return p.collectors
+}
+
+func newCounter(subsystem string) prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: subsystem,
+		Name:      "events_total",
+		Help:      "Number of events.",
+	})
+}
+
+func TestNewRegistry(t *testing.T) {
+	jobsCounter, queueCounter := newCounter("jobs"), newCounter("queue")
+	jobsCounter.Inc()
+	reg, err := NewRegistry(provider{[]prometheus.Collector{jobsCounter}}, provider{[]prometheus.Collector{queueCounter}})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	Handler(reg).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, w.Body.String(), "centrifuge_jobs_events_total 1")
+	assert.Contains(t, w.Body.String(), "centrifuge_queue_events_total 0")
+
+	// collectors registered twice
+	_, err = NewRegistry(provider{[]prometheus.Collector{jobsCounter}}, provider{[]prometheus.Collector{jobsCounter}})
+	assert.Error(t, err)
+}
+
+func TestBootstrapper_Bootstrap(t *testing.T) {
+	ctx := map[string]interface{}{
+		jobs.BootstrappedService:          provider{[]prometheus.Collector{newCounter("jobs")}},
+		bootstrap.BootstrappedQueueServer: provider{[]prometheus.Collector{newCounter("queue")}},
+		// subsystems without metrics are skipped
+		bootstrap.BootstrappedNFTService: struct{}{},
+	}
+	assert.NoError(t, Bootstrapper{}.Bootstrap(ctx))
+	reg, ok := ctx[BootstrappedRegistry].(*prometheus.Registry)
+	assert.True(t, ok)
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+	assert.Len(t, families, 2)
+}
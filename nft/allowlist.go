@@ -0,0 +1,67 @@
+package nft
+
+import (
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// RegistryMethodMint is the registry method minting the tokens
+	RegistryMethodMint = "mint"
+
+	// RegistryMethodBurn is the registry method burning the tokens
+	RegistryMethodBurn = "burn"
+
+	// RegistryMethodTransfer is the registry method transferring the tokens
+	RegistryMethodTransfer = "transfer"
+
+	// ErrRegistryMethodNotAllowed error when the registry method is not allowed to be called on the registry
+	ErrRegistryMethodNotAllowed = errors.Error("registry method not allowed")
+
+	// ErrUnknownRegistryMethod error when the allowed methods of a registry list an unknown method
+	ErrUnknownRegistryMethod = errors.Error("unknown registry method")
+)
+
+// registryMethods holds the methods allowed to be called keyed by the lower cased registry address.
+// Registries that are not listed only allow the mint.
+type registryMethods map[string]map[string]struct{}
+
+// newRegistryMethods returns the allowed registry methods as configured.
+func newRegistryMethods(cfg map[string][]string) (registryMethods, error) {
+	rms := make(registryMethods)
+	for registry, methods := range cfg {
+		if !common.IsHexAddress(registry) {
+			return nil, errors.New("invalid registry address %s", registry)
+		}
+
+		allowed := make(map[string]struct{})
+		for _, method := range methods {
+			switch method {
+			case RegistryMethodMint, RegistryMethodBurn, RegistryMethodTransfer:
+				allowed[method] = struct{}{}
+			default:
+				return nil, errors.NewTypedError(ErrUnknownRegistryMethod, errors.New("method %s for registry %s", method, registry))
+			}
+		}
+
+		rms[strings.ToLower(registry)] = allowed
+	}
+
+	return rms, nil
+}
+
+// check returns an error if the method is not allowed to be called on the registry.
+func (rms registryMethods) check(registry common.Address, method string) error {
+	allowed, ok := rms[strings.ToLower(registry.Hex())]
+	if !ok {
+		allowed = map[string]struct{}{RegistryMethodMint: {}}
+	}
+
+	if _, ok := allowed[method]; !ok {
+		return errors.NewTypedError(ErrRegistryMethodNotAllowed, errors.New("method %s for registry %s", method, registry.Hex()))
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// +build unit
+
+package nft
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRegistryMethods(t *testing.T) {
+	// unknown method
+	_, err := newRegistryMethods(map[string][]string{
+		"0x111855759a39fb75fc7341139f5d7a3974d4da08": {RegistryMethodMint, "approve"},
+	})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrUnknownRegistryMethod, err))
+
+	// invalid registry
+	_, err = newRegistryMethods(map[string][]string{"invalid": {RegistryMethodMint}})
+	assert.Error(t, err)
+
+	rms, err := newRegistryMethods(map[string][]string{
+		"0x111855759A39FB75FC7341139F5D7A3974D4DA08": {RegistryMethodTransfer, RegistryMethodBurn},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rms, 1)
+}
+
+func TestRegistryMethods_check(t *testing.T) {
+	listed := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	unlisted := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	rms, err := newRegistryMethods(map[string][]string{
+		"0x111855759a39fb75fc7341139f5d7a3974d4da08": {RegistryMethodTransfer, RegistryMethodBurn},
+	})
+	assert.NoError(t, err)
+
+	// listed registries only allow the listed methods
+	assert.NoError(t, rms.check(listed, RegistryMethodTransfer))
+	assert.NoError(t, rms.check(listed, RegistryMethodBurn))
+	err = rms.check(listed, RegistryMethodMint)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrRegistryMethodNotAllowed, err))
+
+	// unlisted registries default to mint only
+	assert.NoError(t, rms.check(unlisted, RegistryMethodMint))
+	assert.True(t, errors.IsOfType(ErrRegistryMethodNotAllowed, rms.check(unlisted, RegistryMethodTransfer)))
+	assert.True(t, errors.IsOfType(ErrRegistryMethodNotAllowed, rms.check(unlisted, RegistryMethodBurn)))
+
+	// no configuration
+	var none registryMethods
+	assert.NoError(t, none.check(listed, RegistryMethodMint))
+	assert.Error(t, none.check(listed, RegistryMethodTransfer))
+}
@@ -0,0 +1,97 @@
+package nft
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// anchorStepName is the task of an anchor and mint job anchoring the document
+	anchorStepName = "nft_anchor_document"
+
+	// mintStepName is the task of an anchor and mint job minting the NFT
+	mintStepName = "nft_mint"
+)
+
+// anchorAndMintJob anchors the current version of the document if it isn't anchored yet and mints the NFT once the
+// anchor is confirmed. Each step is tracked in the task status of the job, a failure of either step fails the job.
+// The work can be run again within the same job, such as after a restart of the node, and resumes from the first
+// step the job didn't complete.
+func (s *service) anchorAndMintJob(ctx context.Context, tokenID TokenID, model documents.Model, req MintNFTRequest) func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	return func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		current := model
+		if current.GetStatus() != documents.Committed {
+			skipped, err := runStep(txMan, accountID, jobID, anchorStepName, func() error {
+				jobCtx := contextutil.WithJob(ctx, jobID)
+				_, done, err := documents.CreateAnchorJob(jobCtx, txMan, s.queue, accountID, jobID, current.ID(), current.CurrentVersion())
+				if err != nil {
+					return err
+				}
+
+				if err := <-done; err != nil {
+					return err
+				}
+
+				// the mint builds on the anchored version
+				current, err = s.docSrv.GetCurrentVersion(ctx, req.DocumentID)
+				return err
+			})
+			if err == nil && skipped {
+				// anchored by an earlier run of the job
+				current, err = s.docSrv.GetCurrentVersion(ctx, req.DocumentID)
+			}
+			if err != nil {
+				errOut <- errors.New("anchor step failed for document %s: %v", hexutil.Encode(req.DocumentID), err)
+				return
+			}
+		}
+
+		_, err := runStep(txMan, accountID, jobID, mintStepName, func() error {
+			mintErr := make(chan error, 1)
+			s.minterJob(ctx, tokenID, current, req)(accountID, jobID, txMan, mintErr)
+			return <-mintErr
+		})
+		if err != nil {
+			errOut <- errors.New("mint step failed for document %s: %v", hexutil.Encode(req.DocumentID), err)
+			return
+		}
+
+		errOut <- nil
+	}
+}
+
+// runStep runs the step of the job and records its outcome in the task status of the job.
+// The step is skipped and true is returned if the task status of the job records it as successful already.
+// Steps left started or failed by an earlier run are run again, so they must be safe to repeat.
+func runStep(txMan jobs.Manager, accountID identity.DID, jobID jobs.JobID, stepName string, step func() error) (skipped bool, err error) {
+	job, err := txMan.GetJob(accountID, jobID)
+	if err != nil {
+		return false, err
+	}
+
+	if job.TaskStatus[stepName] == jobs.Success {
+		return true, nil
+	}
+
+	err = txMan.UpdateTaskStatus(accountID, jobID, jobs.Pending, stepName, "started")
+	if err != nil {
+		return false, err
+	}
+
+	err = step()
+	if err != nil {
+		if serr := txMan.UpdateTaskStatus(accountID, jobID, jobs.Failed, stepName, err.Error()); serr != nil {
+			return false, errors.AppendError(err, serr)
+		}
+
+		return false, err
+	}
+
+	return false, txMan.UpdateTaskStatus(accountID, jobID, jobs.Success, stepName, "")
+}
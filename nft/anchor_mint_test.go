@@ -0,0 +1,119 @@
+// +build unit
+
+package nft
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils"
+	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newGenericModel(t *testing.T, status documents.Status) documents.Model {
+	cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	cd.Status = status
+	return &generic.Generic{CoreDocument: cd}
+}
+
+func TestService_anchorAndMintJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	jobID := jobs.NewJobID()
+	req := MintNFTRequest{DocumentID: []byte{1, 2}}
+	done := func(err error) chan error {
+		done := make(chan error, 1)
+		done <- err
+		return done
+	}
+
+	// anchor fails, the mint is skipped
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("GetJob", did, jobID).Return(jobs.NewJob(did, "Minting NFT"), nil)
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Pending, anchorStepName, "started").Return(nil).Once()
+	jobMan.On("ExecuteWithinJob", mock.Anything, did, jobID, mock.Anything, mock.Anything).Return(jobID, done(errors.New("anchor failed")), nil).Once()
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Failed, anchorStepName, "anchor failed").Return(nil).Once()
+	srv := newService(nil, nil, nil, new(testingutils.MockQueue), nil, nil, jobMan, nil, nil)
+	errOut := make(chan error, 1)
+	srv.anchorAndMintJob(context.Background(), NewTokenID(), newGenericModel(t, documents.Committing), req)(did, jobID, jobMan, errOut)
+	err := <-errOut
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "anchor step failed for document 0x0102: anchor failed")
+	jobMan.AssertExpectations(t)
+
+	// anchored, the mint fails on the anchored version
+	anchored := newGenericModel(t, documents.Committed)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", req.DocumentID).Return(anchored, nil).Once()
+	docSrv.On("Update", mock.Anything, anchored).Return(nil, nil, errors.New("update failed")).Once()
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("GetJob", did, jobID).Return(jobs.NewJob(did, "Minting NFT"), nil)
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Pending, anchorStepName, "started").Return(nil).Once()
+	jobMan.On("ExecuteWithinJob", mock.Anything, did, jobID, mock.Anything, mock.Anything).Return(jobID, done(nil), nil).Once()
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Success, anchorStepName, "").Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Pending, mintStepName, "started").Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Failed, mintStepName, "update failed").Return(nil).Once()
+	srv = newService(nil, nil, nil, new(testingutils.MockQueue), docSrv, nil, jobMan, nil, nil)
+	srv.anchorAndMintJob(context.Background(), NewTokenID(), newGenericModel(t, documents.Committing), req)(did, jobID, jobMan, errOut)
+	err = <-errOut
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "mint step failed for document 0x0102: update failed")
+	jobMan.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+
+	// already anchored documents are minted right away
+	anchored = newGenericModel(t, documents.Committed)
+	docSrv = new(testingdocuments.MockService)
+	docSrv.On("Update", mock.Anything, anchored).Return(nil, nil, errors.New("update failed")).Once()
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("GetJob", did, jobID).Return(jobs.NewJob(did, "Minting NFT"), nil)
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Pending, mintStepName, "started").Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Failed, mintStepName, "update failed").Return(nil).Once()
+	srv = newService(nil, nil, nil, new(testingutils.MockQueue), docSrv, nil, jobMan, nil, nil)
+	srv.anchorAndMintJob(context.Background(), NewTokenID(), anchored, req)(did, jobID, jobMan, errOut)
+	err = <-errOut
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "mint step failed")
+	jobMan.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+
+	// resumed after the anchor, the mint started before the restart runs again on the anchored version
+	job := jobs.NewJob(did, "Minting NFT")
+	job.TaskStatus[anchorStepName] = jobs.Success
+	job.TaskStatus[mintStepName] = jobs.Pending
+	anchored = newGenericModel(t, documents.Committed)
+	docSrv = new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", req.DocumentID).Return(anchored, nil).Once()
+	docSrv.On("Update", mock.Anything, anchored).Return(nil, nil, errors.New("update failed")).Once()
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("GetJob", did, jobID).Return(job, nil)
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Pending, mintStepName, "started").Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, jobID, jobs.Failed, mintStepName, "update failed").Return(nil).Once()
+	srv = newService(nil, nil, nil, new(testingutils.MockQueue), docSrv, nil, jobMan, nil, nil)
+	srv.anchorAndMintJob(context.Background(), NewTokenID(), newGenericModel(t, documents.Committing), req)(did, jobID, jobMan, errOut)
+	err = <-errOut
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "mint step failed")
+	jobMan.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+
+	// resumed after the mint, nothing is run again
+	job.TaskStatus[mintStepName] = jobs.Success
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("GetJob", did, jobID).Return(job, nil)
+	docSrv = new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", req.DocumentID).Return(anchored, nil).Once()
+	srv = newService(nil, nil, nil, new(testingutils.MockQueue), docSrv, nil, jobMan, nil, nil)
+	srv.anchorAndMintJob(context.Background(), NewTokenID(), newGenericModel(t, documents.Committing), req)(did, jobID, jobMan, errOut)
+	assert.NoError(t, <-errOut)
+	jobMan.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
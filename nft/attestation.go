@@ -0,0 +1,101 @@
+package nft
+
+import (
+	"context"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto/secp256k1"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// AttestationSchemeEthSign signs the keccak256 hash of the attestation prefixed as an ethereum signed message
+	AttestationSchemeEthSign = "eth_sign"
+
+	// AttestationSchemeSecp256k1 signs the plain keccak256 hash of the attestation
+	AttestationSchemeSecp256k1 = "secp256k1"
+
+	// AttestationKey is the job value key of the mint attestation
+	AttestationKey = "mint_attestation"
+
+	// AttestationSignatureKey is the job value key of the mint attestation signature
+	AttestationSignatureKey = "mint_attestation_signature"
+
+	// ErrUnsupportedAttestationScheme error when the attestation scheme configured for a registry is unknown
+	ErrUnsupportedAttestationScheme = errors.Error("unsupported attestation scheme")
+
+	// AttestedMintMethodABI constant interface to interact with the mint methods of the registries requiring an attestation
+	AttestedMintMethodABI = `[{"constant":false,"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tkn","type":"uint256"},{"internalType":"bytes32","name":"dataRoot","type":"bytes32"},{"internalType":"bytes[]","name":"properties","type":"bytes[]"},{"internalType":"bytes[]","name":"values","type":"bytes[]"},{"internalType":"bytes32[]","name":"salts","type":"bytes32[]"},{"internalType":"bytes","name":"attestation","type":"bytes"},{"internalType":"bytes","name":"signature","type":"bytes"}],"name":"mint","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+)
+
+// attestationScheme returns the attestation scheme configured for the registry.
+// Empty scheme means the registry doesn't require an attestation.
+func (s *service) attestationScheme(registry common.Address) (string, error) {
+	scheme := s.cfg.GetNFTRegistryAttestationSchemes()[strings.ToLower(registry.Hex())]
+	switch scheme {
+	case "", AttestationSchemeEthSign, AttestationSchemeSecp256k1:
+		return scheme, nil
+	default:
+		return "", errors.NewTypedError(ErrUnsupportedAttestationScheme, errors.New("scheme %s for registry %s", scheme, registry.Hex()))
+	}
+}
+
+// newAttestation returns the attestation over the token data.
+// registry(20) + to(20) + tokenID(32) + signingRoot(32) + bundledHash(32)
+func newAttestation(registry common.Address, req MintRequest) []byte {
+	var attestation []byte
+	attestation = append(attestation, registry.Bytes()...)
+	attestation = append(attestation, req.To.Bytes()...)
+	attestation = append(attestation, common.LeftPadBytes(req.TokenID.Bytes(), TokenIDLength)...)
+	attestation = append(attestation, req.SigningRoot[:]...)
+	return append(attestation, req.BundledHash[:]...)
+}
+
+// signAttestation signs the attestation with the signing key of the account as per the scheme.
+func signAttestation(acc config.Account, scheme string, attestation []byte) ([]byte, error) {
+	keys, err := acc.GetKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	key := keys[identity.KeyPurposeSigning.Name].PrivateKey
+	switch scheme {
+	case AttestationSchemeEthSign:
+		return secp256k1.SignEthereum(attestation, key)
+	case AttestationSchemeSecp256k1:
+		return secp256k1.Sign(attestation, key)
+	default:
+		return nil, errors.NewTypedError(ErrUnsupportedAttestationScheme, errors.New("scheme %s", scheme))
+	}
+}
+
+// attest creates and signs the mint attestation and records both on the job.
+func attest(ctx context.Context, jobMan jobs.Manager, accountID identity.DID, jobID jobs.JobID, scheme string, registry common.Address, req MintRequest) (attestation, signature []byte, err error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attestation = newAttestation(registry, req)
+	signature, err = signAttestation(acc, scheme, attestation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = jobMan.UpdateJobWithValue(accountID, jobID, AttestationKey, attestation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = jobMan.UpdateJobWithValue(accountID, jobID, AttestationSignatureKey, signature)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attestation, signature, nil
+}
@@ -0,0 +1,70 @@
+// +build unit
+
+package nft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto/secp256k1"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_attestationScheme(t *testing.T) {
+	configMock := &testingconfig.MockConfig{}
+	configMock.On("GetNFTRegistryAttestationSchemes").Return(map[string]string{
+		"0x111855759a39fb75fc7341139f5d7a3974d4da08": AttestationSchemeEthSign,
+		"0x222855759a39fb75fc7341139f5d7a3974d4da08": "rsa",
+	})
+	service := newService(configMock, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	scheme, err := service.attestationScheme(common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08"))
+	assert.NoError(t, err)
+	assert.Equal(t, AttestationSchemeEthSign, scheme)
+
+	// registry without attestation
+	scheme, err = service.attestationScheme(common.HexToAddress("0x333855759a39fb75fc7341139f5d7a3974d4da08"))
+	assert.NoError(t, err)
+	assert.Empty(t, scheme)
+
+	_, err = service.attestationScheme(common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08"))
+	assert.True(t, errors.IsOfType(ErrUnsupportedAttestationScheme, err))
+}
+
+func TestSignAttestation(t *testing.T) {
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	req := MintRequest{
+		To:      common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08"),
+		TokenID: big.NewInt(1),
+	}
+	copy(req.SigningRoot[:], utils.RandomSlice(32))
+	copy(req.BundledHash[:], utils.RandomSlice(32))
+	attestation := newAttestation(registry, req)
+	assert.Len(t, attestation, 2*common.AddressLength+3*32)
+	assert.Equal(t, req.BundledHash[:], attestation[len(attestation)-32:])
+
+	acc, err := contextutil.Account(testingconfig.CreateAccountContext(t, cfg))
+	assert.NoError(t, err)
+	keys, err := acc.GetKeys()
+	assert.NoError(t, err)
+	address := common.BytesToAddress(keys[identity.KeyPurposeSigning.Name].PublicKey)
+
+	signature, err := signAttestation(acc, AttestationSchemeEthSign, attestation)
+	assert.NoError(t, err)
+	assert.True(t, secp256k1.VerifySignatureWithAddress(address.Hex(), hexutil.Encode(signature), attestation))
+
+	signature, err = signAttestation(acc, AttestationSchemeSecp256k1, attestation)
+	assert.NoError(t, err)
+	assert.Len(t, signature, 65)
+	assert.False(t, secp256k1.VerifySignatureWithAddress(address.Hex(), hexutil.Encode(signature), attestation))
+
+	_, err = signAttestation(acc, "rsa", attestation)
+	assert.True(t, errors.IsOfType(ErrUnsupportedAttestationScheme, err))
+}
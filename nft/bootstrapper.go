@@ -53,6 +53,16 @@ func (*Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("transactions repository not initialised")
 	}
 
+	methods, err := newRegistryMethods(cfg.GetNFTRegistryMethods())
+	if err != nil {
+		return err
+	}
+
+	events, err := newRegistryEvents(cfg.GetNFTRegistryEvents())
+	if err != nil {
+		return err
+	}
+
 	client := ethereum.GetClient()
 	nftSrv := newService(
 		cfg,
@@ -74,6 +84,15 @@ func (*Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 
 			return h.Number.Uint64(), nil
 		})
+	nftSrv.methods = methods
+	nftSrv.events = events
+
+	if cfg.GetNFTPrebindRegistries() {
+		pctx, cancel := context.WithTimeout(context.Background(), cfg.GetEthereumContextWaitTimeout())
+		nftSrv.prebindRegistries(pctx)
+		cancel()
+	}
+
 	ctx[bootstrap.BootstrappedNFTService] = nftSrv
 	return nil
 }
@@ -0,0 +1,128 @@
+package nft
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// mintRegistryKey is the job value key of the registry the mint job mints in
+const mintRegistryKey = "nft_mint_registry"
+
+// EnsureMinted mints the NFT of the document in the registry of the request unless it is minted or being minted.
+// The pending mint of the document is returned with a done channel that reports its outcome. The token of a document
+// minted already is returned with its mint job, if the latest mint job of the document minted it, and a done channel
+// holding nil. Repeated calls therefore return the same job until the document is minted by another job.
+// The other fields of the request are only used if the NFT is minted by the call.
+func (s *service) EnsureMinted(ctx context.Context, req MintNFTRequest) (*TokenResponse, chan error, error) {
+	tc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	did, err := identity.NewDIDFromBytes(tc.GetIdentityID())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, done, err := s.existingMint(ctx, did, req)
+	if err != nil || resp != nil {
+		return resp, done, err
+	}
+
+	resp, done, err = s.MintNFT(ctx, req)
+	if !errors.IsOfType(ErrMintInProgress, err) {
+		return resp, done, err
+	}
+
+	// a concurrent call submitted the mint first
+	resp, done, err = s.existingMint(ctx, did, req)
+	if err != nil || resp != nil {
+		return resp, done, err
+	}
+
+	return nil, nil, errors.NewTypedError(ErrMintInProgress, errors.New("document %s", hexutil.Encode(req.DocumentID)))
+}
+
+// existingMint returns the pending mint of the document in the registry or the token the document is minted with.
+// Nil is returned if the document is neither minted nor being minted in the registry.
+func (s *service) existingMint(ctx context.Context, accountID identity.DID, req MintNFTRequest) (*TokenResponse, chan error, error) {
+	job, err := s.jobsManager.GetIndexedJob(accountID, mintJobIndexKey, hexutil.Encode(req.DocumentID))
+	if err != nil && !errors.IsOfType(jobs.ErrJobsMissing, err) {
+		return nil, nil, err
+	}
+
+	if job != nil && job.Status == jobs.Pending {
+		if !mintsIn(job, req.RegistryAddress) {
+			return nil, nil, errors.NewTypedError(ErrMintInProgress, errors.New("document %s is being minted in another registry by job %s", hexutil.Encode(req.DocumentID), job.ID))
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- s.jobsManager.WaitForJob(accountID, job.ID)
+		}()
+
+		return &TokenResponse{JobID: job.ID.String(), TokenID: jobTokenID(job).String()}, done, nil
+	}
+
+	model, err := s.docSrv.GetCurrentVersion(ctx, req.DocumentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenID, ok := s.mintedTokenID(model, req.RegistryAddress)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	resp := &TokenResponse{TokenID: tokenID.String()}
+	if job != nil && job.Status == jobs.Success && mintsIn(job, req.RegistryAddress) && jobTokenID(job) == tokenID {
+		resp.JobID = job.ID.String()
+	}
+
+	done := make(chan error, 1)
+	done <- nil
+	return resp, done, nil
+}
+
+// mintedTokenID returns the token the document is minted with in the registry.
+func (s *service) mintedTokenID(model documents.Model, registry common.Address) (TokenID, bool) {
+	if !model.IsNFTMinted(s, registry) {
+		return TokenID{}, false
+	}
+
+	for _, n := range model.NFTs() {
+		if bytes.Equal(n.RegistryId[:common.AddressLength], registry.Bytes()) {
+			var tokenID TokenID
+			copy(tokenID[:], n.TokenId)
+			return tokenID, true
+		}
+	}
+
+	return TokenID{}, false
+}
+
+// mintsIn returns true if the mint job mints in the registry. Jobs not recording their registry are assumed to.
+func mintsIn(job *jobs.Job, registry common.Address) bool {
+	v, ok := job.Values[mintRegistryKey]
+	return !ok || bytes.Equal(v.Value, registry.Bytes())
+}
+
+// jobTokenID returns the token ID minted by the mint job, the predicted one unless the registry minted another.
+func jobTokenID(job *jobs.Job) TokenID {
+	var tokenID TokenID
+	v, ok := job.Values[MintedTokenIDKey]
+	if !ok {
+		v = job.Values[PredictedTokenIDKey]
+	}
+
+	copy(tokenID[:], v.Value)
+	return tokenID
+}
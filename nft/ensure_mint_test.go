@@ -0,0 +1,100 @@
+// +build unit
+
+package nft
+
+import (
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_EnsureMinted(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	id, err := cfg.GetIdentityID()
+	assert.NoError(t, err)
+	did, err := identity.NewDIDFromBytes(id)
+	assert.NoError(t, err)
+	docID := utils.RandomSlice(32)
+	registry := common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08")
+	req := MintNFTRequest{DocumentID: docID, RegistryAddress: registry}
+	tokenID := NewTokenID()
+	mintJob := func(status jobs.Status, registry common.Address) *jobs.Job {
+		job := jobs.NewJob(did, "Minting NFT")
+		job.Status = status
+		job.Values[PredictedTokenIDKey] = jobs.JobValue{Key: PredictedTokenIDKey, Value: tokenID[:]}
+		job.Values[mintRegistryKey] = jobs.JobValue{Key: mintRegistryKey, Value: registry.Bytes()}
+		return job
+	}
+
+	// pending mint in the registry is returned with its outcome
+	job := mintJob(jobs.Pending, registry)
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("GetIndexedJob", did, mintJobIndexKey, hexutil.Encode(docID)).Return(job, nil).Once()
+	jobMan.On("WaitForJob", did, job.ID).Return(nil).Once()
+	srv := newService(cfg, nil, nil, nil, nil, nil, jobMan, nil, nil)
+	resp, done, err := srv.EnsureMinted(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, &TokenResponse{JobID: job.ID.String(), TokenID: tokenID.String()}, resp)
+	assert.NoError(t, <-done)
+	jobMan.AssertExpectations(t)
+
+	// pending mint in another registry
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("GetIndexedJob", did, mintJobIndexKey, hexutil.Encode(docID)).Return(mintJob(jobs.Pending, common.Address{}), nil).Once()
+	srv = newService(cfg, nil, nil, nil, nil, nil, jobMan, nil, nil)
+	_, _, err = srv.EnsureMinted(ctx, req)
+	assert.True(t, errors.IsOfType(ErrMintInProgress, err))
+
+	// minted by the latest mint job
+	model := new(testingdocuments.MockModel)
+	model.On("IsNFTMinted", mock.Anything, registry).Return(true)
+	model.On("NFTs").Return([]*coredocumentpb.NFT{
+		{RegistryId: common.LeftPadBytes(common.Address{}.Bytes(), 32), TokenId: utils.RandomSlice(32)},
+		{RegistryId: common.RightPadBytes(registry.Bytes(), 32), TokenId: tokenID[:]},
+	})
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", docID).Return(model, nil)
+	job = mintJob(jobs.Success, registry)
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("GetIndexedJob", did, mintJobIndexKey, hexutil.Encode(docID)).Return(job, nil).Twice()
+	srv = newService(cfg, nil, nil, nil, docSrv, nil, jobMan, nil, nil)
+	for i := 0; i < 2; i++ {
+		resp, done, err = srv.EnsureMinted(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, &TokenResponse{JobID: job.ID.String(), TokenID: tokenID.String()}, resp)
+		assert.NoError(t, <-done)
+	}
+	jobMan.AssertExpectations(t)
+
+	// minted by another job
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("GetIndexedJob", did, mintJobIndexKey, hexutil.Encode(docID)).Return(nil, jobs.ErrJobsMissing).Once()
+	srv = newService(cfg, nil, nil, nil, docSrv, nil, jobMan, nil, nil)
+	resp, _, err = srv.EnsureMinted(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, &TokenResponse{TokenID: tokenID.String()}, resp)
+
+	// neither minted nor being minted
+	model = new(testingdocuments.MockModel)
+	model.On("IsNFTMinted", mock.Anything, registry).Return(false)
+	docSrv = new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", docID).Return(model, nil)
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("GetIndexedJob", did, mintJobIndexKey, hexutil.Encode(docID)).Return(mintJob(jobs.Failed, registry), nil).Once()
+	srv = newService(cfg, nil, nil, nil, docSrv, nil, jobMan, nil, nil)
+	resp, done, err = srv.existingMint(ctx, did, req)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+	assert.Nil(t, done)
+}
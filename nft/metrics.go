@@ -0,0 +1,45 @@
+package nft
+
+import (
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	mints = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "nft",
+		Name:      "mints_total",
+		Help:      "Number of mint jobs done by result.",
+	}, []string{"result"})
+
+	staleOwnerReads = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "nft",
+		Name:      "stale_owner_reads_total",
+		Help:      "Number of owner reads served from the read cache as the chain could not be read.",
+	})
+)
+
+// Collectors returns the collectors of the nft metrics.
+func (s *service) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{mints, staleOwnerReads}
+}
+
+// countMint counts the result of the mint work.
+func countMint(work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error)) func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	return func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		res := make(chan error, 1)
+		work(accountID, jobID, txMan, res)
+		err := <-res
+		result := "success"
+		if err != nil {
+			result = "failure"
+		}
+
+		mints.WithLabelValues(result).Inc()
+		errOut <- err
+	}
+}
@@ -3,8 +3,11 @@ package nft
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -93,6 +96,17 @@ type MintNFTRequest struct {
 	GrantNFTReadAccess       bool
 	SubmitTokenProof         bool
 	SubmitNFTReadAccessProof bool
+
+	// Deadline after which the mint is abandoned and the job fails. Zero means no deadline.
+	Deadline time.Time
+
+	// PropertyMapping maps the token property slots of the registry to the document fields proven for them.
+	// Required for the registries with a configured property schema.
+	PropertyMapping map[string]string
+
+	// AnchorDocument anchors the current version of the document within the mint job if it isn't anchored yet.
+	// Without it, minting a version which isn't anchored fails with ErrDocumentNotAnchored.
+	AnchorDocument bool
 }
 
 // Service defines the NFT service to mint and transfer NFTs.
@@ -103,6 +117,12 @@ type Service interface {
 	TransferFrom(ctx context.Context, registry common.Address, to common.Address, tokenID TokenID) (*TokenResponse, chan error, error)
 	// OwnerOf returns the owner of an NFT
 	OwnerOf(registry common.Address, tokenID []byte) (owner common.Address, err error)
+	// CachedOwnerOf returns the owner of an NFT, stale is true if the chain is unreachable and the last known owner is returned
+	CachedOwnerOf(registry common.Address, tokenID []byte) (owner common.Address, stale bool, err error)
+	// IsMintInProgress returns true and the ID of the mint job if a mint of the document by the account is pending
+	IsMintInProgress(accountID identity.DID, documentID []byte) (bool, jobs.JobID, error)
+	// EnsureMinted returns the existing or pending mint of the document in the registry and mints the NFT otherwise
+	EnsureMinted(ctx context.Context, request MintNFTRequest) (*TokenResponse, chan error, error)
 }
 
 // TokenResponse holds tokenID and transaction ID.
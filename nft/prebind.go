@@ -0,0 +1,59 @@
+package nft
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// configuredRegistries returns the registries configured with a property schema, an attestation scheme or allowed methods.
+func (s *service) configuredRegistries() []common.Address {
+	seen := make(map[string]struct{})
+	for registry := range s.cfg.GetNFTRegistryProperties() {
+		seen[registry] = struct{}{}
+	}
+
+	for registry := range s.cfg.GetNFTRegistryAttestationSchemes() {
+		seen[registry] = struct{}{}
+	}
+
+	for registry := range s.methods {
+		seen[registry] = struct{}{}
+	}
+
+	var registries []common.Address
+	for registry := range seen {
+		if !common.IsHexAddress(registry) {
+			log.Warningf("skipping invalid registry address %s", registry)
+			continue
+		}
+
+		registries = append(registries, common.HexToAddress(registry))
+	}
+
+	sort.Slice(registries, func(i, j int) bool {
+		return registries[i].Hex() < registries[j].Hex()
+	})
+	return registries
+}
+
+// prebindRegistries binds the contracts of the configured registries that are deployed on chain.
+// Failures are only logged so that the node starts regardless, the contracts are then bound on first use.
+func (s *service) prebindRegistries(ctx context.Context) {
+	for _, registry := range s.configuredRegistries() {
+		code, err := s.ethClient.GetEthClient().CodeAt(ctx, registry, nil)
+		if err != nil {
+			log.Warningf("failed to prebind registry %s: %v", registry.Hex(), err)
+			continue
+		}
+
+		if len(code) == 0 {
+			log.Warningf("failed to prebind registry %s: no contract deployed", registry.Hex())
+			continue
+		}
+
+		s.registryContract(registry)
+		log.Infof("Prebound registry %s", registry.Hex())
+	}
+}
@@ -0,0 +1,78 @@
+//go:build unit
+// +build unit
+
+package nft
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type codeClient struct {
+	ethereum.Client
+	backend *codeBackend
+}
+
+func (c *codeClient) GetEthClient() ethereum.EthClient {
+	return c.backend
+}
+
+// codeBackend returns the code of the deployed contracts, unreachable registries fail.
+type codeBackend struct {
+	ethereum.EthClient
+	code        map[common.Address][]byte
+	unreachable map[common.Address]bool
+}
+
+func (c *codeBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if c.unreachable[contract] {
+		return nil, errors.New("connection refused")
+	}
+
+	return c.code[contract], nil
+}
+
+func TestService_prebindRegistries(t *testing.T) {
+	deployed := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	missing := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	unreachable := common.HexToAddress("0x333855759a39fb75fc7341139f5d7a3974d4da08")
+	configMock := &testingconfig.MockConfig{}
+	configMock.On("GetNFTRegistryProperties").Return(map[string][]string{
+		"0x111855759a39fb75fc7341139f5d7a3974d4da08": {"amount"},
+		"0x222855759a39fb75fc7341139f5d7a3974d4da08": {"amount"},
+		"invalid": {"amount"},
+	})
+	configMock.On("GetNFTRegistryAttestationSchemes").Return(map[string]string{
+		"0x111855759a39fb75fc7341139f5d7a3974d4da08": AttestationSchemeEthSign,
+		"0x333855759a39fb75fc7341139f5d7a3974d4da08": AttestationSchemeEthSign,
+	})
+	client := &codeClient{backend: &codeBackend{
+		code:        map[common.Address][]byte{deployed: {0x60, 0x80}},
+		unreachable: map[common.Address]bool{unreachable: true},
+	}}
+
+	var bound []common.Address
+	bindContract := func(address common.Address, abi abi.ABI, client ethereum.Client) *bind.BoundContract {
+		bound = append(bound, address)
+		return new(bind.BoundContract)
+	}
+	service := newService(configMock, nil, client, nil, nil, bindContract, nil, nil, nil)
+	assert.Equal(t, []common.Address{deployed, missing, unreachable}, service.configuredRegistries())
+
+	service.prebindRegistries(context.Background())
+	assert.Equal(t, []common.Address{deployed}, bound)
+
+	// prebound contract is reused, others are bound on first use
+	service.registryContract(deployed)
+	service.registryContract(unreachable)
+	assert.Equal(t, []common.Address{deployed, unreachable}, bound)
+}
@@ -0,0 +1,68 @@
+package nft
+
+import (
+	"sync"
+	"time"
+)
+
+// maxCachedReads bounds the number of on-chain reads kept in the read cache.
+const maxCachedReads = 1024
+
+type cachedRead struct {
+	value  interface{}
+	readAt time.Time
+}
+
+// readCache keeps the last known values of recent on-chain reads so that they can be served while the chain is unreachable.
+type readCache struct {
+	mu    sync.Mutex
+	reads map[string]cachedRead
+	now   func() time.Time
+}
+
+func newReadCache() *readCache {
+	return &readCache{reads: make(map[string]cachedRead), now: time.Now}
+}
+
+// put records the value read from chain. If the cache is full, expired reads are dropped first and then the oldest one.
+func (c *readCache) put(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, ok := c.reads[key]; !ok && len(c.reads) >= maxCachedReads {
+		var oldest string
+		for k, r := range c.reads {
+			if now.Sub(r.readAt) > ttl {
+				delete(c.reads, k)
+				continue
+			}
+
+			if oldest == "" || r.readAt.Before(c.reads[oldest].readAt) {
+				oldest = k
+			}
+		}
+
+		if len(c.reads) >= maxCachedReads {
+			delete(c.reads, oldest)
+		}
+	}
+
+	c.reads[key] = cachedRead{value: value, readAt: now}
+}
+
+// get returns the last known value if it was read within the ttl.
+func (c *readCache) get(key string, ttl time.Duration) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.reads[key]
+	if !ok {
+		return nil, false
+	}
+
+	if c.now().Sub(r.readAt) > ttl {
+		delete(c.reads, key)
+		return nil, false
+	}
+
+	return r.value, true
+}
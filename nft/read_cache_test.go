@@ -0,0 +1,121 @@
+//go:build unit
+// +build unit
+
+package nft
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCache(t *testing.T) {
+	now := time.Now()
+	c := newReadCache()
+	c.now = func() time.Time { return now }
+
+	_, ok := c.get("key", time.Minute)
+	assert.False(t, ok)
+
+	c.put("key", "value", time.Minute)
+	v, ok := c.get("key", time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, "value", v)
+
+	// expired
+	now = now.Add(2 * time.Minute)
+	_, ok = c.get("key", time.Minute)
+	assert.False(t, ok)
+	assert.Empty(t, c.reads)
+
+	// full cache drops the expired reads and then the oldest
+	for i := 0; i < maxCachedReads; i++ {
+		c.put(strconv.Itoa(i), i, time.Minute)
+		now = now.Add(time.Millisecond)
+	}
+	c.put("0", 0, time.Minute)
+	assert.Len(t, c.reads, maxCachedReads)
+	c.put("new", "value", time.Minute)
+	assert.Len(t, c.reads, maxCachedReads)
+	_, ok = c.get("1", time.Minute)
+	assert.False(t, ok)
+	_, ok = c.get("0", time.Minute)
+	assert.True(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	c.put("after", "value", time.Minute)
+	assert.Len(t, c.reads, 1)
+}
+
+// ownerCaller returns the owner of the tokens, all calls fail while unreachable.
+type ownerCaller struct {
+	owner       common.Address
+	unreachable bool
+}
+
+func (c *ownerCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x60, 0x80}, nil
+}
+
+func (c *ownerCaller) CallContract(ctx context.Context, call goethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if c.unreachable {
+		return nil, errors.New("connection refused")
+	}
+
+	return common.LeftPadBytes(c.owner.Bytes(), 32), nil
+}
+
+func TestService_CachedOwnerOf(t *testing.T) {
+	registry := common.BytesToAddress(utils.RandomSlice(20))
+	tokenID := utils.RandomSlice(32)
+	caller := &ownerCaller{owner: common.BytesToAddress(utils.RandomSlice(20))}
+	bindContract := func(address common.Address, abi abi.ABI, client ethereum.Client) *bind.BoundContract {
+		return bind.NewBoundContract(address, abi, caller, nil, nil)
+	}
+	ethClient := new(ethereum.MockEthClient)
+	ethClient.On("GetGethCallOpts").Return(&bind.CallOpts{})
+	configMock := &testingconfig.MockConfig{}
+	configMock.On("GetNFTReadCacheTTL").Return(time.Duration(0)).Twice()
+	configMock.On("GetNFTReadCacheTTL").Return(time.Minute)
+	service := newService(configMock, nil, ethClient, nil, nil, bindContract, nil, nil, nil)
+
+	// caching disabled
+	owner, stale, err := service.CachedOwnerOf(registry, tokenID)
+	assert.NoError(t, err)
+	assert.False(t, stale)
+	assert.Equal(t, caller.owner, owner)
+	caller.unreachable = true
+	_, _, err = service.CachedOwnerOf(registry, tokenID)
+	assert.Error(t, err)
+
+	// no known owner
+	_, _, err = service.CachedOwnerOf(registry, tokenID)
+	assert.Error(t, err)
+
+	// last known owner served as stale
+	caller.unreachable = false
+	_, _, err = service.CachedOwnerOf(registry, tokenID)
+	assert.NoError(t, err)
+	caller.unreachable = true
+	owner, stale, err = service.CachedOwnerOf(registry, tokenID)
+	assert.NoError(t, err)
+	assert.True(t, stale)
+	assert.Equal(t, caller.owner, owner)
+
+	// other tokens are not served from the cache
+	_, _, err = service.CachedOwnerOf(registry, utils.RandomSlice(32))
+	assert.Error(t, err)
+	configMock.AssertExpectations(t)
+}
@@ -0,0 +1,171 @@
+package nft
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// TransferEventABI is the ABI of the ERC721 Transfer event, the mints are the transfers from the zero address.
+	TransferEventABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":true,"name":"tokenId","type":"uint256"}],"name":"Transfer","type":"event"}]`
+
+	// ErrInvalidRegistryEvent error when the mint event configured for a registry cannot be used to decode the mints
+	ErrInvalidRegistryEvent = errors.Error("invalid registry mint event")
+
+	// ErrUnknownMintEvent error when the mint of a registry cannot be decoded from the logs of the mint transaction
+	ErrUnknownMintEvent = errors.Error("unknown mint event layout")
+)
+
+// transferEvent is the mint event of the registries without a configured one
+var transferEvent mintEvent
+
+// mintEvent decodes the minted token ID from the mint event of a registry.
+type mintEvent struct {
+	event abi.Event
+
+	// tokenID is the name of the first uint256 input of the event
+	tokenID string
+
+	// from is the name of the address input the transfers are made from, empty if the event is emitted on mint only
+	from string
+}
+
+// newMintEvent returns the mint event of the ABI holding a single event.
+func newMintEvent(eventABI string) (mintEvent, error) {
+	parsed, err := abi.JSON(strings.NewReader(eventABI))
+	if err != nil {
+		return mintEvent{}, err
+	}
+
+	if len(parsed.Events) != 1 {
+		return mintEvent{}, errors.New("expected a single event, got %d", len(parsed.Events))
+	}
+
+	var me mintEvent
+	for _, event := range parsed.Events {
+		me.event = event
+	}
+
+	// anonymous events have no topic identifying them
+	if me.event.Anonymous {
+		return mintEvent{}, errors.New("event %s is anonymous", me.event.Sig)
+	}
+
+	for _, input := range me.event.Inputs {
+		switch {
+		case me.tokenID == "" && input.Type.T == abi.UintTy && input.Type.Size == 256:
+			me.tokenID = input.Name
+		case input.Name == "from" && input.Type.T == abi.AddressTy:
+			me.from = input.Name
+		}
+	}
+
+	if me.tokenID == "" {
+		return mintEvent{}, errors.New("event %s has no uint256 input", me.event.Sig)
+	}
+
+	return me, nil
+}
+
+// decode returns the token ID minted in the log. False is returned if the log is not a mint of the event.
+func (me mintEvent) decode(l *types.Log) (TokenID, bool, error) {
+	if len(l.Topics) == 0 || l.Topics[0] != me.event.ID {
+		return TokenID{}, false, nil
+	}
+
+	var indexed abi.Arguments
+	for _, input := range me.event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	values := make(map[string]interface{})
+	err := abi.ParseTopicsIntoMap(values, indexed, l.Topics[1:])
+	if err != nil {
+		return TokenID{}, false, err
+	}
+
+	if nonIndexed := me.event.Inputs.NonIndexed(); len(nonIndexed) > 0 {
+		err = nonIndexed.UnpackIntoMap(values, l.Data)
+		if err != nil {
+			return TokenID{}, false, err
+		}
+	}
+
+	if me.from != "" {
+		if from, ok := values[me.from].(common.Address); !ok || from != (common.Address{}) {
+			return TokenID{}, false, nil
+		}
+	}
+
+	id, ok := values[me.tokenID].(*big.Int)
+	if !ok {
+		return TokenID{}, false, errors.New("input %s of event %s is not decoded", me.tokenID, me.event.Sig)
+	}
+
+	// uint256 fits the token ID, prefixed with zeroes to match the big endian bytes
+	var tokenID TokenID
+	b := id.Bytes()
+	copy(tokenID[TokenIDLength-len(b):], b)
+	return tokenID, true, nil
+}
+
+// registryEvents holds the mint events keyed by the lower cased registry address.
+// Registries that are not listed emit the ERC721 Transfer event.
+type registryEvents map[string]mintEvent
+
+// newRegistryEvents returns the mint events of the registries as configured.
+func newRegistryEvents(cfg map[string]string) (registryEvents, error) {
+	res := make(registryEvents)
+	for registry, eventABI := range cfg {
+		if !common.IsHexAddress(registry) {
+			return nil, errors.New("invalid registry address %s", registry)
+		}
+
+		me, err := newMintEvent(eventABI)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidRegistryEvent, errors.New("registry %s: %v", registry, err))
+		}
+
+		res[strings.ToLower(registry)] = me
+	}
+
+	return res, nil
+}
+
+// mintedTokenID returns the ID of the token minted by the registry in the transaction.
+// False is returned if the receipt holds no Transfer mint of a registry that is not listed.
+// Listed registries without a decodable mint event in the receipt are an error so that the mint isn't confirmed silently.
+func (res registryEvents) mintedTokenID(receipt *types.Receipt, registry common.Address) (TokenID, bool, error) {
+	me, listed := res[strings.ToLower(registry.Hex())]
+	if !listed {
+		me = transferEvent
+	}
+
+	for _, l := range receipt.Logs {
+		if l.Address != registry {
+			continue
+		}
+
+		tokenID, ok, err := me.decode(l)
+		if err != nil {
+			return TokenID{}, false, errors.NewTypedError(ErrUnknownMintEvent, errors.New("event %s of registry %s: %v", me.event.Sig, registry.Hex(), err))
+		}
+
+		if ok {
+			return tokenID, true, nil
+		}
+	}
+
+	if listed {
+		return TokenID{}, false, errors.NewTypedError(ErrUnknownMintEvent, errors.New("no %s event of registry %s in the transaction", me.event.Sig, registry.Hex()))
+	}
+
+	return TokenID{}, false, nil
+}
@@ -0,0 +1,107 @@
+// +build unit
+
+package nft
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const mintedEventABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"dataRoot","type":"bytes32"},{"indexed":false,"name":"tokenId","type":"uint256"}],"name":"Minted","type":"event"}]`
+
+func mintedEvent(t *testing.T) mintEvent {
+	me, err := newMintEvent(mintedEventABI)
+	assert.NoError(t, err)
+	return me
+}
+
+func mintedLog(t *testing.T, registry common.Address, tokenID TokenID) *types.Log {
+	me := mintedEvent(t)
+	data, err := me.event.Inputs.NonIndexed().Pack([32]byte{1}, tokenID.BigInt())
+	assert.NoError(t, err)
+	return &types.Log{
+		Address: registry,
+		Topics:  []common.Hash{me.event.ID, common.BytesToHash(common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08").Bytes())},
+		Data:    data,
+	}
+}
+
+func TestNewRegistryEvents(t *testing.T) {
+	registry := "0x111855759a39fb75fc7341139f5d7a3974d4da08"
+
+	// invalid registry
+	_, err := newRegistryEvents(map[string]string{"0xabc": mintedEventABI})
+	assert.Error(t, err)
+
+	// invalid events
+	for _, eventABI := range []string{
+		"not an abi",
+		`[]`,
+		TransferEventABI[:len(TransferEventABI)-1] + `,{"inputs":[],"name":"Burned","type":"event"}]`,
+		`[{"anonymous":true,"inputs":[{"indexed":false,"name":"tokenId","type":"uint256"}],"name":"Minted","type":"event"}]`,
+		`[{"anonymous":false,"inputs":[{"indexed":true,"name":"to","type":"address"}],"name":"Minted","type":"event"}]`,
+	} {
+		_, err = newRegistryEvents(map[string]string{registry: eventABI})
+		assert.True(t, errors.IsOfType(ErrInvalidRegistryEvent, err), eventABI)
+	}
+
+	res, err := newRegistryEvents(map[string]string{"0x111855759A39FB75FC7341139F5D7A3974D4DA08": mintedEventABI})
+	assert.NoError(t, err)
+	assert.Equal(t, "tokenId", res[registry].tokenID)
+	assert.Empty(t, res[registry].from)
+	assert.Equal(t, "from", transferEvent.from)
+}
+
+func TestRegistryEvents_mintedTokenID(t *testing.T) {
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	other := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	tokenID := NewTokenID()
+	res, err := newRegistryEvents(map[string]string{other.Hex(): mintedEventABI})
+	assert.NoError(t, err)
+
+	// no logs
+	_, ok, err := res.mintedTokenID(&types.Receipt{}, registry)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// transfers and mints of other registries are skipped
+	receipt := &types.Receipt{Logs: []*types.Log{
+		mintLog(registry, other, NewTokenID()),
+		mintLog(other, common.Address{}, NewTokenID()),
+		mintLog(registry, common.Address{}, tokenID),
+	}}
+	minted, ok, err := res.mintedTokenID(receipt, registry)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, tokenID, minted)
+
+	// transfer event with an unknown layout
+	l := mintLog(registry, common.Address{}, tokenID)
+	l.Topics = l.Topics[:3]
+	_, _, err = res.mintedTokenID(&types.Receipt{Logs: []*types.Log{l}}, registry)
+	assert.True(t, errors.IsOfType(ErrUnknownMintEvent, err))
+
+	// configured event of the registry
+	receipt = &types.Receipt{Logs: []*types.Log{
+		mintLog(other, common.Address{}, NewTokenID()),
+		mintedLog(t, other, tokenID),
+	}}
+	minted, ok, err = res.mintedTokenID(receipt, other)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, tokenID, minted)
+
+	// configured event missing
+	_, _, err = res.mintedTokenID(&types.Receipt{Logs: []*types.Log{mintLog(other, common.Address{}, tokenID)}}, other)
+	assert.True(t, errors.IsOfType(ErrUnknownMintEvent, err))
+
+	// configured event with undecodable data
+	l = mintedLog(t, other, tokenID)
+	l.Data = l.Data[:32]
+	_, _, err = res.mintedTokenID(&types.Receipt{Logs: []*types.Log{l}}, other)
+	assert.True(t, errors.IsOfType(ErrUnknownMintEvent, err))
+}
@@ -0,0 +1,178 @@
+package nft
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gopkg.in/resty.v1"
+)
+
+const (
+	// ErrUnsupportedRelayerSigningScheme error when the signing scheme configured for the relayer is unknown
+	ErrUnsupportedRelayerSigningScheme = errors.Error("unsupported relayer signing scheme")
+
+	// ErrRelayerRejected error when the relayer doesn't accept the meta-transaction
+	ErrRelayerRejected = errors.Error("relayer rejected the meta-transaction")
+
+	// relayerTimeout bounds the post of a meta-transaction to the relayer
+	relayerTimeout = 30 * time.Second
+
+	// relayedMintJobDescription is the description of the job tracking the relayed mint transaction
+	relayedMintJobDescription = "Check Job for relayed mint"
+)
+
+// MetaTransaction is the mint call signed by the account and posted to the relayer, which submits it and pays for the gas.
+type MetaTransaction struct {
+	// From is the identity the call is made on behalf of
+	From string `json:"from"`
+
+	// To is the registry called
+	To string `json:"to"`
+
+	// Data is the abi encoded mint call
+	Data string `json:"data"`
+
+	// Nonce is the ID of the mint job so that a repeated post of the same mint is relayed once
+	Nonce string `json:"nonce"`
+
+	// SigningScheme is the scheme of the signature, "eth_sign" or "secp256k1"
+	SigningScheme string `json:"signing_scheme"`
+
+	// Signature is the signature of from(20) + to(20) + nonce(16) + data by the account signing key
+	Signature string `json:"signature"`
+}
+
+// relayResponse is the response of the relayer to an accepted meta-transaction.
+type relayResponse struct {
+	TxHash string `json:"tx_hash"`
+}
+
+// relayerSigningScheme returns the scheme the meta-transactions are signed with.
+func (s *service) relayerSigningScheme() (string, error) {
+	scheme := s.cfg.GetNFTRelayerSigningScheme()
+	switch scheme {
+	case AttestationSchemeEthSign, AttestationSchemeSecp256k1:
+		return scheme, nil
+	default:
+		return "", errors.NewTypedError(ErrUnsupportedRelayerSigningScheme, errors.New("scheme %s", scheme))
+	}
+}
+
+// submitMint submits the mint call through the relayer if one is configured and directly from the identity otherwise.
+func (s *service) submitMint(ctx context.Context, accountID identity.DID, jobID jobs.JobID, registry common.Address, data []byte) (identity.IDTX, chan error, error) {
+	url := s.cfg.GetNFTRelayerURL()
+	if url == "" {
+		return s.identityService.RawExecute(ctx, registry, data, s.mintGasLimit(ctx, registry, data))
+	}
+
+	scheme, err := s.relayerSigningScheme()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.jobsManager.ExecuteWithinJob(contextutil.Copy(ctx), accountID, jobID, relayedMintJobDescription,
+		s.relayedTX(ctx, url, scheme, registry, data))
+}
+
+// relayedTX returns the work posting the meta-transaction to the relayer and waiting for the relayed transaction.
+func (s *service) relayedTX(ctx context.Context, url, scheme string, registry common.Address, data []byte) func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	return func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		acc, err := contextutil.Account(ctx)
+		if err != nil {
+			errOut <- err
+			return
+		}
+
+		metaTX, err := newMetaTransaction(acc, scheme, accountID.ToAddress(), registry, jobID.Bytes(), data)
+		if err != nil {
+			errOut <- err
+			return
+		}
+
+		txHash, err := relay(url, metaTX)
+		if err != nil {
+			errOut <- err
+			return
+		}
+		log.Infof("Relayed the mint of job %s with ethTX %s", jobID.String(), txHash.Hex())
+
+		// recorded so that the job can be reconciled with the chain
+		err = txMan.UpdateJobWithValue(accountID, jobID, ethereum.TransactionHashKey, txHash.Bytes())
+		if err != nil {
+			log.Errorf("failed to record the transaction hash on job %s: %v", jobID.String(), err)
+		}
+
+		res, err := ethereum.QueueEthTXStatusTask(accountID, jobID, txHash, s.queue)
+		if err != nil {
+			errOut <- err
+			return
+		}
+
+		_, err = res.Get(txMan.GetDefaultTaskTimeout())
+		errOut <- err
+	}
+}
+
+// newMetaTransaction returns the meta-transaction of the call signed with the signing key of the account as per the scheme.
+func newMetaTransaction(acc config.Account, scheme string, from, to common.Address, nonce, data []byte) (MetaTransaction, error) {
+	var msg []byte
+	msg = append(msg, from.Bytes()...)
+	msg = append(msg, to.Bytes()...)
+	msg = append(msg, nonce...)
+	msg = append(msg, data...)
+	signature, err := signAttestation(acc, scheme, msg)
+	if err != nil {
+		return MetaTransaction{}, err
+	}
+
+	return MetaTransaction{
+		From:          from.Hex(),
+		To:            to.Hex(),
+		Data:          hexutil.Encode(data),
+		Nonce:         hexutil.Encode(nonce),
+		SigningScheme: scheme,
+		Signature:     hexutil.Encode(signature),
+	}, nil
+}
+
+// relay posts the meta-transaction to the relayer and returns the hash of the transaction the relayer submitted.
+func relay(url string, metaTX MetaTransaction) (common.Hash, error) {
+	c := resty.New()
+	c.SetTimeout(relayerTimeout)
+	c.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // Temporary until we have defined a cert truststore
+	resp, err := c.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(metaTX).
+		Post(url)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if !utils.InRange(resp.StatusCode(), 200, 299) {
+		return common.Hash{}, errors.NewTypedError(ErrRelayerRejected, errors.New("status = %v: %s", resp.StatusCode(), resp.String()))
+	}
+
+	var rr relayResponse
+	err = json.Unmarshal(resp.Body(), &rr)
+	if err != nil {
+		return common.Hash{}, errors.NewTypedError(ErrRelayerRejected, err)
+	}
+
+	hash, err := hexutil.Decode(rr.TxHash)
+	if err != nil || len(hash) != common.HashLength {
+		return common.Hash{}, errors.NewTypedError(ErrRelayerRejected, errors.New("invalid transaction hash %q", rr.TxHash))
+	}
+
+	return common.BytesToHash(hash), nil
+}
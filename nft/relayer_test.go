@@ -0,0 +1,171 @@
+// +build unit
+
+package nft
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto/secp256k1"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/testingutils"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskResult struct {
+	err error
+}
+
+func (r taskResult) Get(time.Duration) (interface{}, error) {
+	return nil, r.err
+}
+
+func (r taskResult) State() (string, error) {
+	if r.err != nil {
+		return queue.TaskStateFailure, nil
+	}
+
+	return queue.TaskStateSuccess, nil
+}
+
+// relayerServer returns a relayer decoding the posted meta-transactions into metaTX and responding with the status and body.
+func relayerServer(t *testing.T, status int, body string, metaTX *MetaTransaction) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(metaTX))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestService_relayerSigningScheme(t *testing.T) {
+	configMock := &testingconfig.MockConfig{}
+	configMock.On("GetNFTRelayerSigningScheme").Return(AttestationSchemeSecp256k1).Once()
+	configMock.On("GetNFTRelayerSigningScheme").Return("rsa").Once()
+	service := newService(configMock, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	scheme, err := service.relayerSigningScheme()
+	assert.NoError(t, err)
+	assert.Equal(t, AttestationSchemeSecp256k1, scheme)
+
+	_, err = service.relayerSigningScheme()
+	assert.True(t, errors.IsOfType(ErrUnsupportedRelayerSigningScheme, err))
+	configMock.AssertExpectations(t)
+}
+
+func TestNewMetaTransaction(t *testing.T) {
+	acc, err := contextutil.Account(testingconfig.CreateAccountContext(t, cfg))
+	assert.NoError(t, err)
+	keys, err := acc.GetKeys()
+	assert.NoError(t, err)
+	signer := common.BytesToAddress(keys[identity.KeyPurposeSigning.Name].PublicKey)
+
+	from := common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08")
+	to := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	nonce, data := jobs.NewJobID().Bytes(), utils.RandomSlice(64)
+	metaTX, err := newMetaTransaction(acc, AttestationSchemeEthSign, from, to, nonce, data)
+	assert.NoError(t, err)
+	assert.Equal(t, from.Hex(), metaTX.From)
+	assert.Equal(t, to.Hex(), metaTX.To)
+	assert.Equal(t, hexutil.Encode(data), metaTX.Data)
+	assert.Equal(t, hexutil.Encode(nonce), metaTX.Nonce)
+	assert.Equal(t, AttestationSchemeEthSign, metaTX.SigningScheme)
+
+	var msg []byte
+	msg = append(msg, from.Bytes()...)
+	msg = append(msg, to.Bytes()...)
+	msg = append(msg, nonce...)
+	msg = append(msg, data...)
+	assert.True(t, secp256k1.VerifySignatureWithAddress(signer.Hex(), metaTX.Signature, msg))
+
+	_, err = newMetaTransaction(acc, "rsa", from, to, nonce, data)
+	assert.True(t, errors.IsOfType(ErrUnsupportedAttestationScheme, err))
+}
+
+func TestRelay(t *testing.T) {
+	txHash := common.BytesToHash(utils.RandomSlice(32))
+	metaTX := MetaTransaction{From: "0x1", To: "0x2", Data: "0x3", Nonce: "0x4", SigningScheme: AttestationSchemeEthSign, Signature: "0x5"}
+
+	// relayed
+	var posted MetaTransaction
+	srv := relayerServer(t, http.StatusAccepted, `{"tx_hash":"`+txHash.Hex()+`"}`, &posted)
+	defer srv.Close()
+	hash, err := relay(srv.URL, metaTX)
+	assert.NoError(t, err)
+	assert.Equal(t, txHash, hash)
+	assert.Equal(t, metaTX, posted)
+
+	// rejected
+	srv = relayerServer(t, http.StatusBadRequest, `invalid signature`, &posted)
+	defer srv.Close()
+	_, err = relay(srv.URL, metaTX)
+	assert.True(t, errors.IsOfType(ErrRelayerRejected, err))
+	assert.Contains(t, err.Error(), "invalid signature")
+
+	// invalid hash
+	srv = relayerServer(t, http.StatusOK, `{"tx_hash":"0x1234"}`, &posted)
+	defer srv.Close()
+	_, err = relay(srv.URL, metaTX)
+	assert.True(t, errors.IsOfType(ErrRelayerRejected, err))
+}
+
+func TestService_submitMint(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	jobID := jobs.NewJobID()
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	txHash := common.BytesToHash(utils.RandomSlice(32))
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+
+	// unsupported signing scheme
+	configMock := &testingconfig.MockConfig{}
+	configMock.On("GetNFTRelayerURL").Return("http://localhost/relay").Once()
+	configMock.On("GetNFTRelayerSigningScheme").Return("rsa").Once()
+	service := newService(configMock, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, _, err := service.submitMint(ctxh, did, jobID, registry, []byte{1})
+	assert.True(t, errors.IsOfType(ErrUnsupportedRelayerSigningScheme, err))
+
+	// relayed within the mint job
+	var posted MetaTransaction
+	srv := relayerServer(t, http.StatusOK, `{"tx_hash":"`+txHash.Hex()+`"}`, &posted)
+	defer srv.Close()
+	configMock = &testingconfig.MockConfig{}
+	configMock.On("GetNFTRelayerURL").Return(srv.URL).Once()
+	configMock.On("GetNFTRelayerSigningScheme").Return(AttestationSchemeEthSign).Once()
+	queueSrv := new(testingutils.MockQueue)
+	queueSrv.On("EnqueueJob", ethereum.EthTXStatusTaskName, mock.Anything).Return(taskResult{}, nil).Once()
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("UpdateJobWithValue", did, jobID, ethereum.TransactionHashKey, txHash.Bytes()).Return(nil).Once()
+	jobMan.On("GetDefaultTaskTimeout").Return(time.Second).Once()
+	var work func(identity.DID, jobs.JobID, jobs.Manager, chan<- error)
+	jobMan.On("ExecuteWithinJob", mock.Anything, did, jobID, relayedMintJobDescription, mock.Anything).Return(jobID, make(chan error), nil).Run(func(args mock.Arguments) {
+		work = args.Get(4).(func(identity.DID, jobs.JobID, jobs.Manager, chan<- error))
+	}).Once()
+	service = newService(configMock, nil, nil, queueSrv, nil, nil, jobMan, nil, nil)
+	txID, _, err := service.submitMint(ctxh, did, jobID, registry, []byte{1})
+	assert.NoError(t, err)
+	assert.Equal(t, jobID, txID)
+
+	errOut := make(chan error, 1)
+	work(did, jobID, jobMan, errOut)
+	assert.NoError(t, <-errOut)
+	assert.Equal(t, registry.Hex(), posted.To)
+	assert.Equal(t, did.ToAddress().Hex(), posted.From)
+	assert.Equal(t, hexutil.Encode(jobID.Bytes()), posted.Nonce)
+	configMock.AssertExpectations(t)
+	queueSrv.AssertExpectations(t)
+	jobMan.AssertExpectations(t)
+}
@@ -3,11 +3,14 @@ package nft
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -33,6 +36,28 @@ const (
 	// ErrNFTMinted error for NFT already minted for registry
 	ErrNFTMinted = errors.Error("NFT already minted")
 
+	// ErrMintDeadlineExceeded error when the mint is not confirmed before the requested deadline
+	ErrMintDeadlineExceeded = errors.Error("mint deadline exceeded")
+
+	// ErrInvalidPropertyMapping error when the token property mapping doesn't match the property schema of the registry
+	ErrInvalidPropertyMapping = errors.Error("invalid token property mapping")
+
+	// ErrInvalidProofFields error when a field selected for the mint proofs doesn't exist in the document
+	ErrInvalidProofFields = errors.Error("invalid proof fields")
+
+	// ErrMintInProgress error when a mint of the document is already pending
+	ErrMintInProgress = errors.Error("NFT mint in progress")
+
+	// ErrDocumentNotAnchored error when the current version of the document to mint isn't anchored
+	ErrDocumentNotAnchored = errors.Error("document version not anchored")
+
+	// mintJobIndexKey is the key the mint jobs are indexed by with the hex encoded document ID
+	mintJobIndexKey = "nft_mint_document_id"
+
+	// minMintGasPadding and maxMintGasPadding bound the padding of the estimated mint gas
+	minMintGasPadding = 1.0
+	maxMintGasPadding = 2.0
+
 	// GenericMintMethodABI constant interface to interact with mint methods
 	GenericMintMethodABI = `[{"constant":false,"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tkn","type":"uint256"},{"internalType":"bytes32","name":"dataRoot","type":"bytes32"},{"internalType":"bytes[]","name":"properties","type":"bytes[]"},{"internalType":"bytes[]","name":"values","type":"bytes[]"},{"internalType":"bytes32[]","name":"salts","type":"bytes32[]"}],"name":"mint","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
 
@@ -46,18 +71,41 @@ const (
 // nftABI is the default abi for caller functions on NFT registry
 var nftABI abi.ABI
 
+// mintABIs are the decoded mint method ABIs keyed by their JSON
+var mintABIs = make(map[string]abi.ABI)
+
 func init() {
 	var err error
 	nftABI, err = abi.JSON(strings.NewReader(ABI))
 	if err != nil {
 		log.Fatalf("failed to decode NFT ABI: %v", err)
 	}
+
+	transferEvent, err = newMintEvent(TransferEventABI)
+	if err != nil {
+		log.Fatalf("failed to decode the Transfer event ABI: %v", err)
+	}
+
+	for _, mintABI := range []string{GenericMintMethodABI, AttestedMintMethodABI} {
+		mintABIs[mintABI], err = abi.JSON(strings.NewReader(mintABI))
+		if err != nil {
+			log.Fatalf("failed to decode mint ABI: %v", err)
+		}
+	}
 }
 
 // Config is the config interface for nft package
 type Config interface {
 	GetEthereumContextWaitTimeout() time.Duration
 	GetLowEntropyNFTTokenEnabled() bool
+	GetNFTRegistryProperties() map[string][]string
+	GetNFTRegistryAttestationSchemes() map[string]string
+	GetNFTRegistryMethods() map[string][]string
+	GetNFTMintGasPadding() float64
+	GetNFTReadCacheTTL() time.Duration
+	GetNFTRelayerURL() string
+	GetNFTRelayerSigningScheme() string
+	GetEthereumGasLimit(op config.ContractOp) uint64
 }
 
 // service handles all interactions related to minting of NFTs for unpaid invoices on Ethereum
@@ -71,6 +119,22 @@ type service struct {
 	jobsManager        jobs.Manager
 	api                API
 	blockHeightFunc    func() (height uint64, err error)
+
+	// contracts caches the bound registry contracts by the registry address
+	contractsMu sync.Mutex
+	contracts   map[common.Address]*bind.BoundContract
+
+	// reads caches the last known values of the on-chain reads served while the chain is unreachable
+	reads *readCache
+
+	// mintMu makes the check for a pending mint and the creation of the mint job atomic
+	mintMu sync.Mutex
+
+	// methods are the registry methods allowed to be called, configured at bootstrap
+	methods registryMethods
+
+	// events are the mint events of the registries, configured at bootstrap
+	events registryEvents
 }
 
 // newService creates InvoiceUnpaid given the parameters
@@ -94,9 +158,24 @@ func newService(
 		jobsManager:        jobsMan,
 		blockHeightFunc:    blockHeightFunc,
 		api:                api,
+		contracts:          make(map[common.Address]*bind.BoundContract),
+		reads:              newReadCache(),
 	}
 }
 
+// registryContract returns the contract of the registry, bound on first use.
+func (s *service) registryContract(registry common.Address) *bind.BoundContract {
+	s.contractsMu.Lock()
+	defer s.contractsMu.Unlock()
+	if c, ok := s.contracts[registry]; ok {
+		return c
+	}
+
+	c := s.bindCallerContract(registry, nftABI, s.ethClient)
+	s.contracts[registry] = c
+	return c
+}
+
 func (s *service) prepareMintRequest(ctx context.Context, tokenID TokenID, cid identity.DID, req MintNFTRequest) (mreq MintRequest, err error) {
 	docProofs, err := s.docSrv.CreateProofs(ctx, req.DocumentID, req.ProofFields)
 	if err != nil {
@@ -173,6 +252,30 @@ func (s *service) MintNFT(ctx context.Context, req MintNFTRequest) (*TokenRespon
 		return nil, nil, errors.New("enable grant_nft_access to generate Read Access Proof")
 	}
 
+	if deadlineExceeded(req.Deadline) {
+		return nil, nil, ErrMintDeadlineExceeded
+	}
+
+	if err := s.methods.check(req.RegistryAddress, RegistryMethodMint); err != nil {
+		return nil, nil, err
+	}
+
+	propFields, err := s.tokenPropertyFields(req.RegistryAddress, req.PropertyMapping)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.ProofFields = mergeProofFields(propFields, req.ProofFields)
+
+	if _, err := s.attestationScheme(req.RegistryAddress); err != nil {
+		return nil, nil, err
+	}
+
+	if s.cfg.GetNFTRelayerURL() != "" {
+		if _, err := s.relayerSigningScheme(); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	tokenID := NewTokenID()
 	if s.cfg.GetLowEntropyNFTTokenEnabled() {
 		log.Warningf("Security consideration: Using a reduced maximum of %s integer for NFT token ID generation. "+
@@ -190,6 +293,17 @@ func (s *service) MintNFT(ctx context.Context, req MintNFTRequest) (*TokenRespon
 		return nil, nil, errors.NewTypedError(ErrNFTMinted, errors.New("registry %v", req.RegistryAddress.String()))
 	}
 
+	// the version is anchored as the first step of the mint job on request, otherwise the mint fails fast
+	if model.GetStatus() != documents.Committed && !req.AnchorDocument {
+		return nil, nil, errors.NewTypedError(ErrDocumentNotAnchored, errors.New("version %s of document %s, "+
+			"request the anchor of the document within the mint", hexutil.Encode(model.CurrentVersion()), hexutil.Encode(req.DocumentID)))
+	}
+
+	// the token commits to the selected fields only, fail before the job if any of them isn't in the document
+	if _, err := s.docSrv.CreateProofs(ctx, req.DocumentID, req.ProofFields); err != nil {
+		return nil, nil, errors.NewTypedError(ErrInvalidProofFields, err)
+	}
+
 	didBytes := tc.GetIdentityID()
 
 	// Mint NFT within transaction
@@ -199,19 +313,128 @@ func (s *service) MintNFT(ctx context.Context, req MintNFTRequest) (*TokenRespon
 		return nil, nil, err
 	}
 
-	jobID, done, err := s.jobsManager.ExecuteWithinJob(contextutil.Copy(ctx), did, jobs.NilJobID(), "Minting NFT",
-		s.minterJob(ctx, tokenID, model, req))
+	// a second mint of the document would race the pending one for the document anchor.
+	// The mint job is indexed under the lock so that concurrent mints of the document can't both pass the check.
+	s.mintMu.Lock()
+	defer s.mintMu.Unlock()
+	inProgress, pendingJobID, err := s.IsMintInProgress(did, req.DocumentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if inProgress {
+		return nil, nil, errors.NewTypedError(ErrMintInProgress, errors.New("document %s is being minted by job %s", hexutil.Encode(req.DocumentID), pendingJobID))
+	}
+
+	work := s.minterJob(ctx, tokenID, model, req)
+	if req.AnchorDocument {
+		work = s.anchorAndMintJob(ctx, tokenID, model, req)
+	}
+
+	// the mint job is tagged with the document version it mints
+	jctx := contextutil.WithDocumentVersion(contextutil.Copy(ctx), model.ID(), model.CurrentVersion())
+	jobID, done, err := s.jobsManager.ExecuteWithinJob(jctx, did, jobs.NilJobID(), "Minting NFT",
+		countMint(withDeadline(req.Deadline, work)))
 
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// a missing index only hides the mint from IsMintInProgress, the mint itself goes on
+	err = s.jobsManager.IndexJob(did, jobID, mintJobIndexKey, hexutil.Encode(req.DocumentID))
+	if err != nil {
+		log.Warningf("failed to index mint job %s of document %s: %v", jobID, hexutil.Encode(req.DocumentID), err)
+	}
+
+	// the token ID is known before the confirmation, the mint job verifies the registry minted it
+	err = s.jobsManager.UpdateJobWithValue(did, jobID, PredictedTokenIDKey, tokenID[:])
+	if err != nil {
+		log.Warningf("failed to record the predicted token ID %s on mint job %s: %v", tokenID.String(), jobID, err)
+	}
+
+	// EnsureMinted tells the mints of the document apart by their registry
+	err = s.jobsManager.UpdateJobWithValue(did, jobID, mintRegistryKey, req.RegistryAddress.Bytes())
+	if err != nil {
+		log.Warningf("failed to record the registry %s on mint job %s: %v", req.RegistryAddress.Hex(), jobID, err)
+	}
+
 	return &TokenResponse{
 		JobID:   jobID.String(),
 		TokenID: tokenID.String(),
 	}, done, nil
 }
 
+// IsMintInProgress returns true and the ID of the mint job if the latest mint of the document by the account is pending.
+func (s *service) IsMintInProgress(accountID identity.DID, documentID []byte) (bool, jobs.JobID, error) {
+	job, err := s.jobsManager.GetIndexedJob(accountID, mintJobIndexKey, hexutil.Encode(documentID))
+	if err != nil {
+		if errors.IsOfType(jobs.ErrJobsMissing, err) {
+			return false, jobs.NilJobID(), nil
+		}
+
+		return false, jobs.NilJobID(), err
+	}
+
+	if job.Status != jobs.Pending {
+		return false, jobs.NilJobID(), nil
+	}
+
+	return true, job.ID, nil
+}
+
+// tokenPropertyFields returns the document fields mapped to the token property slots of the registry in the slot order.
+// Every slot of the registry must be mapped. Registries without a configured property schema take no mapping.
+func (s *service) tokenPropertyFields(registry common.Address, mapping map[string]string) ([]string, error) {
+	slots, ok := s.cfg.GetNFTRegistryProperties()[strings.ToLower(registry.Hex())]
+	if !ok {
+		if len(mapping) > 0 {
+			return nil, errors.NewTypedError(ErrInvalidPropertyMapping, errors.New("no property schema for registry %s", registry.Hex()))
+		}
+
+		return nil, nil
+	}
+
+	fields := make([]string, 0, len(slots))
+	known := make(map[string]bool)
+	for _, slot := range slots {
+		field := mapping[slot]
+		if field == "" {
+			return nil, errors.NewTypedError(ErrInvalidPropertyMapping, errors.New("required property %s is not mapped", slot))
+		}
+
+		known[slot] = true
+		fields = append(fields, field)
+	}
+
+	for slot := range mapping {
+		if !known[slot] {
+			return nil, errors.NewTypedError(ErrInvalidPropertyMapping, errors.New("registry %s has no property %s", registry.Hex(), slot))
+		}
+	}
+
+	return fields, nil
+}
+
+// mergeProofFields returns the token property fields followed by the other proof fields not already included.
+func mergeProofFields(propFields, proofFields []string) []string {
+	if len(propFields) < 1 {
+		return proofFields
+	}
+
+	seen := make(map[string]bool)
+	var fields []string
+	for _, f := range append(propFields, proofFields...) {
+		if seen[f] {
+			continue
+		}
+
+		seen[f] = true
+		fields = append(fields, f)
+	}
+
+	return fields
+}
+
 // TransferFrom transfers an NFT to another address
 func (s *service) TransferFrom(ctx context.Context, registry common.Address, to common.Address, tokenID TokenID) (*TokenResponse, chan error, error) {
 	tc, err := contextutil.Account(ctx)
@@ -219,6 +442,10 @@ func (s *service) TransferFrom(ctx context.Context, registry common.Address, to
 		return nil, nil, err
 	}
 
+	if err := s.methods.check(registry, RegistryMethodTransfer); err != nil {
+		return nil, nil, err
+	}
+
 	didBytes := tc.GetIdentityID()
 	did, err := identity.NewDIDFromBytes(didBytes)
 	if err != nil {
@@ -307,9 +534,41 @@ func (s *service) minterJob(ctx context.Context, tokenID TokenID, model document
 		}
 
 		// to common.Address, tokenId *big.Int, bytes32, properties [][]byte, values [][]byte, salts [][32]byte
+		mintABI := GenericMintMethodABI
 		args := []interface{}{requestData.To, requestData.TokenID, requestData.SigningRoot, requestData.Props, requestData.Values, requestData.Salts}
 
-		txID, done, err := s.identityService.Execute(ctx, req.RegistryAddress, GenericMintMethodABI, "mint", args...)
+		// registries with an attestation scheme additionally expect the attestation bytes and its signature
+		scheme, err := s.attestationScheme(req.RegistryAddress)
+		if err != nil {
+			errOut <- err
+			return
+		}
+
+		if scheme != "" {
+			attestation, signature, err := attest(ctx, txMan, accountID, jobID, scheme, req.RegistryAddress, requestData)
+			if err != nil {
+				errOut <- errors.New("failed to attest the mint: %v", err)
+				return
+			}
+
+			mintABI = AttestedMintMethodABI
+			args = append(args, attestation, signature)
+		}
+
+		// once the mint tx is sent off, it cannot be cancelled anymore. So skip it if the deadline is already crossed.
+		if deadlineExceeded(req.Deadline) {
+			log.Warningf("mint deadline %s exceeded before submitting the mint tx for document %s", req.Deadline, hexutil.Encode(req.DocumentID))
+			errOut <- ErrMintDeadlineExceeded
+			return
+		}
+
+		mintData, err := packMint(mintABI, args...)
+		if err != nil {
+			errOut <- err
+			return
+		}
+
+		txID, done, err := s.submitMint(ctx, accountID, jobID, req.RegistryAddress, mintData)
 		if err != nil {
 			errOut <- err
 			return
@@ -340,6 +599,12 @@ func (s *service) minterJob(ctx context.Context, tokenID TokenID, model document
 			return
 		}
 
+		err = s.verifyMintedTokenID(ctx, txMan, accountID, jobID, req.RegistryAddress, tokenID)
+		if err != nil {
+			errOut <- errors.New("failed to verify the minted token ID: %v", err)
+			return
+		}
+
 		// Check if tokenID exists in registry and owner is deposit address
 		owner, err := s.OwnerOfWithRetrial(req.RegistryAddress, tokenID[:])
 		if err != nil {
@@ -357,6 +622,72 @@ func (s *service) minterJob(ctx context.Context, tokenID TokenID, model document
 	}
 }
 
+// packMint returns the abi encoded call of the mint method.
+func packMint(mintABI string, args ...interface{}) ([]byte, error) {
+	abiObj, ok := mintABIs[mintABI]
+	if !ok {
+		var err error
+		abiObj, err = abi.JSON(strings.NewReader(mintABI))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return abiObj.Pack("mint", args...)
+}
+
+// mintGasLimit returns the gas limit of the mint transaction.
+// If the gas padding is configured, the gas estimated for the mint is padded with it.
+// Otherwise, or if the estimation fails, the configured mint gas limit is used.
+func (s *service) mintGasLimit(ctx context.Context, registry common.Address, data []byte) uint64 {
+	limit := s.cfg.GetEthereumGasLimit(config.NftMint)
+	padding := s.cfg.GetNFTMintGasPadding()
+	if padding <= 0 {
+		return limit
+	}
+
+	if padding < minMintGasPadding || padding > maxMintGasPadding {
+		log.Warningf("mint gas padding %.2f is out of bounds [%.1f, %.1f]", padding, minMintGasPadding, maxMintGasPadding)
+		padding = math.Min(math.Max(padding, minMintGasPadding), maxMintGasPadding)
+	}
+
+	estimate, err := s.identityService.EstimateRawExecuteGas(ctx, registry, data)
+	if err != nil {
+		log.Warningf("failed to estimate the mint gas, using the configured gas limit %d: %v", limit, err)
+		return limit
+	}
+
+	padded := uint64(float64(estimate) * padding)
+	log.Infof("Applied mint gas limit %d: estimated %d padded by %.2f", padded, estimate, padding)
+	return padded
+}
+
+// deadlineExceeded returns true if the deadline is set and crossed.
+func deadlineExceeded(deadline time.Time) bool {
+	return !deadline.IsZero() && !time.Now().Before(deadline)
+}
+
+// withDeadline fails the job with ErrMintDeadlineExceeded if the work is not done before the deadline.
+// The work is not interrupted and any tx submitted already will still be mined.
+func withDeadline(deadline time.Time, work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error)) func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	if deadline.IsZero() {
+		return work
+	}
+
+	return func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		res := make(chan error, 1)
+		go work(accountID, jobID, txMan, res)
+
+		select {
+		case err := <-res:
+			errOut <- err
+		case <-time.After(time.Until(deadline)):
+			log.Warningf("mint deadline %s exceeded for job %s", deadline, jobID.String())
+			errOut <- ErrMintDeadlineExceeded
+		}
+	}
+}
+
 func (s *service) transferFromJob(ctx context.Context, registry common.Address, from common.Address, to common.Address, tokenID TokenID) func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
 	return func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
 		owner, err := s.OwnerOf(registry, tokenID[:])
@@ -406,7 +737,7 @@ func (s *service) OwnerOf(registry common.Address, tokenID []byte) (common.Addre
 	var owner common.Address
 	var err error
 
-	c := s.bindCallerContract(registry, nftABI, s.ethClient)
+	c := s.registryContract(registry)
 	opts, cancF := s.ethClient.GetGethCallOpts(false)
 	defer cancF()
 
@@ -442,9 +773,32 @@ func (s *service) OwnerOfWithRetrial(registry common.Address, tokenID []byte) (c
 	return owner, err
 }
 
+// CachedOwnerOf returns the owner of the NFT token on ethereum chain.
+// If the chain cannot be read, the last known owner read within the configured read cache TTL is returned flagged as stale.
+func (s *service) CachedOwnerOf(registry common.Address, tokenID []byte) (owner common.Address, stale bool, err error) {
+	ttl := s.cfg.GetNFTReadCacheTTL()
+	key := "ownerOf/" + registry.Hex() + "/" + hexutil.Encode(tokenID)
+	owner, err = s.OwnerOf(registry, tokenID)
+	if err == nil {
+		if ttl > 0 {
+			s.reads.put(key, owner, ttl)
+		}
+		return owner, false, nil
+	}
+
+	cached, ok := s.reads.get(key, ttl)
+	if !ok {
+		return owner, false, err
+	}
+
+	log.Warningf("serving the last known owner of token [%x] as the chain cannot be read: %v", tokenID, err)
+	staleOwnerReads.Inc()
+	return cached.(common.Address), true, nil
+}
+
 // CurrentIndexOfToken returns the current index of the token in the given registry
 func (s *service) CurrentIndexOfToken(registry common.Address, tokenID []byte) (*big.Int, error) {
-	c := s.bindCallerContract(registry, nftABI, s.ethClient)
+	c := s.registryContract(registry)
 	opts, cancF := s.ethClient.GetGethCallOpts(false)
 	defer cancF()
 
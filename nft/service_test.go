@@ -3,6 +3,7 @@
 package nft
 
 import (
+	"context"
 	"math/big"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
@@ -154,13 +156,14 @@ func TestInvoiceUnpaid(t *testing.T) {
 			func() (testingdocuments.MockService, *MockInvoiceUnpaid, testingcommons.MockIdentityService, ethereum.MockEthClient, testingconfig.MockConfig, *testingutils.MockQueue, *testingjobs.MockJobManager) {
 				cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
 				assert.NoError(t, err)
+				assert.NoError(t, cd.SetStatus(documents.Committed))
 				proof := getDummyProof(cd.GetTestCoreDocWithReset())
 				docServiceMock := testingdocuments.MockService{}
 				docServiceMock.On("GetCurrentVersion", decodeHex("0x1212")).Return(&generic.Generic{
 					CoreDocument: cd,
 					Data:         generic.Data{},
 				}, nil)
-				docServiceMock.On("CreateProofs", decodeHex("0x1212"), []string{"collaborators[0]"}).Return(proof, nil)
+				docServiceMock.On("CreateProofs", mock.Anything, decodeHex("0x1212"), []string{"collaborators[0]"}).Return(proof, nil)
 				invoiceUnpaidMock := &MockInvoiceUnpaid{}
 				idServiceMock := testingcommons.MockIdentityService{}
 				ethClientMock := ethereum.MockEthClient{}
@@ -181,17 +184,138 @@ func TestInvoiceUnpaid(t *testing.T) {
 				configMock.On("GetSigningKeyPair").Return("", "")
 				configMock.On("GetPrecommitEnabled").Return(false)
 				configMock.On("GetLowEntropyNFTTokenEnabled").Return(false)
+				configMock.On("GetNFTRegistryProperties").Return(map[string][]string{})
+				configMock.On("GetNFTRegistryAttestationSchemes").Return(map[string]string{})
+				configMock.On("GetNFTRelayerURL").Return("")
 				configMock.On("GetCentChainAccount").Return(config.CentChainAccount{}, nil).Once()
 				queueSrv := new(testingutils.MockQueue)
 				jobMan := new(testingjobs.MockJobManager)
 				jobMan.On("ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
 					mock.Anything, mock.Anything).Return(jobs.NilJobID(), make(chan error), nil)
+				jobMan.On("GetIndexedJob", mock.Anything, mintJobIndexKey, "0x1212").Return(nil, jobs.ErrJobsMissing)
+				jobMan.On("IndexJob", mock.Anything, mock.Anything, mintJobIndexKey, "0x1212").Return(nil)
+				jobMan.On("UpdateJobWithValue", mock.Anything, mock.Anything, PredictedTokenIDKey, mock.Anything).Return(nil)
+				jobMan.On("UpdateJobWithValue", mock.Anything, mock.Anything, mintRegistryKey, mock.Anything).Return(nil)
 				return docServiceMock, invoiceUnpaidMock, idServiceMock, ethClientMock, configMock, queueSrv, jobMan
 			},
 			MintNFTRequest{DocumentID: decodeHex("0x1212"), ProofFields: []string{"collaborators[0]"}, DepositAddress: common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08")},
 			nil,
 			"",
 		},
+		{
+			"anchored within the mint",
+			func() (testingdocuments.MockService, *MockInvoiceUnpaid, testingcommons.MockIdentityService, ethereum.MockEthClient, testingconfig.MockConfig, *testingutils.MockQueue, *testingjobs.MockJobManager) {
+				cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+				assert.NoError(t, err)
+				proof := getDummyProof(cd.GetTestCoreDocWithReset())
+				docServiceMock := testingdocuments.MockService{}
+				docServiceMock.On("GetCurrentVersion", decodeHex("0x1212")).Return(&generic.Generic{
+					CoreDocument: cd,
+					Data:         generic.Data{},
+				}, nil)
+				docServiceMock.On("CreateProofs", mock.Anything, decodeHex("0x1212"), []string{"collaborators[0]"}).Return(proof, nil)
+				invoiceUnpaidMock := &MockInvoiceUnpaid{}
+				idServiceMock := testingcommons.MockIdentityService{}
+				ethClientMock := ethereum.MockEthClient{}
+				ethClientMock.On("GetTxOpts", "ethacc").Return(&bind.TransactOpts{}, nil)
+				ethClientMock.On("SubmitTransactionWithRetries",
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything, mock.Anything,
+				).Return(&types.Transaction{}, nil)
+				configMock := testingconfig.MockConfig{}
+				configMock.On("GetEthereumDefaultAccountName").Return("ethacc")
+				cid := testingidentity.GenerateRandomDID()
+				configMock.On("GetIdentityID").Return(cid[:], nil)
+				configMock.On("GetEthereumAccount", "main").Return(&config.AccountConfig{}, nil)
+				configMock.On("GetEthereumContextWaitTimeout").Return(time.Second)
+				configMock.On("GetReceiveEventNotificationEndpoint").Return("")
+				configMock.On("GetP2PKeyPair").Return("", "")
+				configMock.On("GetSigningKeyPair").Return("", "")
+				configMock.On("GetPrecommitEnabled").Return(false)
+				configMock.On("GetLowEntropyNFTTokenEnabled").Return(false)
+				configMock.On("GetNFTRegistryProperties").Return(map[string][]string{})
+				configMock.On("GetNFTRegistryAttestationSchemes").Return(map[string]string{})
+				configMock.On("GetNFTRelayerURL").Return("")
+				configMock.On("GetCentChainAccount").Return(config.CentChainAccount{}, nil).Once()
+				queueSrv := new(testingutils.MockQueue)
+				jobMan := new(testingjobs.MockJobManager)
+				jobMan.On("ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything, mock.Anything).Return(jobs.NilJobID(), make(chan error), nil)
+				jobMan.On("GetIndexedJob", mock.Anything, mintJobIndexKey, "0x1212").Return(nil, jobs.ErrJobsMissing)
+				jobMan.On("IndexJob", mock.Anything, mock.Anything, mintJobIndexKey, "0x1212").Return(nil)
+				jobMan.On("UpdateJobWithValue", mock.Anything, mock.Anything, PredictedTokenIDKey, mock.Anything).Return(nil)
+				jobMan.On("UpdateJobWithValue", mock.Anything, mock.Anything, mintRegistryKey, mock.Anything).Return(nil)
+				return docServiceMock, invoiceUnpaidMock, idServiceMock, ethClientMock, configMock, queueSrv, jobMan
+			},
+			MintNFTRequest{DocumentID: decodeHex("0x1212"), ProofFields: []string{"collaborators[0]"}, DepositAddress: common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08"), AnchorDocument: true},
+			nil,
+			"",
+		},
+		{
+			"invalid proof fields",
+			func() (testingdocuments.MockService, *MockInvoiceUnpaid, testingcommons.MockIdentityService, ethereum.MockEthClient, testingconfig.MockConfig, *testingutils.MockQueue, *testingjobs.MockJobManager) {
+				cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+				assert.NoError(t, err)
+				assert.NoError(t, cd.SetStatus(documents.Committed))
+				docServiceMock := testingdocuments.MockService{}
+				docServiceMock.On("GetCurrentVersion", decodeHex("0x1212")).Return(&generic.Generic{
+					CoreDocument: cd,
+					Data:         generic.Data{},
+				}, nil)
+				docServiceMock.On("CreateProofs", mock.Anything, decodeHex("0x1212"), []string{"unknown"}).Return(nil, errors.New("failed to find prefix tree in supported list"))
+				configMock := testingconfig.MockConfig{}
+				cid := testingidentity.GenerateRandomDID()
+				configMock.On("GetIdentityID").Return(cid[:], nil)
+				configMock.On("GetEthereumAccount", "main").Return(&config.AccountConfig{}, nil)
+				configMock.On("GetEthereumDefaultAccountName").Return("ethacc")
+				configMock.On("GetEthereumContextWaitTimeout").Return(time.Second)
+				configMock.On("GetReceiveEventNotificationEndpoint").Return("")
+				configMock.On("GetP2PKeyPair").Return("", "")
+				configMock.On("GetSigningKeyPair").Return("", "")
+				configMock.On("GetPrecommitEnabled").Return(false)
+				configMock.On("GetLowEntropyNFTTokenEnabled").Return(false)
+				configMock.On("GetNFTRegistryProperties").Return(map[string][]string{})
+				configMock.On("GetNFTRegistryAttestationSchemes").Return(map[string]string{})
+				configMock.On("GetNFTRelayerURL").Return("")
+				configMock.On("GetCentChainAccount").Return(config.CentChainAccount{}, nil).Once()
+				return docServiceMock, &MockInvoiceUnpaid{}, testingcommons.MockIdentityService{}, ethereum.MockEthClient{}, configMock, new(testingutils.MockQueue), new(testingjobs.MockJobManager)
+			},
+			MintNFTRequest{DocumentID: decodeHex("0x1212"), ProofFields: []string{"unknown"}, DepositAddress: common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08")},
+			ErrInvalidProofFields,
+			"",
+		},
+		{
+			"document not anchored",
+			func() (testingdocuments.MockService, *MockInvoiceUnpaid, testingcommons.MockIdentityService, ethereum.MockEthClient, testingconfig.MockConfig, *testingutils.MockQueue, *testingjobs.MockJobManager) {
+				cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+				assert.NoError(t, err)
+				docServiceMock := testingdocuments.MockService{}
+				docServiceMock.On("GetCurrentVersion", decodeHex("0x1212")).Return(&generic.Generic{
+					CoreDocument: cd,
+					Data:         generic.Data{},
+				}, nil)
+				configMock := testingconfig.MockConfig{}
+				cid := testingidentity.GenerateRandomDID()
+				configMock.On("GetIdentityID").Return(cid[:], nil)
+				configMock.On("GetEthereumAccount", "main").Return(&config.AccountConfig{}, nil)
+				configMock.On("GetEthereumDefaultAccountName").Return("ethacc")
+				configMock.On("GetEthereumContextWaitTimeout").Return(time.Second)
+				configMock.On("GetReceiveEventNotificationEndpoint").Return("")
+				configMock.On("GetP2PKeyPair").Return("", "")
+				configMock.On("GetSigningKeyPair").Return("", "")
+				configMock.On("GetPrecommitEnabled").Return(false)
+				configMock.On("GetLowEntropyNFTTokenEnabled").Return(false)
+				configMock.On("GetNFTRegistryProperties").Return(map[string][]string{})
+				configMock.On("GetNFTRegistryAttestationSchemes").Return(map[string]string{})
+				configMock.On("GetNFTRelayerURL").Return("")
+				configMock.On("GetCentChainAccount").Return(config.CentChainAccount{}, nil).Once()
+				return docServiceMock, &MockInvoiceUnpaid{}, testingcommons.MockIdentityService{}, ethereum.MockEthClient{}, configMock, new(testingutils.MockQueue), new(testingjobs.MockJobManager)
+			},
+			MintNFTRequest{DocumentID: decodeHex("0x1212"), ProofFields: []string{"collaborators[0]"}, DepositAddress: common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08")},
+			ErrDocumentNotAnchored,
+			"",
+		},
 	}
 
 	for _, test := range tests {
@@ -207,10 +331,11 @@ func TestInvoiceUnpaid(t *testing.T) {
 				RegistryAddress: test.request.RegistryAddress,
 				DepositAddress:  test.request.DepositAddress,
 				ProofFields:     test.request.ProofFields,
+				AnchorDocument:  test.request.AnchorDocument,
 			}
 			_, _, err := service.MintNFT(ctxh, req)
 			if test.err != nil {
-				assert.Equal(t, test.err.Error(), err.Error())
+				assert.True(t, errors.IsOfType(test.err, err))
 			} else if err != nil {
 				panic(err)
 			}
@@ -248,7 +373,17 @@ func TestTokenTransfer(t *testing.T) {
 	registryAddress := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
 	to := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
 
+	// transfers are not allowed by default
 	tokenID := NewTokenID()
+	_, _, err := service.TransferFrom(ctxh, registryAddress, to, tokenID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrRegistryMethodNotAllowed, err))
+	jobMan.AssertNotCalled(t, "ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	service.methods, err = newRegistryMethods(map[string][]string{
+		"0x111855759a39fb75fc7341139f5d7a3974d4da08": {RegistryMethodTransfer},
+	})
+	assert.NoError(t, err)
 	resp, _, err := service.TransferFrom(ctxh, registryAddress, to, tokenID)
 	assert.NoError(t, err)
 	assert.Equal(t, jobID.String(), resp.JobID)
@@ -321,3 +456,144 @@ func Test_getBundledHash(t *testing.T) {
 	got := getBundledHash(to, props, values, salts)
 	assert.Equal(t, bh, got[:], "bundled hash mismatch")
 }
+
+func TestService_MintNFT_deadlineExceeded(t *testing.T) {
+	configMock := &testingconfig.MockConfig{}
+	cid := testingidentity.GenerateRandomDID()
+	configMock.On("GetIdentityID").Return(cid[:], nil)
+	configMock.On("GetEthereumAccount", "main").Return(&config.AccountConfig{}, nil)
+	configMock.On("GetEthereumDefaultAccountName").Return("ethacc")
+	configMock.On("GetEthereumContextWaitTimeout").Return(time.Second)
+	configMock.On("GetReceiveEventNotificationEndpoint").Return("")
+	configMock.On("GetP2PKeyPair").Return("", "")
+	configMock.On("GetSigningKeyPair").Return("", "")
+	configMock.On("GetPrecommitEnabled").Return(false)
+	configMock.On("GetCentChainAccount").Return(config.CentChainAccount{}, nil).Once()
+	service := newService(configMock, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctxh := testingconfig.CreateAccountContext(t, configMock)
+	_, _, err := service.MintNFT(ctxh, MintNFTRequest{Deadline: time.Now().Add(-time.Minute)})
+	assert.Equal(t, ErrMintDeadlineExceeded, err)
+}
+
+func Test_withDeadline(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	work := func(d time.Duration) func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		return func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+			time.Sleep(d)
+			errOut <- nil
+		}
+	}
+
+	// done before deadline
+	errOut := make(chan error, 1)
+	withDeadline(time.Now().Add(time.Second), work(0))(did, jobs.NewJobID(), nil, errOut)
+	assert.NoError(t, <-errOut)
+
+	// deadline exceeded
+	withDeadline(time.Now().Add(10*time.Millisecond), work(time.Second))(did, jobs.NewJobID(), nil, errOut)
+	assert.Equal(t, ErrMintDeadlineExceeded, <-errOut)
+
+	// no deadline
+	withDeadline(time.Time{}, work(20*time.Millisecond))(did, jobs.NewJobID(), nil, errOut)
+	assert.NoError(t, <-errOut)
+}
+
+func TestService_tokenPropertyFields(t *testing.T) {
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	configMock := &testingconfig.MockConfig{}
+	configMock.On("GetNFTRegistryProperties").Return(map[string][]string{
+		"0x111855759a39fb75fc7341139f5d7a3974d4da08": {"amount", "due_date"},
+	})
+	service := newService(configMock, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	// registry without schema
+	other := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	fields, err := service.tokenPropertyFields(other, nil)
+	assert.NoError(t, err)
+	assert.Len(t, fields, 0)
+	_, err = service.tokenPropertyFields(other, map[string]string{"amount": "invoice.gross_amount"})
+	assert.True(t, errors.IsOfType(ErrInvalidPropertyMapping, err))
+
+	// required property not mapped
+	_, err = service.tokenPropertyFields(registry, map[string]string{"amount": "invoice.gross_amount"})
+	assert.True(t, errors.IsOfType(ErrInvalidPropertyMapping, err))
+
+	// unknown property
+	_, err = service.tokenPropertyFields(registry, map[string]string{
+		"amount":   "invoice.gross_amount",
+		"due_date": "invoice.date_due",
+		"currency": "invoice.currency",
+	})
+	assert.True(t, errors.IsOfType(ErrInvalidPropertyMapping, err))
+
+	// fields are in the slot order
+	fields, err = service.tokenPropertyFields(registry, map[string]string{
+		"due_date": "invoice.date_due",
+		"amount":   "invoice.gross_amount",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"invoice.gross_amount", "invoice.date_due"}, fields)
+	assert.Equal(t, []string{"invoice.gross_amount", "invoice.date_due", "collaborators[0]"},
+		mergeProofFields(fields, []string{"invoice.date_due", "collaborators[0]"}))
+	assert.Equal(t, []string{"collaborators[0]"}, mergeProofFields(nil, []string{"collaborators[0]"}))
+}
+
+func TestService_mintGasLimit(t *testing.T) {
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	data := []byte{1, 2, 3}
+	ctx := context.Background()
+	mintGasLimit := func(padding float64, estimate uint64, err error) uint64 {
+		configMock := &testingconfig.MockConfig{}
+		configMock.On("GetEthereumGasLimit", config.NftMint).Return(uint64(900000))
+		configMock.On("GetNFTMintGasPadding").Return(padding)
+		idService := &testingcommons.MockIdentityService{}
+		idService.On("EstimateRawExecuteGas", ctx, registry, data).Return(estimate, err)
+		return newService(configMock, idService, nil, nil, nil, nil, nil, nil, nil).mintGasLimit(ctx, registry, data)
+	}
+
+	// estimation disabled
+	assert.Equal(t, uint64(900000), mintGasLimit(0, 0, nil))
+
+	// padded estimate
+	assert.Equal(t, uint64(120000), mintGasLimit(1.2, 100000, nil))
+
+	// padding bounded
+	assert.Equal(t, uint64(200000), mintGasLimit(5, 100000, nil))
+	assert.Equal(t, uint64(100000), mintGasLimit(0.5, 100000, nil))
+
+	// failed estimation
+	assert.Equal(t, uint64(900000), mintGasLimit(1.2, 0, errors.New("execution reverted")))
+}
+
+func TestService_IsMintInProgress(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	docID := utils.RandomSlice(32)
+	isMintInProgress := func(job *jobs.Job, err error) (bool, jobs.JobID, error) {
+		jobMan := new(testingjobs.MockJobManager)
+		jobMan.On("GetIndexedJob", did, mintJobIndexKey, hexutil.Encode(docID)).Return(job, err)
+		return newService(nil, nil, nil, nil, nil, nil, jobMan, nil, nil).IsMintInProgress(did, docID)
+	}
+
+	// never minted
+	ok, jobID, err := isMintInProgress(nil, errors.NewTypedError(jobs.ErrJobsMissing, errors.New("not found")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.True(t, jobs.JobIDEqual(jobs.NilJobID(), jobID))
+
+	// lookup failed
+	_, _, err = isMintInProgress(nil, errors.New("db closed"))
+	assert.Error(t, err)
+
+	// pending
+	job := jobs.NewJob(did, "Minting NFT")
+	ok, jobID, err = isMintInProgress(job, nil)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, job.ID, jobID)
+
+	// finished
+	job.Status = jobs.Success
+	ok, _, err = isMintInProgress(job, nil)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
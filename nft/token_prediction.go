@@ -0,0 +1,63 @@
+package nft
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// PredictedTokenIDKey is the job value key of the token ID predicted when the mint was submitted
+	PredictedTokenIDKey = "nft_predicted_token_id"
+
+	// MintedTokenIDKey is the job value key of the token ID actually minted, only recorded if it differs from the prediction
+	MintedTokenIDKey = "nft_minted_token_id"
+
+	// ErrTokenIDMismatch error when the registry minted a token ID other than the predicted one
+	ErrTokenIDMismatch = errors.Error("minted token ID doesn't match the predicted token ID")
+)
+
+// verifyMintedTokenID checks that the mint transaction recorded on the job minted the predicted token ID.
+// The actual token ID is recorded on the job if it differs. Mints that cannot be verified, such as those of
+// registries without a configured mint event not emitting the Transfer event, are only logged.
+func (s *service) verifyMintedTokenID(ctx context.Context, txMan jobs.Manager, accountID identity.DID, jobID jobs.JobID, registry common.Address, predicted TokenID) error {
+	job, err := txMan.GetJob(accountID, jobID)
+	if err != nil {
+		return err
+	}
+
+	v, ok := job.Values[ethereum.TransactionHashKey]
+	if !ok {
+		log.Warningf("no mint transaction recorded on job %s, skipping the token ID verification", jobID.String())
+		return nil
+	}
+
+	receipt, err := s.ethClient.TransactionReceipt(ctx, common.BytesToHash(v.Value))
+	if err != nil {
+		return err
+	}
+
+	minted, ok, err := s.events.mintedTokenID(receipt, registry)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		log.Warningf("no mint event of registry %s in the transaction of job %s, skipping the token ID verification", registry.Hex(), jobID.String())
+		return nil
+	}
+
+	if minted == predicted {
+		return nil
+	}
+
+	if err := txMan.UpdateJobWithValue(accountID, jobID, MintedTokenIDKey, minted[:]); err != nil {
+		log.Errorf("failed to record the minted token ID %s on job %s: %v", minted.String(), jobID.String(), err)
+	}
+
+	return errors.NewTypedError(ErrTokenIDMismatch, errors.New("predicted %s, minted %s", predicted.String(), minted.String()))
+}
@@ -0,0 +1,77 @@
+// +build unit
+
+package nft
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mintLog(registry common.Address, from common.Address, tokenID TokenID) *types.Log {
+	to := common.HexToAddress("0xf72855759a39fb75fc7341139f5d7a3974d4da08")
+	return &types.Log{
+		Address: registry,
+		Topics: []common.Hash{
+			transferEvent.event.ID,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+			common.BytesToHash(tokenID[:]),
+		},
+	}
+}
+
+func TestService_verifyMintedTokenID(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	jobID := jobs.NewJobID()
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	txHash := common.HexToHash("0x1")
+	predicted := NewTokenID()
+	job := jobs.NewJob(did, "Minting NFT")
+	job.Values[ethereum.TransactionHashKey] = jobs.JobValue{Key: ethereum.TransactionHashKey, Value: txHash.Bytes()}
+
+	// no transaction recorded
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("GetJob", did, jobID).Return(jobs.NewJob(did, "Minting NFT"), nil).Once()
+	srv := newService(nil, nil, nil, nil, nil, nil, jobMan, nil, nil)
+	assert.NoError(t, srv.verifyMintedTokenID(context.Background(), jobMan, did, jobID, registry, predicted))
+
+	// no mint event
+	ethClient := new(ethereum.MockEthClient)
+	ethClient.On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{}, nil).Once()
+	jobMan.On("GetJob", did, jobID).Return(job, nil)
+	srv = newService(nil, nil, ethClient, nil, nil, nil, jobMan, nil, nil)
+	assert.NoError(t, srv.verifyMintedTokenID(context.Background(), jobMan, did, jobID, registry, predicted))
+
+	// predicted token minted
+	receipt := &types.Receipt{Logs: []*types.Log{mintLog(registry, common.Address{}, predicted)}}
+	ethClient.On("TransactionReceipt", mock.Anything, txHash).Return(receipt, nil).Once()
+	assert.NoError(t, srv.verifyMintedTokenID(context.Background(), jobMan, did, jobID, registry, predicted))
+
+	// mismatch is recorded on the job
+	minted := NewTokenID()
+	receipt = &types.Receipt{Logs: []*types.Log{mintLog(registry, common.Address{}, minted)}}
+	ethClient.On("TransactionReceipt", mock.Anything, txHash).Return(receipt, nil).Once()
+	jobMan.On("UpdateJobWithValue", did, jobID, MintedTokenIDKey, minted[:]).Return(nil).Once()
+	err := srv.verifyMintedTokenID(context.Background(), jobMan, did, jobID, registry, predicted)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTokenIDMismatch, err))
+
+	// mint event of the registry unknown
+	srv.events = registryEvents{strings.ToLower(registry.Hex()): mintedEvent(t)}
+	ethClient.On("TransactionReceipt", mock.Anything, txHash).Return(receipt, nil).Once()
+	err = srv.verifyMintedTokenID(context.Background(), jobMan, did, jobID, registry, predicted)
+	assert.True(t, errors.IsOfType(ErrUnknownMintEvent, err))
+	jobMan.AssertExpectations(t)
+	ethClient.AssertExpectations(t)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -36,8 +37,9 @@ func (*Bootstrapper) Bootstrap(c map[string]interface{}) error {
 	case sig := <-controlC:
 		log.Info("Node shutting down because of ", sig)
 		canc()
-		cleanUp(c)
+		// the db is closed only once the servers are stopped as the job manager updates the cancelled jobs
 		err := <-feedback
+		cleanUp(c)
 		return err
 	}
 
@@ -66,7 +68,12 @@ func GetServers(ctx map[string]interface{}) ([]Server, error) {
 		return nil, errors.New("queue server not initialized")
 	}
 
+	jobsMan, ok := ctx[jobs.BootstrappedService]
+	if !ok {
+		return nil, errors.New("job manager not initialized")
+	}
+
 	var servers []Server
-	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), queueSrv.(Server))
+	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), queueSrv.(Server), jobsMan.(Server))
 	return servers, nil
 }
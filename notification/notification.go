@@ -2,13 +2,20 @@ package notification
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/hmac"
+	"crypto/sha256"
+	"net"
+	"net/http"
+	"sync"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	logging "github.com/ipfs/go-log"
+	"gopkg.in/resty.v1"
 )
 
 var log = logging.Logger("notification-api")
@@ -23,10 +30,31 @@ type Status int
 const (
 	ReceivedPayload EventType = 1
 	JobCompleted    EventType = 2
+	JobSuperseded   EventType = 3
+	JobRetrying     EventType = 4
 	Failure         Status    = 0
 	Success         Status    = 1
+
+	// OptInJobRetrying is the name of the JobRetrying event in the opt-in events config.
+	OptInJobRetrying = "job_retrying"
+
+	// SignatureHeader is the header carrying the hex encoded HMAC-SHA256 of the payload when the account defines a webhook secret.
+	SignatureHeader = "X-Centrifuge-Signature"
+
+	defaultDialTimeout           = 10 * time.Second
+	defaultTLSHandshakeTimeout   = 10 * time.Second
+	defaultResponseHeaderTimeout = 30 * time.Second
 )
 
+// Config defines the node wide endpoint and the timeouts used when sending the notifications.
+type Config interface {
+	GetReceiveEventNotificationEndpoint() string
+	GetNotificationDialTimeout() time.Duration
+	GetNotificationTLSHandshakeTimeout() time.Duration
+	GetNotificationResponseHeaderTimeout() time.Duration
+	GetNotificationMaxPayloadSize() int
+}
+
 // Message is the payload used to send the notifications.
 type Message struct {
 	EventType    EventType `json:"event_type"`
@@ -38,6 +66,12 @@ type Message struct {
 	AccountID    string    `json:"account_id"` // account_id is the account associated to webhook
 	FromID       string    `json:"from_id"`    // from_id if provided, original trigger of the event
 	ToID         string    `json:"to_id"`      // to_id if provided, final destination of the event
+	// Metadata if provided, additional details of the event such as the originating API request
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Truncated is true if the message or the metadata values were truncated to fit the max payload size
+	Truncated bool `json:"truncated,omitempty"`
+	// SchemaVersion is the schema version of the payload, set when the notification is sent
+	SchemaVersion int `json:"schema_version"`
 }
 
 // Sender defines methods that can handle a notification.
@@ -45,42 +79,192 @@ type Sender interface {
 	Send(ctx context.Context, notification Message) (Status, error)
 }
 
+// Receipt is the outcome of the delivery of a notification to a webhook.
+type Receipt struct {
+	URL string
+
+	// StatusCode is the HTTP status the webhook responded with, 0 if it didn't respond
+	StatusCode int
+	Delivered  bool
+	Error      string
+	SentAt     time.Time
+}
+
+// ReceiptSender is implemented by the Senders reporting the outcome of the deliveries.
+type ReceiptSender interface {
+	Sender
+
+	// SendWithReceipt sends the notification and returns the receipt of the delivery, also if it failed.
+	// The receipt is nil if the notification wasn't delivered to any webhook.
+	SendWithReceipt(ctx context.Context, notification Message) (*Receipt, error)
+}
+
+// AccountStore returns the accounts of the node by identity.
+type AccountStore interface {
+	GetAccount(identifier []byte) (config.Account, error)
+}
+
 // NewWebhookSender returns an implementation of a Sender that sends notifications through webhooks.
-func NewWebhookSender() Sender {
-	return webhookSender{}
+// The webhook of the account a notification is for is looked up in the accounts store.
+// Accounts can be nil in which case the account is taken from the context.
+// The connections to the webhooks are kept alive and reused by the notifications sent through the sender.
+func NewWebhookSender(config Config, accounts AccountStore) Sender {
+	return webhookSender{config: config, accounts: accounts, client: new(webhookClient)}
 }
 
 // NewWebhookSender implements Sender.
 // Sends notification through a webhook defined.
-type webhookSender struct{}
+type webhookSender struct {
+	config   Config
+	accounts AccountStore
+
+	// client is shared by the copies of the sender
+	client *webhookClient
+}
 
-// Send sends notification to the defined webhook.
+// webhookClient sends the webhooks through the transport built with the configured timeouts on the first send.
+type webhookClient struct {
+	once   sync.Once
+	client *resty.Client
+}
+
+// get returns the client, built with the config on the first call.
+func (c *webhookClient) get(config Config) *resty.Client {
+	c.once.Do(func() {
+		c.client = utils.NewHTTPClient(newTransport(config))
+	})
+
+	return c.client
+}
+
+// webhook is where and how the notifications of an account are delivered.
+type webhook struct {
+	url     string
+	headers map[string]string
+	secret  string
+	version int
+
+	// template renders the payloads instead of the schema version, optional
+	template string
+}
+
+// Send sends notification to the webhook of the account the notification is for.
 func (wh webhookSender) Send(ctx context.Context, notification Message) (Status, error) {
-	tc, err := contextutil.Account(ctx)
+	_, err := wh.SendWithReceipt(ctx, notification)
 	if err != nil {
 		return Failure, err
 	}
-	url := tc.GetReceiveEventNotificationEndpoint()
-	if url == "" {
+
+	return Success, nil
+}
+
+// SendWithReceipt sends notification to the webhook of the account the notification is for and returns the receipt.
+func (wh webhookSender) SendWithReceipt(ctx context.Context, notification Message) (*Receipt, error) {
+	hook, err := wh.webhook(ctx, notification.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hook.url == "" {
 		log.Warningf("Webhook URL not defined, manually fetch received document")
-		return Success, nil
+		return nil, nil
 	}
 
-	payload, err := json.Marshal(notification)
+	payload, err := renderPayload(notification, hook, wh.config.GetNotificationMaxPayloadSize())
 	if err != nil {
-		return Failure, err
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+	for k, v := range hook.headers {
+		headers[k] = v
+	}
+
+	if hook.secret != "" {
+		headers[SignatureHeader] = sign(hook.secret, payload)
+	}
+
+	receipt := &Receipt{URL: hook.url, SentAt: time.Now().UTC()}
+	receipt.StatusCode, err = utils.SendPOSTRequestWithClient(wh.client.get(wh.config), hook.url, "application/json", payload, headers)
+	if err == nil && !utils.InRange(receipt.StatusCode, 200, 299) {
+		err = errors.New("failed to send webhook: status = %v", receipt.StatusCode)
 	}
 
-	statusCode, err := utils.SendPOSTRequest(url, "application/json", payload)
 	if err != nil {
-		return Failure, err
+		receipt.Error = err.Error()
+		return receipt, err
 	}
 
-	if !utils.InRange(statusCode, 200, 299) {
-		return Failure, errors.New("failed to send webhook: status = %v", statusCode)
+	log.Infof("Sent Webhook Notification with Payload [%v] to [%s]", notification, hook.url)
+	receipt.Delivered = true
+	return receipt, nil
+}
+
+// webhook returns the webhook of the account. The account is looked up in the accounts store
+// and falls back to the account in the context. Accounts without an endpoint use the node wide endpoint.
+// The payloads are in the schema version pinned by the account, if any, or rendered by the payload template of the account.
+func (wh webhookSender) webhook(ctx context.Context, accountID string) (webhook, error) {
+	acc, err := wh.account(ctx, accountID)
+	if err != nil {
+		return webhook{}, err
 	}
 
-	log.Infof("Sent Webhook Notification with Payload [%v] to [%s]", notification, url)
+	hook := webhook{
+		url:      acc.GetReceiveEventNotificationEndpoint(),
+		headers:  acc.GetReceiveEventNotificationHeaders(),
+		secret:   acc.GetReceiveEventNotificationSecret(),
+		version:  schemaVersion(acc.GetReceiveEventNotificationVersion()),
+		template: acc.GetReceiveEventNotificationTemplate(),
+	}
 
-	return Success, nil
+	if hook.url == "" {
+		hook.url = wh.config.GetReceiveEventNotificationEndpoint()
+	}
+
+	return hook, nil
+}
+
+func (wh webhookSender) account(ctx context.Context, accountID string) (config.Account, error) {
+	if wh.accounts != nil && accountID != "" {
+		id, err := hexutil.Decode(accountID)
+		if err == nil {
+			acc, err := wh.accounts.GetAccount(id)
+			if err == nil {
+				return acc, nil
+			}
+		}
+
+		log.Debugf("account %s not found in the store, using the context account", accountID)
+	}
+
+	return contextutil.Account(ctx)
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the payload keyed with the secret.
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hexutil.Encode(mac.Sum(nil))
+}
+
+// newTransport returns the transport with the configured timeouts so that no single phase of the request can stall indefinitely.
+// Unset timeouts fall back to the defaults.
+func newTransport(config Config) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   timeoutOrDefault(config.GetNotificationDialTimeout(), defaultDialTimeout),
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   timeoutOrDefault(config.GetNotificationTLSHandshakeTimeout(), defaultTLSHandshakeTimeout),
+		ResponseHeaderTimeout: timeoutOrDefault(config.GetNotificationResponseHeaderTimeout(), defaultResponseHeaderTimeout),
+	}
+}
+
+func timeoutOrDefault(timeout, def time.Duration) time.Duration {
+	if timeout <= 0 {
+		return def
+	}
+
+	return timeout
 }
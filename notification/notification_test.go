@@ -3,11 +3,15 @@
 package notification
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,6 +19,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
 	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/utils"
@@ -77,7 +84,7 @@ func TestWebhookSender_Send(t *testing.T) {
 	go server.ListenAndServe()
 	defer server.Close()
 
-	wb := NewWebhookSender()
+	wb := NewWebhookSender(cfg, nil)
 	notif := Message{
 		DocumentID:   hexutil.Encode(docID),
 		DocumentType: documenttypes.InvoiceDataTypeUrl,
@@ -96,3 +103,186 @@ func TestWebhookSender_Send(t *testing.T) {
 	assert.Equal(t, status, Success)
 	wg.Wait()
 }
+
+func TestWebhookSender_SendWithReceipt(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cfg.Set("notifications.endpoint", server.URL)
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	wb := NewWebhookSender(cfg, nil).(ReceiptSender)
+	notif := Message{EventType: JobCompleted, Recorded: time.Now().UTC()}
+
+	// delivered
+	receipt, err := wb.SendWithReceipt(ctx, notif)
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL, receipt.URL)
+	assert.Equal(t, http.StatusOK, receipt.StatusCode)
+	assert.True(t, receipt.Delivered)
+	assert.Empty(t, receipt.Error)
+	assert.False(t, receipt.SentAt.IsZero())
+
+	// rejected by the webhook
+	status = http.StatusServiceUnavailable
+	receipt, err = wb.SendWithReceipt(ctx, notif)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, receipt.StatusCode)
+	assert.False(t, receipt.Delivered)
+	assert.Equal(t, err.Error(), receipt.Error)
+
+	// no response
+	server.Close()
+	receipt, err = wb.SendWithReceipt(ctx, notif)
+	assert.Error(t, err)
+	assert.Zero(t, receipt.StatusCode)
+	assert.False(t, receipt.Delivered)
+
+	// no webhook
+	cfg.Set("notifications.endpoint", "")
+	receipt, err = wb.SendWithReceipt(testingconfig.CreateAccountContext(t, cfg), notif)
+	assert.NoError(t, err)
+	assert.Nil(t, receipt)
+}
+
+func TestWebhookSender_keepAlive(t *testing.T) {
+	var conns int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	cfg.Set("notifications.endpoint", server.URL)
+	defer cfg.Set("notifications.endpoint", "")
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	wb := NewWebhookSender(cfg, nil)
+	for i := 0; i < 3; i++ {
+		status, err := wb.Send(ctx, Message{EventType: JobCompleted, Recorded: time.Now().UTC()})
+		assert.NoError(t, err)
+		assert.Equal(t, Success, status)
+	}
+
+	// the connection is reused by the sends
+	assert.Equal(t, int32(1), atomic.LoadInt32(&conns))
+}
+
+type mockConfig struct {
+	timeout        time.Duration
+	endpoint       string
+	maxPayloadSize int
+}
+
+func (m mockConfig) GetReceiveEventNotificationEndpoint() string {
+	return m.endpoint
+}
+
+func (m mockConfig) GetNotificationDialTimeout() time.Duration {
+	return m.timeout
+}
+
+func (m mockConfig) GetNotificationTLSHandshakeTimeout() time.Duration {
+	return m.timeout
+}
+
+func (m mockConfig) GetNotificationResponseHeaderTimeout() time.Duration {
+	return m.timeout
+}
+
+func (m mockConfig) GetNotificationMaxPayloadSize() int {
+	return m.maxPayloadSize
+}
+
+func TestNewTransport(t *testing.T) {
+	// defaults
+	tr := newTransport(mockConfig{})
+	assert.Equal(t, defaultTLSHandshakeTimeout, tr.TLSHandshakeTimeout)
+	assert.Equal(t, defaultResponseHeaderTimeout, tr.ResponseHeaderTimeout)
+
+	// configured
+	tr = newTransport(mockConfig{timeout: time.Second})
+	assert.Equal(t, time.Second, tr.TLSHandshakeTimeout)
+	assert.Equal(t, time.Second, tr.ResponseHeaderTimeout)
+
+	// from config file
+	tr = newTransport(cfg)
+	assert.Equal(t, 10*time.Second, tr.TLSHandshakeTimeout)
+	assert.Equal(t, 30*time.Second, tr.ResponseHeaderTimeout)
+}
+
+type mockAccountStore map[string]config.Account
+
+func (m mockAccountStore) GetAccount(id []byte) (config.Account, error) {
+	acc, ok := m[hexutil.Encode(id)]
+	if !ok {
+		return nil, errors.New("account not found")
+	}
+
+	return acc, nil
+}
+
+func TestWebhookSender_webhook(t *testing.T) {
+	ctxAcc := &configstore.Account{IdentityID: utils.RandomSlice(identity.DIDLength), ReceiveEventNotificationEndpoint: "http://ctx"}
+	ctx, err := contextutil.New(context.Background(), ctxAcc)
+	assert.NoError(t, err)
+	storeID := hexutil.Encode(utils.RandomSlice(identity.DIDLength))
+	headers := map[string]string{"Authorization": "Bearer token"}
+	store := mockAccountStore{
+		storeID: &configstore.Account{
+			ReceiveEventNotificationEndpoint: "http://store",
+			ReceiveEventNotificationHeaders:  headers,
+			ReceiveEventNotificationSecret:   "secret",
+		},
+	}
+
+	// account from the store
+	wh := webhookSender{config: mockConfig{endpoint: "http://node"}, accounts: store}
+	hook, err := wh.webhook(ctx, storeID)
+	assert.NoError(t, err)
+	assert.Equal(t, webhook{url: "http://store", headers: headers, secret: "secret", version: LatestSchemaVersion}, hook)
+
+	// pinned schema version
+	store[storeID].(*configstore.Account).ReceiveEventNotificationVersion = SchemaV1
+	hook, err = wh.webhook(ctx, storeID)
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaV1, hook.version)
+
+	// payload template
+	store[storeID].(*configstore.Account).ReceiveEventNotificationTemplate = `{"text": {{json .Message}}}`
+	hook, err = wh.webhook(ctx, storeID)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"text": {{json .Message}}}`, hook.template)
+
+	// unknown account falls back to the context account
+	hook, err = wh.webhook(ctx, hexutil.Encode(utils.RandomSlice(identity.DIDLength)))
+	assert.NoError(t, err)
+	assert.Equal(t, "http://ctx", hook.url)
+
+	// no store
+	wh.accounts = nil
+	hook, err = wh.webhook(ctx, storeID)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://ctx", hook.url)
+
+	// node wide endpoint
+	ctxAcc.ReceiveEventNotificationEndpoint = ""
+	hook, err = wh.webhook(ctx, storeID)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://node", hook.url)
+
+	// no account
+	_, err = wh.webhook(context.Background(), storeID)
+	assert.Error(t, err)
+}
+
+func TestSign(t *testing.T) {
+	// RFC 4231 test case 2
+	assert.Equal(t, "0x5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", sign("Jefe", []byte("what do ya want for nothing?")))
+}
@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"context"
+	"sync"
+)
+
+// deliveryQueues orders the deliveries of the notifications per account.
+// Each queued delivery waits for the one ahead of it to finish.
+type deliveryQueues struct {
+	mu     sync.Mutex
+	queues map[string][]chan struct{}
+}
+
+// ordered is shared by the ordered senders so that the notifications of an account are ordered across them.
+var ordered = &deliveryQueues{queues: make(map[string][]chan struct{})}
+
+// enqueue queues a delivery for the account and returns the channel closed once it is the delivery's turn.
+func (q *deliveryQueues) enqueue(accountID string) <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	turn := make(chan struct{})
+	q.queues[accountID] = append(q.queues[accountID], turn)
+	if len(q.queues[accountID]) == 1 {
+		close(turn)
+	}
+
+	return turn
+}
+
+// done removes the finished delivery of the account and hands the turn to the next one.
+func (q *deliveryQueues) done(accountID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	queue := q.queues[accountID][1:]
+	if len(queue) == 0 {
+		delete(q.queues, accountID)
+		return
+	}
+
+	q.queues[accountID] = queue
+	close(queue[0])
+}
+
+// orderedSender delivers the notifications of an account one at a time in the order they are sent.
+type orderedSender struct {
+	sender Sender
+	queues *deliveryQueues
+}
+
+// NewOrderedSender returns a Sender delivering the notifications of an account through sender one at a time,
+// in the order Send is called. Send blocks until the notifications sent before it for the account are delivered.
+// Notifications of different accounts are delivered concurrently.
+func NewOrderedSender(sender Sender) Sender {
+	return orderedSender{sender: sender, queues: ordered}
+}
+
+// Send waits for the earlier notifications of the account and delivers the notification.
+func (s orderedSender) Send(ctx context.Context, notification Message) (Status, error) {
+	turn := s.queues.enqueue(notification.AccountID)
+	defer s.queues.done(notification.AccountID)
+	<-turn
+	return s.sender.Send(ctx, notification)
+}
+
+// SendWithReceipt waits for the earlier notifications of the account and delivers the notification.
+// The receipt is nil if the wrapped sender doesn't report receipts.
+func (s orderedSender) SendWithReceipt(ctx context.Context, notification Message) (*Receipt, error) {
+	rs, ok := s.sender.(ReceiptSender)
+	if !ok {
+		_, err := s.Send(ctx, notification)
+		return nil, err
+	}
+
+	turn := s.queues.enqueue(notification.AccountID)
+	defer s.queues.done(notification.AccountID)
+	<-turn
+	return rs.SendWithReceipt(ctx, notification)
+}
@@ -0,0 +1,92 @@
+// +build unit
+
+package notification
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSender records the delivered notifications, the first delivery of account "slow" takes a while.
+type recordingSender struct {
+	mu        sync.Mutex
+	delivered []string
+	slow      chan struct{}
+}
+
+func (s *recordingSender) Send(ctx context.Context, notification Message) (Status, error) {
+	if notification.Message == "first" {
+		<-s.slow
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delivered = append(s.delivered, notification.AccountID+":"+notification.Message)
+	return Success, nil
+}
+
+func TestOrderedSender_Send(t *testing.T) {
+	rs := &recordingSender{slow: make(chan struct{})}
+	sender := NewOrderedSender(rs)
+	var wg sync.WaitGroup
+	send := func(accountID, msg string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := sender.Send(context.Background(), Message{AccountID: accountID, Message: msg})
+			assert.NoError(t, err)
+			assert.Equal(t, Success, status)
+		}()
+		// let the send queue before the next one
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	send("0x01", "first")
+	send("0x01", "second")
+	send("0x01", "third")
+
+	// other accounts are not held up by the slow delivery
+	send("0x02", "other")
+	rs.mu.Lock()
+	assert.Equal(t, []string{"0x02:other"}, rs.delivered)
+	rs.mu.Unlock()
+
+	close(rs.slow)
+	wg.Wait()
+	assert.Equal(t, []string{"0x02:other", "0x01:first", "0x01:second", "0x01:third"}, rs.delivered)
+
+	// queues are released once delivered
+	ordered.mu.Lock()
+	assert.Empty(t, ordered.queues)
+	ordered.mu.Unlock()
+}
+
+// receiptSender reports the receipt of every delivery.
+type receiptSender struct {
+	recordingSender
+}
+
+func (s *receiptSender) SendWithReceipt(ctx context.Context, notification Message) (*Receipt, error) {
+	_, err := s.Send(ctx, notification)
+	return &Receipt{URL: "http://webhook", StatusCode: 200, Delivered: true}, err
+}
+
+func TestOrderedSender_SendWithReceipt(t *testing.T) {
+	msg := Message{AccountID: "0x01", Message: "delivered"}
+	rs := &receiptSender{}
+	receipt, err := NewOrderedSender(rs).(ReceiptSender).SendWithReceipt(context.Background(), msg)
+	assert.NoError(t, err)
+	assert.Equal(t, &Receipt{URL: "http://webhook", StatusCode: 200, Delivered: true}, receipt)
+	assert.Equal(t, []string{"0x01:delivered"}, rs.delivered)
+
+	// senders without receipts still deliver
+	plain := &recordingSender{}
+	receipt, err = NewOrderedSender(plain).(ReceiptSender).SendWithReceipt(context.Background(), msg)
+	assert.NoError(t, err)
+	assert.Nil(t, receipt)
+	assert.Equal(t, []string{"0x01:delivered"}, plain.delivered)
+}
@@ -0,0 +1,100 @@
+package notification
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// truncatedSuffix marks the truncated fields of the notifications exceeding the max payload size.
+const truncatedSuffix = "...[truncated]"
+
+// ErrPayloadTooLarge is returned if the notification exceeds the max payload size even with its message and metadata values truncated.
+const ErrPayloadTooLarge = errors.Error("notification payload too large")
+
+// marshalPayload returns the JSON payload of the notification in the shape of the schema version within maxSize bytes,
+// 0 means no limit. Oversized notifications have their message and then their largest metadata values truncated and
+// are flagged as truncated in the schema versions carrying the flag. The other fields are never truncated.
+func marshalPayload(notification Message, version, maxSize int) ([]byte, error) {
+	if version == SchemaV1 {
+		// the metadata isn't part of the payload
+		notification.Metadata = nil
+	}
+
+	data, err := marshalSchema(notification, version)
+	if err != nil || maxSize <= 0 || len(data) <= maxSize {
+		return data, err
+	}
+
+	notification.Truncated = true
+	metadata := make(map[string]string, len(notification.Metadata))
+	var keys []string
+	for k, v := range notification.Metadata {
+		metadata[k] = v
+		keys = append(keys, k)
+	}
+	notification.Metadata = metadata
+	sort.Slice(keys, func(i, j int) bool {
+		if len(metadata[keys[i]]) != len(metadata[keys[j]]) {
+			return len(metadata[keys[i]]) > len(metadata[keys[j]])
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	fields := []func(trim func(string) string){
+		func(trim func(string) string) { notification.Message = trim(notification.Message) },
+	}
+	for _, k := range keys {
+		k := k
+		fields = append(fields, func(trim func(string) string) { metadata[k] = trim(metadata[k]) })
+	}
+
+	for _, field := range fields {
+		emptied := false
+		for !emptied {
+			data, err = marshalSchema(notification, version)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(data) <= maxSize {
+				return data, nil
+			}
+
+			overflow := len(data) - maxSize
+			field(func(s string) string {
+				s = truncate(s, overflow)
+				emptied = s == ""
+				return s
+			})
+		}
+	}
+
+	data, err = marshalSchema(notification, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxSize {
+		return nil, errors.NewTypedError(ErrPayloadTooLarge, errors.New("size %d exceeds %d bytes", len(data), maxSize))
+	}
+
+	return data, nil
+}
+
+// truncate drops at least n bytes from the end of s and marks it as truncated.
+// Strings too short to be truncated are emptied.
+func truncate(s string, n int) string {
+	keep := len(s) - n - len(truncatedSuffix)
+	if keep <= 0 {
+		return ""
+	}
+
+	for keep > 0 && !utf8.RuneStart(s[keep]) {
+		keep--
+	}
+
+	return s[:keep] + truncatedSuffix
+}
@@ -0,0 +1,76 @@
+// +build unit
+
+package notification
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalPayload(t *testing.T) {
+	msg := Message{
+		EventType: JobCompleted,
+		Status:    "failed",
+		Message:   strings.Repeat("log ", 100),
+		AccountID: "0x010203",
+		Metadata:  map[string]string{"request_id": "req-1", "details": strings.Repeat("d", 200)},
+	}
+	msg.SchemaVersion = LatestSchemaVersion
+	full, err := json.Marshal(msg)
+	assert.NoError(t, err)
+
+	// no limit
+	data, err := marshalPayload(msg, LatestSchemaVersion, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, full, data)
+
+	// within the limit
+	data, err = marshalPayload(msg, LatestSchemaVersion, len(full))
+	assert.NoError(t, err)
+	assert.Equal(t, full, data)
+
+	// message is truncated first
+	data, err = marshalPayload(msg, LatestSchemaVersion, len(full)-100)
+	assert.NoError(t, err)
+	assert.True(t, len(data) <= len(full)-100)
+	var got Message
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.True(t, got.Truncated)
+	assert.True(t, strings.HasSuffix(got.Message, truncatedSuffix))
+	assert.True(t, strings.HasPrefix(msg.Message, strings.TrimSuffix(got.Message, truncatedSuffix)))
+	assert.Equal(t, msg.Metadata, got.Metadata)
+	assert.Equal(t, msg.AccountID, got.AccountID)
+
+	// then the largest metadata values
+	data, err = marshalPayload(msg, LatestSchemaVersion, len(full)-500)
+	assert.NoError(t, err)
+	assert.True(t, len(data) <= len(full)-500)
+	got = Message{}
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.True(t, got.Truncated)
+	assert.Empty(t, got.Message)
+	assert.True(t, strings.HasSuffix(got.Metadata["details"], truncatedSuffix))
+	assert.Equal(t, "req-1", got.Metadata["request_id"])
+
+	// notification is left untouched
+	assert.Len(t, msg.Metadata["details"], 200)
+
+	// structural fields are never truncated
+	_, err = marshalPayload(msg, LatestSchemaVersion, 50)
+	assert.True(t, errors.IsOfType(ErrPayloadTooLarge, err))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "", truncate("short", 1))
+	assert.Equal(t, "abcdef"+truncatedSuffix, truncate(strings.Repeat("abcdefghij", 2), 0))
+
+	// multi byte runes are not split
+	s := truncate(strings.Repeat("é", 20), 3)
+	assert.True(t, utf8.ValidString(s))
+	assert.True(t, strings.HasSuffix(s, truncatedSuffix))
+}
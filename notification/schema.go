@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// Schema versions of the webhook payloads. Webhooks are sent the latest version unless their account pins another one.
+const (
+	// SchemaV1 is the payload without the schema version, the metadata and the truncated flag.
+	SchemaV1 = 1
+
+	// SchemaV2 is the payload with the schema version, the metadata and the truncated flag.
+	SchemaV2 = 2
+
+	// LatestSchemaVersion is the version of the payloads sent to the webhooks without a pinned version.
+	LatestSchemaVersion = SchemaV2
+)
+
+// ErrUnknownSchemaVersion is returned if the payload is requested in a schema version that doesn't exist.
+const ErrUnknownSchemaVersion = errors.Error("unknown notification schema version")
+
+// messageV1 is the payload of the SchemaV1 notifications.
+type messageV1 struct {
+	EventType    EventType `json:"event_type"`
+	Recorded     time.Time `json:"recorded"`
+	DocumentType string    `json:"document_type"`
+	Status       string    `json:"status"`
+	Message      string    `json:"message"`
+	DocumentID   string    `json:"document_id"`
+	AccountID    string    `json:"account_id"`
+	FromID       string    `json:"from_id"`
+	ToID         string    `json:"to_id"`
+}
+
+// ValidSchemaVersion returns true if the version is a schema version or 0 for the latest version.
+func ValidSchemaVersion(version int) bool {
+	return version >= 0 && version <= LatestSchemaVersion
+}
+
+// schemaVersion returns the schema version the webhook is pinned to, 0 is the latest version.
+func schemaVersion(pinned int) int {
+	if pinned == 0 {
+		return LatestSchemaVersion
+	}
+
+	return pinned
+}
+
+// marshalSchema returns the JSON of the notification in the shape of the schema version.
+func marshalSchema(notification Message, version int) ([]byte, error) {
+	switch version {
+	case SchemaV1:
+		return json.Marshal(messageV1{
+			EventType:    notification.EventType,
+			Recorded:     notification.Recorded,
+			DocumentType: notification.DocumentType,
+			Status:       notification.Status,
+			Message:      notification.Message,
+			DocumentID:   notification.DocumentID,
+			AccountID:    notification.AccountID,
+			FromID:       notification.FromID,
+			ToID:         notification.ToID,
+		})
+	case SchemaV2:
+		notification.SchemaVersion = SchemaV2
+		return json.Marshal(notification)
+	default:
+		return nil, errors.NewTypedError(ErrUnknownSchemaVersion, errors.New("version %d", version))
+	}
+}
@@ -0,0 +1,66 @@
+// +build unit
+
+package notification
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalSchema(t *testing.T) {
+	msg := Message{
+		EventType:  JobCompleted,
+		Recorded:   time.Now().UTC(),
+		Status:     "success",
+		Message:    "done",
+		AccountID:  "0x010203",
+		DocumentID: "0x0405",
+		Metadata:   map[string]string{"request_id": "req-1"},
+	}
+
+	// latest version carries every field
+	data, err := marshalSchema(msg, LatestSchemaVersion)
+	assert.NoError(t, err)
+	var latest map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &latest))
+	assert.Equal(t, float64(SchemaV2), latest["schema_version"])
+	assert.Equal(t, map[string]interface{}{"request_id": "req-1"}, latest["metadata"])
+
+	// v1 keeps the original shape
+	data, err = marshalSchema(msg, SchemaV1)
+	assert.NoError(t, err)
+	var v1 map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &v1))
+	assert.Len(t, v1, 9)
+	assert.NotContains(t, v1, "schema_version")
+	assert.NotContains(t, v1, "metadata")
+	assert.Equal(t, "done", v1["message"])
+	assert.Equal(t, "0x0405", v1["document_id"])
+
+	// unknown version
+	_, err = marshalSchema(msg, 3)
+	assert.True(t, errors.IsOfType(ErrUnknownSchemaVersion, err))
+
+	// v1 payloads are truncated without the flag
+	msg.Message = strings.Repeat("log ", 100)
+	data, err = marshalPayload(msg, SchemaV1, 300)
+	assert.NoError(t, err)
+	assert.True(t, len(data) <= 300)
+	v1 = nil
+	assert.NoError(t, json.Unmarshal(data, &v1))
+	assert.Len(t, v1, 9)
+	assert.True(t, strings.HasSuffix(v1["message"].(string), truncatedSuffix))
+}
+
+func TestValidSchemaVersion(t *testing.T) {
+	assert.True(t, ValidSchemaVersion(0))
+	assert.True(t, ValidSchemaVersion(SchemaV1))
+	assert.True(t, ValidSchemaVersion(LatestSchemaVersion))
+	assert.False(t, ValidSchemaVersion(-1))
+	assert.False(t, ValidSchemaVersion(LatestSchemaVersion+1))
+}
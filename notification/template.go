@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// ErrInvalidPayloadTemplate is returned if the payload template of a webhook cannot be parsed.
+const ErrInvalidPayloadTemplate = errors.Error("invalid notification payload template")
+
+// templateFuncs are the functions available to the payload templates.
+var templateFuncs = template.FuncMap{
+	// json encodes the value, such as {{json .Message}} for a quoted and escaped string
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// ParsePayloadTemplate parses the Go template rendering the notifications into the payloads expected by a webhook.
+// The template is executed with the Message, an empty template is nil and means the default payload.
+func ParsePayloadTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("payload").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidPayloadTemplate, err)
+	}
+
+	return tmpl, nil
+}
+
+// renderPayload returns the payload of the notification rendered by the template of the webhook.
+// Templates failing to render or rendering payloads over maxSize bytes fall back to the default payload, 0 means no limit.
+func renderPayload(notification Message, hook webhook, maxSize int) ([]byte, error) {
+	if hook.template == "" {
+		return marshalPayload(notification, hook.version, maxSize)
+	}
+
+	data, err := executeTemplate(hook.template, notification)
+	if err == nil && maxSize > 0 && len(data) > maxSize {
+		err = errors.New("size %d exceeds %d bytes", len(data), maxSize)
+	}
+
+	if err != nil {
+		log.Errorf("failed to render the payload template of webhook %s, sending the default payload: %v", hook.url, err)
+		return marshalPayload(notification, hook.version, maxSize)
+	}
+
+	return data, nil
+}
+
+func executeTemplate(text string, notification Message) ([]byte, error) {
+	tmpl, err := ParsePayloadTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, notification)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,74 @@
+// +build unit
+
+package notification
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePayloadTemplate(t *testing.T) {
+	tmpl, err := ParsePayloadTemplate("")
+	assert.NoError(t, err)
+	assert.Nil(t, tmpl)
+
+	_, err = ParsePayloadTemplate(`{"text": {{json .Message}`)
+	assert.True(t, errors.IsOfType(ErrInvalidPayloadTemplate, err))
+
+	tmpl, err = ParsePayloadTemplate(`{"text": {{json .Message}}}`)
+	assert.NoError(t, err)
+	assert.NotNil(t, tmpl)
+}
+
+func TestRenderPayload(t *testing.T) {
+	msg := Message{
+		EventType:  JobCompleted,
+		Recorded:   time.Now().UTC(),
+		Status:     "success",
+		Message:    `done "quoted"`,
+		DocumentID: "0x0405",
+		Metadata:   map[string]string{"request_id": "req-1"},
+	}
+
+	// no template is the default payload
+	data, err := renderPayload(msg, webhook{version: LatestSchemaVersion}, 0)
+	assert.NoError(t, err)
+	def, err := marshalPayload(msg, LatestSchemaVersion, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, def, data)
+
+	// rendered by the template
+	hook := webhook{
+		version:  LatestSchemaVersion,
+		template: `{"event": {{.EventType}}, "text": {{json .Message}}, "request": {{json (index .Metadata "request_id")}}}`,
+	}
+	data, err = renderPayload(msg, hook, 0)
+	assert.NoError(t, err)
+	var rendered map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &rendered))
+	assert.Equal(t, map[string]interface{}{"event": float64(JobCompleted), "text": `done "quoted"`, "request": "req-1"}, rendered)
+
+	// templates failing to render fall back to the default payload
+	hook.template = `{"text": {{.Unknown}}}`
+	data, err = renderPayload(msg, hook, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, def, data)
+
+	hook.template = `{"text": {{json .Message}`
+	data, err = renderPayload(msg, hook, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, def, data)
+
+	// as do the rendered payloads over the max size
+	hook.template = `{"text": "{{.Message}}` + strings.Repeat(" ", 500) + `"}`
+	data, err = renderPayload(msg, hook, 300)
+	assert.NoError(t, err)
+	def, err = marshalPayload(msg, LatestSchemaVersion, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, def, data)
+}
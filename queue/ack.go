@@ -0,0 +1,318 @@
+package queue
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+)
+
+// AckMode defines when a task is acknowledged to the broker.
+type AckMode string
+
+const (
+	// AckBefore acknowledges the task before it is executed.
+	// Fewer redeliveries but the task is lost if the queue server stops while running it.
+	AckBefore AckMode = "before"
+
+	// AckAfter acknowledges the task once its result is stored.
+	// The tasks not acknowledged when the queue server stops are redelivered once it is started again,
+	// so they may be delivered more than once.
+	// The broker keeps the tasks in memory: the redelivery only covers a restart of the queue server within the
+	// same process, the queued and unacknowledged tasks are lost if the node crashes or restarts.
+	AckAfter AckMode = "after"
+)
+
+// AckModer can be implemented by a TaskType to override the acknowledgment mode configured for the node.
+type AckModer interface {
+
+	// AckMode returns when the task must be acknowledged
+	AckMode() AckMode
+}
+
+// toAckMode converts the configured value to AckMode and defaults to AckAfter.
+func toAckMode(mode string) AckMode {
+	if AckMode(mode) == AckBefore {
+		return AckBefore
+	}
+
+	return AckAfter
+}
+
+// ackBroker wraps a broker and keeps track of the task messages handed to the workers
+// that are not acknowledged yet so that they can be redelivered when the queue server is started again.
+// The unacknowledged tasks are kept in memory, they don't survive the node.
+type ackBroker struct {
+	gocelery.CeleryBroker
+	mode    func(taskName string) AckMode
+	mu      sync.Mutex
+	unacked map[string]string // task ID -> encoded task message
+
+	// queueMu is held exclusively while the queued tasks are drained from the broker
+	queueMu sync.RWMutex
+
+	// queued is the number of tasks waiting in the broker
+	queued int64
+
+	// reserved is the number of enqueues given room in the queue that haven't sent their task yet
+	reserveMu sync.Mutex
+	reserved  int
+
+	// registered returns true if the task type is registered, the other tasks are routed to the unknown task handler
+	registered func(taskName string) bool
+
+	// storeResult returns false if the results of the task type are discarded
+	storeResult func(taskName string) bool
+
+	// discarded holds the IDs of the running tasks whose results are discarded
+	discarded map[string]struct{}
+
+	// states holds the states of the tasks handed to the workers until their results are stored
+	states map[string]string
+
+	// dequeued is closed and replaced every time tasks leave the broker to wake up the enqueues waiting for room
+	dequeuedMu sync.Mutex
+	dequeued   chan struct{}
+}
+
+func newAckBroker(broker gocelery.CeleryBroker, mode func(taskName string) AckMode) *ackBroker {
+	return &ackBroker{
+		CeleryBroker: broker,
+		mode:         mode,
+		unacked:      make(map[string]string),
+		discarded:    make(map[string]struct{}),
+		states:       make(map[string]string),
+	}
+}
+
+// GetTaskMessage returns the next task message and keeps track of it until acknowledged
+// if the task is running with AckAfter.
+// Tasks whose task type is not registered are routed to the unknown task handler,
+// they are redelivered with their original task name.
+func (b *ackBroker) GetTaskMessage() (*gocelery.TaskMessage, error) {
+	b.queueMu.RLock()
+	msg, err := b.CeleryBroker.GetTaskMessage()
+	b.queueMu.RUnlock()
+	if err != nil || msg == nil {
+		return msg, err
+	}
+
+	atomic.AddInt64(&b.queued, -1)
+	b.signalRoom()
+	b.track(msg)
+	b.received(msg)
+	if b.registered != nil && !b.registered(msg.Task) {
+		toUnknownTask(msg)
+	}
+
+	if b.storeResult != nil && !b.storeResult(msg.Task) {
+		b.discard(msg.ID)
+	}
+
+	return msg, nil
+}
+
+// track keeps track of the task message until acknowledged if the task is running with AckAfter.
+func (b *ackBroker) track(msg *gocelery.TaskMessage) {
+	if b.mode(msg.Task) == AckBefore {
+		return
+	}
+
+	enc, err := msg.Encode()
+	if err != nil {
+		log.Errorf("failed to track task %s for acknowledgment: %v", msg.ID, err)
+		return
+	}
+
+	b.mu.Lock()
+	b.unacked[msg.ID] = enc
+	b.mu.Unlock()
+}
+
+// SendCeleryMessage sends the message to the broker.
+// A message sent back by the worker (delayed or retried) is owned by the broker again and no longer tracked.
+// The state of a retried task is kept until its next run.
+func (b *ackBroker) SendCeleryMessage(msg *gocelery.CeleryMessage) error {
+	b.queueMu.RLock()
+	err := b.CeleryBroker.SendCeleryMessage(msg)
+	b.queueMu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	atomic.AddInt64(&b.queued, 1)
+
+	if tm := msg.GetTaskMessage(); tm != nil {
+		b.ack(tm.ID)
+		if tm.Tries > 0 {
+			b.setState(tm.ID, TaskStateRetry)
+		}
+	}
+
+	return nil
+}
+
+// ack acknowledges the task.
+func (b *ackBroker) ack(taskID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.unacked, taskID)
+}
+
+// redeliver sends all the unacknowledged tasks back to the broker, pending again.
+func (b *ackBroker) redeliver() {
+	b.mu.Lock()
+	unacked := b.unacked
+	b.unacked = make(map[string]string)
+	b.mu.Unlock()
+
+	for id, enc := range unacked {
+		log.Warningf("redelivering unacknowledged task %s", id)
+		b.forgetState(id)
+		err := b.send(enc)
+		if err != nil {
+			log.Errorf("failed to redeliver task %s: %v", id, err)
+		}
+	}
+}
+
+// send sends the encoded task message to the wrapped broker.
+func (b *ackBroker) send(enc string) error {
+	err := b.CeleryBroker.SendCeleryMessage(&gocelery.CeleryMessage{
+		Body:            enc,
+		ContentType:     "application/json",
+		ContentEncoding: "utf-8",
+		Properties:      gocelery.CeleryProperties{BodyEncoding: "base64"},
+	})
+	if err != nil {
+		return err
+	}
+
+	atomic.AddInt64(&b.queued, 1)
+	return nil
+}
+
+// depth returns the number of tasks waiting in the broker.
+func (b *ackBroker) depth() int {
+	return int(atomic.LoadInt64(&b.queued))
+}
+
+// reserve reserves room for a task if the queued tasks and the reservations are less than max.
+// The reservation is released by the enqueue once its task is sent, or failed to be sent.
+func (b *ackBroker) reserve(max int) bool {
+	b.reserveMu.Lock()
+	defer b.reserveMu.Unlock()
+	if b.depth()+b.reserved >= max {
+		return false
+	}
+
+	b.reserved++
+	return true
+}
+
+// unreserve releases a reservation and wakes up the enqueues waiting for room in case the task wasn't sent.
+// The task sent is counted as queued before the reservation is released so that the queue is never over-committed.
+func (b *ackBroker) unreserve() {
+	b.reserveMu.Lock()
+	b.reserved--
+	b.reserveMu.Unlock()
+	b.signalRoom()
+}
+
+// remove drops the queued tasks of the task type from the broker and returns the number of tasks dropped.
+// The other queued tasks are sent back in the same order.
+func (b *ackBroker) remove(taskName string) (removed int, err error) {
+	return b.removeIf(func(msg *gocelery.TaskMessage) bool {
+		return msg.Task == taskName
+	})
+}
+
+// removeIf drops the queued tasks matching drop from the broker and returns the number of tasks dropped.
+// The other queued tasks are sent back in the same order.
+func (b *ackBroker) removeIf(drop func(msg *gocelery.TaskMessage) bool) (removed int, err error) {
+	b.queueMu.Lock()
+	defer b.queueMu.Unlock()
+
+	var kept []string
+	for {
+		msg, gerr := b.CeleryBroker.GetTaskMessage()
+		if gerr != nil || msg == nil {
+			err = gerr
+			break
+		}
+
+		atomic.AddInt64(&b.queued, -1)
+		if drop(msg) {
+			b.forgetState(msg.ID)
+			removed++
+			continue
+		}
+
+		enc, eerr := msg.Encode()
+		if eerr != nil {
+			err = errors.AppendError(err, errors.New("failed to encode task %s, task is lost: %v", msg.ID, eerr))
+			continue
+		}
+
+		kept = append(kept, enc)
+	}
+
+	for _, enc := range kept {
+		if serr := b.send(enc); serr != nil {
+			err = errors.AppendError(err, serr)
+		}
+	}
+
+	if removed > 0 {
+		b.signalRoom()
+	}
+
+	return removed, err
+}
+
+// room returns the channel closed once tasks leave the broker.
+func (b *ackBroker) room() <-chan struct{} {
+	b.dequeuedMu.Lock()
+	defer b.dequeuedMu.Unlock()
+	if b.dequeued == nil {
+		b.dequeued = make(chan struct{})
+	}
+
+	return b.dequeued
+}
+
+// signalRoom wakes up the enqueues waiting for room.
+func (b *ackBroker) signalRoom() {
+	b.dequeuedMu.Lock()
+	defer b.dequeuedMu.Unlock()
+	if b.dequeued != nil {
+		close(b.dequeued)
+		b.dequeued = nil
+	}
+}
+
+// ackBackend wraps a backend and acknowledges the task to the broker once its result is stored.
+type ackBackend struct {
+	gocelery.CeleryBackend
+	broker *ackBroker
+}
+
+// SetResult stores the result and acknowledges the task.
+// Results of the task types opted out of storing results are discarded.
+// The state of the task is no longer tracked, it is derived from the stored result.
+func (b ackBackend) SetResult(taskID string, result *gocelery.ResultMessage) error {
+	defer b.broker.forgetState(taskID)
+	if b.broker.isDiscarded(taskID) {
+		b.broker.ack(taskID)
+		return nil
+	}
+
+	err := b.CeleryBackend.SetResult(taskID, result)
+	if err != nil {
+		return err
+	}
+
+	b.broker.ack(taskID)
+	return nil
+}
@@ -0,0 +1,83 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToAckMode(t *testing.T) {
+	assert.Equal(t, AckBefore, toAckMode("before"))
+	assert.Equal(t, AckAfter, toAckMode("after"))
+	assert.Equal(t, AckAfter, toAckMode(""))
+	assert.Equal(t, AckAfter, toAckMode("invalid"))
+}
+
+func TestAckBroker(t *testing.T) {
+	modes := map[string]AckMode{"after": AckAfter, "before": AckBefore}
+	broker := newAckBroker(gocelery.NewInMemoryBroker(), func(taskName string) AckMode {
+		return modes[taskName]
+	})
+	backend := ackBackend{CeleryBackend: gocelery.NewInMemoryBackend(), broker: broker}
+	client, err := gocelery.NewCeleryClient(broker, backend, 1, 1)
+	assert.NoError(t, err)
+
+	// ack before is never tracked
+	_, err = client.Delay(gocelery.Task{Name: "before", Kwargs: map[string]interface{}{"key": "value"}})
+	assert.NoError(t, err)
+	msg, err := broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "before", msg.Task)
+	assert.Len(t, broker.unacked, 0)
+
+	// ack after is tracked until the result is stored
+	_, err = client.Delay(gocelery.Task{Name: "after", Kwargs: map[string]interface{}{"key": "value"}})
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "after", msg.Task)
+	assert.Len(t, broker.unacked, 1)
+	assert.NoError(t, backend.SetResult(msg.ID, &gocelery.ResultMessage{}))
+	assert.Len(t, broker.unacked, 0)
+
+	// unacknowledged tasks are redelivered
+	_, err = client.Delay(gocelery.Task{Name: "after", Kwargs: map[string]interface{}{"key": "value"}})
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	id := msg.ID
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+	broker.redeliver()
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, id, msg.ID)
+	assert.Equal(t, "value", msg.Kwargs["key"])
+}
+
+func TestServer_ackMode_restart(t *testing.T) {
+	qs := &Server{config: mockConfig{}}
+	qs.RegisterTaskType("echo", new(echoTask))
+	stop := startServer(t, qs)
+	assert.Equal(t, AckAfter, qs.ackMode("echo"))
+	assert.True(t, qs.isRegistered("echo"))
+
+	// the workers read the ack modes while the server starts again
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			qs.ackMode("echo")
+			qs.isRegistered("echo")
+		}
+	}()
+	stop()
+	stop = startServer(t, qs)
+	defer stop()
+	<-done
+	assert.True(t, qs.isRegistered("echo"))
+}
@@ -3,6 +3,8 @@ package queue
 import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -16,7 +18,14 @@ func (b *Bootstrapper) Bootstrap(context map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	srv := &Server{config: cfg, taskTypes: []TaskType{}}
+	srv := &Server{config: cfg, taskTypes: []TaskType{}, replay: cfg.GetTaskReplayEnabled()}
+	if db, ok := context[storage.BootstrappedDB].(storage.Repository); ok {
+		srv.setStorage(db)
+	}
+	if jobMan, ok := context[jobs.BootstrappedService].(jobs.Manager); ok {
+		srv.jobMan = jobMan
+	}
+	jobs.RegisterFailureClassifier(classifyFailure)
 	context[bootstrap.BootstrappedQueueServer] = srv
 	b.context = context
 	return nil
@@ -0,0 +1,76 @@
+package queue
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// ContextSetter can be implemented by a task to receive a context that is cancelled
+// when the tasks of its type are cancelled with Server.CancelTasksByType.
+type ContextSetter interface {
+
+	// SetContext is called with the context of the run before the task is run
+	SetContext(ctx context.Context)
+}
+
+// runningTasks keeps track of the cancel functions of the running tasks by task type name.
+type runningTasks struct {
+	mu      sync.Mutex
+	seq     uint64
+	cancels map[string]map[uint64]context.CancelFunc
+}
+
+// start returns the context of a new run of the task type and the function to call once the run is done.
+func (r *runningTasks) start(taskName string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancels == nil {
+		r.cancels = make(map[string]map[uint64]context.CancelFunc)
+	}
+
+	if r.cancels[taskName] == nil {
+		r.cancels[taskName] = make(map[uint64]context.CancelFunc)
+	}
+
+	r.seq++
+	id := r.seq
+	r.cancels[taskName][id] = cancel
+	return ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.cancels[taskName], id)
+		cancel()
+	}
+}
+
+// cancel cancels the contexts of the running tasks of the task type and returns the number of tasks cancelled.
+func (r *runningTasks) cancel(taskName string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cancels := r.cancels[taskName]
+	for _, cancel := range cancels {
+		cancel()
+	}
+
+	delete(r.cancels, taskName)
+	return len(cancels)
+}
+
+// CancelTasksByType removes the queued tasks of the task type from the broker and cancels the context
+// of the running ones. Running tasks stop only if they implement ContextSetter and honour the context.
+// The results of the removed tasks are never set. Returns the number of tasks removed or cancelled.
+func (qs *Server) CancelTasksByType(name string) (int, error) {
+	qs.lock.RLock()
+	defer qs.lock.RUnlock()
+	if qs.broker == nil {
+		return 0, errors.New("queue hasn't been initialised")
+	}
+
+	removed, err := qs.broker.remove(name)
+	cancelled := qs.running.cancel(name)
+	log.Warningf("cancelled task type %s: %d queued tasks removed, %d running tasks cancelled", name, removed, cancelled)
+	return removed + cancelled, err
+}
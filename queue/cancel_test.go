@@ -0,0 +1,80 @@
+// +build unit
+
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockContextTask struct {
+	mockCeleryTask
+	ctx     context.Context
+	started chan struct{}
+}
+
+func (m *mockContextTask) Copy() (gocelery.CeleryTask, error) {
+	return &mockContextTask{started: m.started}, nil
+}
+
+func (m *mockContextTask) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+func (m *mockContextTask) RunTask() (interface{}, error) {
+	close(m.started)
+	<-m.ctx.Done()
+	return nil, m.ctx.Err()
+}
+
+func TestServer_CancelTasksByType(t *testing.T) {
+	qs := &Server{config: mockConfig{}}
+	_, err := qs.CancelTasksByType("task")
+	assert.Error(t, err)
+
+	qs.broker = newAckBroker(gocelery.NewInMemoryBroker(), func(string) AckMode { return AckAfter })
+	qs.queue, err = gocelery.NewCeleryClient(qs.broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+
+	// queued tasks of the type are removed
+	for _, name := range []string{"mint", "anchor", "mint", "anchor"} {
+		_, err = qs.EnqueueJob(name, map[string]interface{}{"value": name})
+		assert.NoError(t, err)
+	}
+
+	n, err := qs.CancelTasksByType("mint")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	for i := 0; i < 2; i++ {
+		msg, err := qs.broker.GetTaskMessage()
+		assert.NoError(t, err)
+		assert.Equal(t, "anchor", msg.Task)
+	}
+	msg, err := qs.broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+
+	// running tasks of the type are cancelled
+	started := make(chan struct{})
+	task := withMiddlewares("mint", &mockContextTask{started: started}, []Middleware{Recoverer}, &qs.running, nil).(gocelery.CeleryTask)
+	ct, err := task.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{"value": "mint"}))
+	res := make(chan error)
+	go func() {
+		_, err := ct.RunTask()
+		res <- err
+	}()
+
+	<-started
+	n, err = qs.CancelTasksByType("anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	n, err = qs.CancelTasksByType("mint")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, context.Canceled, <-res)
+}
@@ -0,0 +1,27 @@
+package queue
+
+// count returns the number of the running tasks of all the task types.
+func (r *runningTasks) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int
+	for _, cancels := range r.cancels {
+		n += len(cancels)
+	}
+
+	return n
+}
+
+// HasCapacity returns true if a local worker is idle and can run a task of the task type right away.
+// The workers are shared by the task types, so every registered task type has the same answer.
+// Task types not registered on the node have no capacity, nor do the nodes whose local workers are not running.
+// The answer is instantaneous and best-effort: the idle worker may pick another task before the caller enqueues one.
+func (qs *Server) HasCapacity(taskType string) bool {
+	qs.lock.RLock()
+	defer qs.lock.RUnlock()
+	if !qs.workersRunning || !qs.isRegistered(taskType) {
+		return false
+	}
+
+	return qs.running.count() < qs.startedWorkers
+}
@@ -0,0 +1,65 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingTask runs until released.
+type blockingTask struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (*blockingTask) TaskTypeName() string {
+	return "block"
+}
+
+func (m *blockingTask) Copy() (gocelery.CeleryTask, error) {
+	return &blockingTask{started: m.started, release: m.release}, nil
+}
+
+func (m *blockingTask) ParseKwargs(map[string]interface{}) error {
+	return nil
+}
+
+func (m *blockingTask) RunTask() (interface{}, error) {
+	m.started <- struct{}{}
+	<-m.release
+	return nil, nil
+}
+
+func TestServer_HasCapacity(t *testing.T) {
+	qs := &Server{config: mockConfig{}}
+	task := &blockingTask{started: make(chan struct{}, 1), release: make(chan struct{})}
+	qs.RegisterTaskType(task.TaskTypeName(), task)
+	qs.RegisterTaskType("echo", new(echoTask))
+	qs.SetNumWorkers(1)
+
+	// not started
+	assert.False(t, qs.HasCapacity("block"))
+
+	stop := startServer(t, qs)
+	assert.Eventually(t, func() bool { return qs.HasCapacity("block") }, time.Second, 10*time.Millisecond)
+	assert.True(t, qs.HasCapacity("echo"))
+	assert.False(t, qs.HasCapacity("unknown"))
+
+	// the only worker is busy, for every task type
+	_, err := qs.EnqueueJob("block", map[string]interface{}{})
+	assert.NoError(t, err)
+	<-task.started
+	assert.False(t, qs.HasCapacity("block"))
+	assert.False(t, qs.HasCapacity("echo"))
+
+	close(task.release)
+	assert.Eventually(t, func() bool { return qs.HasCapacity("echo") }, time.Second, 10*time.Millisecond)
+
+	// stopped
+	stop()
+	assert.False(t, qs.HasCapacity("block"))
+}
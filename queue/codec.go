@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// TypedParamsParam holds the params of a task enqueued with EnqueueTypedJob encoded with the codec of the task type.
+	TypedParamsParam string = "TypedParams"
+
+	// ErrInvalidTypedParams is returned when the typed params of a task are missing or fail to decode.
+	ErrInvalidTypedParams = errors.Error("invalid typed task params")
+)
+
+// Codec serializes the typed params of a task.
+type Codec interface {
+
+	// Marshal encodes the params
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes the data into the params
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Codecer can be implemented by a TaskType to encode its typed params with a codec other than JSON.
+type Codecer interface {
+
+	// Codec returns the codec of the typed params of the task
+	Codec() Codec
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type protoCodec struct{}
+
+func (protoCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, errors.New("%T is not a proto message", v)
+	}
+
+	return proto.Marshal(msg)
+}
+
+func (protoCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return errors.New("%T is not a proto message", v)
+	}
+
+	return proto.Unmarshal(data, msg)
+}
+
+var (
+	// JSONCodec encodes the typed params as JSON. Default codec of the task types.
+	JSONCodec Codec = jsonCodec{}
+
+	// ProtoCodec encodes the typed params that are proto messages as protobuf.
+	ProtoCodec Codec = protoCodec{}
+)
+
+// codec returns the codec of the registered task type, JSON if the task type doesn't define one.
+func (qs *Server) codec(taskName string) Codec {
+	for _, task := range qs.taskTypes {
+		if task.TaskTypeName() != taskName {
+			continue
+		}
+
+		if c, ok := task.(Codecer); ok {
+			return c.Codec()
+		}
+	}
+
+	return JSONCodec
+}
+
+// EnqueueTypedJob enqueues a job with the params encoded with the codec of the task type into TypedParamsParam.
+// The task decodes the params with DecodeTypedParams.
+func (qs *Server) EnqueueTypedJob(taskName string, params interface{}) (TaskResult, error) {
+	qs.lock.RLock()
+	data, err := qs.codec(taskName).Marshal(params)
+	qs.lock.RUnlock()
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidTypedParams, err)
+	}
+
+	return qs.EnqueueJob(taskName, map[string]interface{}{TypedParamsParam: data})
+}
+
+// DecodeTypedParams decodes the params enqueued with EnqueueTypedJob into v with the codec.
+func DecodeTypedParams(kwargs map[string]interface{}, codec Codec, v interface{}) error {
+	var data []byte
+	switch p := kwargs[TypedParamsParam].(type) {
+	case []byte:
+		data = p
+	case string:
+		// kwargs passed through the broker are JSON encoded, which encodes the bytes as base64
+		d, err := base64.StdEncoding.DecodeString(p)
+		if err != nil {
+			return errors.NewTypedError(ErrInvalidTypedParams, err)
+		}
+		data = d
+	default:
+		return errors.NewTypedError(ErrInvalidTypedParams, errors.New("missing kwarg %s", TypedParamsParam))
+	}
+
+	if err := codec.Unmarshal(data, v); err != nil {
+		return errors.NewTypedError(ErrInvalidTypedParams, err)
+	}
+
+	return nil
+}
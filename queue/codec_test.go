@@ -0,0 +1,67 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+type protoTask struct{}
+
+func (protoTask) TaskTypeName() string {
+	return "protoTask"
+}
+
+func (protoTask) Codec() Codec {
+	return ProtoCodec
+}
+
+func TestServer_EnqueueTypedJob(t *testing.T) {
+	broker := gocelery.NewInMemoryBroker()
+	client, err := gocelery.NewCeleryClient(broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	qs := &Server{config: mockConfig{}, queue: client}
+	qs.RegisterTaskType("protoTask", protoTask{})
+
+	// binary params with the proto codec of the task type
+	proof := []byte{0x00, 0xff, 0x10, 0x80}
+	_, err = qs.EnqueueTypedJob("protoTask", &wrappers.BytesValue{Value: proof})
+	assert.NoError(t, err)
+	msg, err := broker.GetTaskMessage()
+	assert.NoError(t, err)
+	var got wrappers.BytesValue
+	assert.NoError(t, DecodeTypedParams(msg.Kwargs, ProtoCodec, &got))
+	assert.Equal(t, proof, got.Value)
+
+	// params that are not a proto message
+	_, err = qs.EnqueueTypedJob("protoTask", struct{}{})
+	assert.True(t, errors.IsOfType(ErrInvalidTypedParams, err))
+
+	// JSON by default
+	type params struct {
+		Proof []byte
+		Count int64
+	}
+	_, err = qs.EnqueueTypedJob("jsonTask", params{Proof: proof, Count: 1 << 60})
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	var p params
+	assert.NoError(t, DecodeTypedParams(msg.Kwargs, JSONCodec, &p))
+	assert.Equal(t, params{Proof: proof, Count: 1 << 60}, p)
+
+	// kwargs not encoded by the broker
+	data, err := JSONCodec.Marshal(params{Proof: proof})
+	assert.NoError(t, err)
+	assert.NoError(t, DecodeTypedParams(map[string]interface{}{TypedParamsParam: data}, JSONCodec, &p))
+	assert.Equal(t, proof, p.Proof)
+
+	// missing params
+	err = DecodeTypedParams(map[string]interface{}{}, JSONCodec, &p)
+	assert.True(t, errors.IsOfType(ErrInvalidTypedParams, err))
+}
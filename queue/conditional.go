@@ -0,0 +1,27 @@
+package queue
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// ErrEnqueueConditionNotMet is returned when a conditional enqueue is skipped as its predicate returned false.
+const ErrEnqueueConditionNotMet = errors.Error("enqueue condition not met")
+
+// EnqueueIf enqueues the task only if the predicate returns true, e.g. if the jobs repository holds no successful job
+// for the same document. The predicate and the enqueue run under a lock shared by all the conditional enqueues,
+// so that concurrent conditional enqueues can't both pass a check that the first enqueue invalidates.
+// Returns ErrEnqueueConditionNotMet if the predicate returns false. The predicate must not call EnqueueIf.
+func (qs *Server) EnqueueIf(predicate func() (bool, error), taskName string, params map[string]interface{}) (TaskResult, error) {
+	qs.enqueueIfMu.Lock()
+	defer qs.enqueueIfMu.Unlock()
+	ok, err := predicate()
+	if err != nil {
+		return nil, errors.New("failed to check the enqueue condition of task %s: %v", taskName, err)
+	}
+
+	if !ok {
+		return nil, ErrEnqueueConditionNotMet
+	}
+
+	return qs.EnqueueJob(taskName, params)
+}
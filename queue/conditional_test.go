@@ -0,0 +1,56 @@
+// +build unit
+
+package queue
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_EnqueueIf(t *testing.T) {
+	// tasks stay queued without the workers
+	qs := &Server{config: mockConfig{enqOnly: true}}
+	qs.RegisterTaskType("echo", new(echoTask))
+	stop := startServer(t, qs)
+	defer stop()
+
+	// failed predicate
+	_, err := qs.EnqueueIf(func() (bool, error) {
+		return false, errors.New("repository unavailable")
+	}, "echo", map[string]interface{}{"value": "echo"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository unavailable")
+	assert.Equal(t, 0, qs.broker.depth())
+
+	// only one of the concurrent enqueues passes the check
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var enqueued, skipped int
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := qs.EnqueueIf(func() (bool, error) {
+				return qs.broker.depth() == 0, nil
+			}, "echo", map[string]interface{}{"value": "echo"})
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				assert.NotNil(t, res)
+				enqueued++
+				return
+			}
+
+			assert.Equal(t, ErrEnqueueConditionNotMet, err)
+			skipped++
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, 1, enqueued)
+	assert.Equal(t, 9, skipped)
+	assert.Equal(t, 1, qs.broker.depth())
+}
@@ -0,0 +1,58 @@
+package queue
+
+import (
+	"sync"
+	"time"
+)
+
+// DedupKeyParam is the kwarg holding the dedup key of a task.
+// Enqueues of a task with the same dedup key within the dedup window return the result of the first enqueue.
+const DedupKeyParam string = "DedupKey"
+
+// dedupEntry is the result of an enqueue and the time until which it is returned for the same dedup key.
+type dedupEntry struct {
+	result  TaskResult
+	expires time.Time
+}
+
+// dedupCache holds the results of the recent enqueues by task name and dedup key.
+type dedupCache struct {
+	mu      sync.Mutex
+	entries map[string]dedupEntry
+}
+
+func dedupCacheKey(taskName, dedupKey string) string {
+	return taskName + "\x00" + dedupKey
+}
+
+// getOrEnqueue returns the result cached for the task name and dedup key if it hasn't expired.
+// Otherwise the enqueue is called and its result cached for the window.
+func (c *dedupCache) getOrEnqueue(taskName, dedupKey string, window time.Duration, enqueue func() (TaskResult, error)) (TaskResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	key := dedupCacheKey(taskName, dedupKey)
+	if e, ok := c.entries[key]; ok && now.Before(e.expires) {
+		log.Debugf("task %s with dedup key %s was enqueued within %s, returning the prior result", taskName, dedupKey, window)
+		return e.result, nil
+	}
+
+	res, err := enqueue()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.entries == nil {
+		c.entries = make(map[string]dedupEntry)
+	}
+
+	// drop the expired entries so that the cache holds at most the enqueues of one window
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = dedupEntry{result: res, expires: now.Add(window)}
+	return res, nil
+}
@@ -0,0 +1,90 @@
+package queue
+
+import (
+	"time"
+)
+
+// EffectiveConfig holds the queue settings in use by the server, after the defaults, the task type overrides
+// and the runtime overrides are applied.
+type EffectiveConfig struct {
+	// NumWorkers is the number of workers the server started with, or will start with if not started yet
+	NumWorkers int
+
+	// WorkerWaitTime is how long a worker waits for a task to be available while polling
+	WorkerWaitTime time.Duration
+
+	// TaskValidDuration is how long a task is valid from its enqueue unless overridden by TaskTimeouts
+	TaskValidDuration time.Duration
+
+	// TaskTimeouts override TaskValidDuration keyed by the lower cased task type name
+	TaskTimeouts map[string]time.Duration
+
+	// AckModes are the acknowledgment modes keyed by the task type name, empty until the server started
+	AckModes map[string]AckMode
+
+	// StoreResults holds whether the results are stored keyed by the task type name, empty until the server started
+	StoreResults map[string]bool
+
+	ScheduleCatchUp  string
+	DedupWindow      time.Duration
+	MaxQueueDepth    int
+	QueueFullTimeout time.Duration
+
+	// EnqueueOnly is true if the local workers don't run the tasks, as configured or as the tasks are run by ProcessNext
+	EnqueueOnly bool
+
+	ReconcilePolicy string
+}
+
+// SetNumWorkers overrides the configured number of workers. The override takes effect on the next start of the server.
+func (qs *Server) SetNumWorkers(n int) {
+	qs.lock.Lock()
+	defer qs.lock.Unlock()
+	qs.numWorkers = n
+}
+
+// workers returns the number of workers to start with. Expects the lock to be held.
+func (qs *Server) workers() int {
+	if qs.numWorkers > 0 {
+		return qs.numWorkers
+	}
+
+	return qs.config.GetNumWorkers()
+}
+
+// EffectiveConfig returns the queue settings in use by the server.
+func (qs *Server) EffectiveConfig() EffectiveConfig {
+	qs.lock.RLock()
+	defer qs.lock.RUnlock()
+	workers := qs.startedWorkers
+	if workers == 0 {
+		workers = qs.workers()
+	}
+
+	qs.ackModesMu.RLock()
+	ackModes := make(map[string]AckMode, len(qs.ackModes))
+	for name, mode := range qs.ackModes {
+		ackModes[name] = mode
+	}
+	qs.ackModesMu.RUnlock()
+
+	storeResults := make(map[string]bool, len(qs.resultStores))
+	for name, store := range qs.resultStores {
+		storeResults[name] = store
+	}
+
+	return EffectiveConfig{
+		NumWorkers:        workers,
+		WorkerWaitTime:    time.Duration(qs.config.GetWorkerWaitTimeMS()) * time.Millisecond,
+		TaskValidDuration: qs.config.GetTaskValidDuration(),
+		TaskTimeouts:      qs.config.GetTaskTimeouts(),
+		AckModes:          ackModes,
+		StoreResults:      storeResults,
+		ScheduleCatchUp:   qs.config.GetTaskScheduleCatchUp(),
+		DedupWindow:       qs.config.GetTaskDedupWindow(),
+		MaxQueueDepth:     qs.config.GetTaskMaxQueueDepth(),
+		QueueFullTimeout:  qs.config.GetTaskQueueFullTimeout(),
+		EnqueueOnly:       qs.config.GetTaskEnqueueOnly() || qs.manual,
+		ReconcilePolicy:   qs.config.GetTaskReconcilePolicy(),
+	}
+}
@@ -0,0 +1,51 @@
+// +build unit
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_EffectiveConfig(t *testing.T) {
+	qs := &Server{config: mockConfig{
+		timeouts: map[string]time.Duration{"slowtask": time.Hour},
+		maxDepth: 5,
+		enqOnly:  true,
+	}}
+
+	// not started
+	cfg := qs.EffectiveConfig()
+	assert.Equal(t, 1, cfg.NumWorkers)
+	assert.Equal(t, time.Millisecond, cfg.WorkerWaitTime)
+	assert.Equal(t, time.Minute, cfg.TaskValidDuration)
+	assert.Equal(t, map[string]time.Duration{"slowtask": time.Hour}, cfg.TaskTimeouts)
+	assert.Equal(t, 5, cfg.MaxQueueDepth)
+	assert.True(t, cfg.EnqueueOnly)
+	assert.Empty(t, cfg.AckModes)
+	assert.Empty(t, cfg.StoreResults)
+
+	qs.SetNumWorkers(3)
+	assert.Equal(t, 3, qs.EffectiveConfig().NumWorkers)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go qs.Start(ctx, &wg, make(chan error, 1))
+	assert.Eventually(t, func() bool {
+		return len(qs.EffectiveConfig().AckModes) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	// overrides after the start only take effect on the next start
+	qs.SetNumWorkers(5)
+	cfg = qs.EffectiveConfig()
+	assert.Equal(t, 3, cfg.NumWorkers)
+	assert.Equal(t, AckAfter, cfg.AckModes[unknownTaskName])
+	assert.Contains(t, cfg.StoreResults, unknownTaskName)
+	cancel()
+	wg.Wait()
+}
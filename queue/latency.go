@@ -0,0 +1,152 @@
+package queue
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// EnqueuedAtParam holds the time the task was enqueued at, set by the queue server.
+	EnqueuedAtParam string = "EnqueuedAt"
+
+	// latencySamples is the number of the latest runs per task type the latency percentiles are computed over.
+	latencySamples = 1000
+)
+
+// Percentiles holds the 50th, 90th and 99th percentiles of a latency.
+type Percentiles struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+// TaskLatency holds the latency percentiles of the latest runs of a task type.
+type TaskLatency struct {
+	// Samples is the number of runs the percentiles are computed over
+	Samples int
+
+	// Wait is the time from the enqueue of the task to the start of its run.
+	// Runs of the tasks enqueued by other nodes are only counted if the clocks agree.
+	Wait Percentiles
+
+	// Run is the time the run of the task took
+	Run Percentiles
+}
+
+// durationRing keeps the latest latencySamples durations.
+type durationRing struct {
+	samples []time.Duration
+	next    int
+}
+
+func (r *durationRing) add(d time.Duration) {
+	if len(r.samples) < latencySamples {
+		r.samples = append(r.samples, d)
+		return
+	}
+
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % latencySamples
+}
+
+// percentiles returns the nearest rank percentiles of the durations.
+func (r *durationRing) percentiles() Percentiles {
+	if len(r.samples) == 0 {
+		return Percentiles{}
+	}
+
+	sorted := append([]time.Duration(nil), r.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p int) time.Duration {
+		i := (len(sorted)*p+99)/100 - 1
+		if i < 0 {
+			i = 0
+		}
+
+		return sorted[i]
+	}
+
+	return Percentiles{P50: rank(50), P90: rank(90), P99: rank(99)}
+}
+
+// latencyStats records the wait and run latencies per task type.
+type latencyStats struct {
+	mu   sync.Mutex
+	wait map[string]*durationRing
+	run  map[string]*durationRing
+}
+
+func (s *latencyStats) add(rings *map[string]*durationRing, taskName string, d time.Duration) {
+	if *rings == nil {
+		*rings = make(map[string]*durationRing)
+	}
+
+	r, ok := (*rings)[taskName]
+	if !ok {
+		r = new(durationRing)
+		(*rings)[taskName] = r
+	}
+
+	r.add(d)
+}
+
+// record is the middleware recording the wait and the run latencies of the tasks.
+func (s *latencyStats) record(next TaskHandler) TaskHandler {
+	return func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		start := time.Now()
+		wait, waited := waitTime(kwargs, start)
+		res, err := next(taskName, kwargs)
+		run := time.Since(start)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if waited {
+			s.add(&s.wait, taskName, wait)
+			taskWaitSeconds.WithLabelValues(taskName).Observe(wait.Seconds())
+		}
+
+		s.add(&s.run, taskName, run)
+		taskRunSeconds.WithLabelValues(taskName).Observe(run.Seconds())
+		return res, err
+	}
+}
+
+// snapshot returns the latency percentiles per task type.
+func (s *latencyStats) snapshot() map[string]TaskLatency {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	latencies := make(map[string]TaskLatency, len(s.run))
+	for name, run := range s.run {
+		l := TaskLatency{Samples: len(run.samples), Run: run.percentiles()}
+		if wait, ok := s.wait[name]; ok {
+			l.Wait = wait.percentiles()
+		}
+
+		latencies[name] = l
+	}
+
+	return latencies
+}
+
+// waitTime returns the time the task waited since it was enqueued.
+// False is returned if the task holds no enqueue time or the enqueue time is ahead of the start.
+func waitTime(kwargs map[string]interface{}, start time.Time) (time.Duration, bool) {
+	v, ok := kwargs[EnqueuedAtParam].(string)
+	if !ok {
+		return 0, false
+	}
+
+	enqueuedAt, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil || enqueuedAt.After(start) {
+		return 0, false
+	}
+
+	return start.Sub(enqueuedAt), true
+}
+
+// Latencies returns the wait and the run latency percentiles of the latest runs per task type name.
+// Only the runs of the tasks by the local workers are recorded.
+func (qs *Server) Latencies() map[string]TaskLatency {
+	return qs.latency.snapshot()
+}
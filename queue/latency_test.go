@@ -0,0 +1,90 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationRing_percentiles(t *testing.T) {
+	var r durationRing
+	assert.Equal(t, Percentiles{}, r.percentiles())
+
+	for i := 100; i > 0; i-- {
+		r.add(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, Percentiles{P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond}, r.percentiles())
+
+	// only the latest samples are kept
+	for i := 0; i < latencySamples; i++ {
+		r.add(time.Second)
+	}
+	assert.Len(t, r.samples, latencySamples)
+	assert.Equal(t, Percentiles{P50: time.Second, P90: time.Second, P99: time.Second}, r.percentiles())
+}
+
+func TestWaitTime(t *testing.T) {
+	start := time.Now()
+	_, ok := waitTime(nil, start)
+	assert.False(t, ok)
+
+	_, ok = waitTime(map[string]interface{}{EnqueuedAtParam: "invalid"}, start)
+	assert.False(t, ok)
+
+	_, ok = waitTime(map[string]interface{}{EnqueuedAtParam: start.Add(time.Second).Format(time.RFC3339Nano)}, start)
+	assert.False(t, ok)
+
+	wait, ok := waitTime(map[string]interface{}{EnqueuedAtParam: start.Add(-time.Second).Format(time.RFC3339Nano)}, start)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, wait)
+}
+
+func TestServer_Latencies(t *testing.T) {
+	qs := &Server{config: mockConfig{}}
+	qs.broker = newAckBroker(gocelery.NewInMemoryBroker(), func(string) AckMode { return AckAfter })
+	var err error
+	qs.queue, err = gocelery.NewCeleryClient(qs.broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+
+	// the enqueue time is recorded on the task, the params of the caller are left untouched
+	params := map[string]interface{}{}
+	_, err = qs.EnqueueJob("latency", params)
+	assert.NoError(t, err)
+	msg, err := qs.broker.GetTaskMessage()
+	assert.NoError(t, err)
+	_, ok := waitTime(msg.Kwargs, time.Now())
+	assert.True(t, ok)
+	assert.Empty(t, params)
+
+	handler := qs.latency.record(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		if kwargs["fail"] == true {
+			return nil, errors.New("failed")
+		}
+
+		return nil, nil
+	})
+	waited := testutil.CollectAndCount(taskWaitSeconds)
+	_, err = handler("latency", map[string]interface{}{EnqueuedAtParam: time.Now().Add(-time.Second).Format(time.RFC3339Nano)})
+	assert.NoError(t, err)
+	_, err = handler("latency", map[string]interface{}{"fail": true})
+	assert.Error(t, err)
+	_, err = handler("other", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, waited+1, testutil.CollectAndCount(taskWaitSeconds))
+
+	latencies := qs.Latencies()
+	assert.Len(t, latencies, 2)
+	l := latencies["latency"]
+	assert.Equal(t, 2, l.Samples)
+	assert.True(t, l.Wait.P50 >= time.Second)
+	assert.True(t, l.Run.P50 >= 10*time.Millisecond)
+	assert.Equal(t, Percentiles{}, latencies["other"].Wait)
+	assert.True(t, latencies["other"].Run.P99 >= 10*time.Millisecond)
+}
@@ -0,0 +1,63 @@
+package queue
+
+import (
+	"sync"
+)
+
+// LockKeyParam is the kwarg holding the lock key of a task, such as the ID of the document the task mutates.
+// Tasks holding the same lock key run one at a time whatever their task type, the others wait for the lock
+// on their worker. The lock is released once the task finishes. Only the runs by the same node are serialized.
+const LockKeyParam string = "LockKey"
+
+// keyLock is a lock key shared by the runs waiting for it.
+type keyLock struct {
+	sync.Mutex
+	waiters int
+}
+
+// keyLocks holds the locks of the lock keys held or waited for.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+// lock locks the key and returns the function unlocking it.
+func (l *keyLocks) lock(key string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*keyLock)
+	}
+
+	kl, ok := l.locks[key]
+	if !ok {
+		kl = new(keyLock)
+		l.locks[key] = kl
+	}
+	kl.waiters++
+	l.mu.Unlock()
+
+	kl.Lock()
+	return func() {
+		kl.Unlock()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		kl.waiters--
+		if kl.waiters == 0 {
+			delete(l.locks, key)
+		}
+	}
+}
+
+// serialize is the middleware running the tasks holding the same lock key one at a time.
+func (l *keyLocks) serialize(next TaskHandler) TaskHandler {
+	return func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		key, ok := kwargs[LockKeyParam].(string)
+		if !ok || key == "" {
+			return next(taskName, kwargs)
+		}
+
+		unlock := l.lock(key)
+		defer unlock()
+		return next(taskName, kwargs)
+	}
+}
@@ -0,0 +1,72 @@
+// +build unit
+
+package queue
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyLocks_serialize(t *testing.T) {
+	var l keyLocks
+	var mu sync.Mutex
+	running := make(map[string]int)
+	maxRunning := make(map[string]int)
+	handler := l.serialize(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		key, _ := kwargs[LockKeyParam].(string)
+		mu.Lock()
+		running[key]++
+		if running[key] > maxRunning[key] {
+			maxRunning[key] = running[key]
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running[key]--
+		mu.Unlock()
+		return taskName, nil
+	})
+
+	// tasks of different types holding the same key run one at a time, the others and the tasks without a key don't wait
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		for _, kwargs := range []map[string]interface{}{
+			{LockKeyParam: "0x01"},
+			{LockKeyParam: "0x02"},
+			{},
+		} {
+			for _, name := range []string{"mint", "update"} {
+				wg.Add(1)
+				go func(name string, kwargs map[string]interface{}) {
+					defer wg.Done()
+					res, err := handler(name, kwargs)
+					assert.NoError(t, err)
+					assert.Equal(t, name, res)
+				}(name, kwargs)
+			}
+		}
+	}
+
+	wg.Wait()
+	assert.Equal(t, 1, maxRunning["0x01"])
+	assert.Equal(t, 1, maxRunning["0x02"])
+	assert.True(t, maxRunning[""] > 1)
+	assert.Len(t, l.locks, 0)
+}
+
+func TestKeyLocks_serialize_panic(t *testing.T) {
+	var l keyLocks
+	handler := l.serialize(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		panic("task panicked")
+	})
+
+	// the lock is released by the panicking task
+	kwargs := map[string]interface{}{LockKeyParam: "0x01"}
+	assert.Panics(t, func() { _, _ = handler("mint", kwargs) })
+	assert.Len(t, l.locks, 0)
+	assert.Panics(t, func() { _, _ = handler("mint", kwargs) })
+}
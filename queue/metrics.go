@@ -0,0 +1,65 @@
+package queue
+
+import (
+	"github.com/centrifuge/go-centrifuge/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	tasksEnqueued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "queue",
+		Name:      "tasks_enqueued_total",
+		Help:      "Number of tasks enqueued by task type.",
+	}, []string{"task"})
+
+	tasksRun = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "queue",
+		Name:      "tasks_run_total",
+		Help:      "Number of task runs by the local workers by task type and result.",
+	}, []string{"task", "result"})
+
+	tasksPanicked = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "queue",
+		Name:      "tasks_panicked_total",
+		Help:      "Number of recovered panics of the tasks by task type.",
+	}, []string{"task"})
+
+	taskWaitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "queue",
+		Name:      "task_wait_seconds",
+		Help:      "Time the tasks waited from the enqueue to the start of the run by task type.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 15),
+	}, []string{"task"})
+
+	taskRunSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "queue",
+		Name:      "task_run_seconds",
+		Help:      "Time the task runs by the local workers took by task type.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 15),
+	}, []string{"task"})
+)
+
+// Collectors returns the collectors of the queue metrics.
+func (qs *Server) Collectors() []prometheus.Collector {
+	depth := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "queue",
+		Name:      "depth",
+		Help:      "Number of tasks waiting in the queue.",
+	}, func() float64 {
+		qs.lock.RLock()
+		defer qs.lock.RUnlock()
+		if qs.broker == nil {
+			return 0
+		}
+
+		return float64(qs.broker.depth())
+	})
+
+	return []prometheus.Collector{tasksEnqueued, tasksRun, tasksPanicked, depth, taskWaitSeconds, taskRunSeconds}
+}
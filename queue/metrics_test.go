@@ -0,0 +1,42 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_Collectors(t *testing.T) {
+	// tasks stay queued without the workers
+	qs := &Server{config: mockConfig{enqOnly: true}}
+	qs.RegisterTaskType("echo", new(echoTask))
+	collectors := qs.Collectors()
+	assert.Len(t, collectors, 6)
+	assert.Equal(t, float64(0), testutil.ToFloat64(collectors[3]))
+
+	stop := startServer(t, qs)
+	defer stop()
+	enqueued := testutil.ToFloat64(tasksEnqueued.WithLabelValues("echo"))
+	_, err := qs.EnqueueJob("echo", map[string]interface{}{"value": "echo"})
+	assert.NoError(t, err)
+	assert.Equal(t, enqueued+1, testutil.ToFloat64(tasksEnqueued.WithLabelValues("echo")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collectors[3]))
+
+	// runs are counted by result
+	succeeded := testutil.ToFloat64(tasksRun.WithLabelValues("metrics", "success"))
+	failed := testutil.ToFloat64(tasksRun.WithLabelValues("metrics", "failure"))
+	_, err = qs.stats.record(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		return nil, nil
+	})("metrics", nil)
+	assert.NoError(t, err)
+	_, err = qs.stats.record(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		return nil, errors.New("task failed")
+	})("metrics", nil)
+	assert.Error(t, err)
+	assert.Equal(t, succeeded+1, testutil.ToFloat64(tasksRun.WithLabelValues("metrics", "success")))
+	assert.Equal(t, failed+1, testutil.ToFloat64(tasksRun.WithLabelValues("metrics", "failure")))
+}
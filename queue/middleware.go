@@ -0,0 +1,103 @@
+package queue
+
+import (
+	"runtime/debug"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+)
+
+// TaskHandler runs the task with the given kwargs and returns the result.
+type TaskHandler func(taskName string, kwargs map[string]interface{}) (interface{}, error)
+
+// Middleware wraps a TaskHandler to add behaviour around every task execution.
+type Middleware func(next TaskHandler) TaskHandler
+
+// Recoverer converts a panic in the task into a task failure instead of crashing the worker.
+func Recoverer(next TaskHandler) TaskHandler {
+	return func(taskName string, kwargs map[string]interface{}) (res interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("task %s panicked: %v\n%s", taskName, r, debug.Stack())
+				tasksPanicked.WithLabelValues(taskName).Inc()
+				res, err = nil, errors.New("task %s panicked: %v", taskName, r)
+			}
+		}()
+
+		return next(taskName, kwargs)
+	}
+}
+
+// chain wraps the handler with the middlewares. First middleware is the outermost.
+func chain(handler TaskHandler, mws ...Middleware) TaskHandler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		handler = mws[i](handler)
+	}
+
+	return handler
+}
+
+// middlewareTask wraps a gocelery.CeleryTask so that every run goes through the middlewares.
+type middlewareTask struct {
+	name    string
+	task    gocelery.CeleryTask
+	mws     []Middleware
+	running *runningTasks
+	kwargs  map[string]interface{}
+
+	// pool runs the task itself, optional
+	pool *taskPool
+}
+
+// Copy returns a copy of the wrapped task.
+func (t *middlewareTask) Copy() (gocelery.CeleryTask, error) {
+	task, err := t.task.Copy()
+	if err != nil {
+		return nil, err
+	}
+
+	return &middlewareTask{name: t.name, task: task, mws: t.mws, running: t.running, pool: t.pool}, nil
+}
+
+// ParseKwargs holds the kwargs until the task is run so that the parsing is covered by the middlewares.
+func (t *middlewareTask) ParseKwargs(kwargs map[string]interface{}) error {
+	t.kwargs = kwargs
+	return nil
+}
+
+// RunTask runs the wrapped task through the middlewares. The task itself runs on the pool, if any.
+func (t *middlewareTask) RunTask() (interface{}, error) {
+	ctx, done := t.running.start(t.name)
+	defer done()
+	return chain(func(_ string, kwargs map[string]interface{}) (interface{}, error) {
+		run := func() (interface{}, error) {
+			err := t.task.ParseKwargs(kwargs)
+			if err != nil {
+				return nil, err
+			}
+
+			if cs, ok := t.task.(ContextSetter); ok {
+				cs.SetContext(ctx)
+			}
+
+			return t.task.RunTask()
+		}
+
+		if t.pool == nil {
+			return run()
+		}
+
+		return t.pool.run(t.name, run)
+	}, t.mws...)(t.name, t.kwargs)
+}
+
+// withMiddlewares wraps the task with the middlewares if the task is a gocelery.CeleryTask.
+// Runs of the wrapped task are tracked in running so that they can be cancelled and run on the pool if not nil.
+func withMiddlewares(name string, task interface{}, mws []Middleware, running *runningTasks, pool *taskPool) interface{} {
+	ct, ok := task.(gocelery.CeleryTask)
+	if !ok || len(mws) < 1 {
+		return task
+	}
+
+	return &middlewareTask{name: name, task: ct, mws: mws, running: running, pool: pool}
+}
@@ -0,0 +1,73 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCeleryTask struct {
+	value string
+}
+
+func (m *mockCeleryTask) Copy() (gocelery.CeleryTask, error) {
+	return &mockCeleryTask{}, nil
+}
+
+func (m *mockCeleryTask) ParseKwargs(kwargs map[string]interface{}) error {
+	m.value = kwargs["value"].(string)
+	return nil
+}
+
+func (m *mockCeleryTask) RunTask() (interface{}, error) {
+	if m.value == "panic" {
+		panic("task panicked")
+	}
+
+	return m.value, nil
+}
+
+func TestMiddlewareTask(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next TaskHandler) TaskHandler {
+			return func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+				assert.Equal(t, "task", taskName)
+				calls = append(calls, name)
+				return next(taskName, kwargs)
+			}
+		}
+	}
+
+	// not a celery task
+	fn := func() {}
+	assert.NotNil(t, withMiddlewares("task", fn, []Middleware{mw("first")}, new(runningTasks), nil))
+
+	task := withMiddlewares("task", new(mockCeleryTask), []Middleware{Recoverer, mw("first"), mw("second")}, new(runningTasks), nil).(gocelery.CeleryTask)
+	ct, err := task.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{"value": "result"}))
+	res, err := ct.RunTask()
+	assert.NoError(t, err)
+	assert.Equal(t, "result", res)
+	assert.Equal(t, []string{"first", "second"}, calls)
+
+	// panic is converted to task failure
+	ct, err = task.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{"value": "panic"}))
+	res, err = ct.RunTask()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "task panicked")
+	assert.Nil(t, res)
+
+	// panic while parsing kwargs is recovered as well
+	ct, err = task.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{}))
+	_, err = ct.RunTask()
+	assert.Error(t, err)
+}
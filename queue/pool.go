@@ -0,0 +1,100 @@
+package queue
+
+import (
+	"runtime/debug"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// ErrTaskPoolStopped is returned if a task is run after the task pool stopped.
+const ErrTaskPoolStopped = errors.Error("task pool stopped")
+
+// taskPool runs the tasks on dedicated routines so that the code of the tasks never runs on the routines of the workers.
+// A panicking task fails and the routine it ran on is retired and replaced with a fresh one,
+// so a bad task neither takes the node down nor leaves the pool with a routine in an unknown state.
+type taskPool struct {
+	runs chan poolRun
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// poolRun is a run of a task on the pool.
+type poolRun struct {
+	taskName string
+	run      func() (interface{}, error)
+	result   chan<- poolResult
+}
+
+type poolResult struct {
+	res interface{}
+	err error
+}
+
+// newTaskPool starts a pool of size routines. The pool runs at least one routine.
+func newTaskPool(size int) *taskPool {
+	if size < 1 {
+		size = 1
+	}
+
+	p := &taskPool{runs: make(chan poolRun), quit: make(chan struct{})}
+	for i := 0; i < size; i++ {
+		p.spawn()
+	}
+
+	return p
+}
+
+// spawn starts a routine of the pool.
+func (p *taskPool) spawn() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		for {
+			select {
+			case <-p.quit:
+				return
+			case run := <-p.runs:
+				if !p.execute(run) {
+					p.spawn()
+					return
+				}
+			}
+		}
+	}()
+}
+
+// execute runs the task and returns false if the task panicked.
+func (p *taskPool) execute(run poolRun) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("task %s panicked, retiring its routine: %v\n%s", run.taskName, r, debug.Stack())
+			tasksPanicked.WithLabelValues(run.taskName).Inc()
+			run.result <- poolResult{err: errors.New("task %s panicked: %v", run.taskName, r)}
+			ok = false
+		}
+	}()
+
+	res, err := run.run()
+	run.result <- poolResult{res: res, err: err}
+	return true
+}
+
+// run runs the task on one of the routines of the pool and waits for its result.
+func (p *taskPool) run(taskName string, run func() (interface{}, error)) (interface{}, error) {
+	result := make(chan poolResult, 1)
+	select {
+	case p.runs <- poolRun{taskName: taskName, run: run, result: result}:
+	case <-p.quit:
+		return nil, ErrTaskPoolStopped
+	}
+
+	r := <-result
+	return r.res, r.err
+}
+
+// stop stops the routines of the pool once they completed their current task.
+func (p *taskPool) stop() {
+	close(p.quit)
+	p.wg.Wait()
+}
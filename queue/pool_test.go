@@ -0,0 +1,68 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskPool(t *testing.T) {
+	pool := newTaskPool(1)
+	res, err := pool.run("echo", func() (interface{}, error) {
+		return "echo", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "echo", res)
+
+	_, err = pool.run("echo", func() (interface{}, error) {
+		return nil, errors.New("task failed")
+	})
+	assert.EqualError(t, err, "task failed")
+
+	// the panicking routine is replaced
+	panics := testutil.ToFloat64(tasksPanicked.WithLabelValues("pool"))
+	for i := 0; i < 3; i++ {
+		_, err = pool.run("pool", func() (interface{}, error) {
+			panic("task panicked")
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "task pool panicked: task panicked")
+	}
+	assert.Equal(t, panics+3, testutil.ToFloat64(tasksPanicked.WithLabelValues("pool")))
+	res, err = pool.run("echo", func() (interface{}, error) {
+		return "echo", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "echo", res)
+
+	pool.stop()
+	_, err = pool.run("echo", func() (interface{}, error) {
+		return "echo", nil
+	})
+	assert.Equal(t, ErrTaskPoolStopped, err)
+}
+
+func TestServer_panickingTask(t *testing.T) {
+	qs := &Server{config: mockConfig{}}
+	qs.RegisterTaskType("echo", new(echoTask))
+	stop := startServer(t, qs)
+	defer stop()
+
+	res, err := qs.EnqueueJob("echo", map[string]interface{}{"value": "panic"})
+	assert.NoError(t, err)
+	_, err = res.Get(time.Second)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "task echo panicked")
+
+	// workers keep running the tasks
+	res, err = qs.EnqueueJob("echo", map[string]interface{}{"value": "echo"})
+	assert.NoError(t, err)
+	val, err := res.Get(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "echo", val)
+}
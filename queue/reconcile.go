@@ -0,0 +1,73 @@
+package queue
+
+import (
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/gocelery"
+)
+
+// reconcileJobs reconciles the jobs with the tasks queued in the broker if a reconcile policy is configured.
+// Runs while the server starts, before the workers pick up the queued tasks.
+func (qs *Server) reconcileJobs() {
+	policy := qs.config.GetTaskReconcilePolicy()
+	if policy == "" || qs.jobMan == nil {
+		return
+	}
+
+	report, err := qs.jobMan.ReconcileJobs(reconcileBackend{qs: qs}, policy)
+	if err != nil {
+		log.Errorf("failed to reconcile the jobs with the queued tasks: %v", err)
+		return
+	}
+
+	log.Infof("Reconciled the jobs with the queued tasks: %d tasks dropped, %d jobs reopened, %d jobs requeued, %d jobs failed",
+		report.Dropped, report.Reopened, report.Requeued, report.Failed)
+}
+
+// reconcileBackend exposes the tasks queued in the broker to the jobs reconciliation.
+// Only used while the server starts and holds the server lock.
+type reconcileBackend struct {
+	qs *Server
+}
+
+// QueuedJobs returns the IDs of the jobs with at least one task queued in the broker.
+func (b reconcileBackend) QueuedJobs() (map[jobs.JobID]struct{}, error) {
+	ids := make(map[jobs.JobID]struct{})
+	_, err := b.qs.broker.removeIf(func(msg *gocelery.TaskMessage) bool {
+		if id, ok := taskJobID(msg); ok {
+			ids[id] = struct{}{}
+		}
+		return false
+	})
+
+	return ids, err
+}
+
+// DropJobTasks drops the queued tasks of the job.
+func (b reconcileBackend) DropJobTasks(id jobs.JobID) (int, error) {
+	return b.qs.broker.removeIf(func(msg *gocelery.TaskMessage) bool {
+		jobID, ok := taskJobID(msg)
+		return ok && jobs.JobIDEqual(id, jobID)
+	})
+}
+
+// RequeueJobTask queues the task of the job again.
+func (b reconcileBackend) RequeueJobTask(task jobs.JobTask) error {
+	params := make(map[string]interface{}, len(task.Params))
+	for k, v := range task.Params {
+		params[k] = v
+	}
+
+	_, err := b.qs.enqueueWithTimeout(task.Name, params)
+	return err
+}
+
+// taskJobID returns the ID of the job the task runs for, if any.
+func taskJobID(msg *gocelery.TaskMessage) (jobs.JobID, bool) {
+	hex, ok := msg.Kwargs[jobs.JobIDParam].(string)
+	if !ok {
+		return jobs.NilJobID(), false
+	}
+
+	id, err := jobs.FromString(hex)
+	return id, err == nil
+}
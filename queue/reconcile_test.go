@@ -0,0 +1,70 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/stretchr/testify/assert"
+)
+
+// reconcilingJobManager reconciles the jobs with reconcile, the other methods are not used by the queue.
+type reconcilingJobManager struct {
+	jobs.Manager
+	reconcile func(backend jobs.TaskBackend, policy string) (jobs.ReconcileReport, error)
+}
+
+func (m reconcilingJobManager) ReconcileJobs(backend jobs.TaskBackend, policy string) (jobs.ReconcileReport, error) {
+	return m.reconcile(backend, policy)
+}
+
+func TestServer_reconcileJobs(t *testing.T) {
+	// tasks queued by a previous run
+	qs := &Server{config: mockConfig{enqOnly: true}}
+	qs.RegisterTaskType("echo", new(echoTask))
+	stop := startServer(t, qs)
+	done, running, orphaned := jobs.NewJobID(), jobs.NewJobID(), jobs.NewJobID()
+	for _, params := range []map[string]interface{}{
+		{"value": "echo", jobs.JobIDParam: done.String()},
+		{"value": "echo", jobs.JobIDParam: running.String()},
+		{"value": "echo"},
+	} {
+		_, err := qs.EnqueueJob("echo", params)
+		assert.NoError(t, err)
+	}
+	stop()
+
+	reconciled := make(chan struct{})
+	qs.config = mockConfig{enqOnly: true, policy: jobs.ReconcileTrustStore}
+	qs.jobMan = reconcilingJobManager{reconcile: func(backend jobs.TaskBackend, policy string) (jobs.ReconcileReport, error) {
+		defer close(reconciled)
+		assert.Equal(t, jobs.ReconcileTrustStore, policy)
+		queued, err := backend.QueuedJobs()
+		assert.NoError(t, err)
+		assert.Equal(t, map[jobs.JobID]struct{}{done: {}, running: {}}, queued)
+		assert.Equal(t, 3, qs.broker.depth())
+
+		dropped, err := backend.DropJobTasks(done)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dropped)
+		assert.NoError(t, backend.RequeueJobTask(jobs.JobTask{
+			Name:   "echo",
+			Params: map[string]interface{}{"value": "echo", jobs.JobIDParam: orphaned.String()},
+		}))
+
+		queued, err = backend.QueuedJobs()
+		assert.NoError(t, err)
+		assert.Equal(t, map[jobs.JobID]struct{}{running: {}, orphaned: {}}, queued)
+		return jobs.ReconcileReport{Dropped: 1, Requeued: 1}, nil
+	}}
+	stop = startServer(t, qs)
+	defer stop()
+	select {
+	case <-reconciled:
+	case <-time.After(time.Second):
+		t.Fatal("jobs not reconciled")
+	}
+	assert.Equal(t, 3, qs.broker.depth())
+}
@@ -0,0 +1,116 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+)
+
+// recordJobTask records the task in the job if the replay is enabled and the params hold the job and the account of the job.
+// The record is what ReplayJobTask enqueues again, failing to record doesn't fail the enqueue.
+// The record saves the job, it runs without the lock of the server so that the other enqueues don't wait on it.
+func (qs *Server) recordJobTask(taskName string, params map[string]interface{}) {
+	if !qs.replay || qs.jobMan == nil {
+		return
+	}
+
+	accountID, jobID, ok := taskJob(params)
+	if !ok {
+		return
+	}
+
+	kwargs := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		kwargs[k] = v
+	}
+
+	err := qs.jobMan.RecordJobTask(accountID, jobID, jobs.JobTask{Name: taskName, Params: kwargs})
+	if err != nil {
+		log.Warningf("failed to record task %s of job %s: %v", taskName, jobID.String(), err)
+	}
+}
+
+// ReplayJobTask enqueues the task recorded by the job again within a new job and returns the result of the task.
+// The new job records the ID of the replayed job under jobs.ReplayOfKey and completes with the task.
+// Useful to re-run the task of a job whose done channel is gone, such as after a node restart.
+// The tasks are only recorded with queue.replayEnabled set.
+func (qs *Server) ReplayJobTask(accountID identity.DID, id jobs.JobID) (TaskResult, error) {
+	if qs.jobMan == nil {
+		return nil, errors.New("jobs manager hasn't been initialised")
+	}
+
+	job, err := qs.jobMan.GetJob(accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Task == nil {
+		return nil, jobs.ErrJobTaskNotRecorded
+	}
+
+	type enqueued struct {
+		res TaskResult
+		err error
+	}
+
+	task := *job.Task
+	results := make(chan enqueued, 1)
+	_, _, err = qs.jobMan.ExecuteWithinJob(context.Background(), accountID, jobs.NilJobID(), job.Description, func(accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error) {
+		err := jobMan.UpdateJobWithValue(accountID, jobID, jobs.ReplayOfKey, id.Bytes())
+		if err != nil {
+			results <- enqueued{err: err}
+			errOut <- err
+			return
+		}
+
+		params := make(map[string]interface{}, len(task.Params))
+		for k, v := range task.Params {
+			params[k] = v
+		}
+
+		// the replay runs for the new job and must not be deduplicated with the replayed task
+		params[jobs.JobIDParam] = jobID.String()
+		delete(params, DedupKeyParam)
+		res, err := qs.EnqueueJob(task.Name, params)
+		if err != nil {
+			results <- enqueued{err: err}
+			errOut <- err
+			return
+		}
+
+		// the result is read once by the job and shared with the caller
+		shared := &sharedResult{TaskResult: res, done: make(chan struct{})}
+		results <- enqueued{res: shared}
+		shared.res, shared.err = res.Get(jobMan.GetDefaultTaskTimeout())
+		close(shared.done)
+		errOut <- shared.err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := <-results
+	return r.res, r.err
+}
+
+// sharedResult is a task result read once and shared with the other readers.
+// The state is read from the task result.
+type sharedResult struct {
+	TaskResult
+	done chan struct{}
+	res  interface{}
+	err  error
+}
+
+// Get returns the result once read or an error if the result isn't read within the timeout.
+func (r *sharedResult) Get(timeout time.Duration) (interface{}, error) {
+	select {
+	case <-r.done:
+		return r.res, r.err
+	case <-time.After(timeout):
+		return nil, errors.New("timed out waiting for the task result")
+	}
+}
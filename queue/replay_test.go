@@ -0,0 +1,131 @@
+// +build unit
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingJobManager keeps the jobs in memory, the other methods are not used by the queue.
+type recordingJobManager struct {
+	jobs.Manager
+	mu   sync.Mutex
+	jobs map[jobs.JobID]*jobs.Job
+	done chan error
+}
+
+func (m *recordingJobManager) GetJob(accountID identity.DID, id jobs.JobID) (*jobs.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, jobs.ErrJobsMissing
+	}
+
+	return job, nil
+}
+
+func (m *recordingJobManager) RecordJobTask(accountID identity.DID, id jobs.JobID, task jobs.JobTask) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[id].Task = &task
+	return nil
+}
+
+func (m *recordingJobManager) UpdateJobWithValue(accountID identity.DID, id jobs.JobID, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[id].Values[key] = jobs.JobValue{Key: key, Value: value}
+	return nil
+}
+
+func (m *recordingJobManager) GetDefaultTaskTimeout() time.Duration {
+	return time.Second
+}
+
+func (m *recordingJobManager) ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingJobID jobs.JobID, desc string, work func(accountID identity.DID, jobID jobs.JobID, jobManager jobs.Manager, err chan<- error)) (jobs.JobID, chan error, error) {
+	job := m.newJob(accountID, desc)
+	go work(accountID, job.ID, m, m.done)
+	return job.ID, m.done, nil
+}
+
+func (m *recordingJobManager) newJob(accountID identity.DID, desc string) *jobs.Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := jobs.NewJob(accountID, desc)
+	m.jobs[job.ID] = job
+	return job
+}
+
+func TestServer_ReplayJobTask(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	jobMan := &recordingJobManager{jobs: make(map[jobs.JobID]*jobs.Job), done: make(chan error, 1)}
+	qs := &Server{config: mockConfig{}, jobMan: jobMan, replay: true}
+	qs.RegisterTaskType("echo", new(echoTask))
+	stop := startServer(t, qs)
+	defer stop()
+
+	// nothing recorded
+	job := jobMan.newJob(did, "echo job")
+	_, err := qs.ReplayJobTask(did, job.ID)
+	assert.Equal(t, jobs.ErrJobTaskNotRecorded, err)
+
+	// missing job
+	_, err = qs.ReplayJobTask(did, jobs.NewJobID())
+	assert.Equal(t, jobs.ErrJobsMissing, err)
+
+	// not recorded with the replay disabled
+	qs.replay = false
+	res, err := qs.EnqueueJob("echo", map[string]interface{}{
+		"value":             "echo",
+		jobs.JobIDParam:     job.ID.String(),
+		jobs.AccountIDParam: did.String(),
+	})
+	assert.NoError(t, err)
+	_, err = res.Get(time.Second)
+	assert.NoError(t, err)
+	assert.Nil(t, job.Task)
+	qs.replay = true
+
+	// tasks without the account are not recorded
+	_, err = qs.EnqueueJob("echo", map[string]interface{}{"value": "echo", jobs.JobIDParam: job.ID.String()})
+	assert.NoError(t, err)
+	assert.Nil(t, job.Task)
+
+	res, err = qs.EnqueueJob("echo", map[string]interface{}{
+		"value":             "echo",
+		jobs.JobIDParam:     job.ID.String(),
+		jobs.AccountIDParam: did.String(),
+	})
+	assert.NoError(t, err)
+	_, err = res.Get(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "echo", job.Task.Name)
+	assert.Equal(t, "echo", job.Task.Params["value"])
+
+	// replayed in a new job linked to the original
+	res, err = qs.ReplayJobTask(did, job.ID)
+	assert.NoError(t, err)
+	val, err := res.Get(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "echo", val)
+	assert.NoError(t, <-jobMan.done)
+	assert.Len(t, jobMan.jobs, 2)
+	for id, replay := range jobMan.jobs {
+		if id == job.ID {
+			continue
+		}
+
+		assert.Equal(t, "echo job", replay.Description)
+		assert.Equal(t, job.ID.Bytes(), replay.Values[jobs.ReplayOfKey].Value)
+		assert.Equal(t, id.String(), replay.Task.Params[jobs.JobIDParam])
+	}
+}
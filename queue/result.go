@@ -0,0 +1,54 @@
+package queue
+
+import (
+	"strings"
+)
+
+// ResultStorer can be implemented by a TaskType to declare whether its results are stored in the backend.
+// The results of the other task types are stored. The node config can override the declaration per task type.
+type ResultStorer interface {
+
+	// StoreResult returns false if the results of the task are discarded once executed.
+	// Getting the TaskResult of a discarded result times out, so only fire and forget tasks should opt out.
+	StoreResult() bool
+}
+
+// storeResults returns whether the results of the task types are stored keyed by the task type name.
+func storeResults(taskTypes []TaskType, overrides map[string]bool) map[string]bool {
+	store := make(map[string]bool)
+	for _, task := range taskTypes {
+		name := task.TaskTypeName()
+		store[name] = true
+		if rs, ok := task.(ResultStorer); ok {
+			store[name] = rs.StoreResult()
+		}
+
+		if ok, overridden := overrides[strings.ToLower(name)]; overridden {
+			store[name] = ok
+		}
+	}
+
+	return store
+}
+
+// storeResult returns false if the results of the task type are discarded.
+func (qs *Server) storeResult(taskName string) bool {
+	store, ok := qs.resultStores[taskName]
+	return !ok || store
+}
+
+// discard marks the result of the task to be discarded instead of stored.
+func (b *ackBroker) discard(taskID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.discarded[taskID] = struct{}{}
+}
+
+// isDiscarded returns true if the result of the task is to be discarded and forgets the task.
+func (b *ackBroker) isDiscarded(taskID string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.discarded[taskID]
+	delete(b.discarded, taskID)
+	return ok
+}
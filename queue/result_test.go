@@ -0,0 +1,58 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fireAndForgetTask struct {
+	mockCeleryTask
+}
+
+func (*fireAndForgetTask) TaskTypeName() string {
+	return "fireAndForget"
+}
+
+func (*fireAndForgetTask) StoreResult() bool {
+	return false
+}
+
+func TestStoreResults(t *testing.T) {
+	taskTypes := []TaskType{new(echoTask), new(fireAndForgetTask)}
+	assert.Equal(t, map[string]bool{"echo": true, "fireAndForget": false}, storeResults(taskTypes, nil))
+	assert.Equal(t, map[string]bool{"echo": false, "fireAndForget": true},
+		storeResults(taskTypes, map[string]bool{"echo": false, "fireandforget": true}))
+}
+
+func TestServer_discardedResults(t *testing.T) {
+	ran := make(chan string, 2)
+	qs := &Server{config: mockConfig{store: map[string]bool{"echo": false}}}
+	qs.RegisterTaskType("fireAndForget", new(fireAndForgetTask))
+	qs.RegisterTaskType("echo", new(echoTask))
+	qs.Use(func(next TaskHandler) TaskHandler {
+		return func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+			defer func() { ran <- taskName }()
+			return next(taskName, kwargs)
+		}
+	})
+	stop := startServer(t, qs)
+	defer stop()
+	assert.True(t, qs.storeResult(unknownTaskName))
+
+	for _, name := range []string{"fireAndForget", "echo"} {
+		res, err := qs.EnqueueJob(name, map[string]interface{}{"value": "discarded"})
+		assert.NoError(t, err)
+		assert.Equal(t, name, <-ran)
+		// acknowledged without storing the result
+		assert.Eventually(t, func() bool {
+			qs.broker.mu.Lock()
+			defer qs.broker.mu.Unlock()
+			return len(qs.broker.unacked) == 0 && len(qs.broker.discarded) == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.False(t, res.(taskResult).Ready())
+	}
+}
@@ -0,0 +1,126 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/gocelery"
+)
+
+const (
+	// retryBackOffStep and retryMaxBackOff mirror the backoff of the gocelery workers,
+	// which delay the nth retry of a task by n times the step, at most the max.
+	retryBackOffStep = 5 * time.Second
+	retryMaxBackOff  = 5 * time.Minute
+)
+
+// retryBackOff returns the delay of the retry following the nth failed run of a task.
+func retryBackOff(failures int) time.Duration {
+	d := retryBackOffStep * time.Duration(failures)
+	if d > retryMaxBackOff {
+		return retryMaxBackOff
+	}
+
+	return d
+}
+
+// retryTracker counts the consecutive retryable failures of the tasks of the jobs.
+// gocelery keeps the number of tries to itself so the failures are counted as the runs go through the middleware.
+type retryTracker struct {
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+// failed records the retryable failure of the task and returns the number of its consecutive failures.
+func (r *retryTracker) failed(key string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures == nil {
+		r.failures = make(map[string]int)
+	}
+
+	r.failures[key]++
+	return r.failures[key]
+}
+
+// done forgets the failures of the task once it is not retried anymore.
+func (r *retryTracker) done(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failures, key)
+}
+
+// notifyRetries is the middleware notifying the jobs whose task failed and is scheduled to run again.
+// The notification is sent through the jobs manager, which only sends it if the event is opted in.
+func (qs *Server) notifyRetries(next TaskHandler) TaskHandler {
+	return func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		res, err := next(taskName, kwargs)
+		if qs.jobMan == nil {
+			return res, err
+		}
+
+		accountID, jobID, ok := taskJob(kwargs)
+		if !ok {
+			return res, err
+		}
+
+		key := taskName + jobID.String()
+		if err != gocelery.ErrTaskRetryable || !qs.retryable(taskName, kwargs) {
+			qs.retries.done(key)
+			return res, err
+		}
+
+		failures := qs.retries.failed(key)
+		qs.jobMan.NotifyJobRetrying(context.Background(), accountID, jobID, taskName, failures+1, retryBackOff(failures))
+		return res, err
+	}
+}
+
+// retryable returns false if the task is past the validity it was enqueued with, in which case gocelery drops it.
+// Tasks without the enqueue time are assumed to be valid.
+func (qs *Server) retryable(taskName string, kwargs map[string]interface{}) bool {
+	v, ok := kwargs[EnqueuedAtParam].(string)
+	if !ok {
+		return true
+	}
+
+	enqueuedAt, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return true
+	}
+
+	validity := qs.config.GetTaskValidDuration()
+	if timeout, ok := qs.taskTimeout(taskName); ok {
+		validity = timeout
+	}
+
+	return time.Now().Before(enqueuedAt.Add(validity))
+}
+
+// taskJob returns the account and the job the task runs for. False is returned if the kwargs hold no job.
+func taskJob(kwargs map[string]interface{}) (identity.DID, jobs.JobID, bool) {
+	jobIDHex, ok := kwargs[jobs.JobIDParam].(string)
+	if !ok {
+		return identity.DID{}, jobs.JobID{}, false
+	}
+
+	accountHex, ok := kwargs[jobs.AccountIDParam].(string)
+	if !ok {
+		return identity.DID{}, jobs.JobID{}, false
+	}
+
+	jobID, err := jobs.FromString(jobIDHex)
+	if err != nil {
+		return identity.DID{}, jobs.JobID{}, false
+	}
+
+	accountID, err := identity.NewDIDFromString(accountHex)
+	if err != nil {
+		return identity.DID{}, jobs.JobID{}, false
+	}
+
+	return accountID, jobID, true
+}
@@ -0,0 +1,66 @@
+// +build unit
+
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBackOff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, retryBackOff(1))
+	assert.Equal(t, 15*time.Second, retryBackOff(3))
+	assert.Equal(t, 5*time.Minute, retryBackOff(100))
+}
+
+func TestServer_notifyRetries(t *testing.T) {
+	jobMan := new(testingjobs.MockJobManager)
+	qs := &Server{config: mockConfig{timeouts: map[string]time.Duration{"expired": time.Millisecond}}, jobMan: jobMan}
+	did := testingidentity.GenerateRandomDID()
+	jobID := jobs.NewJobID()
+	kwargs := map[string]interface{}{
+		jobs.JobIDParam:     jobID.String(),
+		jobs.AccountIDParam: did.String(),
+		EnqueuedAtParam:     time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var taskErr error
+	handler := qs.notifyRetries(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		return nil, taskErr
+	})
+
+	// every retryable failure is notified with the attempt and the delay of the next run
+	taskErr = gocelery.ErrTaskRetryable
+	jobMan.On("NotifyJobRetrying", context.Background(), did, jobID, "task", 2, 5*time.Second).Once()
+	jobMan.On("NotifyJobRetrying", context.Background(), did, jobID, "task", 3, 10*time.Second).Once()
+	_, err := handler("task", kwargs)
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+	_, err = handler("task", kwargs)
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+	jobMan.AssertExpectations(t)
+
+	// the count restarts once the task is not retried anymore
+	taskErr = errors.New("failed")
+	_, err = handler("task", kwargs)
+	assert.Error(t, err)
+	taskErr = gocelery.ErrTaskRetryable
+	jobMan.On("NotifyJobRetrying", context.Background(), did, jobID, "task", 2, 5*time.Second).Once()
+	_, err = handler("task", kwargs)
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+	jobMan.AssertExpectations(t)
+
+	// expired tasks and tasks without a job are not notified, the expectations are used up
+	time.Sleep(5 * time.Millisecond)
+	_, err = handler("expired", kwargs)
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+	_, err = handler("task", map[string]interface{}{})
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+}
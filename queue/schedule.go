@@ -0,0 +1,286 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+const (
+	// CatchUpSkip ignores the runs missed while the node was down and waits for the next scheduled run.
+	CatchUpSkip = "skip"
+
+	// CatchUpOnce runs the task once at start if any run was missed while the node was down.
+	CatchUpOnce = "once"
+
+	scheduledRunPrefix = "queue_scheduled_run_"
+)
+
+// schedule is a parsed cron expression. Every field is a bit set of the matching values.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// restricted day fields match if either of them matches as in the standard cron
+	domStar, dowStar bool
+}
+
+// parseSchedule parses the standard 5 field cron expression "minute hour day-of-month month day-of-week".
+// Fields support *, values, ranges (1-5), lists (1,3) and steps (*/15, 0-30/10).
+func parseSchedule(expr string) (*schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.New("cron expression %q must have 5 fields", expr)
+	}
+
+	s := new(schedule)
+	var err error
+	for _, f := range []struct {
+		bits     *uint64
+		field    string
+		min, max int
+	}{
+		{&s.minute, fields[0], 0, 59},
+		{&s.hour, fields[1], 0, 23},
+		{&s.dom, fields[2], 1, 31},
+		{&s.month, fields[3], 1, 12},
+		{&s.dow, fields[4], 0, 7},
+	} {
+		*f.bits, err = parseField(f.field, f.min, f.max)
+		if err != nil {
+			return nil, errors.New("cron expression %q: %v", expr, err)
+		}
+	}
+
+	// 7 is sunday as well
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	s.domStar, s.dowStar = fields[2] == "*", fields[4] == "*"
+	return s, nil
+}
+
+// parseField returns the bit set of the values matching the field.
+func parseField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rng = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, errors.New("invalid step in %q", part)
+			}
+
+			step = n
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			n, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, errors.New("invalid value in %q", part)
+			}
+
+			lo, hi = n, n
+			if len(bounds) == 2 {
+				hi, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return 0, errors.New("invalid value in %q", part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, errors.New("%q is out of range %d-%d", part, min, max)
+		}
+
+		for i := lo; i <= hi; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+// dayMatches returns true if the day of t matches the day of month and day of week fields.
+func (s *schedule) dayMatches(t time.Time) bool {
+	dom, dow := has(s.dom, t.Day()), has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// next returns the first time after t matching the schedule in UTC.
+// Returns zero time if the schedule doesn't match within the next 5 years.
+func (s *schedule) next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	end := t.AddDate(5, 0, 0)
+	for t.Before(end) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(s.hour, t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// scheduledTask is a task enqueued on a cron schedule.
+type scheduledTask struct {
+	name     string
+	schedule *schedule
+}
+
+// scheduledRun holds the last time a scheduled task was enqueued.
+type scheduledRun struct {
+	LastRun time.Time
+}
+
+// JSON returns json marshaled scheduled run.
+func (r *scheduledRun) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// FromJSON loads the data into scheduled run.
+func (r *scheduledRun) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// Type returns the reflect.Type of the scheduled run.
+func (r *scheduledRun) Type() reflect.Type {
+	return reflect.TypeOf(r)
+}
+
+// RegisterScheduledTask registers the task type and enqueues it on the cron schedule once the server is started.
+// Scheduled tasks are enqueued without kwargs.
+func (qs *Server) RegisterScheduledTask(name string, cron string, task TaskType) error {
+	s, err := parseSchedule(cron)
+	if err != nil {
+		return err
+	}
+
+	if s.next(time.Now()).IsZero() {
+		return errors.New("cron expression %q never matches", cron)
+	}
+
+	qs.RegisterTaskType(name, task)
+	qs.lock.Lock()
+	defer qs.lock.Unlock()
+	qs.schedules = append(qs.schedules, scheduledTask{name: name, schedule: s})
+	return nil
+}
+
+// runSchedules enqueues the scheduled tasks at their scheduled times until the context is done.
+func (qs *Server) runSchedules(ctx context.Context) {
+	qs.lock.RLock()
+	schedules := qs.schedules
+	qs.lock.RUnlock()
+	if len(schedules) < 1 {
+		return
+	}
+
+	now := time.Now().UTC()
+	next := make([]time.Time, len(schedules))
+	for i, st := range schedules {
+		next[i] = st.schedule.next(now)
+		if qs.config.GetTaskScheduleCatchUp() == CatchUpOnce && qs.missedRun(st, now) {
+			log.Infof("catching up the missed runs of the scheduled task %s", st.name)
+			qs.runScheduled(st, now)
+		}
+	}
+
+	for {
+		i := -1
+		for j := range next {
+			if !next[j].IsZero() && (i < 0 || next[j].Before(next[i])) {
+				i = j
+			}
+		}
+
+		if i < 0 {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next[i]))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		qs.runScheduled(schedules[i], next[i])
+		next[i] = schedules[i].schedule.next(next[i])
+	}
+}
+
+// runScheduled enqueues the scheduled task and records the run.
+func (qs *Server) runScheduled(st scheduledTask, at time.Time) {
+	_, err := qs.EnqueueJob(st.name, map[string]interface{}{})
+	if err != nil {
+		log.Errorf("failed to enqueue the scheduled task %s: %v", st.name, err)
+		return
+	}
+
+	if qs.db == nil {
+		return
+	}
+
+	key := []byte(scheduledRunPrefix + st.name)
+	run := &scheduledRun{LastRun: at}
+	if qs.db.Exists(key) {
+		err = qs.db.Update(key, run)
+	} else {
+		err = qs.db.Create(key, run)
+	}
+
+	if err != nil {
+		log.Errorf("failed to record the run of the scheduled task %s: %v", st.name, err)
+	}
+}
+
+// missedRun returns true if the scheduled task had a run due between its last recorded run and now.
+// Tasks never run before have nothing to catch up.
+func (qs *Server) missedRun(st scheduledTask, now time.Time) bool {
+	if qs.db == nil {
+		return false
+	}
+
+	m, err := qs.db.Get([]byte(scheduledRunPrefix + st.name))
+	if err != nil {
+		return false
+	}
+
+	next := st.schedule.next(m.(*scheduledRun).LastRun)
+	return !next.IsZero() && next.Before(now)
+}
+
+// setStorage sets the repository recording the runs of the scheduled tasks.
+func (qs *Server) setStorage(db storage.Repository) {
+	db.Register(new(scheduledRun))
+	qs.db = db
+}
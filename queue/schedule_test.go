@@ -0,0 +1,134 @@
+// +build unit
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTaskType struct{}
+
+func (mockTaskType) TaskTypeName() string {
+	return "scheduledTask"
+}
+
+func TestParseSchedule(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"a * * * *",
+		"5-1 * * * *",
+	} {
+		_, err := parseSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+
+	s, err := parseSchedule("*/15 1,2 * * 7")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1|1<<15|1<<30|1<<45), s.minute)
+	assert.Equal(t, uint64(1<<1|1<<2), s.hour)
+	assert.True(t, has(s.dow, 0))
+}
+
+func TestSchedule_next(t *testing.T) {
+	from := time.Date(2020, time.January, 31, 10, 20, 30, 0, time.UTC)
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2020, time.January, 31, 10, 21, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, time.January, 31, 10, 30, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2020, time.January, 31, 11, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2020, time.February, 1, 2, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// 2020-02-03 is a monday
+		{"0 0 * * 1", time.Date(2020, time.February, 3, 0, 0, 0, 0, time.UTC)},
+		// either of the restricted day fields
+		{"0 0 15 * 1", time.Date(2020, time.February, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, c := range tests {
+		s, err := parseSchedule(c.expr)
+		assert.NoError(t, err)
+		assert.Equal(t, c.next, s.next(from), c.expr)
+	}
+}
+
+func TestServer_RegisterScheduledTask(t *testing.T) {
+	qs := new(Server)
+	assert.Error(t, qs.RegisterScheduledTask("scheduledTask", "invalid", mockTaskType{}))
+	assert.Error(t, qs.RegisterScheduledTask("scheduledTask", "0 0 30 2 *", mockTaskType{}))
+	assert.Len(t, qs.taskTypes, 0)
+
+	assert.NoError(t, qs.RegisterScheduledTask("scheduledTask", "*/5 * * * *", mockTaskType{}))
+	assert.Len(t, qs.taskTypes, 1)
+	assert.Len(t, qs.schedules, 1)
+}
+
+func TestServer_scheduledRuns(t *testing.T) {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	broker := gocelery.NewInMemoryBroker()
+	client, err := gocelery.NewCeleryClient(broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	qs := &Server{config: mockConfig{}, queue: client}
+	qs.setStorage(leveldb.NewLevelDBRepository(db))
+	assert.NoError(t, qs.RegisterScheduledTask("scheduledTask", "0 * * * *", mockTaskType{}))
+	st := qs.schedules[0]
+
+	// never run
+	now := time.Now().UTC()
+	assert.False(t, qs.missedRun(st, now))
+
+	// run is recorded
+	qs.runScheduled(st, now.Add(-2*time.Hour))
+	msg, err := broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "scheduledTask", msg.Task)
+	assert.True(t, qs.missedRun(st, now))
+
+	qs.runScheduled(st, now)
+	assert.False(t, qs.missedRun(st, now))
+}
+
+func TestServer_runSchedules_catchUp(t *testing.T) {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	broker := gocelery.NewInMemoryBroker()
+	client, err := gocelery.NewCeleryClient(broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	qs := &Server{config: mockConfig{catchUp: CatchUpOnce}, queue: client}
+	qs.setStorage(leveldb.NewLevelDBRepository(db))
+	assert.NoError(t, qs.RegisterScheduledTask("scheduledTask", "0 0 1 1 *", mockTaskType{}))
+	qs.runScheduled(qs.schedules[0], time.Now().AddDate(-2, 0, 0))
+	_, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		qs.runSchedules(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		msg, err := broker.GetTaskMessage()
+		return err == nil && msg != nil && msg.Task == "scheduledTask"
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	wg.Wait()
+}
@@ -2,10 +2,13 @@ package queue
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/gocelery"
 	logging "github.com/ipfs/go-log"
 )
@@ -15,8 +18,22 @@ const (
 	TimeoutParam string = "Timeout"
 )
 
+const (
+	// ErrQueueFull is returned when a task is enqueued while the queue holds the maximum number of tasks.
+	ErrQueueFull = errors.Error("queue is full")
+)
+
 var log = logging.Logger("queue-server")
 
+// classifyFailure recognises the jobs failed by a full queue as rate limited.
+func classifyFailure(err error) (jobs.FailureCategory, bool) {
+	if errors.IsOfType(ErrQueueFull, err) {
+		return jobs.FailureRateLimited, true
+	}
+
+	return "", false
+}
+
 // Config is an interface for queue specific configurations
 type Config interface {
 
@@ -29,6 +46,33 @@ type Config interface {
 
 	// GetTaskValidDuration until which the task is valid from the creation
 	GetTaskValidDuration() time.Duration
+
+	// GetTaskTimeouts returns the durations overriding GetTaskValidDuration keyed by the lower cased task type name
+	GetTaskTimeouts() map[string]time.Duration
+
+	// GetTaskAckMode returns when the tasks are acknowledged to the broker, before or after their execution
+	GetTaskAckMode() string
+
+	// GetTaskScheduleCatchUp returns what happens to the runs of the scheduled tasks missed while the node was down
+	GetTaskScheduleCatchUp() string
+
+	// GetTaskDedupWindow returns the window within which the enqueues of a task with the same dedup key return the first result
+	GetTaskDedupWindow() time.Duration
+
+	// GetTaskMaxQueueDepth returns the maximum number of tasks waiting in the queue, 0 is unbounded
+	GetTaskMaxQueueDepth() int
+
+	// GetTaskQueueFullTimeout returns how long an enqueue waits for room in a full queue before failing
+	GetTaskQueueFullTimeout() time.Duration
+
+	// GetTaskStoreResults returns whether the results of the tasks are stored keyed by the lower cased task type name
+	GetTaskStoreResults() map[string]bool
+
+	// GetTaskEnqueueOnly returns true if the tasks are only enqueued and run by the workers of other nodes
+	GetTaskEnqueueOnly() bool
+
+	// GetTaskReconcilePolicy returns how the jobs are reconciled with the queued tasks at start, empty disables it
+	GetTaskReconcilePolicy() string
 }
 
 // TaskType is a task to be queued in the centrifuge node to be completed asynchronously
@@ -43,14 +87,63 @@ type TaskResult interface {
 
 	// Get the result within a timeout from the queue task execution
 	Get(timeout time.Duration) (interface{}, error)
+
+	// State returns the state of the queued task, one of the TaskState constants
+	State() (string, error)
 }
 
 // Server represents the queue server currently implemented based on gocelery
 type Server struct {
-	config    Config
-	lock      sync.RWMutex
-	queue     *gocelery.CeleryClient
-	taskTypes []TaskType
+	config      Config
+	lock        sync.RWMutex
+	queue       *gocelery.CeleryClient
+	broker      *ackBroker
+	taskTypes   []TaskType
+	middlewares []Middleware
+	schedules   []scheduledTask
+	running     runningTasks
+	dedup       dedupCache
+	enqueueIfMu sync.Mutex
+	stats       queueStats
+	latency     latencyStats
+	retries     retryTracker
+	locks       keyLocks
+
+	// ackModes holds the acknowledgment modes keyed by the task type name, read by the workers
+	ackModesMu sync.RWMutex
+	ackModes   map[string]AckMode
+
+	// tracer traces the task executions, optional
+	tracer Tracer
+
+	// unknownHandler handles the tasks whose task type is not registered, optional
+	unknownHandler TaskHandler
+
+	// resultStores holds whether the results are stored keyed by the task type name
+	resultStores map[string]bool
+
+	// jobMan records the tasks enqueued for the jobs so that they can be replayed, optional
+	jobMan jobs.Manager
+
+	// replay records the tasks enqueued for the jobs, set at bootstrap
+	replay bool
+
+	// db records the runs of the scheduled tasks, optional
+	db storage.Repository
+
+	// manual keeps the workers stopped so that the tasks are only run by ProcessNext
+	manual bool
+
+	// worker runs the tasks processed by ProcessNext and backend stores their results
+	worker  *gocelery.CeleryWorker
+	backend gocelery.CeleryBackend
+
+	// numWorkers overrides the configured number of workers if set, startedWorkers is the number the server started with
+	numWorkers     int
+	startedWorkers int
+
+	// workersRunning is true while the local workers run the tasks
+	workersRunning bool
 }
 
 // Name of the queue server
@@ -63,30 +156,95 @@ func (qs *Server) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan
 	defer wg.Done()
 	qs.lock.Lock()
 	var err error
+	defaultAckMode := toAckMode(qs.config.GetTaskAckMode())
+	ackModes := make(map[string]AckMode)
+	handler := qs.unknownHandler
+	if handler == nil {
+		handler = logUnknownTask
+	}
+	taskTypes := append(qs.taskTypes[:len(qs.taskTypes):len(qs.taskTypes)], &unknownTask{handler: handler})
+	for _, task := range taskTypes {
+		ackModes[task.TaskTypeName()] = defaultAckMode
+		if am, ok := task.(AckModer); ok {
+			ackModes[task.TaskTypeName()] = am.AckMode()
+		}
+	}
+	qs.ackModesMu.Lock()
+	qs.ackModes = ackModes
+	qs.ackModesMu.Unlock()
+	qs.resultStores = storeResults(taskTypes, qs.config.GetTaskStoreResults())
+
+	// broker is retained across restarts so that the tasks which were not acknowledged are redelivered.
+	if qs.broker == nil {
+		qs.broker = newAckBroker(gocelery.NewInMemoryBroker(), qs.ackMode)
+	}
+	qs.broker.registered = qs.isRegistered
+	qs.broker.storeResult = qs.storeResult
+	qs.broker.redeliver()
+	qs.backend = ackBackend{CeleryBackend: gocelery.NewInMemoryBackend(), broker: qs.broker}
+	qs.startedWorkers = qs.workers()
 	qs.queue, err = gocelery.NewCeleryClient(
-		gocelery.NewInMemoryBroker(),
-		gocelery.NewInMemoryBackend(),
-		qs.config.GetNumWorkers(),
+		qs.broker,
+		qs.backend,
+		qs.startedWorkers,
 		qs.config.GetWorkerWaitTimeMS(),
 	)
 	if err != nil {
 		startupErr <- err
 	}
-	for _, task := range qs.taskTypes {
-		qs.queue.Register(task.TaskTypeName(), task)
+	// panics are always recovered so that a misbehaving task doesn't take the worker down
+	// and the runs are counted including the recovered panics.
+	// The runs waiting for their lock key count as waiting, not as running.
+	mws := []Middleware{qs.locks.serialize, qs.stats.record, qs.latency.record, qs.notifyRetries}
+	if qs.tracer != nil {
+		// within the Recoverer the span records the recovered panics as failures
+		mws = append(mws, tracing(qs.tracer))
+	}
+	mws = append(append(mws, Recoverer), qs.middlewares...)
+	// the tasks run on their own routines so that a panicking task cannot affect the workers
+	pool := newTaskPool(qs.startedWorkers)
+	qs.worker = gocelery.NewCeleryWorker(qs.broker, qs.backend, 1, 0)
+	for _, task := range taskTypes {
+		wrapped := withMiddlewares(task.TaskTypeName(), task, mws, &qs.running, pool)
+		qs.queue.Register(task.TaskTypeName(), wrapped)
+		qs.worker.Register(task.TaskTypeName(), wrapped)
+	}
+	qs.reconcileJobs()
+	// start the workers unless the tasks are run by the worker nodes
+	enqueueOnly := qs.config.GetTaskEnqueueOnly() || qs.manual
+	if enqueueOnly {
+		log.Info("Queue server started in enqueue only mode, local workers are not running")
+	} else {
+		qs.queue.StartWorker()
+		qs.workersRunning = true
 	}
-	// start the workers
-	qs.queue.StartWorker()
 	qs.lock.Unlock()
+	go qs.runSchedules(ctx)
 
 	<-ctx.Done()
 	log.Info("Shutting down Queue server with context done")
-	qs.lock.Lock()
-	qs.queue.StopWorker()
-	qs.lock.Unlock()
+	if !enqueueOnly {
+		qs.lock.Lock()
+		qs.queue.StopWorker()
+		qs.workersRunning = false
+		qs.lock.Unlock()
+	}
+	pool.stop()
 	log.Info("Queue server stopped")
 }
 
+// ackMode returns the acknowledgment mode of the task.
+func (qs *Server) ackMode(taskName string) AckMode {
+	qs.ackModesMu.RLock()
+	mode, ok := qs.ackModes[taskName]
+	qs.ackModesMu.RUnlock()
+	if ok {
+		return mode
+	}
+
+	return toAckMode(qs.config.GetTaskAckMode())
+}
+
 // RegisterTaskType registers a task type on the queue server
 func (qs *Server) RegisterTaskType(name string, task interface{}) {
 	qs.lock.Lock()
@@ -94,25 +252,125 @@ func (qs *Server) RegisterTaskType(name string, task interface{}) {
 	qs.taskTypes = append(qs.taskTypes, task.(TaskType))
 }
 
-// EnqueueJob enqueues a job on the queue server for the given taskTypeName
+// Use registers middlewares that are applied around every task execution.
+// Middlewares are applied in the order registered and must be registered before the server is started.
+func (qs *Server) Use(mws ...Middleware) {
+	qs.lock.Lock()
+	defer qs.lock.Unlock()
+	qs.middlewares = append(qs.middlewares, mws...)
+}
+
+// EnqueueJob enqueues a job on the queue server for the given taskTypeName.
+// If the params hold a DedupKeyParam and the dedup window is configured, the result of the task enqueued
+// with the same dedup key within the window is returned instead of enqueuing the task again.
+// If the replay is enabled and the params hold the job and the account of the job, the task is recorded in the job to be replayed later.
+// Tasks whose params hold the same LockKeyParam run one at a time.
 func (qs *Server) EnqueueJob(taskName string, params map[string]interface{}) (TaskResult, error) {
+	qs.recordJobTask(taskName, params)
+	dedupKey, ok := params[DedupKeyParam].(string)
+	window := qs.config.GetTaskDedupWindow()
+	if !ok || dedupKey == "" || window <= 0 {
+		return qs.enqueue(taskName, params)
+	}
+
+	return qs.dedup.getOrEnqueue(taskName, dedupKey, window, func() (TaskResult, error) {
+		return qs.enqueue(taskName, params)
+	})
+}
+
+// enqueue waits for room in the queue and enqueues the task.
+// The lock is not held while waiting so that a full queue doesn't stall Stop and the other writers of the server,
+// the room is reserved in the broker instead until the task is sent.
+func (qs *Server) enqueue(taskName string, params map[string]interface{}) (TaskResult, error) {
+	qs.lock.RLock()
+	broker := qs.broker
+	qs.lock.RUnlock()
+	release, err := qs.waitForRoom(broker, taskName)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	qs.lock.RLock()
 	defer qs.lock.RUnlock()
+	return qs.enqueueWithTimeout(taskName, params)
+}
+
+// enqueueWithTimeout enqueues the task valid until the timeout of its task type.
+func (qs *Server) enqueueWithTimeout(taskName string, params map[string]interface{}) (TaskResult, error) {
 	settings := gocelery.DefaultSettings()
 	settings.ValidUntil = time.Now().Add(qs.config.GetTaskValidDuration())
+	if timeout, ok := qs.taskTimeout(taskName); ok {
+		settings.ValidUntil = time.Now().Add(timeout)
+		// tasks honouring the TimeoutParam use it as their execution deadline
+		if _, ok := params[TimeoutParam]; !ok {
+			params = withParam(params, TimeoutParam, timeout)
+		}
+	}
 	return qs.enqueueJob(taskName, params, settings)
 }
 
+// withParam returns a copy of the params with the param set so that the params of the caller are left untouched.
+func withParam(params map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	cp := make(map[string]interface{}, len(params)+1)
+	for k, v := range params {
+		cp[k] = v
+	}
+
+	cp[key] = value
+	return cp
+}
+
+// taskTimeout returns the timeout configured for the task type, if any.
+func (qs *Server) taskTimeout(taskName string) (time.Duration, bool) {
+	timeout, ok := qs.config.GetTaskTimeouts()[strings.ToLower(taskName)]
+	return timeout, ok && timeout > 0
+}
+
 func (qs *Server) enqueueJob(name string, params map[string]interface{}, settings *gocelery.TaskSettings) (TaskResult, error) {
 	if qs.queue == nil {
 		return nil, errors.New("queue hasn't been initialised")
 	}
 
-	return qs.queue.Delay(gocelery.Task{
+	params = withParam(params, EnqueuedAtParam, time.Now().UTC().Format(time.RFC3339Nano))
+
+	res, err := qs.queue.Delay(gocelery.Task{
 		Name:     name,
 		Kwargs:   params,
 		Settings: settings,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	qs.stats.add(&qs.stats.enqueued, time.Now())
+	tasksEnqueued.WithLabelValues(name).Inc()
+	return newTaskResult(res, qs.broker, qs.backend, !qs.storeResult(name)), nil
+}
+
+// waitForRoom waits up to the configured timeout for room in the queue, reserved until the returned function is called.
+// The room is checked again every time tasks leave the broker. Returns ErrQueueFull if the queue is still full.
+func (qs *Server) waitForRoom(broker *ackBroker, name string) (release func(), err error) {
+	max := qs.config.GetTaskMaxQueueDepth()
+	if max <= 0 || broker == nil {
+		return func() {}, nil
+	}
+
+	timer := time.NewTimer(qs.config.GetTaskQueueFullTimeout())
+	defer timer.Stop()
+	for {
+		room := broker.room()
+		if broker.reserve(max) {
+			return broker.unreserve, nil
+		}
+
+		select {
+		case <-room:
+		case <-timer.C:
+			log.Warningf("rejected task %s: %d tasks are queued", name, broker.depth())
+			return nil, ErrQueueFull
+		}
+	}
 }
 
 // GetDuration parses key parameter to time.Duration type
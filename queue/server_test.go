@@ -0,0 +1,305 @@
+//go:build unit
+// +build unit
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockConfig struct {
+	timeouts map[string]time.Duration
+	catchUp  string
+	dedup    time.Duration
+	maxDepth int
+	fullWait time.Duration
+	enqOnly  bool
+	store    map[string]bool
+	policy   string
+}
+
+func (mockConfig) GetNumWorkers() int {
+	return 1
+}
+
+func (mockConfig) GetWorkerWaitTimeMS() int {
+	return 1
+}
+
+func (mockConfig) GetTaskValidDuration() time.Duration {
+	return time.Minute
+}
+
+func (m mockConfig) GetTaskTimeouts() map[string]time.Duration {
+	return m.timeouts
+}
+
+func (mockConfig) GetTaskAckMode() string {
+	return string(AckAfter)
+}
+
+func (m mockConfig) GetTaskScheduleCatchUp() string {
+	return m.catchUp
+}
+
+func (m mockConfig) GetTaskDedupWindow() time.Duration {
+	return m.dedup
+}
+
+func (m mockConfig) GetTaskMaxQueueDepth() int {
+	return m.maxDepth
+}
+
+func (m mockConfig) GetTaskQueueFullTimeout() time.Duration {
+	return m.fullWait
+}
+
+func (m mockConfig) GetTaskStoreResults() map[string]bool {
+	return m.store
+}
+
+func (m mockConfig) GetTaskEnqueueOnly() bool {
+	return m.enqOnly
+}
+
+func (m mockConfig) GetTaskReconcilePolicy() string {
+	return m.policy
+}
+
+func TestServer_EnqueueJob_taskTimeout(t *testing.T) {
+	broker := gocelery.NewInMemoryBroker()
+	client, err := gocelery.NewCeleryClient(broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	qs := &Server{config: mockConfig{timeouts: map[string]time.Duration{"slowtask": time.Hour}}, queue: client}
+
+	// default
+	_, err = qs.EnqueueJob("fastTask", map[string]interface{}{})
+	assert.NoError(t, err)
+	msg, err := broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.True(t, msg.Settings.ValidUntil.Before(time.Now().Add(time.Minute)))
+	assert.NotContains(t, msg.Kwargs, TimeoutParam)
+
+	// overridden
+	_, err = qs.EnqueueJob("slowTask", map[string]interface{}{})
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.True(t, msg.Settings.ValidUntil.After(time.Now().Add(time.Minute)))
+	d, err := GetDuration(msg.Kwargs[TimeoutParam])
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, d)
+
+	// params of the caller are left untouched
+	params := map[string]interface{}{"key": "value"}
+	_, err = qs.EnqueueJob("slowTask", params)
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Contains(t, msg.Kwargs, TimeoutParam)
+	assert.NotContains(t, params, TimeoutParam)
+
+	// timeout provided by the caller is kept
+	_, err = qs.EnqueueJob("slowTask", map[string]interface{}{TimeoutParam: time.Second})
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	d, err = GetDuration(msg.Kwargs[TimeoutParam])
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, d)
+}
+
+func TestServer_EnqueueJob_dedup(t *testing.T) {
+	broker := gocelery.NewInMemoryBroker()
+	client, err := gocelery.NewCeleryClient(broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	enqueued := func() int {
+		n := 0
+		for {
+			msg, err := broker.GetTaskMessage()
+			assert.NoError(t, err)
+			if msg == nil {
+				return n
+			}
+			n++
+		}
+	}
+
+	// disabled
+	qs := &Server{config: mockConfig{}, queue: client}
+	for i := 0; i < 2; i++ {
+		_, err = qs.EnqueueJob("task", map[string]interface{}{DedupKeyParam: "key"})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, enqueued())
+
+	qs = &Server{config: mockConfig{dedup: 50 * time.Millisecond}, queue: client}
+	res, err := qs.EnqueueJob("task", map[string]interface{}{DedupKeyParam: "key"})
+	assert.NoError(t, err)
+	res1, err := qs.EnqueueJob("task", map[string]interface{}{DedupKeyParam: "key"})
+	assert.NoError(t, err)
+	assert.True(t, res == res1)
+
+	// other dedup key, other task and no dedup key
+	_, err = qs.EnqueueJob("task", map[string]interface{}{DedupKeyParam: "other"})
+	assert.NoError(t, err)
+	_, err = qs.EnqueueJob("otherTask", map[string]interface{}{DedupKeyParam: "key"})
+	assert.NoError(t, err)
+	_, err = qs.EnqueueJob("task", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, enqueued())
+
+	// window elapsed
+	time.Sleep(50 * time.Millisecond)
+	res1, err = qs.EnqueueJob("task", map[string]interface{}{DedupKeyParam: "key"})
+	assert.NoError(t, err)
+	assert.False(t, res == res1)
+	assert.Equal(t, 1, enqueued())
+}
+
+func TestServer_EnqueueJob_queueFull(t *testing.T) {
+	qs := &Server{config: mockConfig{maxDepth: 2}}
+	qs.broker = newAckBroker(gocelery.NewInMemoryBroker(), func(string) AckMode { return AckBefore })
+	client, err := gocelery.NewCeleryClient(qs.broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	qs.queue = client
+
+	for i := 0; i < 2; i++ {
+		_, err = qs.EnqueueJob("task", map[string]interface{}{})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, qs.broker.depth())
+	_, err = qs.EnqueueJob("task", map[string]interface{}{})
+	assert.True(t, errors.IsOfType(ErrQueueFull, err))
+	category, ok := classifyFailure(err)
+	assert.True(t, ok)
+	assert.Equal(t, jobs.FailureRateLimited, category)
+
+	// waits for room
+	qs.config = mockConfig{maxDepth: 2, fullWait: time.Second}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, err := qs.broker.GetTaskMessage()
+		assert.NoError(t, err)
+	}()
+	_, err = qs.EnqueueJob("task", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, qs.broker.depth())
+
+	// the server isn't held while waiting for room
+	enqueued := make(chan error, 1)
+	go func() {
+		_, err := qs.EnqueueJob("task", map[string]interface{}{})
+		enqueued <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	locked := make(chan struct{})
+	go func() {
+		qs.Use()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(500 * time.Millisecond):
+		assert.Fail(t, "server held while waiting for room")
+	}
+	_, err = qs.broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.NoError(t, <-enqueued)
+	assert.Equal(t, 2, qs.broker.depth())
+}
+
+// depthBroker records the maximum number of tasks held by the broker.
+type depthBroker struct {
+	gocelery.CeleryBroker
+	mu     sync.Mutex
+	n, max int
+}
+
+func (b *depthBroker) SendCeleryMessage(msg *gocelery.CeleryMessage) error {
+	// widens the window between checking the room and sending the task
+	time.Sleep(time.Millisecond)
+	err := b.CeleryBroker.SendCeleryMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.n++
+	if b.n > b.max {
+		b.max = b.n
+	}
+
+	return nil
+}
+
+func (b *depthBroker) GetTaskMessage() (*gocelery.TaskMessage, error) {
+	msg, err := b.CeleryBroker.GetTaskMessage()
+	if err == nil && msg != nil {
+		b.mu.Lock()
+		b.n--
+		b.mu.Unlock()
+	}
+
+	return msg, err
+}
+
+func TestServer_EnqueueJob_queueFullConcurrent(t *testing.T) {
+	inner := &depthBroker{CeleryBroker: gocelery.NewInMemoryBroker()}
+	qs := &Server{config: mockConfig{maxDepth: 3, fullWait: 5 * time.Second}}
+	qs.broker = newAckBroker(inner, func(string) AckMode { return AckBefore })
+	client, err := gocelery.NewCeleryClient(qs.broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	qs.queue = client
+
+	const tasks = 30
+	var wg sync.WaitGroup
+	for i := 0; i < tasks; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := qs.EnqueueJob("task", map[string]interface{}{})
+			assert.NoError(t, err)
+		}()
+	}
+
+	// drains the queue slowly so that the enqueues wait for room
+	for drained := 0; drained < tasks; {
+		msg, err := qs.broker.GetTaskMessage()
+		assert.NoError(t, err)
+		if msg != nil {
+			drained++
+		}
+		time.Sleep(time.Millisecond)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, inner.max, 3)
+	assert.Equal(t, 0, qs.broker.depth())
+}
+
+func TestServer_Start_enqueueOnly(t *testing.T) {
+	qs := &Server{config: mockConfig{enqOnly: true}}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go qs.Start(ctx, &wg, make(chan error, 1))
+	assert.Eventually(t, func() bool {
+		_, err := qs.EnqueueJob("task", map[string]interface{}{})
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	// no worker picks the task up
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, qs.broker.depth())
+	cancel()
+	wg.Wait()
+}
@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+)
+
+// The states of a task as reported by TaskResult.State, named after the celery task states.
+const (
+	// TaskStatePending is the state of a task waiting in the queue or unknown to the node.
+	TaskStatePending = "PENDING"
+
+	// TaskStateReceived is the state of a task handed to a worker that waits for its delay before it is run.
+	TaskStateReceived = "RECEIVED"
+
+	// TaskStateStarted is the state of a running task.
+	TaskStateStarted = "STARTED"
+
+	// TaskStateRetry is the state of a task that failed with a retryable error and waits for its next run.
+	TaskStateRetry = "RETRY"
+
+	// TaskStateSuccess is the state of a task whose result is stored.
+	TaskStateSuccess = "SUCCESS"
+
+	// TaskStateFailure is the state of a task whose error is stored.
+	TaskStateFailure = "FAILURE"
+)
+
+// ErrTaskStateUnknown is returned for the state of a task whose results are discarded and is not running.
+const ErrTaskStateUnknown = errors.Error("state of the task is unknown as its result is discarded")
+
+// taskResult is the result of an enqueued task which reports the state of the task on top of its result.
+type taskResult struct {
+	*gocelery.AsyncResult
+	taskID  string
+	broker  *ackBroker
+	backend gocelery.CeleryBackend
+
+	// discarded is true if the results of the task type are discarded
+	discarded bool
+}
+
+func newTaskResult(res *gocelery.AsyncResult, broker *ackBroker, backend gocelery.CeleryBackend, discarded bool) taskResult {
+	return taskResult{
+		AsyncResult: res,
+		// gocelery keeps the task ID of the result to itself
+		taskID:    reflect.ValueOf(res).Elem().FieldByName("taskID").String(),
+		broker:    broker,
+		backend:   backend,
+		discarded: discarded,
+	}
+}
+
+// State returns the state of the task. The states of the tasks handed to the workers are tracked by the node,
+// the tasks which are done are succeeded or failed according to their stored result.
+// ErrTaskStateUnknown is returned outside of the runs of the tasks whose results are discarded.
+func (r taskResult) State() (string, error) {
+	if r.broker != nil {
+		if state, ok := r.broker.state(r.taskID); ok {
+			return state, nil
+		}
+	}
+
+	if r.discarded {
+		return "", ErrTaskStateUnknown
+	}
+
+	if r.backend == nil {
+		return TaskStatePending, nil
+	}
+
+	res, err := r.backend.GetResult(r.taskID)
+	if err != nil {
+		// no result is stored yet
+		return TaskStatePending, nil
+	}
+
+	if res.Error != "" {
+		return TaskStateFailure, nil
+	}
+
+	return TaskStateSuccess, nil
+}
+
+// received records the state of the task message handed to a worker.
+// The worker sends the tasks whose delay is not over back to the broker.
+func (b *ackBroker) received(msg *gocelery.TaskMessage) {
+	state := TaskStateStarted
+	switch {
+	case msg.Settings == nil || !time.Now().UTC().Before(msg.Settings.Delay):
+	case msg.Tries > 0:
+		state = TaskStateRetry
+	default:
+		state = TaskStateReceived
+	}
+
+	b.setState(msg.ID, state)
+}
+
+// setState records the state of the task.
+func (b *ackBroker) setState(taskID, state string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.states[taskID] = state
+}
+
+// state returns the state of the task if it is tracked.
+func (b *ackBroker) state(taskID string) (string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.states[taskID]
+	return state, ok
+}
+
+// forgetState stops tracking the state of the task.
+func (b *ackBroker) forgetState(taskID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.states, taskID)
+}
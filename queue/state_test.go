@@ -0,0 +1,98 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+func stateOf(t *testing.T, res TaskResult) string {
+	state, err := res.State()
+	assert.NoError(t, err)
+	return state
+}
+
+func TestTaskResult_State(t *testing.T) {
+	qs := &Server{config: mockConfig{}}
+	task := &blockingTask{started: make(chan struct{}, 1), release: make(chan struct{})}
+	qs.RegisterTaskType(task.TaskTypeName(), task)
+	qs.RegisterTaskType("echo", new(echoTask))
+	qs.RegisterTaskType("fireAndForget", new(fireAndForgetTask))
+	stop := startServer(t, qs)
+	defer stop()
+
+	// started then succeeded
+	res, err := qs.EnqueueJob("block", nil)
+	assert.NoError(t, err)
+	<-task.started
+	assert.Equal(t, TaskStateStarted, stateOf(t, res))
+	close(task.release)
+	_, err = res.Get(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateSuccess, stateOf(t, res))
+
+	// failed
+	res, err = qs.EnqueueJob("echo", map[string]interface{}{"value": "panic"})
+	assert.NoError(t, err)
+	_, err = res.Get(time.Second)
+	assert.Error(t, err)
+	assert.Equal(t, TaskStateFailure, stateOf(t, res))
+
+	// received, waiting for its delay
+	settings := gocelery.DefaultSettings()
+	settings.Delay = time.Now().UTC().Add(time.Hour)
+	res, err = qs.enqueueJob("echo", map[string]interface{}{"value": "delayed"}, settings)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return stateOf(t, res) == TaskStateReceived
+	}, time.Second, 10*time.Millisecond)
+
+	// unknown once the discarded result is dropped
+	res, err = qs.EnqueueJob("fireAndForget", map[string]interface{}{"value": "discarded"})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		_, err := res.State()
+		return err == ErrTaskStateUnknown
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTaskResult_State_pending(t *testing.T) {
+	qs := &Server{config: mockConfig{}}
+	qs.broker = newAckBroker(gocelery.NewInMemoryBroker(), func(string) AckMode { return AckAfter })
+	qs.backend = ackBackend{CeleryBackend: gocelery.NewInMemoryBackend(), broker: qs.broker}
+	client, err := gocelery.NewCeleryClient(qs.broker, qs.backend, 1, 1)
+	assert.NoError(t, err)
+	qs.queue = client
+
+	res, err := qs.EnqueueJob("echo", map[string]interface{}{"value": "queued"})
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStatePending, stateOf(t, res))
+
+	// retried once sent back to the broker, until its next run
+	msg, err := qs.broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateStarted, stateOf(t, res))
+	msg.Tries++
+	msg.Settings.Delay = time.Now().UTC().Add(time.Hour)
+	enc, err := msg.Encode()
+	assert.NoError(t, err)
+	assert.NoError(t, qs.broker.SendCeleryMessage(&gocelery.CeleryMessage{
+		Body:            enc,
+		ContentType:     "application/json",
+		ContentEncoding: "utf-8",
+		Properties:      gocelery.CeleryProperties{BodyEncoding: "base64"},
+	}))
+	assert.Equal(t, TaskStateRetry, stateOf(t, res))
+	_, err = qs.broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateRetry, stateOf(t, res))
+
+	// derived from the stored result
+	assert.NoError(t, qs.backend.SetResult(msg.ID, &gocelery.ResultMessage{Result: "done"}))
+	assert.Equal(t, TaskStateSuccess, stateOf(t, res))
+	assert.Empty(t, qs.broker.states)
+}
@@ -0,0 +1,99 @@
+package queue
+
+import (
+	"sync"
+	"time"
+)
+
+// statsMinutes is the number of minutes the task counts are kept for.
+const statsMinutes = 60
+
+// QueueStats holds the number of tasks enqueued, completed and failed per minute over the last hour.
+// Counts are ordered oldest minute first, the last count is the current minute.
+type QueueStats struct {
+	// Since is the start of the oldest minute
+	Since     time.Time
+	Enqueued  []uint64
+	Completed []uint64
+	Failed    []uint64
+}
+
+// minuteCounter is a ring buffer counting the events per minute over the last statsMinutes minutes.
+type minuteCounter struct {
+	counts  [statsMinutes]uint64
+	minutes [statsMinutes]int64
+}
+
+// add counts an event in the minute.
+func (c *minuteCounter) add(minute int64) {
+	i := minute % statsMinutes
+	if c.minutes[i] != minute {
+		c.minutes[i], c.counts[i] = minute, 0
+	}
+
+	c.counts[i]++
+}
+
+// series returns the counts of the last statsMinutes minutes up to the current minute, oldest first.
+func (c *minuteCounter) series(current int64) []uint64 {
+	counts := make([]uint64, statsMinutes)
+	for j := range counts {
+		minute := current - statsMinutes + 1 + int64(j)
+		if i := minute % statsMinutes; minute >= 0 && c.minutes[i] == minute {
+			counts[j] = c.counts[i]
+		}
+	}
+
+	return counts
+}
+
+// queueStats counts the tasks enqueued, completed and failed.
+type queueStats struct {
+	mu                          sync.Mutex
+	enqueued, completed, failed minuteCounter
+}
+
+func toMinute(t time.Time) int64 {
+	return t.Unix() / 60
+}
+
+func (s *queueStats) add(c *minuteCounter, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.add(toMinute(at))
+}
+
+// record is the middleware counting the completed and failed runs of the tasks.
+func (s *queueStats) record(next TaskHandler) TaskHandler {
+	return func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		res, err := next(taskName, kwargs)
+		if err != nil {
+			s.add(&s.failed, time.Now())
+			tasksRun.WithLabelValues(taskName, "failure").Inc()
+		} else {
+			s.add(&s.completed, time.Now())
+			tasksRun.WithLabelValues(taskName, "success").Inc()
+		}
+
+		return res, err
+	}
+}
+
+// snapshot returns the counts of the last hour up to the minute of now.
+func (s *queueStats) snapshot(now time.Time) QueueStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := toMinute(now)
+	return QueueStats{
+		Since:     time.Unix((current-statsMinutes+1)*60, 0).UTC(),
+		Enqueued:  s.enqueued.series(current),
+		Completed: s.completed.series(current),
+		Failed:    s.failed.series(current),
+	}
+}
+
+// Stats returns the number of tasks enqueued, completed and failed per minute over the last hour.
+// Only the runs of the tasks by the local workers are counted as completed or failed.
+func (qs *Server) Stats() QueueStats {
+	return qs.stats.snapshot(time.Now())
+}
@@ -0,0 +1,77 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueStats_snapshot(t *testing.T) {
+	var s queueStats
+	now := time.Date(2020, time.January, 31, 10, 20, 30, 0, time.UTC)
+	s.add(&s.enqueued, now.Add(-61*time.Minute))
+	s.add(&s.enqueued, now.Add(-59*time.Minute))
+	s.add(&s.enqueued, now)
+	s.add(&s.enqueued, now)
+	s.add(&s.failed, now.Add(-time.Minute))
+
+	stats := s.snapshot(now)
+	assert.Equal(t, time.Date(2020, time.January, 31, 9, 21, 0, 0, time.UTC), stats.Since)
+	assert.Len(t, stats.Enqueued, statsMinutes)
+	assert.Equal(t, uint64(1), stats.Enqueued[0])
+	assert.Equal(t, uint64(2), stats.Enqueued[statsMinutes-1])
+	assert.Equal(t, uint64(1), stats.Failed[statsMinutes-2])
+	assert.Equal(t, make([]uint64, statsMinutes), stats.Completed)
+
+	// counts older than an hour are dropped when their slot is reused
+	s.add(&s.enqueued, now.Add(59*time.Minute))
+	stats = s.snapshot(now.Add(59 * time.Minute))
+	assert.Equal(t, uint64(2), stats.Enqueued[statsMinutes-60])
+	assert.Equal(t, uint64(1), stats.Enqueued[statsMinutes-1])
+}
+
+func TestServer_Stats(t *testing.T) {
+	qs := &Server{config: mockConfig{}}
+	qs.broker = newAckBroker(gocelery.NewInMemoryBroker(), func(string) AckMode { return AckAfter })
+	var err error
+	qs.queue, err = gocelery.NewCeleryClient(qs.broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	_, err = qs.EnqueueJob("task", map[string]interface{}{})
+	assert.NoError(t, err)
+
+	handler := chain(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		if kwargs["fail"] == true {
+			return nil, errors.New("failed")
+		}
+
+		if kwargs["panic"] == true {
+			panic("task panicked")
+		}
+
+		return nil, nil
+	}, qs.stats.record, Recoverer)
+	_, err = handler("task", map[string]interface{}{})
+	assert.NoError(t, err)
+	_, err = handler("task", map[string]interface{}{"fail": true})
+	assert.Error(t, err)
+	_, err = handler("task", map[string]interface{}{"panic": true})
+	assert.Error(t, err)
+
+	// summed so that a minute boundary crossed by the test doesn't matter
+	sum := func(counts []uint64) (total uint64) {
+		for _, c := range counts {
+			total += c
+		}
+		return total
+	}
+
+	stats := qs.Stats()
+	assert.Equal(t, uint64(1), sum(stats.Enqueued))
+	assert.Equal(t, uint64(1), sum(stats.Completed))
+	assert.Equal(t, uint64(2), sum(stats.Failed))
+}
@@ -0,0 +1,30 @@
+package queue
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+)
+
+// ProcessNext runs the next queued task on the calling routine and stores its result.
+// Returns false if no task is queued. The task is run regardless of its delay and is not retried on failure.
+// Meant to drive the tasks deterministically with the workers stopped, such as in the enqueue only mode.
+func (qs *Server) ProcessNext() (bool, error) {
+	qs.lock.RLock()
+	broker, worker, backend := qs.broker, qs.worker, qs.backend
+	qs.lock.RUnlock()
+	if broker == nil || worker == nil {
+		return false, errors.New("queue hasn't been initialised")
+	}
+
+	msg, err := broker.GetTaskMessage()
+	if err != nil || msg == nil {
+		return false, err
+	}
+
+	res, err := worker.RunTask(msg)
+	if err != nil {
+		res = &gocelery.ResultMessage{Error: err.Error()}
+	}
+
+	return true, backend.SetResult(msg.ID, res)
+}
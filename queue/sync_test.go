@@ -0,0 +1,53 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_ProcessNext(t *testing.T) {
+	qs := &Server{config: mockConfig{}, manual: true}
+	_, err := qs.ProcessNext()
+	assert.Error(t, err)
+
+	qs.RegisterTaskType("echo", new(echoTask))
+	stop := startServer(t, qs)
+	defer stop()
+
+	// nothing queued
+	ok, err := qs.ProcessNext()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// tasks wait for ProcessNext
+	res, err := qs.EnqueueJob("echo", map[string]interface{}{"value": "first"})
+	assert.NoError(t, err)
+	failed, err := qs.EnqueueJob("echo", map[string]interface{}{"value": "panic"})
+	assert.NoError(t, err)
+	_, err = res.Get(50 * time.Millisecond)
+	assert.Error(t, err)
+
+	ok, err = qs.ProcessNext()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	v, err := res.Get(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", v)
+
+	// failures are stored as the result
+	ok, err = qs.ProcessNext()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	_, err = failed.Get(time.Second)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "panicked")
+
+	ok, err = qs.ProcessNext()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, qs.broker.depth())
+}
@@ -37,3 +37,14 @@ func (s *Starter) TestTearDown() error {
 	s.canF()
 	return nil
 }
+
+// SyncStarter starts the queue server with the workers stopped so that the tests run the tasks with ProcessNext.
+type SyncStarter struct {
+	Starter
+}
+
+func (s *SyncStarter) TestBootstrap(ctx map[string]interface{}) error {
+	qs := ctx[bootstrap.BootstrappedQueueServer].(*Server)
+	qs.manual = true
+	return s.Starter.TestBootstrap(ctx)
+}
@@ -0,0 +1,101 @@
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// TraceParam holds the trace context propagated from the enqueuer of the task.
+	TraceParam string = "TraceContext"
+
+	// TraceTaskNameKey is the span attribute holding the name of the task.
+	TraceTaskNameKey = "queue.task.name"
+
+	// TraceTaskDurationKey is the span attribute holding the duration of the task execution.
+	TraceTaskDurationKey = "queue.task.duration"
+)
+
+// Span is a span of a task execution.
+type Span interface {
+
+	// SetAttribute records the attribute on the span
+	SetAttribute(key string, value interface{})
+
+	// End ends the span with the outcome of the task, nil err is a success
+	End(err error)
+}
+
+// Tracer propagates the trace context from the enqueuer to the task execution.
+// An OpenTelemetry tracer can be adapted with a text map propagator over the carrier.
+type Tracer interface {
+
+	// Inject returns the carrier propagating the span of the context, nil if the context has no span
+	Inject(ctx context.Context) map[string]string
+
+	// StartSpan starts the span of the task execution as a child of the span propagated by the carrier.
+	// carrier is nil if the task was enqueued without a trace context
+	StartSpan(taskName string, carrier map[string]string) Span
+}
+
+// SetTracer sets the tracer of the task executions. Must be set before the server is started.
+func (qs *Server) SetTracer(tracer Tracer) {
+	qs.lock.Lock()
+	defer qs.lock.Unlock()
+	qs.tracer = tracer
+}
+
+// EnqueueJobWithContext enqueues a job like EnqueueJob and propagates the trace context of ctx to the task execution.
+func (qs *Server) EnqueueJobWithContext(ctx context.Context, taskName string, params map[string]interface{}) (TaskResult, error) {
+	qs.lock.RLock()
+	tracer := qs.tracer
+	qs.lock.RUnlock()
+	if tracer == nil {
+		return qs.EnqueueJob(taskName, params)
+	}
+
+	if carrier := tracer.Inject(ctx); len(carrier) > 0 {
+		if params == nil {
+			params = make(map[string]interface{})
+		}
+
+		params[TraceParam] = carrier
+	}
+
+	return qs.EnqueueJob(taskName, params)
+}
+
+// traceCarrier returns the trace context in the kwargs.
+// kwargs passed through the broker are JSON encoded, which turns the carrier into a map of interfaces.
+func traceCarrier(kwargs map[string]interface{}) map[string]string {
+	switch c := kwargs[TraceParam].(type) {
+	case map[string]string:
+		return c
+	case map[string]interface{}:
+		carrier := make(map[string]string, len(c))
+		for k, v := range c {
+			if s, ok := v.(string); ok {
+				carrier[k] = s
+			}
+		}
+
+		return carrier
+	default:
+		return nil
+	}
+}
+
+// tracing returns the middleware running every task execution within a span of the tracer.
+func tracing(tracer Tracer) Middleware {
+	return func(next TaskHandler) TaskHandler {
+		return func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+			span := tracer.StartSpan(taskName, traceCarrier(kwargs))
+			span.SetAttribute(TraceTaskNameKey, taskName)
+			start := time.Now()
+			res, err := next(taskName, kwargs)
+			span.SetAttribute(TraceTaskDurationKey, time.Since(start))
+			span.End(err)
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// +build unit
+
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+type traceKey struct{}
+
+type mockSpan struct {
+	parent map[string]string
+	attrs  map[string]interface{}
+	ended  bool
+	err    error
+}
+
+func (s *mockSpan) SetAttribute(key string, value interface{}) {
+	s.attrs[key] = value
+}
+
+func (s *mockSpan) End(err error) {
+	s.ended, s.err = true, err
+}
+
+type mockTracer struct {
+	spans []*mockSpan
+}
+
+func (m *mockTracer) Inject(ctx context.Context) map[string]string {
+	parent, ok := ctx.Value(traceKey{}).(string)
+	if !ok {
+		return nil
+	}
+
+	return map[string]string{"traceparent": parent}
+}
+
+func (m *mockTracer) StartSpan(taskName string, carrier map[string]string) Span {
+	span := &mockSpan{parent: carrier, attrs: make(map[string]interface{})}
+	m.spans = append(m.spans, span)
+	return span
+}
+
+func TestServer_EnqueueJobWithContext(t *testing.T) {
+	broker := gocelery.NewInMemoryBroker()
+	client, err := gocelery.NewCeleryClient(broker, gocelery.NewInMemoryBackend(), 1, 1)
+	assert.NoError(t, err)
+	qs := &Server{config: mockConfig{}, queue: client}
+	ctx := context.WithValue(context.Background(), traceKey{}, "00-trace-span-01")
+
+	// no tracer
+	_, err = qs.EnqueueJobWithContext(ctx, "task", map[string]interface{}{})
+	assert.NoError(t, err)
+	msg, err := broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Nil(t, traceCarrier(msg.Kwargs))
+
+	// context without a span
+	tracer := new(mockTracer)
+	qs.SetTracer(tracer)
+	_, err = qs.EnqueueJobWithContext(context.Background(), "task", nil)
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Nil(t, traceCarrier(msg.Kwargs))
+
+	// propagated through the broker
+	_, err = qs.EnqueueJobWithContext(ctx, "task", nil)
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"traceparent": "00-trace-span-01"}, traceCarrier(msg.Kwargs))
+}
+
+func TestTracing(t *testing.T) {
+	tracer := new(mockTracer)
+	kwargs := map[string]interface{}{TraceParam: map[string]interface{}{"traceparent": "00-trace-span-01"}}
+
+	// success
+	res, err := tracing(tracer)(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		return "result", nil
+	})("task", kwargs)
+	assert.NoError(t, err)
+	assert.Equal(t, "result", res)
+	assert.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	assert.Equal(t, map[string]string{"traceparent": "00-trace-span-01"}, span.parent)
+	assert.Equal(t, "task", span.attrs[TraceTaskNameKey])
+	assert.IsType(t, time.Duration(0), span.attrs[TraceTaskDurationKey])
+	assert.True(t, span.ended)
+	assert.NoError(t, span.err)
+
+	// recovered panic is recorded as a failure
+	_, err = chain(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		panic("task panicked")
+	}, tracing(tracer), Recoverer)("task", nil)
+	assert.Error(t, err)
+	assert.Len(t, tracer.spans, 2)
+	span = tracer.spans[1]
+	assert.Nil(t, span.parent)
+	assert.True(t, span.ended)
+	assert.Equal(t, err, span.err)
+
+	// failure
+	_, err = tracing(tracer)(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		return nil, errors.New("task failed")
+	})("task", kwargs)
+	assert.Error(t, err)
+	assert.Equal(t, err, tracer.spans[2].err)
+}
@@ -0,0 +1,87 @@
+package queue
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery"
+)
+
+const (
+	// ErrUnknownTask is returned for the tasks whose task type is not registered on the node.
+	ErrUnknownTask = errors.Error("task type not registered")
+
+	// UnknownTaskNameParam holds the original task name of an unknown task routed to the unknown task handler.
+	UnknownTaskNameParam = "UnknownTaskName"
+
+	// unknownTaskName is the task type the unknown tasks are routed to.
+	unknownTaskName = "queue.unknownTask"
+)
+
+// SetUnknownTaskHandler sets the handler of the dequeued tasks whose task type is not registered on the node,
+// e.g. tasks enqueued by a node running another version. The handler can log, dead letter or re-enqueue the task,
+// returning gocelery.ErrTaskRetryable retries the task later. The result of the handler is the result of the task.
+// The handler runs through the middlewares and must be set before the server is started.
+// Without a handler, unknown tasks are logged and fail with ErrUnknownTask.
+func (qs *Server) SetUnknownTaskHandler(handler TaskHandler) {
+	qs.lock.Lock()
+	defer qs.lock.Unlock()
+	qs.unknownHandler = handler
+}
+
+// isRegistered returns true if the task type is registered on the node.
+func (qs *Server) isRegistered(taskName string) bool {
+	qs.ackModesMu.RLock()
+	defer qs.ackModesMu.RUnlock()
+	_, ok := qs.ackModes[taskName]
+	return ok
+}
+
+// toUnknownTask routes the task message to the unknown task handler.
+func toUnknownTask(msg *gocelery.TaskMessage) {
+	if msg.Kwargs == nil {
+		msg.Kwargs = make(map[string]interface{})
+	}
+
+	msg.Kwargs[UnknownTaskNameParam] = msg.Task
+	msg.Task = unknownTaskName
+}
+
+// logUnknownTask is the default handler of the unknown tasks.
+func logUnknownTask(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+	log.Errorf("task %s is not registered on this node, the task fails", taskName)
+	return nil, errors.NewTypedError(ErrUnknownTask, errors.New("task: %s", taskName))
+}
+
+// unknownTask runs the unknown task handler with the original task name.
+type unknownTask struct {
+	handler  TaskHandler
+	taskName string
+	kwargs   map[string]interface{}
+}
+
+// TaskTypeName returns the task type the unknown tasks are routed to.
+func (t *unknownTask) TaskTypeName() string {
+	return unknownTaskName
+}
+
+// Copy returns a new instance of the task.
+func (t *unknownTask) Copy() (gocelery.CeleryTask, error) {
+	return &unknownTask{handler: t.handler}, nil
+}
+
+// ParseKwargs parses the original task name and keeps the rest of the kwargs for the handler.
+func (t *unknownTask) ParseKwargs(kwargs map[string]interface{}) error {
+	t.taskName, _ = kwargs[UnknownTaskNameParam].(string)
+	t.kwargs = make(map[string]interface{}, len(kwargs))
+	for k, v := range kwargs {
+		if k != UnknownTaskNameParam {
+			t.kwargs[k] = v
+		}
+	}
+
+	return nil
+}
+
+// RunTask runs the handler.
+func (t *unknownTask) RunTask() (interface{}, error) {
+	return t.handler(t.taskName, t.kwargs)
+}
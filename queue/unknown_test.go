@@ -0,0 +1,78 @@
+// +build unit
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type echoTask struct {
+	mockCeleryTask
+}
+
+func (*echoTask) TaskTypeName() string {
+	return "echo"
+}
+
+func startServer(t *testing.T, qs *Server) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go qs.Start(ctx, &wg, make(chan error, 1))
+	assert.Eventually(t, func() bool {
+		qs.lock.RLock()
+		defer qs.lock.RUnlock()
+		return qs.queue != nil
+	}, time.Second, 10*time.Millisecond)
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func TestServer_unknownTask(t *testing.T) {
+	// logged and failed by default
+	qs := &Server{config: mockConfig{}}
+	qs.RegisterTaskType("echo", new(echoTask))
+	stop := startServer(t, qs)
+	res, err := qs.EnqueueJob("echo", map[string]interface{}{"value": "known"})
+	assert.NoError(t, err)
+	v, err := res.Get(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "known", v)
+
+	res, err = qs.EnqueueJob("removed", map[string]interface{}{"value": "unknown"})
+	assert.NoError(t, err)
+	_, err = res.Get(time.Second)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrUnknownTask.Error())
+	stop()
+
+	// handled by the fallback handler with the original task name
+	var mu sync.Mutex
+	var handled []string
+	qs = &Server{config: mockConfig{}}
+	qs.SetUnknownTaskHandler(func(taskName string, kwargs map[string]interface{}) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, taskName)
+		_, ok := kwargs[UnknownTaskNameParam]
+		assert.False(t, ok)
+		return kwargs["value"], nil
+	})
+	stop = startServer(t, qs)
+	defer stop()
+	res, err = qs.EnqueueJob("removed", map[string]interface{}{"value": "unknown"})
+	assert.NoError(t, err)
+	v, err = res.Get(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown", v)
+	mu.Lock()
+	assert.Equal(t, []string{"removed"}, handled)
+	mu.Unlock()
+}
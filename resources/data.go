@@ -25,7 +25,7 @@ func bindata_read(data []byte, name string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var _go_centrifuge_build_configs_default_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xdb\x6e\xdb\x30\xd2\xbe\xd7\x53\x0c\x9c\x9b\xf6\x47\x6b\x5b\xf2\x21\x8e\x80\xff\xc2\xb1\x13\x37\xcd\x61\x9d\xd8\x4d\xda\xde\x2c\x68\x6a\x24\xb1\x96\x48\x95\xa4\x7c\xc8\xd3\x2f\x86\x92\x9c\xa4\x69\xb7\xbb\x5d\xec\x02\x0b\x6c\x6e\x22\x90\x9c\x6f\x86\x33\xdf\x1c\xe8\x23\x98\x62\xcc\xca\xcc\x42\x84\x1b\xcc\x54\x91\xa3\xb4\x60\xd1\x58\x89\x16\x58\xc2\x84\x34\x16\xd6\x6a\xc3\xa4\xc7\x51\x5a\x2d\xe2\x32\xc1\x1b\xb4\x5b\xa5\xd7\x21\xc4\x99\x90\xd6\x73\x20\x42\x22\xd8\x14\x21\xaa\xf1\x64\x75\xc6\x80\x4d\x99\x85\xc9\x41\x16\x72\x26\xa4\x25\x5c\xaf\x39\x12\x7a\x00\x47\x70\xa5\x38\xcb\x9c\x6a\x21\x13\xe0\x4a\x5a\xcd\xb8\x05\x16\x45\x1a\x8d\x41\x03\x12\x31\x02\xab\x60\x85\x60\xd0\xc2\x56\xd8\x14\x50\x6e\x60\xc3\xb4\x60\xab\x0c\x4d\xdb\x83\x46\x9e\x20\x01\x44\x14\x42\xaf\xd7\x73\xdf\x68\x53\xd4\x58\xe6\xb5\xed\x17\x51\x08\xa3\xde\xa8\xda\x5b\x29\x65\x8d\xd5\xac\x98\x23\x6a\x53\xc9\xbe\x87\x56\x47\x14\xfd\x8e\x1f\x1c\xb7\xbb\xed\x6e\xdb\xef\x58\x5e\x74\x7a\xa3\xa0\x1b\x74\x44\x11\x9b\xce\x6d\xbe\xbc\xdd\xad\xb6\xeb\xf2\xeb\x97\x2f\xd3\xb8\x7c\x5c\xae\x76\x67\xe3\x3b\x5c\xde\x4c\xae\xd4\xe3\x7e\x3f\x18\x8c\x36\xb7\x32\xb9\xdf\xcc\xaf\xbf\x5d\x7d\x59\xb7\x7e\x03\xda\x6b\x40\xef\xe3\xe1\xd9\xcd\x30\x5f\x7f\x7f\xc0\x6f\x0f\x97\x0f\xc1\xf7\x79\xe9\x0f\x3f\x17\xd1\xac\xb7\xfe\xa8\xfc\x65\x2f\x4f\x59\x3a\x3f\x1d\x2c\x70\x20\xfd\x0a\xb4\x71\xd5\xb8\xf1\x54\x75\x01\xba\x3e\x4a\x2b\xec\xfe\x9c\x71\xab\xf4\x3e\x84\x56\xcb\x73\xae\xbe\x66\x42\xbe\x0a\x78\x13\x31\x78\x73\x49\xe1\x7e\xeb\x41\x15\xde\x0a\xed\x08\x6e\xca\x1c\xb5\xe0\x70\x31\x05\x15\xbb\x50\x3f\x0b\x6a\x2d\x7b\xf0\xba\x1f\xd4\x52\xa7\x8d\x6b\x21\x13\xc6\x92\xa4\x54\x11\xbe\x66\x45\xa1\xd5\x46\xb8\x0d\xe5\xb0\x9d\xea\x86\x88\xbf\x0d\x52\x6f\xd0\x0e\xfa\x41\x3b\xe8\x75\xdb\xbe\x3f\xfc\x31\x52\x7e\x30\xed\x5d\x2a\xf5\xb0\x58\xed\x56\x97\x93\xd5\xd7\xf4\xe4\xe3\xbd\x35\xb7\xfb\xfb\x59\xb4\x9c\x6b\xd6\xbf\x2b\x16\xe3\xbe\x5d\x6d\xcc\x90\x49\xdf\xff\xb6\x9d\x8d\x83\xc7\xd6\x2b\xfc\x5e\xbf\x7d\x1c\xb4\xfd\xe0\xf8\x57\xf0\xb7\x79\xc0\x17\xb9\x3e\x13\x6c\x71\x7d\xdf\x4f\x3e\x6d\x8e\x1f\x66\x69\x91\xdc\x6d\xd5\x68\xab\xce\x17\xe6\x43\xfa\x75\xb6\x9a\x89\x1e\x1b\x8f\x76\xad\xda\x3d\x67\x35\x2b\x0f\xce\xbf\x98\xc2\x7b\x70\x01\xf8\x15\x6b\xfb\x8d\x6b\xaf\x98\x0b\x5b\x84\x45\xa6\xf6\x18\xc1\x22\x67\xda\xc2\xa4\x66\x83\x81\x58\x69\xe7\xca\x44\x6c\x50\xbe\x70\xe5\x3f\xc1\x98\xee\xce\xef\x0d\x83\x33\x7e\x1a\x8f\x86\xc7\x27\x41\xbf\x77\x16\xf4\xe3\x71\xf7\x6c\xd2\x0f\x06\x51\x80\x7e\x77\xdc\x1d\x05\x41\x8f\x1f\x4f\x9f\x73\xcb\x58\x96\x50\x16\xbf\xa6\x14\xcb\x57\xa8\xff\x8c\x52\xfe\xbf\x48\x29\xa7\xfa\xb7\x94\xfa\xf7\x93\xea\x7f\xb4\xfa\x43\x5a\x51\x4b\x7a\x62\x45\x5e\xad\xfc\x19\x97\xba\xff\x48\x49\xf1\x4f\x46\x6d\x3f\x08\xda\xbe\xff\xcb\xe0\x8c\x93\xde\x19\x1f\x5b\xfd\xe5\x7e\xb2\xdb\x3e\x0e\xd7\x43\xb3\x3c\x11\x5f\x17\x77\x8f\xf6\xf1\x64\x7a\xbc\xff\xf4\x58\x9c\xce\xef\xce\xce\x1f\xf5\x27\x75\xff\xba\xa4\x10\xbb\x02\xbf\xed\xfb\xaf\x9a\x4b\x83\x7f\x39\xdb\x8a\xdd\x67\x94\xe5\xe7\xf1\xfd\xf7\xf5\xc7\xcb\x5c\x7e\x58\x8c\x3f\x4e\xbf\x3d\xc6\xc7\x38\xbb\x56\x43\xab\x95\x48\xbe\xee\xf2\xe3\xf1\xe0\xee\xef\x07\xbf\x76\xd7\xaf\xc2\xef\xff\x67\xa3\x3f\x3e\xef\x0f\x86\xdc\x1f\xf6\x46\x43\x36\xec\xc7\x51\xff\xbc\xbf\x1a\x9e\xb0\xd8\xef\xb1\xd1\x70\x1a\x77\x4f\x07\xc3\x60\xcc\xba\xdd\x96\x47\xd3\x05\xb3\x0c\x16\x56\x69\x96\xa0\x67\xaa\xff\xd5\xcc\x30\x67\x36\x75\x26\x65\xd4\xcc\xa6\xa7\x10\x8b\x0c\x3d\x80\x82\xd9\x34\x84\x8e\xcd\x8b\xce\xd3\xd4\xf2\xd7\x88\x59\xd6\x76\x27\xa3\x15\xe1\x4e\x94\x8c\x45\x52\x6a\x66\x85\x92\x07\x05\xdc\xad\x2e\xfe\x5c\x4d\x05\xf0\x4a\xdb\x98\x73\x55\x4a\x6b\x60\x8d\x7b\xa8\x6f\xe1\xb1\x7a\x91\xf4\xac\x71\x4f\xcb\x58\x23\x36\x5b\x24\x7b\x21\x2d\xea\x98\x71\x84\x2d\x45\xce\x45\x60\x3c\xbf\x00\x26\x23\x98\x07\x73\x58\xa0\xde\xa0\x76\xf5\x10\x25\x15\x3c\x8f\x4a\xe2\x07\x65\xac\x64\x39\x52\x3b\xae\xe7\x0d\xef\x08\xe6\x4a\xdb\x1a\x86\x20\x7e\x2e\x4a\x87\x42\x18\x75\x47\x01\xa9\xa7\xf4\x78\x6f\xd5\xfb\x02\x51\x03\x7f\xee\x35\xe3\x15\x41\x51\x39\x69\x51\x20\x17\xf1\x1e\xce\x76\x16\xb5\x64\x19\x5c\xcc\x9f\x59\x4b\xa0\xc0\x99\xa4\xe9\x4d\x23\xe3\x29\x46\xc0\x2c\x88\x18\x56\x98\x0a\x19\xc1\xcd\x78\x49\x30\x58\x4b\x5f\xcc\x43\xd8\xb6\x77\xed\x7d\xfb\xb1\x0a\x01\x59\x5d\x1a\x8c\x0e\x0c\xa4\x7b\x67\x6c\x8f\x9a\x02\xe1\xcc\x75\xf9\xe3\x4e\x2f\x45\x8e\xaa\x74\xd7\x94\xa0\x0a\x94\xf5\x48\x29\x91\x3b\xab\xa9\x25\xd0\x65\x8c\x07\xcd\x72\x2d\x12\x42\xab\xd7\x35\x2d\x87\x92\x0b\x29\xf2\x32\x87\x08\x33\xb6\x77\x7a\x71\x83\x7a\x0f\x45\x50\x80\x46\x53\x28\x69\x90\x90\xd8\x46\x89\x08\xac\xc8\x49\x0b\xb3\x96\xf1\xb5\x71\x00\x2c\xfa\x56\x1a\x0b\x2b\x46\x76\x2b\x09\xa9\x32\x96\x24\x55\xa9\x39\x1a\x78\xb3\x58\x4c\xdf\xc1\x64\xfe\xe9\x1d\x70\xa5\xd1\x40\xbb\xdd\x7e\x5b\xcf\xc2\x6a\x0d\x42\x42\xa6\x12\x97\x72\x21\xb4\xc8\x3e\xb2\xd5\x94\x39\x46\xb0\xda\xd3\xb5\xaa\x18\xb4\xc8\x8b\xbb\xff\x7f\xb3\x61\x59\x89\x77\xc8\x22\xf8\x3f\x08\xde\x82\x30\x90\xa1\x71\x6d\x51\x82\xdb\x83\x15\x66\x6a\xfb\x8e\xbc\x27\x81\xa7\x4c\x26\x78\xb8\xc7\xd4\xdd\xd1\x2a\xd8\x79\xf0\x72\x31\x84\xd6\xa0\xdb\xcd\x8d\x4b\xc5\xdb\x12\x4b\xfc\x81\x02\xce\x33\xcc\xec\x25\x4f\xb5\x92\xaa\x34\xd4\x79\x39\x1a\x23\x64\xe2\x7d\x27\x81\x8a\x20\xd5\x23\xc1\x54\x74\x28\x5d\x33\x56\x31\x50\x01\x42\x6d\x3a\xf5\xd5\x74\xdd\xc7\xb7\x22\xcb\x88\x2b\x2c\xcb\x14\x67\xb6\x62\x8b\xb1\x4c\xdb\xb2\xf0\x80\xe4\x1f\x2a\x41\x2a\xe6\x5d\x87\x7f\xae\x11\x0d\x94\x05\x79\x14\xf8\x9e\x67\x68\x2a\x02\x54\x2a\xc8\x21\x5b\x26\xdc\xeb\xa2\x8e\x25\x65\x17\xd4\xdb\x0f\x4c\x38\x0e\x5c\x2f\xaa\x62\x78\x04\xe3\x9c\xf2\xcf\x75\x13\xf2\x3d\x03\xcb\xcc\x9a\x50\x36\x2c\x13\x11\xc4\x5a\xe5\xee\x2e\x5c\xa3\x73\x84\x07\xd5\xce\xb9\x8b\x97\x1f\xa4\x2d\xcf\x55\x19\x94\x76\x92\xba\xa9\xc8\x65\x88\xe0\x2f\xfd\xe7\xde\x55\xee\x00\xb9\x89\xf2\xe4\xd3\xdd\x55\x08\x5b\x13\x76\x9e\xde\x09\xe1\xc9\x49\xbf\xef\xac\xba\xa1\x44\xb2\x9a\x49\xc3\x1c\x97\xa1\x50\x2a\x83\x9c\xed\x40\xa3\xd5\xa2\x1a\x77\x0c\xca\x88\x0c\x7e\x76\x4c\x6d\x5c\xa6\xe4\x6c\x77\x57\x9d\x0b\x21\xa8\x1d\xf7\x73\x48\x41\x35\x67\xc3\x32\x87\xbb\xaf\x3c\xc9\xc8\x74\x5e\x6a\xed\x1e\x0d\xcf\x24\x52\x66\x60\x85\x48\xaf\x0a\x8b\xdc\x62\xe4\xc1\x01\x80\xf4\x11\x8b\x82\x3a\xad\x9a\x17\x67\x26\x62\xac\x89\x69\x15\xe5\x76\xa5\x83\xab\x3c\x17\xd6\x85\x89\x49\x60\x92\xa7\x44\xaf\xfa\x25\xea\xfc\x8d\xd2\x72\xe7\xd0\xf7\xe0\xc3\x1e\x19\xdd\xab\x3a\x77\x25\x62\x34\x05\x93\x21\xb4\x46\xc7\xc3\x6e\xea\x38\x7b\xe8\x87\xbf\xf0\x7f\xd3\x0d\xeb\x32\x86\x19\x52\xa3\xdb\xa6\x82\xa7\x87\x4e\x09\x75\x35\x6e\x2c\xad\x47\x0c\x45\x7c\xae\xe7\xcc\x88\x12\xd6\xd9\x57\x1a\xab\xf2\x5a\x49\xd3\x2a\xea\x67\x71\xdd\x04\x6e\x5c\x55\x6e\x51\x4f\x6e\x1d\x1e\xbf\x55\x98\x2a\xe0\x83\x5e\x9e\x09\xf2\xb5\x2b\x9f\x6f\xb6\x94\xaf\xdf\x4b\xa1\x11\xb6\x06\x94\x06\x51\xf0\xfa\x45\x4c\x0f\x60\xfa\xe4\xcc\x92\xd9\x8e\xdb\x6f\x9f\xf3\x29\xb5\xb6\x08\x3b\x1d\xca\xa6\x8c\xea\x50\x78\x32\xe8\x0f\xaa\x32\xc7\x76\xae\xcc\x51\xaa\x6d\x31\x82\x84\xd1\x9d\x04\x77\x78\x45\x5d\xf9\x5e\x92\x49\x48\xd8\xa2\x70\xd2\x41\x17\x66\x5b\x14\x20\xd5\xb6\xa2\xd7\x8c\x99\x39\x49\x3b\x7e\x35\x7f\xee\xe8\x8c\x19\xc8\x44\x2e\xea\x31\x22\x12\x71\x8c\x8e\x49\x87\x08\x1d\x6a\x1a\xe5\x65\xc2\xcc\x95\x3b\xdd\x3c\xe6\x27\x94\x68\xe8\x12\xbe\xc6\xa4\xd5\x71\x14\x5d\xe2\x3e\x84\xde\xf3\xc5\x3b\xdc\xa8\x35\xba\xf5\xc1\xa0\x59\xae\x38\x32\x71\xfc\x0a\x61\xf4\xc3\xfa\x5c\x63\xb3\xe5\x3f\x41\xc9\xd8\x5e\xd3\x23\x18\x4e\x5e\xac\x2d\xc9\x19\x31\xea\x73\xad\xf2\x10\xfc\xc1\x61\x8f\x19\x83\x76\x51\xb5\xf1\xe1\x61\xb5\x28\x4d\xba\x54\x7f\xd1\x8c\x67\xd8\x40\xc1\xd1\xa1\xc8\x69\xcc\xd5\x86\x4a\x9c\x01\xa3\x94\xa4\xff\x2b\x2d\xa2\x04\xa9\xda\x50\x1a\x25\x9a\x55\x39\xf5\xd4\xda\xac\x72\xd5\xac\x0a\x8e\x7c\x22\xcc\xf3\x30\xd5\xd4\x88\xa2\xea\x87\x13\x06\xab\x4c\xf1\xb5\x9b\x1a\x2a\x86\x80\xd5\x22\x49\x50\x3b\x6c\x1a\xe0\x70\x67\x9b\x42\x58\x35\xc3\x61\xb7\xe9\x86\x3f\x53\xac\xa9\xdb\x28\x99\x3d\xeb\x46\xe6\x90\xab\x8d\x49\x4f\xd0\xd4\x9c\x5e\xc2\xfb\x83\x1a\xfd\xbf\xbf\xac\x2d\x53\x61\xc0\x60\x55\xb9\x0c\x4d\x55\x86\x02\x99\x97\x99\x15\x45\x26\xa8\xdb\xd1\xa0\xf5\x22\xbb\x9f\x52\x0d\x8d\x15\x79\xd3\x46\x12\x66\xae\x0f\x62\x21\xf8\xed\x2e\xd5\x31\x26\xf7\x10\xe1\xaa\x4c\x92\x7a\x9a\xa1\xf2\xe2\x28\x94\x28\x20\x40\xcf\xed\x56\x65\x0c\xa5\xab\x08\x6e\x85\xc6\x08\x92\xf1\x80\xbe\x42\x88\x59\x66\xd0\x9d\x2a\x0a\xad\xe2\x2a\x19\x1b\x60\x9a\xa6\x68\xb5\x39\xe6\x55\xd9\x51\xff\xa6\x56\x68\xe4\x75\x92\x58\x5d\xa2\xf7\xb7\x00\x00\x00\xff\xff\xda\xc2\x06\xd8\x40\x14\x00\x00")
+var _go_centrifuge_build_configs_default_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xed\x3b\x59\x6f\xdb\xb8\xba\xef\xf9\x15\x84\xfa\x30\xed\x81\xeb\x7a\x89\xb3\x18\x38\x0f\x6e\xb6\xa6\x4d\x32\x69\x9c\x36\x33\x3d\x18\x14\xb4\x44\xd9\xac\x25\x51\x23\x4a\x71\x9c\x83\xf9\xef\xe7\x5b\x48\x49\xce\xd2\xce\xed\xc5\xbd\xc0\x05\xee\xcc\x00\x49\x44\xf2\xdb\x77\x72\x5e\x88\x43\x15\xcb\x2a\x29\x45\xa4\x6e\x55\x62\xf2\x54\x65\xa5\x28\x95\x2d\x33\x55\x0a\x39\x97\x3a\xb3\xa5\x58\x9a\x5b\x99\x6d\x85\xb0\x54\xe8\xb8\x9a\xab\x0b\x55\xae\x4c\xb1\x1c\x8b\x38\xd1\x59\xb9\xf5\x02\x81\xe8\x4c\x89\x72\xa1\x00\x0e\xc3\xcb\x78\x8f\x85\x8f\xb2\x14\x07\xf5\x59\x91\x02\xcc\x12\xe1\x6e\xf9\x2d\xe3\x2d\x21\x5e\x88\x33\x13\xca\x84\x50\xeb\x6c\x2e\x42\x03\x07\x64\x08\x34\x44\x51\xa1\xac\x55\x16\x20\xaa\x48\x94\x46\xcc\x94\xb0\x40\xdc\x4a\x97\x0b\xa1\xb2\x5b\x71\x2b\x0b\x2d\x67\x89\xb2\x5d\x80\xe3\xce\x23\x48\x21\x74\x34\x16\xc3\xe1\x90\x7e\x57\x40\x5c\xa1\xaa\xd4\xd1\x7e\x0a\x4b\x7b\xc3\x3d\x5e\x9b\x19\x53\x5a\x40\x97\x5f\x2a\x55\x58\x3e\xfb\x5a\x04\x6f\x74\xbe\xfd\xa6\x3f\xd8\xed\xf6\xe0\xdf\xfe\x9b\x32\xcc\xdf\x0c\xf7\x06\xbd\x01\x7c\x8f\xed\x9b\x8f\xe9\xf5\xc7\xbb\xd9\x6a\x59\x7d\xf9\xfd\xf7\xc3\xb8\xba\xbf\x9e\xdd\x1d\x4d\xae\xd4\xf5\xc5\xc1\x99\xb9\x5f\xaf\x47\xa3\xbd\xdb\x8f\xd9\xfc\xf3\xed\xe5\xf9\xb7\xb3\xdf\x97\xc1\x0f\x80\x0e\x3d\xd0\xcf\xf1\xce\xd1\xc5\x4e\xba\xfc\xf3\x46\x7d\xbb\xf9\x70\x33\xf8\xf3\xb2\xea\xef\xfc\x96\x47\x27\xc3\xe5\x7b\xd3\xbf\x1e\xa6\x0b\xb9\xb8\x7c\x3b\x9a\xaa\x51\xd6\x67\xa0\x5e\x54\x13\x2f\x29\x66\x00\xd9\x07\xa9\xeb\x72\x7d\x0c\x8b\xa6\x58\x8f\x45\x10\x6c\x91\xa8\xcf\x41\xfc\x8f\x14\xee\x35\x26\x5e\x7e\x40\x75\xbf\x82\x9d\xa4\x5e\x86\xf6\x42\x5c\x54\xa9\x2a\x74\x28\x4e\x0f\x85\x89\x49\xd5\x2d\xa5\xba\xb3\xb5\xd4\xfb\x03\x77\xea\xad\x17\xad\x48\x34\xe0\x80\x93\x99\x89\xd4\x63\xab\xc8\x0b\x73\xab\x69\xc1\x10\x6c\x42\xed\x0d\xf1\x87\x4a\x1a\x8e\xba\x83\xed\x41\x77\x30\x04\x91\xf6\x77\x1e\x6a\xaa\x3f\x38\x1c\x7e\x30\xe6\x66\x3a\xbb\x9b\x7d\x38\x98\x7d\x59\xec\xbf\xff\x5c\xda\x8f\xeb\xcf\x27\xd1\xf5\x65\x21\xb7\xaf\xf2\xe9\x64\xbb\x9c\xdd\xda\x1d\x99\xf5\xfb\xdf\x56\x27\x93\xc1\x7d\xf0\x08\xfe\x70\xbb\xbb\x3b\xe8\x82\xe6\x9e\x03\xff\x31\x1d\x84\xd3\xb4\x38\xd2\x72\x7a\xfe\x79\x7b\xfe\xe9\x76\xf7\xe6\x64\x91\xcf\xaf\x56\x66\x6f\x65\x8e\xa7\xf6\xdd\xe2\xcb\xc9\xec\x44\x0f\xe5\x64\xef\x2e\x70\xe2\x39\x72\x56\x59\x0b\x1f\xa4\xfb\x5a\x90\x02\x9e\xb3\xda\x6d\x2f\xda\x33\x49\x6a\x8b\x54\x9e\x98\x35\xb8\xc6\x34\x95\x05\xc8\xd4\x59\x83\x15\xb1\x29\x48\x94\x73\x7d\xab\xb2\x0d\x51\xfe\x17\x2c\xa6\x77\xd7\x1f\xee\x0c\x8e\xc2\xb7\xf1\xde\xce\xee\xfe\x60\x7b\x78\x34\xd8\x8e\x27\xbd\xa3\x83\xed\xc1\x28\x1a\xa8\x7e\x6f\xd2\xdb\x1b\x0c\x86\xe1\xee\x61\xdb\xb6\x6c\x29\xe7\xe8\xc5\x8f\x4d\x4a\xa6\x33\x55\xfc\x9c\x49\xf5\xff\x9b\x26\x45\xa8\x7f\x68\x52\xff\xf3\x46\xf5\xff\x66\xf5\x93\x66\x85\x29\xa9\xb1\x8a\x94\xbf\xfc\x9c\x2d\xf5\xfe\x4e\x48\xe9\xef\xef\x81\x62\x40\x39\xfd\x67\x95\x33\x99\x0f\x8f\xc2\x49\x59\xfc\xfe\xf9\xe0\x6e\x75\xbf\xb3\xdc\xb1\xd7\xfb\xfa\xcb\xf4\xea\xbe\xbc\xdf\x3f\xdc\x5d\x7f\xba\xcf\xdf\x5e\x5e\x1d\x1d\xdf\x17\x9f\xcc\xe7\xe0\xc9\x90\x35\xe8\x03\xfc\xfe\x73\xf0\x3f\x9c\xac\xf4\xdd\x6f\x2a\xab\x7e\x9b\x7c\xfe\x73\xf9\xfe\x43\x9a\xbd\x9b\x4e\xde\x1f\x7e\xbb\x8f\x77\xd5\xc9\xb9\xd9\x29\x0b\xa3\xe7\x5f\xee\xd2\xdd\xc9\xe8\xea\xfb\xca\x77\xe2\x7a\x4e\xfd\xfd\xff\x5d\xed\x4f\x8e\xb7\x47\x3b\x61\x7f\x67\xb8\xb7\x23\x77\xb6\xe3\x68\xfb\x78\x7b\xb6\xb3\x2f\xe3\xfe\x50\xee\xed\x1c\xc6\xbd\xb7\xa3\x9d\xc1\x44\xf6\x7a\xa0\x7d\xa8\x2e\x64\x29\xc5\x14\xce\xca\xb9\xda\xb2\xfc\x93\x6b\x86\x4b\x09\x35\x00\x92\x94\x60\x32\x3b\x7c\x2b\x62\x9d\x28\x58\xc9\xe1\xfb\x58\xbc\x29\xd3\xfc\x4d\x53\xb5\x7c\x8d\x00\x4e\x97\x76\x46\x33\x84\x0b\x5c\xc5\x7a\x5e\x15\xb2\xd4\x26\xab\x11\x84\xf4\x75\xfa\xf3\x68\x18\xc0\x23\x6c\x93\x30\x34\x55\x06\x22\x5c\xaa\xb5\x70\x5c\x6c\x49\xf7\x11\xf1\xc0\x77\xfc\xac\x1c\x44\xbf\x84\x67\x4f\xb3\x52\x15\xb1\x0c\x95\x58\xa1\xe6\x48\x03\x93\xcb\x53\x21\xb3\x48\x5c\x0e\x2e\xc5\x54\x15\xb7\x10\xdb\x30\x1e\xaa\x0c\x03\xde\x16\x86\xc4\x77\x06\xb4\x23\x53\x85\xe9\xd8\xd5\x1b\x00\xeb\xd2\x80\x42\x19\x0c\x82\x78\xfa\x28\x6e\x82\x02\x09\x9c\x10\xd1\xa3\x7b\xbc\x2e\xcd\xeb\x1c\x7e\x8a\xb0\x2d\x35\xbb\x95\x0f\x72\x16\xd2\x34\x57\xa1\x8e\xd7\xe2\xe8\x0e\x68\xcd\xa0\x94\x3b\xbd\x6c\x51\x8b\x40\x45\x28\x33\xac\xde\x0a\x25\xc3\x05\xd8\x16\x84\x6b\x1d\xc3\x87\x85\x06\x36\x2e\x26\xd7\x08\x46\xb9\xd3\xa7\x97\x63\xb1\xea\xde\x75\xd7\xdd\x7b\x56\x01\x52\x5d\x59\x38\xe5\x2d\x10\xf9\x4e\xe4\x5a\x15\xa8\x08\x22\x97\xfc\x87\x76\x5f\xeb\x54\x99\x8a\xd8\xcc\x84\xc9\x55\xe6\x4a\xca\x4c\x85\x44\x35\xa6\x04\x64\xc6\x6e\x09\xff\xd9\x1d\x01\xeb\x1c\xf6\x6c\x40\x50\x52\x9d\xe9\x14\xfc\x28\x52\x80\x87\xf0\x82\x36\x8b\xb5\x00\x96\x81\x07\x9b\x03\x20\x85\x90\xe4\xad\xd1\x50\x99\xea\x14\xb1\xc8\xb2\x94\xe1\xd2\x12\x00\x19\x7d\xab\xc0\x99\x66\x12\xe9\x06\x13\x5b\x80\x42\xf0\xa4\xa9\x8a\x10\xf2\xd2\xcb\xe9\xf4\xb0\x23\x0e\x2e\x3f\x75\x80\x08\xf8\x2c\xba\xdd\xee\x2b\x57\x0b\x9b\xa5\x80\x3c\x9a\x98\x39\xb9\x1c\x50\x85\xf4\x21\xad\x16\xe2\x5c\x24\x66\x6b\x64\x8b\x75\x10\xa0\x14\xef\xfe\xf9\xf2\x56\x26\x95\xba\x52\x32\x12\xff\x10\x83\x57\x42\x5b\x30\x57\x4b\x69\x31\x13\xb4\x06\xa2\x4e\xcc\xaa\x83\xd2\xcb\x44\x08\x9f\xe7\xaa\xe6\xe3\x90\x78\x04\x66\xee\x80\x80\x8d\x8f\x80\x7b\xd4\xeb\xa5\x96\x5c\xf1\x63\xa5\x2a\xf5\xc0\x04\x48\x32\xd2\xae\xb3\x70\x51\x98\xcc\x54\x16\x33\x2f\xf0\x67\x41\x1c\x5b\x7f\xe2\x01\x36\x10\x6e\x12\x2c\x9b\x43\x45\xc9\x18\x22\x35\x06\x20\x50\xc4\x1b\xc7\x5a\xe1\xf2\xf8\x4a\x27\x09\xda\x8a\x4c\x12\xe8\x0b\x4a\xb6\x16\x28\x2b\x8a\xb2\xca\x01\x1a\x9c\xbf\xe1\x83\x18\xcc\x7b\x04\xff\xb8\x50\x00\xbd\xca\x51\xa2\x22\x5c\x87\xc0\x3d\x1b\x00\xa3\x40\x81\xac\xa4\xa6\xee\xc2\xe9\x12\xbd\x4b\xb8\xe5\x1b\x58\x42\x19\x9f\x4f\x39\x18\x82\xc3\xa6\xe8\x7f\x94\x4d\x50\xf6\x52\x94\xd2\x2e\x11\x0a\x08\x13\xf4\x1d\x17\x26\x25\x5e\x42\xb0\x67\x14\x04\x1c\xa2\x95\x63\xd2\x57\x7f\xb0\x60\x2b\xba\x41\x12\x9a\xc3\x60\x1c\x99\x59\x25\x2a\x9a\x73\x37\x83\x10\x66\x85\x01\x0a\xba\xb4\x3d\x90\x31\x78\x40\xd0\xde\x67\xc1\x76\x42\x76\x23\x82\x12\x9a\x34\x4f\x14\xc8\xa4\x53\x7f\xb3\xa2\xa8\x32\xb2\x72\xe2\x18\x3f\x93\xe4\xa1\x53\x22\xf7\x86\xc0\x92\x03\xee\x02\x15\x0e\xe6\x0c\xc1\x1b\x7e\x12\x3e\x02\xad\x9d\x6c\x2d\xb7\x7c\x5d\x71\x4d\x30\x71\xff\x52\xe5\x25\x9a\x62\xaa\x52\x08\xde\x14\x71\xf0\x73\x66\xca\x36\x28\x41\x54\xb7\x3c\xbd\x90\x76\x81\x84\x17\x68\x4c\x04\xda\xb1\x37\x53\x20\x7c\xf5\x80\x3f\xfe\x58\xf3\xa0\xcb\x8e\x88\xd5\x4a\x15\x0d\x0a\x8d\xbb\xc0\xa3\x11\x83\xdf\x56\xd6\x44\x26\xe8\x58\xdf\xe1\x1c\x71\x03\xc2\x73\x20\x6d\xec\x25\x4c\xe4\xfc\x0a\x5b\x0a\x2a\x12\xbd\xea\x20\x2e\x14\x2c\xe6\x72\x9d\x03\x37\x10\x3f\x3b\xa2\xca\x28\x3e\x46\xcd\x82\xc5\x60\x54\x1f\xea\x42\xd4\x93\xa8\x14\xb6\x74\xdc\xe5\xe2\x89\x6b\x6e\x9b\x9c\x7b\x5d\xc8\xcc\x4a\x0a\x43\x28\x64\xb4\x14\x32\x94\x8d\x33\xe2\xdf\x7f\x3d\x20\x0f\x98\x43\x00\xcc\xbf\xb2\xd0\x61\x5b\xb4\x4c\xd9\x22\x15\x05\x41\x09\x24\x7a\x9e\xe0\x76\x87\xee\xca\xa4\x7a\x07\x2b\xe8\xca\x01\x6f\xa0\x61\xb0\x71\x67\x3a\x22\xd2\x36\x94\x45\x44\xe2\x5f\xa8\x54\x58\x79\x8b\x9a\x01\xe1\x2a\xb0\x0c\x67\x24\x3e\x44\xb3\x82\x32\x33\x33\xd1\x9a\x7c\x0f\x2d\xf9\x09\x59\x61\xb2\x55\x0e\xf1\x0f\xe5\x15\xcb\xc4\x2a\x27\xb0\x8d\x83\x5e\x68\x37\x18\x3f\x16\x32\xcf\x39\x9f\x79\x93\xb1\x9e\x61\x8b\xc9\xa7\x4a\x9c\x70\x2c\x84\x79\x8b\xf1\x79\xb5\x80\xa4\xde\x58\xf0\x4a\x5a\x11\x99\x55\xe6\xcc\xd6\x2e\x75\x1e\x38\x1e\x3c\x7b\x19\x24\xab\x16\x34\xc0\xd1\x11\x01\xfa\x53\xc0\xf8\x6a\xe9\x92\x8f\xf9\xf8\xc5\xe1\x12\xa2\x1b\x2e\x3b\xdc\xb8\x1d\x11\x79\x60\x07\xb2\x0c\x17\x9f\xf2\xb1\xc3\x4b\x24\x1c\x65\x64\xd7\x6d\xb5\xd3\x08\x84\x58\x02\x2b\x05\x1d\x45\x10\xfc\xb0\xba\xc0\xef\x9a\x7d\x61\x05\xb9\xd5\xac\xc0\x64\xca\xaa\xc8\x5a\xd6\xe3\x85\x11\xeb\x02\x5c\x47\x31\x6c\xc7\x2b\xe4\x3f\xd4\x33\xcd\x54\x9c\xc5\x00\xe4\x44\x87\x14\xe6\x70\x13\x7d\xb8\x21\xd0\x63\xda\xef\x6a\xf4\x3b\xca\x97\x4d\x70\x67\x01\xfb\xa8\xab\x5b\xee\xd9\x11\x3d\x8c\x86\x55\x36\x83\x20\x0b\xe0\xba\x54\xd1\xdf\x1d\x42\xb4\x81\x92\x8a\x03\xfa\x3b\x20\x3c\x31\x98\x53\x33\x4f\x61\x4b\x03\x85\x81\xf8\xab\x31\xb4\xc6\x15\x48\x93\x97\x5d\x20\x89\xa5\x4e\x00\x63\x87\x79\xc1\xbf\x20\x40\xea\xf9\xa2\x14\x72\x25\xd7\x88\x0b\xcf\x34\x29\xdf\x73\xf0\x6b\x96\xac\x6b\x54\x8d\x05\xa3\x3c\xb1\x9c\x20\xfd\x39\xd3\x17\x09\xcd\xab\x5c\xfa\x6a\x47\x62\xc9\x91\x0c\xdd\x86\x34\xc0\xd9\x87\x7b\x54\xbb\x90\x85\x07\xd0\x44\x7d\x87\x11\xb1\x37\xf6\xcd\xfc\xe3\xc6\x6f\x66\x66\x29\xf0\x36\x38\x68\x7f\xe4\x25\xca\x90\x5c\x78\x87\x44\x1a\xea\xa4\x95\x30\xc1\xe1\xd2\xbc\x5c\x6f\xaa\xd4\xef\xd3\xb5\x4e\xd1\xc8\x4b\x0a\xcd\x65\x01\x55\x8b\xad\x51\x8f\x1b\xad\x79\x97\xa9\x8d\x27\xd3\x16\x2b\x39\xa6\x10\xd0\x47\x85\x01\xbf\x8b\x6a\x6a\xc1\x07\x29\x56\xd0\x86\xb9\xa2\xf8\xad\x5d\x7c\x75\x10\x39\xe3\x30\x01\xf4\x69\x83\x00\x66\x6d\xfc\x04\x3a\x32\x7e\xd9\xa6\x8b\x28\xf0\x18\x09\xec\xd3\x74\x78\x75\x9a\x8c\x2d\xc5\x21\xc7\x5f\xbd\xb1\x70\xa1\x07\xbb\xb9\x1a\x89\x8c\xb2\xd9\x2f\x25\x58\x28\xf8\xe5\x86\xc8\x01\x3e\xf2\x6c\x05\x9a\x65\x5b\x46\x5d\xaa\xa3\x9c\x32\x2e\x0d\x78\x0f\x0f\xe0\x38\xc6\x42\xbd\x17\xb5\x02\x84\x33\x00\x2e\x6e\x25\xe1\x75\xaa\xc5\x5f\xad\x61\x2a\x20\x4b\xd7\x15\x74\x8e\xa5\x6f\x04\x05\x30\xa4\xb2\xae\x80\x9e\xcb\xba\x73\x18\x90\x29\x87\xb5\x7c\x9a\xb7\x1f\x65\xa8\xfe\xc8\x5b\x18\x54\x73\xef\x51\x1a\x0f\xea\x79\xd2\xf8\x06\x95\x2c\x0b\x30\xa5\x12\x4a\xbb\x12\xc3\xb1\xa6\x86\x89\xe2\xd0\xd3\xf4\x01\x66\xc8\xd8\x35\x79\xa7\x19\xd6\x48\xd6\x99\x9e\xeb\x7d\xb8\x9a\x47\x4a\x01\x06\x52\xb9\xd0\xb8\xf2\x90\xcc\x47\x11\x1d\x7e\xa5\x00\xcd\xc1\xd4\xc5\x77\xd2\x6b\xa2\xe2\xb2\xd6\x34\x78\x9f\xe4\x32\xa4\x83\x31\x91\xd3\x98\x97\x28\xb8\x62\x58\xe8\xbc\x65\xfa\x18\x15\x53\x30\xbd\xa5\x52\x79\x6d\xfa\x8d\x31\x81\x9a\xd9\x30\x34\xf5\x2c\xb6\xc4\xf2\xd4\xaf\x52\x78\x67\x61\xd7\x55\x04\x54\xce\xb9\x55\x76\xc3\xb4\xa0\x95\x5e\x36\xb0\xf1\x1b\x86\xfe\x26\x2c\x89\x4f\x3e\x6b\xd7\xfe\xc4\x9b\x1e\xa4\x4c\x34\x2b\xd4\x89\x4f\x95\xe7\x3a\xa3\xa8\x74\x71\x7c\x3d\xae\x39\x71\xe6\x47\xfb\x7c\x66\x84\x00\xdd\x0a\xce\xd4\x5c\x50\x79\xe7\x94\xc0\x41\xcc\x24\x11\x4e\x00\x68\xb5\xed\xd2\xc4\xa5\x6b\xdd\xba\x10\xbd\x59\x52\x3e\x91\xe1\x76\x66\xf6\x94\xda\x7d\x8c\x8d\x58\xd2\xab\xb0\x2a\xa1\x7e\x6b\xc0\xc9\x04\x58\x45\xab\x4b\x48\x42\x18\xc3\xb0\x85\x12\xd8\x2d\x24\xb4\xcf\x7b\x36\xb5\xbf\x2e\x2f\x9c\xc1\xf1\xa6\xd2\x3f\x57\xa5\xc4\xf6\x9a\x92\x5d\x13\x21\x01\x3a\xa4\x24\x75\xc7\xba\xf6\x56\x09\xeb\x6b\x6f\x97\x09\xf4\x55\xb0\x0a\x69\x12\x36\xa0\xc7\x52\x5f\xd4\x11\xaa\x3b\xef\xba\xb0\x08\x7a\x04\xee\x4f\x0f\xe9\x8e\xc1\x99\x4c\x98\x68\xc5\xa4\xbc\x78\x2a\x96\x12\x52\xf6\xb3\x18\x6a\x16\x90\xd3\x07\xb5\x26\x4d\x10\xb0\xaf\x3a\x62\xb7\x07\xbb\x48\x99\xa0\x86\x60\x74\x57\x94\x01\xa4\xaa\x6f\x16\x7b\x39\xb0\x9d\x20\xb5\xf3\x1c\x2a\xaa\xa0\x2b\xdc\x6f\x98\x2a\x63\x69\xa9\xc4\x36\xe0\xd9\x21\x3a\x40\x0d\x87\xe4\x95\xca\x6c\xdd\xd2\x02\xb9\xb6\x07\x2e\x94\xa6\xe2\x31\x66\x02\x38\x4f\xc8\x76\xd1\xee\x56\x00\x0d\xf7\x85\x94\x8f\xa1\x82\xd6\x60\xcf\xf7\x14\x19\x98\xf8\xb1\x23\xb3\xce\x4f\x06\x40\xb4\x22\x15\x07\x09\x02\xa3\xc2\x65\x5d\x55\xb5\xfb\x2e\x8e\x19\xc0\x86\x6f\x63\xba\xd4\xc0\x27\x09\x0d\x39\x40\x21\x58\x12\x53\xbf\xb9\x51\x03\x60\x7f\x06\x34\x00\x5f\xae\x06\x70\xa9\x9e\x10\xf9\x94\xda\xa2\x81\xe3\x7f\x53\xe2\x60\x0f\xc7\xa2\xb9\x5e\x30\x41\x10\xd5\x2b\xd2\x21\x8b\x81\xc3\x1c\x81\xeb\xa0\x85\xe0\x88\x00\xa8\x02\x9f\x69\x08\x04\x2e\x1e\xd0\x77\x4d\xb6\x0f\x12\x03\x14\x4f\x90\x58\xae\x14\x8a\x67\x65\x18\x70\x9d\x39\x1b\x42\x3d\x70\xc0\x83\x80\x1f\xb2\x5d\x2b\xd9\x37\x3d\x26\x6b\x6a\x54\x54\xa4\x1b\xc4\x35\xe2\x6c\xfa\x20\x0e\x93\x0b\x68\x28\x5a\x95\x2c\x1b\x06\xc5\x2f\x0f\x92\xf8\x5f\x49\x8c\xf5\x08\x11\x7c\x25\x54\x49\xe2\xb2\xb8\x24\x08\x78\x1e\xd3\x08\x4e\x61\x70\xb0\x60\xe6\xde\x46\xdc\xe2\x49\x21\x43\x75\x09\x06\x63\x22\x92\x8f\x0d\x9e\xac\xc5\x65\x3b\x13\x03\xa5\xc6\xd2\x34\xa1\xc4\x4a\x1a\xad\x06\x1a\x39\xf4\x5e\x56\x2d\x8a\x90\x06\x98\x9e\x35\x1f\x4e\x1d\x8c\x00\x22\x3e\xb5\x1f\x0f\xc3\x75\xcb\xf5\x1d\x00\x99\x39\x9e\x6b\x29\x61\x8f\xf9\x9d\xb8\xcc\xf3\x1b\xe0\x3f\x60\x71\x3c\x13\xbd\x71\x47\x23\xaf\xba\x1e\x0f\x1c\x4f\x5f\x99\xa1\x00\xdd\xcd\x72\xae\x71\x2b\x07\xb4\x50\xd7\x05\x9e\xa3\xc7\xca\xe7\xd8\xe3\x62\xa7\x5d\xf2\xd0\x80\xcc\xd7\x37\xcd\xaa\x2c\xd6\xa4\xc6\x36\x61\x2e\x86\xe2\x22\x5d\x8e\x0a\x68\x24\x4d\xf1\x20\x15\xe2\xde\x8a\x34\x5e\xaa\x39\xa4\x5e\x16\xef\xf1\xe6\x57\xec\xbc\x7d\xc5\x44\x85\x38\xa0\xeb\xd4\x85\x15\xd9\xe6\x26\xce\xcc\x64\xaf\x1f\xe1\xc5\xda\x2f\x33\x90\xe3\x39\x8e\x12\xd1\x13\xb4\x3b\x9e\x12\x73\x59\x01\xb0\x1b\xe3\x81\x4d\x40\xc0\xd7\x44\xa7\xba\x54\x64\x54\x29\x8b\x67\x52\x84\x0b\xed\xd5\xde\xce\x56\x8d\x6f\x59\xb1\x80\x05\x14\x4a\x1d\x16\x51\x6c\x38\xb6\xb5\x18\x0f\x23\xa8\x41\xf1\x66\x1b\x83\x20\x9c\xa2\x14\xe7\x5b\xda\xae\xeb\x04\xb0\x65\x23\x89\x22\x28\xe8\x12\x0d\x4d\x32\x25\xba\x16\xa4\x58\xfc\x48\x70\x3b\x94\x02\x71\x34\x81\xe6\x9a\x40\x90\xf6\xf9\xaa\x95\xf3\xd0\xfe\x23\x56\x9d\x26\x5d\x12\x07\x44\x4f\x13\x93\xa8\x8d\xc1\xfc\x07\xc2\xd0\x49\x53\x5e\x81\x5f\xa0\xf9\x58\x95\x62\x5d\xd4\xcc\x9d\x3c\x14\x83\x77\xb9\xc0\x67\xd4\x14\xfa\xc5\x66\x82\x72\x3b\x11\x15\xc0\x77\xf2\x63\xc1\xc3\x5e\x57\x96\x8a\x86\x31\xca\xb4\xbd\x27\x4a\x86\xe7\x23\x51\xe9\x9a\xdc\xb0\x30\xb6\xa9\x0a\xfc\x0c\x1b\xeb\x05\x5f\x43\x93\x42\x6b\x45\x31\xfb\xd3\x12\x6b\x09\x6c\x9a\x5c\xeb\x8a\x86\x76\xa7\xf9\x29\x00\x15\x6c\x06\x8f\x12\x30\x10\x22\xc7\x2a\x2a\x3b\x10\x98\xab\x11\x0e\x80\x82\xaa\x28\xc8\x88\x7a\xcf\x44\x1f\x8a\xbb\xd5\x0c\x0e\x95\x8f\x5b\x7e\x6a\xda\x37\x00\xb5\x48\x0c\x66\xd0\xf0\x2d\xdb\x23\x00\x59\x4b\xe2\x41\x42\xeb\x60\xea\xff\xa6\xc2\x32\x70\x7d\xb7\x25\x7e\xd0\x11\x1e\x74\xa1\xa1\x84\xec\xae\xcb\x75\x1d\x07\x18\x09\xd6\xe6\x37\x6a\xb6\xc0\x29\x70\x66\x4a\x1d\xbb\xd6\xfb\x61\xad\xde\x5e\x73\x45\xbb\x9f\x7c\x13\x39\x34\xd8\xf6\x25\xf2\xca\x01\x04\x4b\xcc\x0d\xb8\x61\x07\x3c\x20\x4c\x2a\x3f\xca\x11\x87\x17\x53\x9a\x4d\x27\x95\x1b\x66\x46\x90\xeb\x9a\x16\xb9\x0e\xe9\x1e\x83\x9f\x82\x5c\x9f\x4d\x41\xc6\x59\x04\xad\xed\x52\x35\x21\xf0\x21\x3a\x9c\xd8\x24\xf6\x9d\xdf\xf8\x1d\xc0\x3e\xbe\x79\x04\x0f\x21\x35\xb3\xf7\x05\xf8\x2f\x4e\x8c\xeb\xf1\xa8\x2f\xdf\xc0\x65\xac\x22\x9c\x7e\xef\x3b\xda\xfa\xc4\x90\xff\x90\xe7\x8b\x75\x60\xdf\x90\x29\x39\x61\xe6\xcd\x98\x1a\x46\xac\x9f\x78\x20\xec\xca\x0b\xe8\x91\x9a\xe3\xb6\x19\xce\xba\x38\x82\xab\x60\x6a\x6e\x8c\xb9\x6e\xd9\x0f\x96\xd2\x35\x73\x21\xb9\x8c\x71\x3d\x6e\x07\x4d\x13\x56\x13\xb3\xe2\x89\x38\xb2\x57\x98\x6a\xbe\xc8\x2b\x1a\xdd\xcc\x2a\xbb\x6e\xbc\x0b\x30\x19\xc6\xe3\xb8\xd9\x18\x23\xa0\x0b\x5b\x7d\x4f\x04\xcf\xd6\xa5\xaa\x03\xa5\xc7\x9d\xcb\x75\x62\x64\x04\x5e\x8a\x61\x28\x55\xd6\x62\x7b\xe6\x22\x3c\x33\x99\xfa\x8a\x9b\x0a\x66\x82\x90\xc8\x62\x4e\x73\x8d\x96\xbc\x38\x6b\x62\xa0\x04\xd7\x70\x63\x7b\x97\x28\x36\xec\x18\xcb\xd9\x44\x62\x0d\x01\x39\xad\xd9\x8c\x61\x22\x55\x90\x09\x30\x55\xb4\x5d\xfb\x92\x29\x9c\x02\x17\xde\xb7\x2f\xda\xf0\x78\x96\xcf\x49\x1f\xd1\x1b\x9c\xe3\x58\x7a\xd9\xb2\x61\xfc\x16\x9b\x37\xd7\x63\xe1\x78\xd1\xb9\x36\x28\xee\xab\x4f\xa3\x81\x37\x1e\xd6\x8f\x1b\xfd\xbb\x0c\xc7\xd3\xbc\x56\x60\x6e\xcd\x12\x0d\xc5\x2f\x2a\x3b\x3b\x8d\x1b\x48\x08\x34\x10\x94\x6b\x31\xd0\x5d\x12\x29\x2c\x2f\x4f\xb3\x23\xa2\x7b\x2c\xfe\xf5\x07\xdd\x47\xc2\x1f\x07\x0b\x7a\x3f\x41\x77\x69\x3a\xdc\x74\x78\x7a\x81\x45\x1b\xd0\xd7\x31\x64\x7d\xba\x3a\x1b\x8b\x95\x1d\xbf\x69\x5e\x14\x8d\xf7\xf7\xb7\xb7\x9d\x84\xb0\x49\x68\xc6\xa7\x50\x66\x9a\x04\xa5\xc9\x55\x01\x3f\x8c\xb0\x8a\x6a\xbc\xf6\x36\xec\x19\x59\xec\x57\xbc\x6f\x2c\x06\xbd\xde\x77\x40\x6a\x57\xb8\x73\x56\xe7\xfa\x13\xdb\xb7\x3a\x8a\xb6\x4f\x2c\x24\xd6\xc5\x0a\x73\x56\x09\xd1\x89\x2a\x2d\x0f\x00\xf1\x61\x14\x1c\xd4\xbe\xc9\x93\xea\x44\xc7\xca\x5d\x61\x01\xc9\x38\xc7\x26\x1c\xe0\x6a\x18\xc8\xb9\x41\x87\xff\xc2\x05\x46\x65\xf7\x66\x8d\x6a\x27\x40\x1e\x92\x40\x5f\x8b\xbe\x58\x2b\x89\x7c\xf1\xbe\x33\x00\x69\x73\x99\x01\xb6\xbd\xdd\x9d\xde\x82\x62\x6e\x7d\x73\xfe\x8c\xfc\xfd\x4c\xda\x5d\x78\xaa\x44\xe1\x95\x38\xbb\xaa\x5f\xab\x83\x85\xa3\xd4\xf9\x9a\xc1\x9b\x2f\xf7\x22\xa5\x9e\xdb\x85\x95\x2d\x21\x99\x33\x12\x7f\xa9\xec\x46\xed\xee\xba\xf8\x82\xee\x6f\x03\xbc\xbd\x0f\xea\x67\x72\x7e\xfc\x82\x30\x6a\xbc\x5c\x28\x72\x26\x7b\xb9\xe2\x58\xa8\xc1\x17\x56\x74\x0b\xa3\xf3\xd0\xbd\x9d\xa3\xaa\x0c\xd3\x03\xcd\xb2\xd8\x73\x5e\xb5\xed\x69\x51\x96\x39\x58\x14\xcd\x37\xf1\xc6\x72\xbc\x3f\xda\x1e\xf1\x85\xa8\x1b\xf0\xe2\xa5\xdc\x0a\xd8\x98\x4b\xe4\x49\x87\x04\x2f\x77\x77\xa4\x9b\xc6\x04\x9c\xae\x94\xa6\xd3\x83\x9e\x38\x81\xdf\x01\xd1\x8a\xcd\xeb\x44\xda\x4b\x3c\x4d\xf6\xe5\xff\xa1\xad\xb0\xc2\xfe\xcf\x91\x32\xd2\x31\x35\xd6\x65\xa3\xa1\xfa\xf6\x13\x63\x0e\xd0\x71\x46\xbb\xfd\xb3\xbf\x03\xbc\x92\x53\x5c\xc6\x30\x4c\xfc\x3a\x89\x22\x6a\xcc\x87\xed\x8f\x57\xea\xd6\x2c\xb9\x61\x1f\x8d\xfc\x67\xb6\x91\x03\xb2\xaf\xb1\xd8\x7b\xf0\xfd\xb2\x50\x7e\xa9\xdf\x80\xca\xe2\x12\x07\x31\x63\xb1\xbf\xf1\x8d\xee\x2f\x80\xfa\x63\x28\xdb\x60\xff\xa8\x5e\xc3\x8a\xae\x9c\xf2\x85\xff\x4e\xfd\x35\xaf\xec\xe2\xda\xfc\x0a\xdd\x54\xa2\x3c\x28\x10\x88\xbf\x0e\x2d\x54\x6a\x6e\x39\x6a\x5a\x03\xe2\x45\x67\x2a\x74\x04\xe1\x5a\x5b\x72\xa3\x39\x16\xcd\xd1\xb3\xf9\x14\xeb\x10\x2f\xc2\xb6\x9a\x9c\x69\x44\xae\x48\x95\x82\x0a\x11\x8a\x5a\x3e\xb6\x42\xe5\x32\x77\xb7\x86\xae\x87\xf1\x57\xa6\x9c\x51\x81\x87\xef\x24\x72\x9a\x37\x50\x58\x6e\x34\x57\xfb\xaa\x27\xa9\x01\x8d\xd7\xd8\x9b\xe0\xfb\x23\x07\xfd\xff\x7e\x58\xbb\x5e\x50\x8a\xe1\xc8\x45\xd7\x94\x16\x15\x99\x82\xd7\xeb\x1c\xbc\xb8\x20\x5a\x37\xbd\xbb\x71\x35\x7c\xe0\x9a\xfa\x0b\x67\xf8\x7c\x5e\x1f\x03\xf3\xea\xf6\x30\x8e\x5d\x1c\x5f\x3f\x2a\x15\xe3\xd2\x15\x88\x60\xed\x19\x46\x22\x50\x03\xf4\x0a\x36\x31\xa0\x5c\x75\x97\x13\xd1\xbe\x31\x44\x00\x85\x9a\x43\xa2\x24\x81\x82\x13\x53\x7d\xf1\xa0\x7d\x74\x3b\xd6\xfe\x8d\x2e\x67\xd3\x73\x2e\xd1\xa8\x10\xb3\x7e\xba\xe7\x72\x6e\x7d\x22\xad\xa8\x4f\xca\x61\x29\x32\x61\x45\x8f\x50\x63\xad\x12\xb2\x3e\x37\x70\x06\xca\x1e\x0d\x3e\xf9\xf8\x25\x53\xaf\x55\x7d\x5b\x88\x0f\xca\xfa\xfd\xbd\xd1\x68\x77\xb4\x2f\x87\xfb\xf1\x6c\x77\x14\x87\xbb\xc3\xed\x7e\x1f\xfe\x18\x45\xbb\xf0\x6d\x77\x3b\xda\x8e\x64\x6f\x2f\x80\x74\x1b\x48\xba\xdb\x0f\xa0\x52\x8f\x2a\x7a\x18\xa4\x82\x3f\xa8\x5a\x7c\x84\xc0\xcf\x4e\xa7\x7a\x4e\xb5\x3e\x66\xfc\xb4\xa9\xa1\x30\xbf\xe3\x20\x87\xec\xd9\x85\xdc\xe7\xc4\x08\xac\xa5\x54\x78\xff\x4d\x29\x3e\x2b\x3d\x2e\xdc\x15\x65\xbd\x06\x7f\x63\x35\x25\xe9\x98\xea\x35\x0b\x64\xb7\x27\x13\x3e\x3b\x59\xc7\x0e\x90\xe2\x5a\xb0\x2a\xc7\xe9\x0e\xec\xf5\x1c\x62\x21\x15\x80\x01\x7e\xc5\xbd\x81\x78\x59\xdb\xa2\x83\xe9\x0a\xc5\x57\x3c\x26\xb1\x2a\xcc\x07\xa3\x9d\x65\x1f\x76\x2e\x55\x18\xca\x25\xfc\x85\x6e\xb1\x78\xf5\x8c\x16\x27\x2d\xd1\xfd\x9c\x1e\x1b\xea\x5a\xba\xdb\x00\xeb\xb5\x77\x55\x1b\x1e\x1c\x31\xae\x79\xa4\x54\x99\xca\x35\x15\xdf\x3f\xd2\x4a\x4b\x40\x1e\x06\x09\x08\x35\x8a\x76\x34\x83\x3e\x2f\x60\x51\x94\x2e\xe4\x07\xdd\x36\x6e\xad\x5a\x05\x2a\x3e\x9f\x70\x5a\xa5\xa0\x48\x19\xd5\x01\x7b\x46\x5c\xe7\x8c\xf5\x67\x2d\xde\xd3\x59\x13\xd7\xb6\x77\x0f\xdb\x8b\x6b\xf2\xf6\xb4\xae\x2f\x6e\x29\xd4\x3d\xb6\x66\x85\x6d\x3c\x58\xdd\xdf\x31\x69\x6a\x2c\x10\x28\xf9\x3d\xcd\x4e\xea\x89\x3f\x21\x68\xa6\x20\x08\x8e\x92\x10\x9a\xf0\xe9\x21\xe6\xb5\x66\x88\x5b\xc1\x22\x9a\x95\xce\x5c\x1f\x54\x53\x08\x42\xe3\x3c\xc5\x43\xa8\xcc\xa3\x76\x5b\xf1\x19\x07\x68\x06\x67\x27\x41\xdd\x21\xf8\x47\x5e\x25\xb5\x05\xba\xf4\xb8\xee\x55\x61\x1a\xd2\x7f\xa0\x3e\xe5\xa7\x19\x47\x57\x07\xbb\x83\xbe\xf0\xf9\xbe\x26\xeb\x29\x5d\xba\xba\xff\xa7\x54\xf9\xcb\xbf\xfe\x1d\xc8\xcc\x64\x6b\x08\x61\x36\x18\x53\x9f\xd7\x09\x88\x4d\xf8\x13\x16\xdd\x1d\x48\x30\x86\xbe\x0a\x56\x90\xf5\x60\x1c\x94\x26\xe8\x04\xf8\xcc\x03\x7e\x77\xbc\x05\x7f\x75\x5a\xbb\x1d\xa0\x7a\x3b\xc8\xff\x34\x6a\xce\x38\xd1\x07\x7f\xfd\x51\xef\x39\x27\x55\x35\x5b\x88\x63\xd8\xf0\x4b\xcb\xb2\x7c\x87\xe3\x6f\xa0\x9a\x1c\x27\x73\xfc\x59\xbf\x81\xc2\x04\xe7\x52\x5b\xeb\x91\x5f\xaa\x37\xf3\xac\xed\x08\xf7\x60\xa0\x1e\xae\x43\xce\x23\xb7\x1b\x40\xf7\xc3\xc6\x0b\xa5\x28\x15\x32\x1e\x1c\x3f\xd0\x83\x90\xcd\x36\x83\x98\x0a\xcc\x31\xfc\x84\x06\x63\x05\xf6\x65\x34\xa2\x03\x6c\x64\x10\x0c\xa9\xb7\x39\x46\x6b\x32\x6f\xdd\xce\xf9\x98\xd8\xad\x4b\xd0\xae\xab\x03\xdd\x9c\x4b\xd3\xbb\x1b\x7e\xde\x00\x5f\xb1\xe4\x05\xe2\xf0\xff\x50\x71\x7d\xec\x5b\xed\x40\x85\xf5\x1b\x5b\x67\xd7\x2d\x5f\xf3\xc9\x1c\x2b\xbf\x19\x84\xf9\xd6\x63\xb8\x8d\x61\x37\x7b\x09\x09\xcd\x8f\xdb\x7c\x59\xae\xcb\xa7\xa3\x51\x28\x33\xb4\xe6\xf6\xb3\x4c\x7a\x50\xc5\xcd\x39\xd0\x46\xf2\x76\xd7\x29\xc0\xb9\x73\x44\xcb\xd7\x36\x85\x9a\x01\xfd\x0d\xcc\x87\xcf\x17\xea\x31\x36\x0d\x46\xf1\xc5\x57\xd6\x1a\x21\xf8\xa8\xc2\x3d\x1a\x96\x88\xa0\x5f\x5b\x41\x5b\x22\x6d\x2b\x91\xc1\x21\xac\x3d\xe8\xba\x0c\xab\xa5\xa8\x3d\x3c\x00\x31\x24\x8c\xaf\x99\xe6\x31\x3c\x7a\x5e\x42\x4c\xa1\x0a\xbb\x6d\x6d\x22\x18\x7a\x12\xca\x1c\x13\x66\x1e\x4b\xcb\xe8\x00\xbf\x5d\x5f\x43\x23\xd4\xb3\xf5\x6d\xe3\xeb\x76\xad\x57\x28\x7a\x77\x5a\x1b\xa8\xbb\xc9\xab\xc7\x8a\x6e\x54\xb3\xa1\x19\x7f\x26\xc7\xcb\xcf\xfa\x11\xb5\xb4\xed\x31\xb4\xcf\x49\x4c\x09\xed\xf7\xaf\xdb\x8f\xfc\xec\x8b\x2e\xd0\x5d\xfe\xc5\xbf\xd3\x07\xc4\x31\x2d\xb9\xe1\xc7\x5f\xe6\xd1\xdb\x0f\x82\xeb\xaf\xb4\x9b\x4b\x22\xa2\xdd\x36\x1c\x75\x09\x6f\x55\x24\xf5\x70\x18\x2a\x04\xaa\x0b\xea\x71\xd0\x63\xac\xed\x5a\xc3\x65\x82\x27\xca\x8d\x4e\xbb\xa8\xc0\xdb\xce\xa6\x72\x60\xac\x6e\x2f\xa3\xdb\x48\xf2\x5b\x78\x25\x83\x8f\xd1\x66\xd5\x7c\xee\x9e\xf4\x62\xe7\x4c\xdd\xd1\xdc\xd0\x48\x67\x8b\x56\x39\xb4\x2a\x7a\x4a\xc0\xfb\xc9\xa4\xf1\x45\x2a\xcd\xc0\xdb\x76\x8a\x23\x77\x7a\xa7\xdd\xbc\x10\xab\x66\x76\x0d\x12\x4c\xed\x66\x5e\x23\xaf\x28\xdc\x9b\x40\x1f\xbf\x9a\x4b\xd4\x0d\x3c\xec\xa8\xec\x73\x0c\x7d\xcc\x1b\x70\xee\x1a\x9b\x0e\xb4\x46\x45\xd6\xf1\xd7\x23\x51\x2e\x33\x1d\x76\x84\xfb\x11\x43\xe9\x96\xf0\x75\x73\x3b\x7d\x00\xe8\x33\x06\xe5\x32\x07\x3d\x3a\x78\xcd\x2f\x1d\x1d\x78\xb7\xc2\x0f\x77\x10\x13\x33\xec\xce\xf9\x38\x9c\x43\x03\x10\x73\x6f\xed\x85\x89\xee\x8c\x5f\xeb\xf7\x21\xdc\xec\xba\xff\x99\x2e\xc7\xc7\x04\xdc\xf3\x62\x5a\xd9\xfa\x0f\x06\x25\xa5\xff\x39\x38\x00\x00")
 
 func go_centrifuge_build_configs_default_config_yaml() ([]byte, error) {
 	return bindata_read(
@@ -34,7 +34,7 @@ func go_centrifuge_build_configs_default_config_yaml() ([]byte, error) {
 	)
 }
 
-var _go_centrifuge_build_configs_testing_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x53\xc9\x6e\x1c\x37\x10\xbd\xf7\x57\x10\xcc\xc1\x97\x59\xb8\x6f\x37\x43\xf1\x12\x08\x11\xe2\x38\x80\x9c\x63\x91\xac\x96\x1a\xa3\x5e\xc2\x66\x4b\x1e\x1b\xfe\xf7\xa0\x67\x46\xb6\x6f\x51\x6e\x64\x75\xbd\xf7\x58\xd5\xef\x25\x1c\x6a\xe9\xda\xe5\x0e\x6f\xb0\x3e\x8d\xe5\x10\x48\xc5\xb9\x76\xc3\x5d\x83\xf5\x1e\x0b\x2e\x7d\x68\x08\x81\x94\xc6\x65\xa8\xf3\x7a\x26\xa4\x87\x6e\x08\xe4\x74\x24\xe4\x80\xc7\x40\x5e\x7d\xa5\x90\x73\xc1\x79\xa6\x81\x3a\x1f\x19\x38\xa3\x9d\x4c\x4a\x29\x05\xa9\xcd\x96\x47\x65\x24\xb2\x2c\x93\xd6\x80\x5c\x71\x01\x9a\x6e\x68\x2a\xc7\xa9\x8e\x34\x7c\xa5\xa9\x9b\xee\xb1\xd0\x40\x01\xe7\x2d\x17\x6e\x9b\x6a\x59\x1b\x4e\xe5\x8a\x9f\x2b\x0d\x34\x59\xeb\x5b\x27\xad\xcf\xd6\xb2\xec\x45\x6a\x13\xcf\x39\x2b\x70\xad\xe4\x59\x03\x83\x9c\x5c\x2b\x80\x45\x01\x5c\x31\x2e\x2d\xcb\xd2\x48\xd6\x4a\x97\x58\x72\xf0\x9d\x6f\x82\x02\xfd\xbc\xca\x76\x8f\x34\x50\x69\x12\x37\x0e\xad\x8c\xad\x77\xac\x45\xab\x23\xb3\xc2\xb6\xce\x33\xb0\x1c\x32\xfd\xb6\xa1\x87\xdc\xd2\x40\xe7\xd3\x83\xe9\xe9\xfa\x83\x24\x1f\x1e\x70\xa0\x41\x8a\x0d\x1d\x68\x10\x46\x70\xa5\x36\x74\xa2\x81\x6f\x68\xa1\xc1\x6d\xe8\x0c\x0f\xeb\x00\x19\x79\x44\x6e\x50\x26\xef\xb8\x57\x2a\x73\x4c\x20\xa2\x8b\xc2\xa2\x42\x83\x2c\xea\xd8\x46\x25\x23\x32\x69\x0d\xe8\xec\x9c\xf3\x2d\x18\xeb\x41\x38\x2e\xc4\xfa\x90\x1e\xd2\xba\x8a\xc4\x85\x8b\x8e\x6b\xad\x75\x04\x8e\x90\x6d\x02\xf4\xcc\x30\x74\x4e\x09\x68\x13\x38\xa9\x4d\x66\x46\x69\x1d\xb3\x07\x6d\xb5\x88\x60\xda\x94\x98\x17\xd8\xae\x4c\x5d\xa6\x81\x2a\x8d\xcc\x30\x30\xdb\x2c\x00\xb7\x4a\x46\xb7\xf5\x42\xb4\x5b\xa5\x9c\xf0\xca\xfb\x2c\x6d\xa6\x1b\xfa\x88\x65\xee\xc6\x75\xc8\x6f\xaf\x2e\x3f\x7e\x82\x79\x7e\x1a\x4b\x0e\xe4\xd5\x73\xe9\xe2\x81\x40\x5e\x6a\x81\xa6\xe9\x32\x0e\xb5\xab\xc7\xdf\x72\x20\x94\x7d\x7e\xb1\x77\x9a\x66\xb5\xee\xd5\xfd\x6a\xc5\x1f\x06\x3d\xfb\xb3\x3b\x73\x65\x25\xb5\x97\xc9\x72\xdd\xe6\x2c\x79\x32\x9c\x2b\x0e\x31\x33\x05\xde\xb7\xd9\x38\x21\x92\xd3\xda\x39\xad\x52\xca\x28\x3d\x68\xe3\x14\x5a\xd0\x26\x83\xb0\x99\x9e\xc8\x66\x4c\x05\x6b\x20\x74\xbf\x7f\xfd\xd0\x25\x3c\x57\xbf\x4f\x4a\xf5\xbb\xf2\xf4\x08\x6f\xde\xea\x2f\x9f\xa2\x30\x6f\xbf\xf8\x92\x3e\x4c\xbf\xde\x7e\xd4\xf6\xaa\xbe\xf9\xf3\xfd\x74\x83\xf7\x9f\xae\xfe\x48\x37\xe3\xfb\x77\xd7\x4b\xfd\xf0\x37\x6d\x9a\x5f\xc8\xeb\x4b\x9e\xd6\xf4\x90\xb9\x8e\x05\xee\xb0\xf9\x39\x64\x07\x3c\xae\x65\x0c\x64\x5f\xfb\x69\xff\xfc\xa9\x69\xfe\x59\x70\xc1\xb5\x63\x58\xfa\xdb\xb1\x1c\xb0\xcc\x81\x88\x86\x90\xa7\xd3\xe5\x16\xba\xfa\x57\xd7\xe3\xef\x1f\x03\xe1\x4d\xb3\xd2\xac\xcd\x93\x98\xce\xab\x99\x96\xf8\xd0\xa5\xeb\x35\xb3\xbb\xdd\x7e\xb7\xdb\xc7\xa5\x7b\xc8\xfb\x82\xf3\xb8\x94\x84\xf3\x7e\x12\xd3\x35\x1e\x77\xd3\x12\x77\x13\xf6\x67\x4c\xe9\x1e\xa1\xe2\x7f\x83\x0e\x2b\xf0\x04\x9a\xbb\xbb\xa1\x1b\xee\x5e\xa8\x79\xe9\xfe\xff\xba\x3f\x01\x9f\xb5\x1b\x18\xd2\xfd\x58\x2e\xe2\x53\xc1\x34\xf6\x7d\x57\x03\xa9\x65\xc1\xe6\xdf\x00\x00\x00\xff\xff\xdc\x3c\xc5\xc4\xef\x04\x00\x00")
+var _go_centrifuge_build_configs_testing_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\x95\x54\x4b\x6f\xdc\x36\x10\xbe\xeb\x57\x08\xea\x21\x97\x5d\x2f\xdf\x0f\xdd\x02\xe7\x55\x04\x35\x9a\xa6\x80\xd3\xe3\x90\x1c\xda\xc2\x5a\x2b\x45\xa2\xec\x38\x41\xfe\x7b\x47\xbb\xeb\x24\xb7\xba\x84\x00\x92\xc3\xef\xfb\x86\x33\x9c\x51\xc4\x43\x99\xba\xbc\xdc\xe0\x15\x96\x87\x61\xda\xb7\x75\xc1\xb9\x74\x87\x9b\x0a\xcb\x2d\x4e\xb8\xf4\x6d\x55\xd7\x10\xe3\xb0\x1c\xca\xbc\xae\xeb\xba\x87\xee\xd0\xd6\xc7\x65\x5d\xef\xf1\xb1\xad\x5f\x7c\x6b\x20\xa5\x09\xe7\xb9\x69\x1b\xe7\x03\x03\x67\xb4\x93\x51\xd1\x80\x98\x93\xe5\x41\x19\x89\x2c\xc9\xa8\x35\x20\x57\x5c\x80\x6e\x36\x4d\x9c\x1e\xc7\x32\x34\xed\xb7\x26\x76\x23\xb9\x23\x36\xe0\xbc\xe5\xc2\x6d\x63\x99\x56\xc0\xd1\x5c\xf0\x4b\xa1\xa3\x68\xad\xcf\x4e\x5a\x9f\xac\x65\xc9\x8b\x98\x23\x4f\x29\x29\x70\x59\xf2\xa4\x81\x41\x8a\x2e\x0b\x60\x41\x00\x57\x8c\x4b\x42\x49\x23\x59\x96\x2e\xb2\xe8\xe0\x87\xde\x08\x13\xf4\xf3\xea\xb6\xbb\x27\x5d\x69\x22\x37\x0e\xad\x0c\xd9\x3b\x96\xd1\xea\xc0\xac\xb0\xd9\x79\x06\x96\x43\x6a\xbe\x6f\x9a\x7d\xca\x84\x9c\x8f\x17\x6e\x8e\xdb\x9f\x22\x69\x7f\x87\x87\xa6\x95\x62\xd3\xd0\x24\x8c\xe0\x4a\x6d\x9a\xb1\x69\xf9\xa6\xa1\x90\xdc\xa6\x99\xe1\x6e\x0d\x20\x21\x0f\xc8\x0d\xca\xe8\x1d\xf7\x4a\x25\x8e\x11\x44\x70\x41\x58\x54\x68\x90\x05\x1d\x72\x50\x32\x20\x93\xd6\x80\x4e\xce\x39\x9f\xc1\x58\x0f\xc2\x71\x21\xd6\x8b\xf4\x10\xd7\x54\x44\xca\x51\x70\x5c\xd3\x08\xc0\x11\x92\x8d\x80\x9e\x19\x86\xce\x29\x01\x39\x82\x93\xda\x24\x66\x14\x01\x92\x07\x6d\xb5\x08\x60\x72\x8c\xcc\x0b\xcc\xab\x52\x97\x48\x48\x69\x24\x12\x98\x6d\x12\x80\x5b\x72\xed\xb6\x5e\x88\xbc\x55\xca\x09\xaf\xbc\x4f\xd2\x26\x8a\xf7\x1e\xa7\xb9\x1b\xd6\x20\xbf\xbf\x38\x3f\xfc\x08\xf3\x4c\x15\x93\xe8\xf5\x9f\x4c\xe7\x1a\x68\xeb\xe7\x96\x40\x55\x75\x89\x2a\xb0\x2b\x8f\xbf\x93\x4e\xc3\xbe\x3c\xbb\x76\xaa\x2a\x12\xf1\xf2\x76\x2d\xc5\x9f\x05\x7a\xaa\xcf\xee\xa4\x95\x94\xd4\x5e\x46\xcb\x75\x4e\x49\xf2\x68\x38\x71\x21\x24\xa6\xc0\xfb\x9c\x8c\x13\x22\x3a\xad\x9d\xd3\x2a\xc6\x84\x92\x92\x64\x9c\x42\x4b\x53\x02\x41\x61\x1f\xc5\x66\x8c\x13\x16\x12\xdc\xed\x5e\xde\x75\x11\x4f\xd6\x1f\x91\x36\xfa\xed\xf4\x70\x0f\xaf\xdf\xe8\xaf\x9f\x82\x30\x6f\xbe\xfa\x29\x7e\x18\x5f\x5d\x7f\xd4\xf6\xb2\xbc\xfe\xeb\xdd\x78\x85\xb7\x9f\x2e\xff\x8c\x57\xc3\xbb\xb7\xef\x97\xf2\xe1\x1f\xba\xf9\x6f\xf5\xcb\x73\x3f\xad\xdd\x53\xcf\x65\x98\xe0\x06\xab\x5f\x9b\x8c\xec\xab\x19\xdb\x7a\x57\xfa\x71\xf7\x74\x54\x55\x9f\x17\x5c\x70\x45\x1c\x96\xfe\x9a\xfa\x95\xde\xa5\xad\x05\xed\x1f\x8e\x9b\x6b\xe8\xca\xdf\x5d\x8f\x7f\x7c\x6c\x6b\x5e\x55\xab\xcc\x0a\x1e\xc5\x78\x4a\xcd\xb8\x04\x0a\xe2\xfd\xda\xb3\x17\x17\x3b\xfa\xc2\xd2\xdd\xa5\x1d\xc5\x32\x2c\x53\xc4\x79\x47\x48\x3a\xbd\x20\xdc\xc5\x88\xfd\x89\x33\x75\xf7\x50\xf0\xbf\x49\xfb\x95\x78\x24\xcd\xdd\xcd\x81\xfe\x21\xcf\xf4\x79\x46\xff\x7f\xbf\xbf\x10\x9f\x7c\x57\x70\x88\xb7\xc3\x74\x76\x3e\x4e\x18\x87\xbe\xef\xe8\xfd\xca\xb4\x60\xf5\x2f\xdc\x3c\xc5\xc4\xef\x04\x00\x00")
 
 func go_centrifuge_build_configs_testing_config_yaml() ([]byte, error) {
 	return bindata_read(
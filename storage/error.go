@@ -9,6 +9,9 @@ const (
 	// ErrModelRepositoryNotFound must be used when model is not found in db
 	ErrModelRepositoryNotFound = errors.Error("model not found in db")
 
+	// ErrRepositoryModelGet must be used when db repository fails to read the model for a reason other than not found
+	ErrRepositoryModelGet = errors.Error("db repository could not get the model")
+
 	// ErrRepositoryModelSave must be used when db repository can not save the given model
 	ErrRepositoryModelSave = errors.Error("db repository could not save the given model")
 
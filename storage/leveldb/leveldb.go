@@ -29,8 +29,14 @@ type levelDBRepo struct {
 	db     *leveldb.DB
 	models map[string]reflect.Type
 	mu     sync.RWMutex // to protect the models
+
+	// binaryModels are the types of the binary models keyed by their header
+	binaryModels map[byte]reflect.Type
 }
 
+// jsonHeader is the first byte of the models saved as JSON.
+const jsonHeader byte = '{'
+
 // value is an internal representation of how levelDb stores the model.
 type value struct {
 	Type string          `json:"type"`
@@ -40,17 +46,27 @@ type value struct {
 // NewLevelDBRepository returns levelDb implementation of Repository
 func NewLevelDBRepository(db *leveldb.DB) storage.Repository {
 	return &levelDBRepo{
-		db:     db,
-		models: make(map[string]reflect.Type),
+		db:           db,
+		models:       make(map[string]reflect.Type),
+		binaryModels: make(map[byte]reflect.Type),
 	}
 }
 
-// Register registers the model so that the DB can return the model without knowing the type
+// Register registers the model so that the DB can return the model without knowing the type.
+// Binary models are also registered by their header.
 func (l *levelDBRepo) Register(model storage.Model) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	tp := getTypeIndirect(model.Type())
 	l.models[tp.String()] = tp
+	if bm, ok := model.(storage.BinaryModel); ok {
+		if bm.BinaryHeader() == jsonHeader {
+			log.Errorf("binary model %s uses the JSON header, it is saved as JSON", tp.String())
+			return
+		}
+
+		l.binaryModels[bm.BinaryHeader()] = tp
+	}
 }
 
 // Exists checks whether the key exists in db
@@ -73,6 +89,10 @@ func (l *levelDBRepo) getModel(mt string) (storage.Model, error) {
 }
 
 func (l *levelDBRepo) parseModel(data []byte) (storage.Model, error) {
+	if len(data) > 0 && data[0] != jsonHeader {
+		return l.parseBinaryModel(data)
+	}
+
 	v := new(value)
 	err := json.Unmarshal(data, v)
 	if err != nil {
@@ -92,15 +112,35 @@ func (l *levelDBRepo) parseModel(data []byte) (storage.Model, error) {
 	return nm, nil
 }
 
+// parseBinaryModel returns the binary model registered for the header the data starts with.
+func (l *levelDBRepo) parseBinaryModel(data []byte) (storage.Model, error) {
+	tp, ok := l.binaryModels[data[0]]
+	if !ok {
+		return nil, errors.NewTypedError(storage.ErrModelTypeNotRegistered, errors.New("binary header %#x", data[0]))
+	}
+
+	nm := reflect.New(tp).Interface().(storage.BinaryModel)
+	err := nm.UnmarshalBinary(data[1:])
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrModelRepositorySerialisation, errors.New("failed to unmarshal to model: %v", err))
+	}
+
+	return nm, nil
+}
+
 // Get retrieves model by key, otherwise returns error
 func (l *levelDBRepo) Get(key []byte) (storage.Model, error) {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 	data, err := l.db.Get(key, nil)
-	if err != nil {
+	if err == leveldb.ErrNotFound {
 		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
 	}
 
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrRepositoryModelGet, err)
+	}
+
 	return l.parseModel(data)
 }
 
@@ -124,7 +164,47 @@ func (l *levelDBRepo) GetAllByPrefix(prefix string) ([]storage.Model, error) {
 	return models, iter.Error()
 }
 
+// IterateByPrefix calls fn for every model which key matches the provided prefix without loading them all into memory.
+// If an error is found parsing one of the matched models, logs warning and continues.
+// Iteration stops at the first error returned by fn.
+// The models are read from a snapshot of the db and fn is called without holding the lock of the registered models,
+// so that a slow fn doesn't block the other users of the repository.
+func (l *levelDBRepo) IterateByPrefix(prefix string, fn func(model storage.Model) error) error {
+	snap, err := l.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+
+	iter := snap.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		l.mu.RLock()
+		model, err := l.parseModel(iter.Value())
+		l.mu.RUnlock()
+		if err != nil {
+			log.Warningf("Error parsing model: %v", err)
+			continue
+		}
+
+		if err := fn(model); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
 func (l *levelDBRepo) save(key []byte, model storage.Model) error {
+	if bm, ok := model.(storage.BinaryModel); ok && bm.BinaryHeader() != jsonHeader {
+		data, err := bm.MarshalBinary()
+		if err != nil {
+			return errors.NewTypedError(storage.ErrModelRepositorySerialisation, errors.New("failed to marshall model: %v", err))
+		}
+
+		return l.put(key, append([]byte{bm.BinaryHeader()}, data...))
+	}
+
 	data, err := model.JSON()
 	if err != nil {
 		return errors.NewTypedError(storage.ErrModelRepositorySerialisation, errors.New("failed to marshall model: %v", err))
@@ -141,7 +221,12 @@ func (l *levelDBRepo) save(key []byte, model storage.Model) error {
 		return errors.NewTypedError(storage.ErrModelRepositorySerialisation, errors.New("failed to marshall value: %v", err))
 	}
 
-	err = l.db.Put(key, data, nil)
+	return l.put(key, data)
+}
+
+// put saves the data under the key.
+func (l *levelDBRepo) put(key, data []byte) error {
+	err := l.db.Put(key, data, nil)
 	if err != nil {
 		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
 	}
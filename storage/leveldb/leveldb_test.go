@@ -64,6 +64,66 @@ func TestLevelDBRepo_Register(t *testing.T) {
 	assert.Contains(t, repo.(*levelDBRepo).models, "leveldb.doc")
 }
 
+// binDoc is a doc saved as its string after the header.
+type binDoc struct {
+	doc
+	header byte
+}
+
+func (m *binDoc) BinaryHeader() byte {
+	if m.header == 0 {
+		return 0x05
+	}
+
+	return m.header
+}
+
+func (m *binDoc) MarshalBinary() ([]byte, error) {
+	return []byte(m.SomeString), nil
+}
+
+func (m *binDoc) UnmarshalBinary(data []byte) error {
+	m.SomeString = string(data)
+	return nil
+}
+
+func (m *binDoc) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
+
+func TestLevelDBRepo_binaryModel(t *testing.T) {
+	repo, _, err := getRandomRepository()
+	assert.Nil(t, err)
+	db := repo.(*levelDBRepo).db
+	repo.Register(&doc{})
+	repo.Register(&binDoc{})
+	assert.Contains(t, repo.(*levelDBRepo).binaryModels, byte(0x05))
+
+	// saved as is after the header, read along the JSON models
+	prefix := "prefix-"
+	binID := append([]byte(prefix), utils.RandomSlice(32)...)
+	assert.Nil(t, repo.Create(binID, &binDoc{doc: doc{SomeString: "binary"}}))
+	assert.Nil(t, repo.Create(append([]byte(prefix), utils.RandomSlice(32)...), &doc{SomeString: "json"}))
+	data, err := db.Get(binID, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, append([]byte{0x05}, "binary"...), data)
+	model, err := repo.Get(binID)
+	assert.Nil(t, err)
+	assert.Equal(t, "binary", model.(*binDoc).SomeString)
+	models, err := repo.GetAllByPrefix(prefix)
+	assert.Nil(t, err)
+	assert.Len(t, models, 2)
+
+	// unknown header
+	assert.Nil(t, db.Put(binID, []byte{0x06}, nil))
+	_, err = repo.Get(binID)
+	assert.True(t, errors.IsOfType(storage.ErrModelTypeNotRegistered, err))
+
+	// the JSON header is not a binary header
+	repo.Register(&binDoc{header: '{'})
+	assert.NotContains(t, repo.(*levelDBRepo).binaryModels, byte('{'))
+}
+
 func TestLevelDBRepo_Exists(t *testing.T) {
 	repo, _, err := getRandomRepository()
 	assert.Nil(t, err)
@@ -102,6 +162,12 @@ func TestLevelDBRepo_Get(t *testing.T) {
 	m, err := repo.Get(id)
 	assert.Nil(t, err)
 	assert.Equal(t, d.SomeString, m.(*doc).SomeString)
+
+	// db failure
+	repo.Close()
+	_, err = repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelGet, err))
+	assert.False(t, errors.IsOfType(storage.ErrModelRepositoryNotFound, err))
 }
 
 func TestLevelDBRepo_GetAllByPrefix(t *testing.T) {
@@ -129,6 +195,53 @@ func TestLevelDBRepo_GetAllByPrefix(t *testing.T) {
 	assert.Equal(t, 2, len(models))
 }
 
+func TestLevelDBRepo_IterateByPrefix(t *testing.T) {
+	prefix := "prefix-"
+	repo, _, err := getRandomRepository()
+	assert.Nil(t, err)
+	repo.Register(&doc{})
+
+	var count int
+	fn := func(model storage.Model) error {
+		count++
+		return nil
+	}
+
+	// No match
+	err = repo.IterateByPrefix(prefix, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	id1 := append([]byte(prefix), utils.RandomSlice(32)...)
+	id2 := append([]byte(prefix), utils.RandomSlice(32)...)
+	assert.Nil(t, repo.Create(id1, &doc{SomeString: "Hello, Repo1!"}))
+	assert.Nil(t, repo.Create(id2, &doc{SomeString: "Hello, Repo2!"}))
+	assert.Nil(t, repo.Create(utils.RandomSlice(32), &doc{SomeString: "Hello, Repo3!"}))
+
+	err = repo.IterateByPrefix(prefix, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	// stops on error
+	count = 0
+	err = repo.IterateByPrefix(prefix, func(model storage.Model) error {
+		count++
+		return errors.New("stop")
+	})
+	assert.EqualError(t, err, "stop")
+	assert.Equal(t, 1, count)
+
+	// fn doesn't hold the repository and reads from a snapshot
+	count = 0
+	err = repo.IterateByPrefix(prefix, func(model storage.Model) error {
+		count++
+		repo.Register(&doc{})
+		return repo.Create(append([]byte(prefix), utils.RandomSlice(32)...), &doc{SomeString: "Hello, Repo4!"})
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
+
 func TestLevelDBRepo_Create(t *testing.T) {
 	repo, _, err := getRandomRepository()
 	assert.Nil(t, err)
@@ -23,12 +23,29 @@ type Model interface {
 	FromJSON(json []byte) error
 }
 
+// BinaryModel can be implemented by a Model to be saved in a binary encoding instead of JSON.
+// The saved data is the header of the model followed by the encoded model, the header tells the binary models
+// apart when they are read. The header must not be '{', which starts the models saved as JSON.
+type BinaryModel interface {
+	Model
+
+	// BinaryHeader returns the byte prefixing the encoded model
+	BinaryHeader() byte
+
+	// MarshalBinary returns the encoded model, without the header
+	MarshalBinary() ([]byte, error)
+
+	// UnmarshalBinary initialises the model with the encoded model, without the header
+	UnmarshalBinary(data []byte) error
+}
+
 // Repository defines the required methods for standard storage repository.
 type Repository interface {
 	Register(model Model)
 	Exists(key []byte) bool
 	Get(key []byte) (Model, error)
 	GetAllByPrefix(prefix string) ([]Model, error)
+	IterateByPrefix(prefix string, fn func(model Model) error) error
 	Create(key []byte, model Model) error
 	Update(key []byte, model Model) error
 	Delete(key []byte) error
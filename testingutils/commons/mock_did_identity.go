@@ -44,6 +44,12 @@ func (i *MockIdentityService) RawExecute(ctx context.Context, to common.Address,
 	return args.Get(0).(identity.IDTX), args.Get(1).(chan error), args.Error(2)
 }
 
+// EstimateRawExecuteGas estimates the gas used by the execute method on the identity contract
+func (i *MockIdentityService) EstimateRawExecuteGas(ctx context.Context, to common.Address, data []byte) (uint64, error) {
+	args := i.Called(ctx, to, data)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
 // Execute creates the abi encoding and calls the execute method on the identity contract
 func (i *MockIdentityService) Execute(ctx context.Context, to common.Address, contractAbi, methodName string, args ...interface{}) (txID identity.IDTX, done chan error, err error) {
 	a := i.Called(ctx, to, contractAbi, methodName, args)
@@ -127,6 +127,16 @@ func (m *MockConfig) GetNetworkString() string {
 	return args.Get(0).(string)
 }
 
+func (m *MockConfig) GetTaskEnqueueOnly() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *MockConfig) GetTaskReplayEnabled() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
 func (m *MockConfig) GetNetworkKey(k string) string {
 	args := m.Called(k)
 	return args.Get(0).(string)
@@ -177,11 +187,61 @@ func (m *MockConfig) GetLowEntropyNFTTokenEnabled() bool {
 	return args.Get(0).(bool)
 }
 
+func (m *MockConfig) GetNFTRegistryProperties() map[string][]string {
+	args := m.Called()
+	return args.Get(0).(map[string][]string)
+}
+
+func (m *MockConfig) GetNFTRegistryAttestationSchemes() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *MockConfig) GetNFTRegistryMethods() map[string][]string {
+	args := m.Called()
+	return args.Get(0).(map[string][]string)
+}
+
+func (m *MockConfig) GetNFTRegistryEvents() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *MockConfig) GetNFTMintGasPadding() float64 {
+	args := m.Called()
+	return args.Get(0).(float64)
+}
+
+func (m *MockConfig) GetNFTPrebindRegistries() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *MockConfig) GetNFTReadCacheTTL() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetNFTRelayerURL() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetNFTRelayerSigningScheme() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
 func (m *MockConfig) IsDebugLogEnabled() bool {
 	args := m.Called()
 	return args.Get(0).(bool)
 }
 
+func (m *MockConfig) GetDebugLogLevels() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
 func (m *MockConfig) GetCentChainAccount() (config.CentChainAccount, error) {
 	args := m.Called()
 	return args.Get(0).(config.CentChainAccount), args.Error(1)
@@ -222,3 +282,79 @@ func HandlerContext(service config.Service) context.Context {
 	ctx := context.WithValue(context.Background(), config.AccountHeaderKey, cidHex)
 	return ctx
 }
+
+func (m *MockConfig) GetJobRecoveryPolicies() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *MockConfig) GetJobSerializationFormat() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfig) GetNotificationOrderedDelivery() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *MockConfig) GetNotificationMaxPayloadSize() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetNotificationOptInEvents() []string {
+	args := m.Called()
+	events, _ := args.Get(0).([]string)
+	return events
+}
+
+func (m *MockConfig) GetJobPollInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetJobPollInitial() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetJobPollMax() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetJobShutdownGracePeriod() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetJobContextClosedPolicy() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetJobRetryAfter() map[string]time.Duration {
+	args := m.Called()
+	return args.Get(0).(map[string]time.Duration)
+}
+
+func (m *MockConfig) GetJobLogArchiveDir() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfig) GetJobLogArchiveThreshold() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *MockConfig) GetMaxConcurrentJobs() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *MockConfig) GetJobCapacityPolicy() string {
+	args := m.Called()
+	return args.String(0)
+}
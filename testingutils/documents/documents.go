@@ -242,3 +242,8 @@ func (m MockRegistry) OwnerOfWithRetrial(registry common.Address, tokenID []byte
 	addr, _ := args.Get(0).(common.Address)
 	return addr, args.Error(1)
 }
+
+func (m *MockModel) IsNFTMinted(tr documents.TokenRegistry, registry common.Address) bool {
+	args := m.Called(tr, registry)
+	return args.Bool(0)
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"math/big"
 
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
@@ -36,6 +38,12 @@ func (m *MockNFTService) OwnerOf(registry common.Address, tokenID []byte) (owner
 	return resp, args.Error(1)
 }
 
+func (m *MockNFTService) CachedOwnerOf(registry common.Address, tokenID []byte) (owner common.Address, stale bool, err error) {
+	args := m.Called(registry, tokenID)
+	resp, _ := args.Get(0).(common.Address)
+	return resp, args.Bool(1), args.Error(2)
+}
+
 func (m *MockNFTService) OwnerOfWithRetrial(registry common.Address, tokenID []byte) (owner common.Address, err error) {
 	args := m.Called(registry, tokenID)
 	resp, _ := args.Get(0).(common.Address)
@@ -47,3 +55,16 @@ func (m *MockNFTService) CurrentIndexOfToken(registry common.Address, tokenID []
 	resp, _ := args.Get(0).(*big.Int)
 	return resp, args.Error(1)
 }
+
+func (m *MockNFTService) IsMintInProgress(accountID identity.DID, documentID []byte) (bool, jobs.JobID, error) {
+	args := m.Called(accountID, documentID)
+	jobID, _ := args.Get(1).(jobs.JobID)
+	return args.Bool(0), jobID, args.Error(2)
+}
+
+func (m *MockNFTService) EnsureMinted(ctx context.Context, request nft.MintNFTRequest) (*nft.TokenResponse, chan error, error) {
+	args := m.Called(ctx, request)
+	resp, _ := args.Get(0).(*nft.TokenResponse)
+	done, _ := args.Get(1).(chan error)
+	return resp, done, args.Error(2)
+}
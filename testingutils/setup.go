@@ -258,6 +258,9 @@ func BuildIntegrationTestingContext() map[string]interface{} {
 	cfg.Set("keys.signing.publicKey", fmt.Sprintf("%s/build/resources/signingKey.pub.pem", projDir))
 	cfg.Set("keys.signing.privateKey", fmt.Sprintf("%s/build/resources/signingKey.key.pem", projDir))
 	SetupSmartContractAddresses(cfg, addresses)
+	if registry, ok := GetDAppSmartContractAddresses()["genericNFT"]; ok {
+		cfg.Set("nft.registryMethods", map[string]interface{}{registry: []string{"mint", "transfer"}})
+	}
 	cm := make(map[string]interface{})
 	cm[bootstrap.BootstrappedConfig] = cfg
 	return cm
@@ -4,6 +4,7 @@ package testingjobs
 
 import (
 	"context"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
@@ -20,6 +21,35 @@ func (m MockJobManager) ExecuteWithinJob(ctx context.Context, accountID identity
 	return args.Get(0).(jobs.JobID), args.Get(1).(chan error), args.Error(2)
 }
 
+func (m MockJobManager) ExecuteAfter(ctx context.Context, accountID identity.DID, dependsOn jobs.JobID, desc string, work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
+	args := m.Called(ctx, accountID, dependsOn, desc, work)
+	txID, _ = args.Get(0).(jobs.JobID)
+	done, _ = args.Get(1).(chan error)
+	return txID, done, args.Error(2)
+}
+
+func (m MockJobManager) GetJob(accountID identity.DID, id jobs.JobID) (*jobs.Job, error) {
+	args := m.Called(accountID, id)
+	job, _ := args.Get(0).(*jobs.Job)
+	return job, args.Error(1)
+}
+
+func (m MockJobManager) WaitForJob(accountID identity.DID, id jobs.JobID) error {
+	args := m.Called(accountID, id)
+	return args.Error(0)
+}
+
+func (m MockJobManager) GetNotificationReceipts(accountID identity.DID, id jobs.JobID) ([]jobs.NotificationReceipt, error) {
+	args := m.Called(accountID, id)
+	r, _ := args.Get(0).([]jobs.NotificationReceipt)
+	return r, args.Error(1)
+}
+
+func (m MockJobManager) WaitForJobWithContext(ctx context.Context, accountID identity.DID, id jobs.JobID) error {
+	args := m.Called(ctx, accountID, id)
+	return args.Error(0)
+}
+
 func (m MockJobManager) GetJobStatus(account identity.DID, id jobs.JobID) (jobs.StatusResponse, error) {
 	args := m.Called(account, id)
 	resp, _ := args.Get(0).(jobs.StatusResponse)
@@ -30,3 +60,89 @@ func (m MockJobManager) UpdateTaskStatus(accountID identity.DID, id jobs.JobID,
 	args := m.Called(accountID, id, status, taskName, message)
 	return args.Error(0)
 }
+
+func (m MockJobManager) UpdateJobWithValue(accountID identity.DID, id jobs.JobID, key string, value []byte) error {
+	args := m.Called(accountID, id, key, value)
+	return args.Error(0)
+}
+
+func (m MockJobManager) AnnotateJob(accountID identity.DID, id jobs.JobID, note string) error {
+	args := m.Called(accountID, id, note)
+	return args.Error(0)
+}
+
+func (m MockJobManager) ForceStatus(accountID identity.DID, id jobs.JobID, status jobs.Status, reason string) error {
+	args := m.Called(accountID, id, status, reason)
+	return args.Error(0)
+}
+
+func (m MockJobManager) SupersedeJob(accountID identity.DID, id, by jobs.JobID) error {
+	args := m.Called(accountID, id, by)
+	return args.Error(0)
+}
+
+func (m MockJobManager) NotifyJobRetrying(ctx context.Context, accountID identity.DID, id jobs.JobID, taskName string, attempt int, delay time.Duration) {
+	m.Called(ctx, accountID, id, taskName, attempt, delay)
+}
+
+func (m MockJobManager) JobTimings(accountID identity.DID, id jobs.JobID) (time.Duration, time.Duration, error) {
+	args := m.Called(accountID, id)
+	return args.Get(0).(time.Duration), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m MockJobManager) GetDefaultTaskTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m MockJobManager) EffectiveConfig() jobs.EffectiveConfig {
+	args := m.Called()
+	return args.Get(0).(jobs.EffectiveConfig)
+}
+
+func (m MockJobManager) ReconcileJobs(backend jobs.TaskBackend, policy string) (jobs.ReconcileReport, error) {
+	args := m.Called(backend, policy)
+	return args.Get(0).(jobs.ReconcileReport), args.Error(1)
+}
+
+func (m MockJobManager) RecordJobTask(accountID identity.DID, id jobs.JobID, task jobs.JobTask) error {
+	args := m.Called(accountID, id, task)
+	return args.Error(0)
+}
+
+func (m MockJobManager) RegisterRetrier(desc string, retrier jobs.Retrier) {}
+
+func (m MockJobManager) RetryFailedJobs(ctx context.Context, accountID identity.DID) ([]jobs.JobID, error) {
+	args := m.Called(ctx, accountID)
+	ids, _ := args.Get(0).([]jobs.JobID)
+	return ids, args.Error(1)
+}
+
+func (m MockJobManager) ListJobs(accountID identity.DID, filter jobs.JobFilter) ([]*jobs.Job, error) {
+	args := m.Called(accountID, filter)
+	list, _ := args.Get(0).([]*jobs.Job)
+	return list, args.Error(1)
+}
+
+func (m MockJobManager) ListJobsPage(accountID identity.DID, filter jobs.JobFilter, offset, limit int) ([]*jobs.Job, int, error) {
+	args := m.Called(accountID, filter, offset, limit)
+	list, _ := args.Get(0).([]*jobs.Job)
+	return list, args.Int(1), args.Error(2)
+}
+
+func (m MockJobManager) GetJobsForDocumentVersion(accountID identity.DID, documentID, versionID []byte) ([]*jobs.Job, error) {
+	args := m.Called(accountID, documentID, versionID)
+	list, _ := args.Get(0).([]*jobs.Job)
+	return list, args.Error(1)
+}
+
+func (m MockJobManager) IndexJob(accountID identity.DID, id jobs.JobID, key, value string) error {
+	args := m.Called(accountID, id, key, value)
+	return args.Error(0)
+}
+
+func (m MockJobManager) GetIndexedJob(accountID identity.DID, key, value string) (*jobs.Job, error) {
+	args := m.Called(accountID, key, value)
+	job, _ := args.Get(0).(*jobs.Job)
+	return job, args.Error(1)
+}
@@ -3,6 +3,8 @@
 package testingutils
 
 import (
+	"time"
+
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/stretchr/testify/mock"
@@ -41,3 +43,17 @@ func (m *MockQueue) EnqueueJobWithMaxTries(taskTypeName string, params map[strin
 	res, _ := args.Get(0).(queue.TaskResult)
 	return res, args.Error(1)
 }
+
+type MockTaskResult struct {
+	mock.Mock
+}
+
+func (m *MockTaskResult) Get(timeout time.Duration) (interface{}, error) {
+	args := m.Called(timeout)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockTaskResult) State() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
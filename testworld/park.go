@@ -278,6 +278,9 @@ func (h *host) init() error {
 			"ethereum.accounts.main.key":      os.Getenv("CENT_ETHEREUM_ACCOUNTS_MAIN_KEY"),
 			"ethereum.accounts.main.password": os.Getenv("CENT_ETHEREUM_ACCOUNTS_MAIN_PASSWORD"),
 		}
+		if registry, ok := h.dappAddresses["genericNFT"]; ok {
+			values["nft.registryMethods"] = map[string]interface{}{registry: []string{"mint", "transfer"}}
+		}
 		err = updateConfig(h.dir, values)
 		if err != nil {
 			return err
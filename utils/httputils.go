@@ -3,6 +3,7 @@ package utils
 import (
 	"crypto/tls"
 	"net"
+	"net/http"
 
 	"github.com/centrifuge/go-centrifuge/errors"
 	"gopkg.in/resty.v1"
@@ -26,6 +27,36 @@ func SendPOSTRequest(url string, contentType string, payload []byte) (statusCode
 	return resp.StatusCode(), nil
 }
 
+// SendPOSTRequestWithHeaders sends post with data and the additional headers to given URL using the given transport.
+func SendPOSTRequestWithHeaders(url string, contentType string, payload []byte, headers map[string]string, transport *http.Transport) (statusCode int, err error) {
+	return SendPOSTRequestWithClient(NewHTTPClient(transport), url, contentType, payload, headers)
+}
+
+// NewHTTPClient returns a client sending the requests through the transport.
+// The client is safe for concurrent use and should be reused so that the connections are kept alive.
+func NewHTTPClient(transport *http.Transport) *resty.Client {
+	c := resty.New()
+	c.SetTransport(transport)
+	cfg := &tls.Config{InsecureSkipVerify: true} // Temporary until we have defined a cert truststore
+	c.SetTLSClientConfig(cfg)
+	return c
+}
+
+// SendPOSTRequestWithClient sends post with data and the additional headers to given URL using the given client.
+func SendPOSTRequestWithClient(c *resty.Client, url string, contentType string, payload []byte, headers map[string]string) (statusCode int, err error) {
+	resp, err := c.R().
+		SetHeaders(headers).
+		SetHeader("Content-Type", contentType).
+		SetBody(payload).
+		Post(url)
+
+	if err != nil {
+		return statusCode, err
+	}
+
+	return resp.StatusCode(), nil
+}
+
 // GetFreeAddrPort returns a loopback address and port that can be listened from.
 // Note: port is included in the address.
 func GetFreeAddrPort() (string, int, error) {
@@ -3,9 +3,14 @@ package httputils
 import (
 	"net/http"
 
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/go-chi/render"
 )
 
+// queueFullRetryAfter is the number of seconds a client is asked to wait before retrying a request rejected by a full queue.
+const queueFullRetryAfter = "5"
+
 // HTTPError contains the error message
 type HTTPError struct {
 	Message string `json:"message"`
@@ -13,12 +18,18 @@ type HTTPError struct {
 
 // RespondIfError if err != nil, returns the HTTPError and code as API response
 // no-op if the err is nil
+// queue.ErrQueueFull is always returned as 503 with a Retry-After header.
 func RespondIfError(code *int, err *error, w http.ResponseWriter, r *http.Request) {
 	if *err == nil {
 		return
 	}
 
 	e := *err
+	if errors.IsOfType(queue.ErrQueueFull, e) {
+		*code = http.StatusServiceUnavailable
+		w.Header().Set("Retry-After", queueFullRetryAfter)
+	}
+
 	render.Status(r, *code)
 	render.JSON(w, r, HTTPError{Message: e.Error()})
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,3 +38,18 @@ func TestRespondIfError_error(t *testing.T) {
 	defer RespondIfError(&code, &err, w, r)
 	err = errors.New("bad request")
 }
+
+func TestRespondIfError_queueFull(t *testing.T) {
+	var err error
+	code := http.StatusInternalServerError
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/documents", nil)
+	defer func() {
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, queueFullRetryAfter, w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), queue.ErrQueueFull.Error())
+	}()
+
+	defer RespondIfError(&code, &err, w, r)
+	err = errors.NewTypedError(queue.ErrQueueFull, errors.New("failed to anchor"))
+}